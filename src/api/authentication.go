@@ -0,0 +1,227 @@
+package api
+
+import (
+	"RentalManagement/logging"
+	"RentalManagement/logic/model"
+	"errors"
+	"fmt"
+	"github.com/MicahParks/keyfunc/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/exp/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const identityContextKey = "identity"
+
+var (
+	errAuthenticationRequired = newProblemError(http.StatusUnauthorized, model.ErrorCodeAUTHENTICATIONREQUIRED,
+		"authentication required")
+	errCustomerMismatch = newProblemError(http.StatusForbidden, model.ErrorCodeCUSTOMERMISMATCH,
+		"customerId does not match the authenticated customer")
+	errMissingRole = newProblemError(http.StatusForbidden, model.ErrorCodeMISSINGROLE,
+		"missing role to perform the operation")
+)
+
+// onBehalfOfCustomersRoles are the roles allowed to act on behalf of every customer
+var onBehalfOfCustomersRoles = []Role{RoleAdmin}
+
+const jwksRefreshInterval = time.Hour
+const jwksRefreshRateLimit = 5 * time.Minute
+
+// signingMethods are the asymmetric signature algorithms accepted for bearer tokens. Symmetric algorithms are never
+// accepted as the keys are published in a JWKS.
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// AuthConfig provides the configuration of the bearer token authentication
+type AuthConfig interface {
+	GetAuthJwksUrl() string
+	GetAuthJwksFile() string
+	GetAuthIssuer() string
+	GetAuthAudience() string
+	GetAuthCustomerClaim() string
+	GetAuthRolesClaim() string
+}
+
+// Identity is the authenticated caller of a request as stated by the claims of its bearer token
+type Identity struct {
+	Subject    string
+	CustomerId string
+	Roles      []Role
+}
+
+// HasAnyRole reports whether the identity has at least one of the given roles
+func (i *Identity) HasAnyRole(roles []Role) bool {
+	for _, role := range roles {
+		for _, identityRole := range i.Roles {
+			if role == identityRole {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TokenVerifier verifies bearer tokens (JWTs) with the keys of the configured JWKS, which is read from a file if one
+// is configured or fetched (and periodically refreshed) from the configured URL otherwise. The customer identity and
+// the roles are taken from the configured claims of the token.
+type TokenVerifier struct {
+	parser         *jwt.Parser
+	keyFunc        jwt.Keyfunc
+	identityClaims identityClaims
+}
+
+// NewTokenVerifier creates a TokenVerifier from the configuration. The JWKS is loaded immediately.
+func NewTokenVerifier(config AuthConfig) (*TokenVerifier, error) {
+	jwks, err := loadJwks(config)
+	if err != nil {
+		return nil, err
+	}
+
+	parserOptions := []jwt.ParserOption{jwt.WithValidMethods(signingMethods)}
+	if issuer := config.GetAuthIssuer(); issuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(issuer))
+	}
+	if audience := config.GetAuthAudience(); audience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(audience))
+	}
+
+	return &TokenVerifier{
+		parser:         jwt.NewParser(parserOptions...),
+		keyFunc:        jwks.Keyfunc,
+		identityClaims: identityClaims{customer: config.GetAuthCustomerClaim(), roles: config.GetAuthRolesClaim()},
+	}, nil
+}
+
+// AddAuthenticationMiddleware adds authentication middleware to the echo server that verifies bearer tokens with the
+// given verifier.
+//
+// Requests with an invalid bearer token are rejected with 401. Requests with a customerId query parameter require a
+// valid bearer token of that very customer or of an admin, they are rejected with 401 without a token and with 403 if
+// the parameter does not match the customer of the token.
+func AddAuthenticationMiddleware(e *echo.Echo, verifier *TokenVerifier) {
+	e.Use(authenticate(verifier))
+}
+
+func loadJwks(config AuthConfig) (*keyfunc.JWKS, error) {
+	if file := config.GetAuthJwksFile(); file != "" {
+		jwksData, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading the JWKS file failed: %w", err)
+		}
+		return keyfunc.NewJSON(jwksData)
+	}
+
+	if url := config.GetAuthJwksUrl(); url != "" {
+		return keyfunc.Get(url, keyfunc.Options{
+			RefreshInterval:   jwksRefreshInterval,
+			RefreshRateLimit:  jwksRefreshRateLimit,
+			RefreshUnknownKID: true,
+			RefreshErrorHandler: func(err error) {
+				slog.Error("refreshing the JWKS failed", logging.KeyError, err)
+			},
+		})
+	}
+
+	return nil, errors.New("neither a JWKS file nor a JWKS URL is configured")
+}
+
+// identityClaims are the names of the claims the identity is taken from
+type identityClaims struct {
+	customer string
+	// roles is the path of the roles claim, nested claims are separated by dots (e.g. realm_access.roles)
+	roles string
+}
+
+func authenticate(verifier *TokenVerifier) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			identity, err := verifier.Verify(ctx.Request().Header.Get(echo.HeaderAuthorization))
+			if err != nil {
+				ctx.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+				return newProblemError(http.StatusUnauthorized, model.ErrorCodeINVALIDTOKEN, "invalid bearer token: "+err.Error())
+			}
+			if identity != nil {
+				ctx.Set(identityContextKey, identity)
+			}
+
+			if ctx.QueryParams().Has("customerId") {
+				if identity == nil {
+					ctx.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+					return errAuthenticationRequired
+				}
+				if !identity.HasAnyRole(onBehalfOfCustomersRoles) &&
+					(identity.CustomerId == "" || ctx.QueryParam("customerId") != identity.CustomerId) {
+					return errCustomerMismatch
+				}
+			}
+
+			return next(ctx)
+		}
+	}
+}
+
+// Verify returns the identity stated by the bearer token of an Authorization header value. If the value is empty,
+// nil is returned. If the token is invalid, an error is returned.
+func (v *TokenVerifier) Verify(authorization string) (*Identity, error) {
+	if authorization == "" {
+		return nil, nil
+	}
+
+	scheme, tokenString, found := strings.Cut(authorization, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return nil, errors.New("unsupported authorization scheme")
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(tokenString, claims, v.keyFunc); err != nil {
+		return nil, err
+	}
+
+	// tokens without expiration time would be valid forever
+	if expirationTime, _ := claims.GetExpirationTime(); expirationTime == nil {
+		return nil, errors.New("token has no expiration time")
+	}
+
+	subject, _ := claims.GetSubject()
+	// staff members and services do not act on behalf of a customer, so their tokens may lack a customer ID
+	customerId, _ := claims[v.identityClaims.customer].(string)
+
+	roles, err := rolesFromClaims(claims, v.identityClaims.roles)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Identity{Subject: subject, CustomerId: customerId, Roles: roles}, nil
+}
+
+// rolesFromClaims returns the roles listed in the claim with the given path. Unknown roles are ignored as tokens
+// usually contain roles of other applications as well.
+func rolesFromClaims(claims jwt.MapClaims, path string) ([]Role, error) {
+	var claim any = map[string]any(claims)
+	for _, name := range strings.Split(path, ".") {
+		object, ok := claim.(map[string]any)
+		if !ok {
+			return nil, nil
+		}
+		if claim, ok = object[name]; !ok || claim == nil {
+			return nil, nil
+		}
+	}
+
+	values, ok := claim.([]any)
+	if !ok {
+		return nil, fmt.Errorf("the %s claim is not an array", path)
+	}
+
+	var roles []Role
+	for _, value := range values {
+		if role, ok := value.(string); ok && isKnownRole(Role(role)) {
+			roles = append(roles, Role(role))
+		}
+	}
+	return roles, nil
+}
@@ -0,0 +1,240 @@
+package api
+
+import (
+	"RentalManagement/testhelpers"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type TestAuthConfig struct {
+	jwksUrl       string
+	jwksFile      string
+	issuer        string
+	audience      string
+	customerClaim string
+	rolesClaim    string
+}
+
+func (c *TestAuthConfig) GetAuthJwksUrl() string {
+	return c.jwksUrl
+}
+
+func (c *TestAuthConfig) GetAuthJwksFile() string {
+	return c.jwksFile
+}
+
+func (c *TestAuthConfig) GetAuthIssuer() string {
+	return c.issuer
+}
+
+func (c *TestAuthConfig) GetAuthAudience() string {
+	return c.audience
+}
+
+func (c *TestAuthConfig) GetAuthCustomerClaim() string {
+	return c.customerClaim
+}
+
+func (c *TestAuthConfig) GetAuthRolesClaim() string {
+	return c.rolesClaim
+}
+
+// newAuthenticatedApp returns an echo instance with authentication middleware whose only route responds with the
+// authenticated identity
+func newAuthenticatedApp(t *testing.T, config *TestAuthConfig) *echo.Echo {
+	app := echo.New()
+	app.HTTPErrorHandler = HandleError
+	verifier, err := NewTokenVerifier(config)
+	assert.Nil(t, err)
+	AddAuthenticationMiddleware(app, verifier)
+
+	app.GET("/rentals", func(ctx echo.Context) error {
+		identity, _ := ctx.Get(identityContextKey).(*Identity)
+		return ctx.JSON(http.StatusOK, identity)
+	})
+	return app
+}
+
+func serveAuthenticated(app *echo.Echo, target string, authorization string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, target, nil)
+	if authorization != "" {
+		request.Header.Set(echo.HeaderAuthorization, authorization)
+	}
+	recorder := httptest.NewRecorder()
+	app.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// testAuthConfig returns the default configuration for tokens of the given issuer
+func testAuthConfig(issuer *testhelpers.TokenIssuer) *TestAuthConfig {
+	return &TestAuthConfig{jwksFile: issuer.JwksFile, customerClaim: "sub", rolesClaim: "roles"}
+}
+
+func newTestTokenIssuer(t *testing.T) *testhelpers.TokenIssuer {
+	issuer, err := testhelpers.NewTokenIssuer(t.TempDir())
+	assert.Nil(t, err)
+	return issuer
+}
+
+func TestAuthentication_success(t *testing.T) {
+	issuer := newTestTokenIssuer(t)
+	app := newAuthenticatedApp(t, testAuthConfig(issuer))
+
+	response := serveAuthenticated(app, "/rentals?customerId=d9COw9vI", issuer.BearerToken("d9COw9vI"))
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `{"Subject": "d9COw9vI", "CustomerId": "d9COw9vI", "Roles": ["customer"]}`,
+		response.Body.String())
+}
+
+func TestAuthentication_success_noCustomerId(t *testing.T) {
+	issuer := newTestTokenIssuer(t)
+	app := newAuthenticatedApp(t, testAuthConfig(issuer))
+
+	response := serveAuthenticated(app, "/rentals", "")
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "null\n", response.Body.String())
+}
+
+func TestAuthentication_success_customerClaim(t *testing.T) {
+	issuer := newTestTokenIssuer(t)
+	app := newAuthenticatedApp(t, &TestAuthConfig{
+		jwksFile:      issuer.JwksFile,
+		issuer:        "https://login.example.com",
+		audience:      "rental-management",
+		customerClaim: "customer_id",
+		rolesClaim:    "realm_access.roles",
+	})
+
+	token := issuer.Sign(jwt.MapClaims{
+		"iss":         "https://login.example.com",
+		"aud":         "rental-management",
+		"sub":         "f0f6b9a2-user",
+		"customer_id": "d9COw9vI",
+		"realm_access": map[string]any{
+			// unknown roles are ignored
+			"roles": []string{"offline_access", "customer", "fleetManager"},
+		},
+		"exp": time.Now().Add(time.Minute).Unix(),
+	})
+	response := serveAuthenticated(app, "/rentals?customerId=d9COw9vI", "Bearer "+token)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `{"Subject": "f0f6b9a2-user", "CustomerId": "d9COw9vI", "Roles": ["customer", "fleetManager"]}`,
+		response.Body.String())
+}
+
+func TestAuthentication_customerIdMismatch(t *testing.T) {
+	issuer := newTestTokenIssuer(t)
+	app := newAuthenticatedApp(t, testAuthConfig(issuer))
+
+	response := serveAuthenticated(app, "/rentals?customerId=d9COw9vI", issuer.BearerToken("M9hUnd8a"))
+
+	assert.Equal(t, http.StatusForbidden, response.Code)
+}
+
+func TestAuthentication_customerIdOfStaff(t *testing.T) {
+	issuer := newTestTokenIssuer(t)
+	config := testAuthConfig(issuer)
+	config.customerClaim = "customer_id"
+	app := newAuthenticatedApp(t, config)
+
+	// the token has no customer_id claim
+	response := serveAuthenticated(app, "/rentals?customerId=d9COw9vI", issuer.StaffBearerToken("fleetManager"))
+
+	assert.Equal(t, http.StatusForbidden, response.Code)
+}
+
+func TestAuthentication_customerIdOfAdmin(t *testing.T) {
+	issuer := newTestTokenIssuer(t)
+	app := newAuthenticatedApp(t, testAuthConfig(issuer))
+
+	// admins act on behalf of every customer
+	response := serveAuthenticated(app, "/rentals?customerId=d9COw9vI", issuer.StaffBearerToken("admin"))
+
+	assert.Equal(t, http.StatusOK, response.Code)
+}
+
+func TestAuthentication_customerIdWithoutToken(t *testing.T) {
+	issuer := newTestTokenIssuer(t)
+	app := newAuthenticatedApp(t, testAuthConfig(issuer))
+
+	response := serveAuthenticated(app, "/rentals?customerId=d9COw9vI", "")
+
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+	assert.Equal(t, "Bearer", response.Header().Get(echo.HeaderWWWAuthenticate))
+}
+
+func TestAuthentication_invalidTokens(t *testing.T) {
+	issuer := newTestTokenIssuer(t)
+	otherIssuer := newTestTokenIssuer(t)
+	app := newAuthenticatedApp(t, &TestAuthConfig{
+		jwksFile:      issuer.JwksFile,
+		issuer:        "https://login.example.com",
+		customerClaim: "sub",
+		rolesClaim:    "roles",
+	})
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss": "https://login.example.com",
+			"sub": "d9COw9vI",
+			"exp": time.Now().Add(time.Minute).Unix(),
+		}
+	}
+
+	expired := validClaims()
+	expired["exp"] = time.Now().Add(-time.Minute).Unix()
+	noExpiration := validClaims()
+	delete(noExpiration, "exp")
+	wrongIssuer := validClaims()
+	wrongIssuer["iss"] = "https://evil.example.com"
+	rolesNoArray := validClaims()
+	rolesNoArray["roles"] = "customer"
+
+	hmacToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims()).SignedString([]byte("secret"))
+
+	authorizations := map[string]string{
+		"unknown key":        "Bearer " + otherIssuer.Sign(validClaims()),
+		"expired":            "Bearer " + issuer.Sign(expired),
+		"no expiration time": "Bearer " + issuer.Sign(noExpiration),
+		"wrong issuer":       "Bearer " + issuer.Sign(wrongIssuer),
+		"roles no array":     "Bearer " + issuer.Sign(rolesNoArray),
+		"symmetric":          "Bearer " + hmacToken,
+		"malformed":          "Bearer not.a.token",
+		"basic auth":         "Basic dXNlcjpwYXNzd29yZA==",
+	}
+
+	for name, authorization := range authorizations {
+		t.Run(name, func(t *testing.T) {
+			response := serveAuthenticated(app, "/rentals", authorization)
+
+			assert.Equal(t, http.StatusUnauthorized, response.Code)
+			assert.Equal(t, `Bearer error="invalid_token"`, response.Header().Get(echo.HeaderWWWAuthenticate))
+		})
+	}
+}
+
+func TestNewTokenVerifier_noJwks(t *testing.T) {
+	verifier, err := NewTokenVerifier(&TestAuthConfig{customerClaim: "sub", rolesClaim: "roles"})
+
+	assert.Nil(t, verifier)
+	assert.NotNil(t, err)
+}
+
+func TestNewTokenVerifier_unknownJwksFile(t *testing.T) {
+	verifier, err := NewTokenVerifier(&TestAuthConfig{
+		jwksFile:      t.TempDir() + "/missing.json",
+		customerClaim: "sub",
+		rolesClaim:    "roles",
+	})
+
+	assert.Nil(t, verifier)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"regexp"
+)
+
+// rolesExtension is the OpenAPI extension of an operation that lists the roles allowed to perform it
+const rolesExtension = "x-roles"
+
+// Role is a role of an authenticated user as stated by its bearer token
+type Role string
+
+// Defines values for Role.
+const (
+	RoleCustomer     Role = "customer"
+	RoleFleetManager Role = "fleetManager"
+	RoleService      Role = "service"
+	RoleAdmin        Role = "admin"
+)
+
+func isKnownRole(role Role) bool {
+	switch role {
+	case RoleCustomer, RoleFleetManager, RoleService, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+var pathParameterPattern = regexp.MustCompile(`\{([^}]+)}`)
+
+// authorizationPolicy maps the method and the (echo) path of a route to the roles allowed to perform its operation.
+// Routes without an entry may be used without authentication.
+type authorizationPolicy map[string][]Role
+
+// AddAuthorizationMiddleware adds authorization middleware to the echo server. The roles allowed to perform an
+// operation are defined by the x-roles extension of the operation in the OpenAPI specification of RentalManagement.
+// Operations without that extension are not restricted.
+//
+// The middleware relies on the identity established by the authentication middleware, so it must be added after
+// that. Requests to restricted operations are rejected with 401 without authentication and with 403 if the
+// authenticated user does not have any of the allowed roles.
+func AddAuthorizationMiddleware(e *echo.Echo) error {
+	swagger, err := openapi3.NewLoader().LoadFromData(openApiData)
+	if err != nil {
+		return err
+	}
+
+	policy, err := newAuthorizationPolicy(swagger)
+	if err != nil {
+		return err
+	}
+
+	e.Use(authorize(policy))
+
+	return nil
+}
+
+// OperationRoles returns the roles allowed to perform the operations of the REST API by their operation ID, as
+// enforced by the middleware of AddAuthorizationMiddleware. The roles of operations that are not restricted are nil.
+// Other APIs offering the same operations use it to apply the same policy.
+func OperationRoles() (map[string][]Role, error) {
+	swagger, err := openapi3.NewLoader().LoadFromData(openApiData)
+	if err != nil {
+		return nil, err
+	}
+
+	rolesByOperation := map[string][]Role{}
+	for _, pathItem := range swagger.Paths {
+		for _, operation := range pathItem.Operations() {
+			roles, err := operationRoles(operation)
+			if err != nil {
+				return nil, err
+			}
+			rolesByOperation[operation.OperationID] = roles
+		}
+	}
+
+	return rolesByOperation, nil
+}
+
+func newAuthorizationPolicy(swagger *openapi3.T) (authorizationPolicy, error) {
+	policy := authorizationPolicy{}
+
+	for path, pathItem := range swagger.Paths {
+		echoPath := pathParameterPattern.ReplaceAllString(path, ":$1")
+
+		for method, operation := range pathItem.Operations() {
+			roles, err := operationRoles(operation)
+			if err != nil {
+				return nil, err
+			}
+			if roles != nil {
+				policy[method+" "+echoPath] = roles
+			}
+		}
+	}
+
+	return policy, nil
+}
+
+// operationRoles returns the roles listed by the x-roles extension of the operation, or nil if it has none
+func operationRoles(operation *openapi3.Operation) ([]Role, error) {
+	extension, restricted := operation.Extensions[rolesExtension]
+	if !restricted {
+		return nil, nil
+	}
+
+	roles, err := parseRoles(extension)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s of operation %s: %w", rolesExtension, operation.OperationID, err)
+	}
+	return roles, nil
+}
+
+func parseRoles(extension any) ([]Role, error) {
+	// depending on the loader, the extension is either decoded already or still raw JSON
+	rawRoles, ok := extension.(json.RawMessage)
+	if !ok {
+		var err error
+		if rawRoles, err = json.Marshal(extension); err != nil {
+			return nil, err
+		}
+	}
+
+	var roles []Role
+	if err := json.Unmarshal(rawRoles, &roles); err != nil {
+		return nil, err
+	}
+	if len(roles) == 0 {
+		return nil, fmt.Errorf("no roles listed")
+	}
+	for _, role := range roles {
+		if !isKnownRole(role) {
+			return nil, fmt.Errorf("unknown role %q", role)
+		}
+	}
+	return roles, nil
+}
+
+func authorize(policy authorizationPolicy) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			roles, restricted := policy[ctx.Request().Method+" "+ctx.Path()]
+			if !restricted {
+				return next(ctx)
+			}
+
+			identity, authenticated := ctx.Get(identityContextKey).(*Identity)
+			if !authenticated {
+				ctx.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+				return errAuthenticationRequired
+			}
+			if !identity.HasAnyRole(roles) {
+				return errMissingRole
+			}
+
+			return next(ctx)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+// publicOperations may be performed without authentication as trunk access tokens and calendar tokens are checked by
+// the operations
+var publicOperations = []string{"getLockState", "setLockState", "getSubscribedRentalCalendar"}
+
+func loadTestPolicy(t *testing.T) authorizationPolicy {
+	swagger, err := openapi3.NewLoader().LoadFromData(openApiData)
+	assert.Nil(t, err)
+
+	policy, err := newAuthorizationPolicy(swagger)
+	assert.Nil(t, err)
+	return policy
+}
+
+func TestNewAuthorizationPolicy(t *testing.T) {
+	policy := loadTestPolicy(t)
+
+	assert.Equal(t, []Role{RoleFleetManager, RoleService, RoleAdmin}, policy["GET /cars/:vin/rentalStatus"])
+	assert.Equal(t, []Role{RoleCustomer, RoleAdmin}, policy["GET /rentals"])
+	assert.NotContains(t, policy, "GET /cars/:vin/trunk")
+	assert.NotContains(t, policy, "PUT /cars/:vin/trunk")
+	assert.NotContains(t, policy, "GET /calendars/:calendarToken/rentals.ics")
+}
+
+func TestNewAuthorizationPolicy_everyOperationIsRestricted(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData(openApiData)
+	assert.Nil(t, err)
+
+	policy := loadTestPolicy(t)
+
+	for path, pathItem := range swagger.Paths {
+		for method, operation := range pathItem.Operations() {
+			if contains(publicOperations, operation.OperationID) {
+				continue
+			}
+			echoPath := pathParameterPattern.ReplaceAllString(path, ":$1")
+			assert.Contains(t, policy, method+" "+echoPath, "operation %s has no roles", operation.OperationID)
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestParseRoles(t *testing.T) {
+	roles, err := parseRoles([]any{"customer", "admin"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []Role{RoleCustomer, RoleAdmin}, roles)
+}
+
+func TestParseRoles_unknownRole(t *testing.T) {
+	_, err := parseRoles([]any{"customer", "superuser"})
+
+	assert.NotNil(t, err)
+}
+
+func TestParseRoles_noRoles(t *testing.T) {
+	_, err := parseRoles([]any{})
+
+	assert.NotNil(t, err)
+}
+
+// newAuthorizedApp returns an echo instance with authentication and authorization middleware (using the policy of the
+// OpenAPI specification) and routes for a restricted and a public operation
+func newAuthorizedApp(t *testing.T, config *TestAuthConfig) *echo.Echo {
+	app := echo.New()
+	app.HTTPErrorHandler = HandleError
+	verifier, err := NewTokenVerifier(config)
+	assert.Nil(t, err)
+	AddAuthenticationMiddleware(app, verifier)
+	assert.Nil(t, AddAuthorizationMiddleware(app))
+
+	respondOk := func(ctx echo.Context) error {
+		return ctx.NoContent(http.StatusOK)
+	}
+	app.GET("/cars/:vin/rentalStatus", respondOk)
+	app.GET("/cars/:vin/trunk", respondOk)
+	return app
+}
+
+func TestAuthorization(t *testing.T) {
+	issuer := newTestTokenIssuer(t)
+	app := newAuthorizedApp(t, testAuthConfig(issuer))
+
+	tests := []struct {
+		name          string
+		target        string
+		authorization string
+		expectedCode  int
+	}{
+		{"fleet manager", "/cars/WVWAA71K08W201030/rentalStatus", issuer.StaffBearerToken("fleetManager"),
+			http.StatusOK},
+		{"admin", "/cars/WVWAA71K08W201030/rentalStatus", issuer.StaffBearerToken("service", "admin"),
+			http.StatusOK},
+		{"customer", "/cars/WVWAA71K08W201030/rentalStatus", issuer.BearerToken("d9COw9vI"),
+			http.StatusForbidden},
+		{"no roles", "/cars/WVWAA71K08W201030/rentalStatus", issuer.StaffBearerToken(),
+			http.StatusForbidden},
+		{"unauthenticated", "/cars/WVWAA71K08W201030/rentalStatus", "", http.StatusUnauthorized},
+		{"public", "/cars/WVWAA71K08W201030/trunk?trunkAccessToken=bumrLuCMbumrLuCMbumrLuCM", "", http.StatusOK},
+		{"unknown route", "/unknown", "", http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := serveAuthenticated(app, test.target, test.authorization)
+
+			assert.Equal(t, test.expectedCode, response.Code)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"RentalManagement/logic/model"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// calendarContentType is the media type of iCalendar (RFC 5545) responses
+const calendarContentType = "text/calendar; charset=utf-8"
+
+const (
+	calendarProductId = "-//RentalManagement//Rentals//EN"
+	// calendarUidDomain makes the UIDs of the rental events globally unique as required by RFC 5545
+	calendarUidDomain = "rentalmanagement"
+	// calendarDateLayout is the UTC form of the DATE-TIME value type of RFC 5545
+	calendarDateLayout = "20060102T150405Z"
+	// calendarLineLength is the maximum number of octets of a content line (without the line break)
+	calendarLineLength = 75
+)
+
+// textEscaper escapes the characters that have a special meaning in TEXT values
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// encodeCalendar encodes the rentals as an RFC 5545 VCALENDAR with one VEVENT per rental. The car of each rental must
+// include its brand and model. The UID of an event is derived from the rental ID, so calendar clients update the
+// event instead of adding a new one when they poll the calendar again. The events of cancelled rentals are kept with
+// the status CANCELLED, so calendar clients remove or mark them, all other events are CONFIRMED.
+func encodeCalendar(rentals []model.Rental, now time.Time) []byte {
+	var calendar strings.Builder
+	writeLine := func(name, value string) {
+		calendar.WriteString(foldLine(name + ":" + value))
+	}
+
+	writeLine("BEGIN", "VCALENDAR")
+	writeLine("VERSION", "2.0")
+	writeLine("PRODID", calendarProductId)
+	writeLine("CALSCALE", "GREGORIAN")
+	writeLine("METHOD", "PUBLISH")
+	writeLine("X-WR-CALNAME", "Rentals")
+
+	for _, rental := range rentals {
+		writeLine("BEGIN", "VEVENT")
+		writeLine("UID", rental.Id+"@"+calendarUidDomain)
+		writeLine("DTSTAMP", now.UTC().Format(calendarDateLayout))
+		writeLine("DTSTART", rental.RentalPeriod.StartDate.UTC().Format(calendarDateLayout))
+		writeLine("DTEND", rental.RentalPeriod.EndDate.UTC().Format(calendarDateLayout))
+		writeLine("SUMMARY", textEscaper.Replace(fmt.Sprintf("Rental of %s %s", rental.Car.Brand, rental.Car.Model)))
+		writeLine("DESCRIPTION", textEscaper.Replace(fmt.Sprintf("Rental %s of the %s %s (VIN %s)",
+			rental.Id, rental.Car.Brand, rental.Car.Model, rental.Car.Vin)))
+		if rental.State == model.CANCELLED {
+			writeLine("STATUS", "CANCELLED")
+			// a cancelled rental does not block the time of the customer
+			writeLine("TRANSP", "TRANSPARENT")
+		} else {
+			writeLine("STATUS", "CONFIRMED")
+			writeLine("TRANSP", "OPAQUE")
+		}
+		writeLine("END", "VEVENT")
+	}
+
+	writeLine("END", "VCALENDAR")
+	return []byte(calendar.String())
+}
+
+// foldLine terminates the content line with CRLF and folds it into lines of at most calendarLineLength octets.
+// Continuation lines start with a space. Multi-octet UTF-8 sequences are not split.
+func foldLine(line string) string {
+	var folded strings.Builder
+	limit := calendarLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		folded.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// the leading space of the continuation line counts towards its length
+		limit = calendarLineLength - 1
+	}
+	folded.WriteString(line + "\r\n")
+	return folded.String()
+}
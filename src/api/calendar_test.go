@@ -0,0 +1,76 @@
+package api
+
+import (
+	"RentalManagement/logic/model"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+var calendarRental = model.Rental{
+	State: model.UPCOMING,
+	Car:   &model.Car{Brand: "Volkswagen", Model: "Golf, Variant", Vin: "WVWAA71K08W201030"},
+	Id:    "rZ6IIwcD",
+	RentalPeriod: model.TimePeriod{
+		StartDate: time.Date(2023, 3, 3, 9, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 3, 5, 18, 30, 0, 0, time.UTC),
+	},
+}
+
+func TestEncodeCalendar(t *testing.T) {
+	now := time.Date(2023, 3, 2, 5, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	calendar := encodeCalendar([]model.Rental{calendarRental}, now)
+
+	assert.Equal(t, "BEGIN:VCALENDAR\r\n"+
+		"VERSION:2.0\r\n"+
+		"PRODID:-//RentalManagement//Rentals//EN\r\n"+
+		"CALSCALE:GREGORIAN\r\n"+
+		"METHOD:PUBLISH\r\n"+
+		"X-WR-CALNAME:Rentals\r\n"+
+		"BEGIN:VEVENT\r\n"+
+		"UID:rZ6IIwcD@rentalmanagement\r\n"+
+		"DTSTAMP:20230302T040000Z\r\n"+
+		"DTSTART:20230303T090000Z\r\n"+
+		"DTEND:20230305T183000Z\r\n"+
+		"SUMMARY:Rental of Volkswagen Golf\\, Variant\r\n"+
+		"DESCRIPTION:Rental rZ6IIwcD of the Volkswagen Golf\\, Variant (VIN WVWAA71K0\r\n"+
+		" 8W201030)\r\n"+
+		"STATUS:CONFIRMED\r\n"+
+		"TRANSP:OPAQUE\r\n"+
+		"END:VEVENT\r\n"+
+		"END:VCALENDAR\r\n", string(calendar))
+}
+
+func TestEncodeCalendar_cancelled(t *testing.T) {
+	cancelledRental := calendarRental
+	cancelledRental.State = model.CANCELLED
+
+	calendar := string(encodeCalendar([]model.Rental{cancelledRental}, time.Now()))
+
+	assert.Contains(t, calendar, "UID:rZ6IIwcD@rentalmanagement\r\n")
+	assert.Contains(t, calendar, "STATUS:CANCELLED\r\nTRANSP:TRANSPARENT\r\n")
+	assert.NotContains(t, calendar, "CONFIRMED")
+}
+
+func TestEncodeCalendar_noRentals(t *testing.T) {
+	calendar := string(encodeCalendar(nil, time.Now()))
+
+	assert.True(t, strings.HasPrefix(calendar, "BEGIN:VCALENDAR\r\n"))
+	assert.True(t, strings.HasSuffix(calendar, "X-WR-CALNAME:Rentals\r\nEND:VCALENDAR\r\n"))
+}
+
+func TestFoldLine(t *testing.T) {
+	assert.Equal(t, "SUMMARY:short\r\n", foldLine("SUMMARY:short"))
+
+	long := strings.Repeat("a", 75) + strings.Repeat("b", 74) + "c"
+	assert.Equal(t, strings.Repeat("a", 75)+"\r\n "+strings.Repeat("b", 74)+"\r\n c\r\n", foldLine(long))
+}
+
+func TestFoldLine_doesNotSplitCharacters(t *testing.T) {
+	// the two-octet ü would start at octet 75
+	line := strings.Repeat("a", 74) + "ü"
+
+	assert.Equal(t, strings.Repeat("a", 74)+"\r\n ü\r\n", foldLine(line))
+}
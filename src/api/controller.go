@@ -1,36 +1,87 @@
 package api
 
 import (
+	"RentalManagement/logging"
 	"RentalManagement/logic/model"
 	"RentalManagement/logic/operations"
 	"RentalManagement/logic/rentalErrors"
 	"RentalManagement/util"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/labstack/echo/v4"
 	"net/http"
+	"net/url"
+	"time"
 )
 
-const (
-	invalidTimePeriodMessage = "startDate must be before endDate"
-	pastTimePeriodMessage    = "startDate must be in the future"
-	carNotFoundMessage       = "car not found"
+var (
+	errInvalidTimePeriod = newProblemError(http.StatusBadRequest, model.ErrorCodeINVALIDTIMEPERIOD,
+		"startDate must be before endDate")
+	errPastTimePeriod = newProblemError(http.StatusForbidden, model.ErrorCodePASTTIMEPERIOD,
+		"startDate must be in the future")
+	errMissingLockStateActor = newProblemError(http.StatusBadRequest, model.ErrorCodeINVALIDLOCKSTATEACTOR,
+		"either customerId or trunkAccessToken must be specified")
+	errAmbiguousLockStateActor = newProblemError(http.StatusBadRequest, model.ErrorCodeINVALIDLOCKSTATEACTOR,
+		"only one of customerId or trunkAccessToken can be specified")
+	errExportPeriodTooLong = newProblemError(http.StatusBadRequest, model.ErrorCodeINVALIDTIMEPERIOD,
+		fmt.Sprintf("the time period of an export must not be longer than %d days", maxExportPeriod/(24*time.Hour)))
+	errInvalidWebhookUrl = newProblemError(http.StatusBadRequest, model.ErrorCodeINVALIDWEBHOOKURL,
+		"url must be an absolute http or https URL")
 )
 
+// carStateKeepAliveInterval is the interval in which a comment is sent on an idle car state stream, so that proxies do
+// not close the connection
+const carStateKeepAliveInterval = 15 * time.Second
+
 type controller struct {
 	operations   operations.IOperations
 	timeProvider util.ITimeProvider
+	// publicBaseUrl is the URL under which clients reach the API, URLs handed out to clients are built from it
+	publicBaseUrl string
+	// streams is done when the server shuts down, which ends the open streams, as they would never finish otherwise
+	streams context.Context
 }
 
-func NewController(operations operations.IOperations, timeProvider util.ITimeProvider) ServerInterface {
+func NewController(operations operations.IOperations, timeProvider util.ITimeProvider, publicBaseUrl string,
+	streams context.Context) ServerInterface {
 	return controller{
 		operations,
 		timeProvider,
+		publicBaseUrl,
+		streams,
 	}
 }
 
+func (c controller) CreateCalendarSubscription(ctx echo.Context,
+	params model.CreateCalendarSubscriptionParams) error {
+
+	token, err := c.operations.CreateCalendarToken(ctx.Request().Context(), params.CustomerId)
+	if err != nil {
+		return err
+	}
+
+	// calendar clients poll the URL without credentials, so it must be absolute. It is not derived from the request,
+	// whose Host header and scheme are controlled by the client and rewritten by proxies.
+	subscriptionUrl, err := url.JoinPath(c.publicBaseUrl, "calendars", token, "rentals.ics")
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusCreated, model.CalendarSubscription{Url: subscriptionUrl})
+}
+
+func (c controller) GetSubscribedRentalCalendar(ctx echo.Context, calendarToken model.CalendarTokenParam) error {
+	rentals, err := c.operations.GetOverviewByCalendarToken(ctx.Request().Context(), calendarToken)
+	if err != nil {
+		return err
+	}
+	return ctx.Blob(http.StatusOK, calendarContentType, encodeCalendar(*rentals, c.timeProvider.Now()))
+}
+
 func (c controller) GetAvailableCars(ctx echo.Context, params model.GetAvailableCarsParams) error {
 	if isInvalidTimePeriod(params.TimePeriod) {
-		return echo.NewHTTPError(http.StatusBadRequest, invalidTimePeriodMessage)
+		return errInvalidTimePeriod
 	}
 	cars, err := c.operations.GetAvailableCars(ctx.Request().Context(), params.TimePeriod)
 	if err != nil {
@@ -41,9 +92,6 @@ func (c controller) GetAvailableCars(ctx echo.Context, params model.GetAvailable
 
 func (c controller) GetCar(ctx echo.Context, vin model.VinParam) error {
 	car, err := c.operations.GetCar(ctx.Request().Context(), vin)
-	if errors.Is(err, rentalErrors.ErrCarNotFound) {
-		return echo.NewHTTPError(http.StatusNotFound, carNotFoundMessage)
-	}
 	if err != nil {
 		return err
 	}
@@ -52,9 +100,6 @@ func (c controller) GetCar(ctx echo.Context, vin model.VinParam) error {
 
 func (c controller) GetNextRental(ctx echo.Context, vin model.VinParam) error {
 	rental, err := c.operations.GetNextRental(ctx.Request().Context(), vin)
-	if errors.Is(err, rentalErrors.ErrCarNotFound) {
-		return echo.NewHTTPError(http.StatusNotFound, carNotFoundMessage)
-	}
 	if err != nil {
 		return err
 	}
@@ -73,19 +118,13 @@ func (c controller) CreateRental(ctx echo.Context, vin model.VinParam, params mo
 	}
 
 	if isInvalidTimePeriod(timePeriod) {
-		return echo.NewHTTPError(http.StatusBadRequest, invalidTimePeriodMessage)
+		return errInvalidTimePeriod
 	}
 	if timePeriod.StartDate.Before(c.timeProvider.Now()) {
-		return echo.NewHTTPError(http.StatusForbidden, pastTimePeriodMessage)
+		return errPastTimePeriod
 	}
 
 	err = c.operations.CreateRental(ctx.Request().Context(), vin, params.CustomerId, timePeriod)
-	if errors.Is(err, rentalErrors.ErrCarNotFound) {
-		return echo.NewHTTPError(http.StatusNotFound, carNotFoundMessage)
-	}
-	if errors.Is(err, rentalErrors.ErrConflictingRentalExists) {
-		return echo.NewHTTPError(http.StatusConflict, "conflicting rental exists")
-	}
 	if err != nil {
 		return err
 	}
@@ -95,10 +134,6 @@ func (c controller) CreateRental(ctx echo.Context, vin model.VinParam, params mo
 func (c controller) GetLockState(ctx echo.Context, vin model.VinParam, params model.GetLockStateParams) error {
 	lockState, err := c.operations.GetLockState(ctx.Request().Context(), vin, params.TrunkAccessToken)
 
-	if errors.Is(err, rentalErrors.ErrTrunkAccessDenied) {
-		return echo.NewHTTPError(http.StatusForbidden, "trunk access denied")
-	}
-
 	if err != nil {
 		return err
 	}
@@ -112,11 +147,11 @@ func (c controller) GetLockState(ctx echo.Context, vin model.VinParam, params mo
 
 func (c controller) SetLockState(ctx echo.Context, vin model.VinParam, params model.SetLockStateParams) error {
 	if params.CustomerId == nil && params.TrunkAccessToken == nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "either customerId or trunkAccessToken must be specified")
+		return errMissingLockStateActor
 	}
 
 	if params.CustomerId != nil && params.TrunkAccessToken != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "only one of customerId or trunkAccessToken can be specified")
+		return errAmbiguousLockStateActor
 	}
 
 	var lockStateObject model.LockStateObject
@@ -137,10 +172,6 @@ func (c controller) SetLockState(ctx echo.Context, vin model.VinParam, params mo
 			vin, *params.TrunkAccessToken)
 	}
 
-	if errors.Is(err, rentalErrors.ErrTrunkAccessDenied) {
-		return echo.NewHTTPError(http.StatusForbidden, "trunk access denied")
-	}
-
 	if err != nil {
 		return err
 	}
@@ -148,6 +179,14 @@ func (c controller) SetLockState(ctx echo.Context, vin model.VinParam, params mo
 	return ctx.NoContent(http.StatusNoContent)
 }
 
+func (c controller) GetCarTrunkAccessLog(ctx echo.Context, vin model.VinParam) error {
+	logEntries, err := c.operations.GetTrunkAccessLogOfCar(ctx.Request().Context(), vin)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, *logEntries)
+}
+
 func (c controller) GetOverview(ctx echo.Context, params model.GetOverviewParams) error {
 	rentals, err := c.operations.GetOverview(ctx.Request().Context(), params.CustomerId)
 	if err != nil {
@@ -156,47 +195,240 @@ func (c controller) GetOverview(ctx echo.Context, params model.GetOverviewParams
 	return ctx.JSON(http.StatusOK, *rentals)
 }
 
+func (c controller) GetRentalCalendar(ctx echo.Context, params model.GetRentalCalendarParams) error {
+	rentals, err := c.operations.GetOverview(ctx.Request().Context(), params.CustomerId)
+	if err != nil {
+		return err
+	}
+	return ctx.Blob(http.StatusOK, calendarContentType, encodeCalendar(*rentals, c.timeProvider.Now()))
+}
+
+func (c controller) ExportRentals(ctx echo.Context, params model.ExportRentalsParams) error {
+	if isInvalidTimePeriod(params.TimePeriod) {
+		return errInvalidTimePeriod
+	}
+	if params.TimePeriod.EndDate.Sub(params.TimePeriod.StartDate) > maxExportPeriod {
+		return errExportPeriodTooLong
+	}
+	rentals, err := c.operations.GetRentalsInPeriod(ctx.Request().Context(), params.TimePeriod)
+	if err != nil {
+		return err
+	}
+
+	response := ctx.Response()
+	response.Header().Set(echo.HeaderContentType, csvContentType)
+	response.Header().Set(echo.HeaderContentDisposition, `attachment; filename="rentals.csv"`)
+	response.WriteHeader(http.StatusOK)
+	return writeRentalsCsv(response, *rentals)
+}
+
+func (c controller) ImportRentals(ctx echo.Context) error {
+	rows, err := readRentalImportCsv(ctx.Request().Body)
+	if err != nil {
+		return newProblemError(http.StatusBadRequest, model.ErrorCodeINVALIDRENTALIMPORT,
+			"invalid rental import: "+err.Error())
+	}
+
+	report := model.RentalImportReport{Rows: make([]model.RentalImportResult, 0, len(rows))}
+	for _, row := range rows {
+		result := c.importRental(ctx.Request().Context(), row)
+		if result.Status == model.CREATED {
+			report.Created++
+		} else {
+			report.Failed++
+		}
+		report.Rows = append(report.Rows, result)
+	}
+	return ctx.JSON(http.StatusOK, report)
+}
+
+// importRental creates the rental of a row of a rental import. Unlike CreateRental, rentals in the past are allowed,
+// so that rentals can be migrated from other systems.
+func (c controller) importRental(ctx context.Context, row rentalImportRow) model.RentalImportResult {
+	result := model.RentalImportResult{Row: row.line, Vin: row.vin, CustomerId: row.customerId}
+	if row.err != nil {
+		result.Status = model.INVALID
+		result.Message = row.err.Error()
+		return result
+	}
+
+	err := c.operations.CreateRental(ctx, row.vin, row.customerId, row.timePeriod)
+	switch {
+	case err == nil:
+		result.Status = model.CREATED
+	case errors.Is(err, rentalErrors.ErrConflictingRentalExists):
+		result.Status = model.CONFLICT
+		result.Message = "conflicting rental exists"
+	case errors.Is(err, rentalErrors.ErrCarNotFound):
+		result.Status = model.CARNOTFOUND
+		result.Message = rentalErrors.ErrCarNotFound.Error()
+	default:
+		logging.FromContext(ctx).Error("rental import failed", "line", row.line, logging.KeyVin, row.vin,
+			logging.KeyError, err)
+		result.Status = model.ERROR
+		result.Message = "internal error"
+	}
+	return result
+}
+
 func (c controller) GetRentalStatus(ctx echo.Context, rentalId model.RentalIdParam) error {
 	rental, err := c.operations.GetRentalStatus(ctx.Request().Context(), rentalId)
-	if errors.Is(err, rentalErrors.ErrRentalNotFound) {
-		return echo.NewHTTPError(http.StatusNotFound, "rentalId not found")
-	}
 	if err != nil {
 		return err
 	}
 	return ctx.JSON(http.StatusOK, *rental)
 }
 
+func (c controller) SetAutoRelock(ctx echo.Context, rentalId model.RentalIdParam) error {
+	var autoRelock model.SetAutoRelockJSONRequestBody
+	// bind errors are unexpected because the auto relock is validated by the Swagger spec
+	err := ctx.Bind(&autoRelock)
+	if err != nil {
+		return err
+	}
+
+	err = c.operations.SetAutoRelock(ctx.Request().Context(), rentalId, autoRelock)
+	if err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (c controller) CancelRental(ctx echo.Context, rentalId model.RentalIdParam) error {
+	err := c.operations.CancelRental(ctx.Request().Context(), rentalId)
+	if err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (c controller) StreamCarState(ctx echo.Context, rentalId model.RentalIdParam) error {
+	requestContext := ctx.Request().Context()
+	states, err := c.operations.WatchCarState(requestContext, rentalId)
+	if err != nil {
+		return err
+	}
+
+	response := ctx.Response()
+	response.Header().Set(echo.HeaderContentType, "text/event-stream")
+	response.Header().Set(echo.HeaderCacheControl, "no-cache")
+	response.WriteHeader(http.StatusOK)
+	response.Flush()
+
+	keepAlive := time.NewTicker(carStateKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case state, ok := <-states:
+			if !ok {
+				// the stream has ended because the rental has expired, unless the client has gone away
+				if requestContext.Err() == nil {
+					_, _ = fmt.Fprint(response, "event: rentalExpired\ndata: {}\n\n")
+					response.Flush()
+				}
+				return nil
+			}
+			payload, err := json.Marshal(state)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(response, "event: carState\ndata: %s\n\n", payload)
+		case <-keepAlive.C:
+			_, _ = fmt.Fprint(response, ": keep-alive\n\n")
+		case <-c.streams.Done():
+			// the server shuts down, clients reconnect to another instance
+			return nil
+		}
+		response.Flush()
+	}
+}
+
+func (c controller) GetRentalTrunkAccessLog(ctx echo.Context, rentalId model.RentalIdParam) error {
+	logEntries, err := c.operations.GetTrunkAccessLogOfRental(ctx.Request().Context(), rentalId)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, *logEntries)
+}
+
 func (c controller) GrantTrunkAccess(ctx echo.Context, rentalId model.RentalIdParam) error {
-	var timePeriod model.TimePeriod
-	// bind errors are unexpected because the timePeriod is validated by the Swagger spec
-	err := ctx.Bind(&timePeriod)
+	var grant model.GrantTrunkAccessJSONRequestBody
+	// bind errors are unexpected because the grant is validated by the Swagger spec
+	err := ctx.Bind(&grant)
 	if err != nil {
 		return err
 	}
 
+	timePeriod := model.TimePeriod{StartDate: grant.StartDate, EndDate: grant.EndDate}
 	if isInvalidTimePeriod(timePeriod) {
-		return echo.NewHTTPError(http.StatusBadRequest, invalidTimePeriodMessage)
+		return errInvalidTimePeriod
+	}
+	if grant.Recurrence != nil {
+		if err := grant.Recurrence.Validate(); err != nil {
+			return newProblemError(http.StatusBadRequest, model.ErrorCodeINVALIDRECURRENCE,
+				"invalid recurrence: "+err.Error())
+		}
 	}
 
-	trunkAccess, err := c.operations.GrantTrunkAccess(ctx.Request().Context(), rentalId, timePeriod)
-	if errors.Is(err, rentalErrors.ErrRentalNotFound) {
-		return echo.NewHTTPError(http.StatusNotFound, "rental not found")
+	trunkAccess, err := c.operations.GrantTrunkAccess(ctx.Request().Context(), rentalId, timePeriod, grant.Recurrence)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusCreated, trunkAccess)
+}
+
+func (c controller) GetWebhooks(ctx echo.Context) error {
+	webhooks, err := c.operations.GetWebhooks(ctx.Request().Context())
+	if err != nil {
+		return err
 	}
-	if errors.Is(err, rentalErrors.ErrRentalNotActive) {
-		return echo.NewHTTPError(http.StatusForbidden, "rental not active")
+	return ctx.JSON(http.StatusOK, *webhooks)
+}
+
+func (c controller) RegisterWebhook(ctx echo.Context) error {
+	var registration model.RegisterWebhookJSONRequestBody
+	// bind errors are unexpected because the registration is validated by the Swagger spec
+	err := ctx.Bind(&registration)
+	if err != nil {
+		return err
+	}
+
+	if !isWebhookUrl(registration.Url) {
+		return errInvalidWebhookUrl
 	}
-	if errors.Is(err, rentalErrors.ErrRentalNotOverlapping) {
-		return echo.NewHTTPError(http.StatusForbidden, "rental not overlapping")
+
+	webhook, err := c.operations.RegisterWebhook(ctx.Request().Context(), registration)
+	if err != nil {
+		return err
 	}
-	if errors.Is(err, rentalErrors.ErrResourceConflict) {
-		return echo.NewHTTPError(http.StatusServiceUnavailable, "failed to grant trunk access")
+	return ctx.JSON(http.StatusCreated, webhook)
+}
+
+func (c controller) DeleteWebhook(ctx echo.Context, webhookId model.WebhookIdParam) error {
+	err := c.operations.DeleteWebhook(ctx.Request().Context(), webhookId)
+	if err != nil {
+		return err
 	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (c controller) GetWebhookDeliveries(ctx echo.Context, webhookId model.WebhookIdParam) error {
+	deliveries, err := c.operations.GetWebhookDeliveries(ctx.Request().Context(), webhookId)
 	if err != nil {
 		return err
 	}
+	return ctx.JSON(http.StatusOK, *deliveries)
+}
 
-	return ctx.JSON(http.StatusCreated, trunkAccess)
+// isWebhookUrl reports whether rental events can be posted to the URL, i.e. whether it is an absolute http(s) URL
+func isWebhookUrl(rawUrl string) bool {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return false
+	}
+	return (parsedUrl.Scheme == "http" || parsedUrl.Scheme == "https") && parsedUrl.Host != ""
 }
 
 func isInvalidTimePeriod(timePeriod model.TimePeriod) bool {
@@ -9,6 +9,8 @@ import (
 	"errors"
 	"github.com/labstack/echo/v4"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,6 +21,8 @@ import (
 
 var exampleCustomerID = "customer@example.com"
 
+var examplePublicBaseUrl = "https://rentals.example.com/api"
+
 var availableCar1 = model.CarAvailable{
 	Brand:         "Audi",
 	Model:         "A3",
@@ -135,6 +139,27 @@ var trunkAccess = model.TrunkAccess{
 	},
 }
 
+var trunkAccessGrant = model.TrunkAccessGrant{
+	StartDate: trunkAccess.ValidityPeriod.StartDate,
+	EndDate:   trunkAccess.ValidityPeriod.EndDate,
+}
+
+var invalidTrunkAccessGrant = model.TrunkAccessGrant{
+	StartDate: invalidTimePeriod.StartDate,
+	EndDate:   invalidTimePeriod.EndDate,
+}
+
+var recurringTrunkAccessGrant = model.TrunkAccessGrant{
+	StartDate: trunkAccess.ValidityPeriod.StartDate,
+	EndDate:   trunkAccess.ValidityPeriod.EndDate,
+	Recurrence: &model.Recurrence{
+		DaysOfWeek: []model.Weekday{model.MONDAY},
+		StartTime:  "08:00",
+		EndTime:    "12:00",
+		TimeZone:   "Europe/Berlin",
+	},
+}
+
 func TestController_GetAvailableCars_success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -152,7 +177,7 @@ func TestController_GetAvailableCars_success(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GetAvailableCars(mockEchoContext, model.GetAvailableCarsParams{TimePeriod: timePeriod})
 	assert.Nil(t, err)
 }
@@ -174,7 +199,7 @@ func TestController_GetAvailableCars_OperationsError(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GetAvailableCars(mockEchoContext, model.GetAvailableCarsParams{TimePeriod: timePeriod})
 	assert.ErrorIs(t, err, operationsError)
 }
@@ -187,10 +212,10 @@ func TestController_GetAvailableCars_InvalidTimePeriod(t *testing.T) {
 	mockOperations := mocks.NewMockIOperations(ctrl)
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GetAvailableCars(mockEchoContext, model.GetAvailableCarsParams{TimePeriod: invalidTimePeriod})
 
-	assert.Equal(t, echo.NewHTTPError(http.StatusBadRequest, "startDate must be before endDate"), err)
+	assert.Equal(t, errInvalidTimePeriod, err)
 }
 
 func TestController_GetCar_success(t *testing.T) {
@@ -210,7 +235,7 @@ func TestController_GetCar_success(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GetCar(mockEchoContext, testdata.VinCar)
 	assert.Nil(t, err)
 }
@@ -232,7 +257,7 @@ func TestController_GetCar_OperationsError(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GetCar(mockEchoContext, testdata.VinCar)
 	assert.ErrorIs(t, err, operationsError)
 }
@@ -253,9 +278,9 @@ func TestController_GetCar_NotFound(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GetCar(mockEchoContext, testdata.VinCar)
-	assert.Equal(t, echo.NewHTTPError(http.StatusNotFound, "car not found"), err)
+	assert.ErrorIs(t, err, rentalErrors.ErrCarNotFound)
 }
 
 func TestController_GetNextRental_success_exists(t *testing.T) {
@@ -275,7 +300,7 @@ func TestController_GetNextRental_success_exists(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GetNextRental(mockEchoContext, testdata.VinCar)
 	assert.Nil(t, err)
 }
@@ -297,7 +322,7 @@ func TestController_GetNextRental_success_notExists(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GetNextRental(mockEchoContext, testdata.VinCar)
 	assert.Nil(t, err)
 }
@@ -318,9 +343,9 @@ func TestController_GetNextRental_UnknownCar(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GetNextRental(mockEchoContext, testdata.VinCar)
-	assert.Equal(t, echo.NewHTTPError(http.StatusNotFound, "car not found"), err)
+	assert.ErrorIs(t, err, rentalErrors.ErrCarNotFound)
 }
 
 func TestController_GetNextRental_OperationsError(t *testing.T) {
@@ -341,7 +366,7 @@ func TestController_GetNextRental_OperationsError(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GetNextRental(mockEchoContext, testdata.VinCar)
 	assert.ErrorIs(t, err, operationsError)
 }
@@ -365,7 +390,7 @@ func TestController_CreateRental_success(t *testing.T) {
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 	mockTime.EXPECT().Now().Return(currentTime)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.CreateRental(mockEchoContext, testdata.VinCar,
 		model.CreateRentalParams{CustomerId: exampleCustomerID})
 	assert.Nil(t, err)
@@ -390,7 +415,7 @@ func TestController_CreateRental_OperationsError(t *testing.T) {
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 	mockTime.EXPECT().Now().Return(currentTime)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.CreateRental(mockEchoContext, testdata.VinCar,
 		model.CreateRentalParams{CustomerId: exampleCustomerID})
 	assert.ErrorIs(t, err, operationsError)
@@ -415,10 +440,10 @@ func TestController_CreateRental_CarNotFound(t *testing.T) {
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 	mockTime.EXPECT().Now().Return(currentTime)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.CreateRental(mockEchoContext, testdata.VinCar,
 		model.CreateRentalParams{CustomerId: exampleCustomerID})
-	assert.Equal(t, echo.NewHTTPError(http.StatusNotFound, "car not found"), err)
+	assert.ErrorIs(t, err, rentalErrors.ErrCarNotFound)
 }
 
 func TestController_CreateRental_invalidTimePeriod(t *testing.T) {
@@ -432,11 +457,11 @@ func TestController_CreateRental_invalidTimePeriod(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.CreateRental(mockEchoContext, testdata.VinCar,
 		model.CreateRentalParams{CustomerId: exampleCustomerID})
 
-	assert.Equal(t, echo.NewHTTPError(http.StatusBadRequest, "startDate must be before endDate"), err)
+	assert.Equal(t, errInvalidTimePeriod, err)
 }
 
 func TestController_CreateRental_Past(t *testing.T) {
@@ -451,11 +476,11 @@ func TestController_CreateRental_Past(t *testing.T) {
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 	mockTime.EXPECT().Now().Return(future)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.CreateRental(mockEchoContext, testdata.VinCar,
 		model.CreateRentalParams{CustomerId: exampleCustomerID})
 
-	assert.Equal(t, echo.NewHTTPError(http.StatusForbidden, "startDate must be in the future"), err)
+	assert.Equal(t, errPastTimePeriod, err)
 }
 
 func TestController_CreateRental_ConflictingRental(t *testing.T) {
@@ -477,11 +502,11 @@ func TestController_CreateRental_ConflictingRental(t *testing.T) {
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 	mockTime.EXPECT().Now().Return(currentTime)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.CreateRental(mockEchoContext, testdata.VinCar,
 		model.CreateRentalParams{CustomerId: exampleCustomerID})
 
-	assert.Equal(t, echo.NewHTTPError(http.StatusConflict, "conflicting rental exists"), err)
+	assert.ErrorIs(t, err, rentalErrors.ErrConflictingRentalExists)
 }
 
 func TestController_GetOverview_success(t *testing.T) {
@@ -501,7 +526,7 @@ func TestController_GetOverview_success(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GetOverview(mockContext, model.GetOverviewParams{CustomerId: exampleCustomerID})
 	assert.Nil(t, err)
 }
@@ -523,11 +548,276 @@ func TestController_GetOverview_operationsError(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GetOverview(mockContext, model.GetOverviewParams{CustomerId: exampleCustomerID})
 	assert.ErrorIs(t, err, operationsError)
 }
 
+func TestController_GetRentalCalendar_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	now := time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC)
+
+	request, _ := http.NewRequestWithContext(ctx, "GET", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+	mockContext.EXPECT().Blob(http.StatusOK, "text/calendar; charset=utf-8", encodeCalendar(customerRentalsShort, now))
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().GetOverview(ctx, exampleCustomerID).Return(&customerRentalsShort, nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.GetRentalCalendar(mockContext, model.GetRentalCalendarParams{CustomerId: exampleCustomerID})
+	assert.Nil(t, err)
+}
+
+var rentalBackOffice = model.Rental{
+	State:    model.EXPIRED,
+	Car:      &model.Car{Vin: "WVWAA71K08W201030"},
+	Customer: &model.Customer{CustomerId: exampleCustomerID},
+	Id:       "rZ6IIwcD",
+	RentalPeriod: model.TimePeriod{
+		StartDate: time.Date(2023, 2, 3, 8, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 2, 5, 18, 30, 0, 0, time.UTC),
+	},
+}
+
+func TestController_ExportRentals_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	request, _ := http.NewRequestWithContext(ctx, "GET", "", nil)
+	recorder := httptest.NewRecorder()
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+	mockContext.EXPECT().Response().Return(echo.NewResponse(recorder, echo.New()))
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().GetRentalsInPeriod(ctx, timePeriod).Return(&[]model.Rental{rentalBackOffice}, nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.ExportRentals(mockContext, model.ExportRentalsParams{TimePeriod: timePeriod})
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="rentals.csv"`, recorder.Header().Get("Content-Disposition"))
+	assert.Equal(t, "vin,rentalId,customerId,startDate,endDate,state\n"+
+		"WVWAA71K08W201030,rZ6IIwcD,customer@example.com,2023-02-03T08:00:00Z,2023-02-05T18:30:00Z,EXPIRED\n",
+		recorder.Body.String())
+}
+
+func TestController_ExportRentals_invalidTimePeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.ExportRentals(mockContext, model.ExportRentalsParams{TimePeriod: invalidTimePeriod})
+
+	assert.Equal(t, errInvalidTimePeriod, err)
+}
+
+func TestController_ExportRentals_periodTooLong(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.ExportRentals(mockContext, model.ExportRentalsParams{TimePeriod: model.TimePeriod{
+		StartDate: timePeriod.StartDate,
+		EndDate:   timePeriod.StartDate.Add(maxExportPeriod + time.Second),
+	}})
+
+	assert.Equal(t, errExportPeriodTooLong, err)
+}
+
+func TestController_ExportRentals_operationsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	operationsError := errors.New("operations error")
+
+	request, _ := http.NewRequestWithContext(ctx, "GET", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().GetRentalsInPeriod(ctx, timePeriod).Return(nil, operationsError)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.ExportRentals(mockContext, model.ExportRentalsParams{TimePeriod: timePeriod})
+
+	assert.ErrorIs(t, err, operationsError)
+}
+
+func TestController_ImportRentals_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	body := "customerId,vin,startDate,endDate\n" +
+		"customer@example.com,WVWAA71K08W201030,2023-02-01T00:00:00Z,2023-03-01T00:00:00Z\n" +
+		"customer@example.com,WVWAA71K08W201030,2023-02-01T00:00:00Z,2023-03-01T00:00:00Z\n" +
+		"customer@example.com,1FVNY5Y90HP312888,2023-02-01T00:00:00Z,2023-03-01T00:00:00Z\n" +
+		"customer@example.com,1FVNY5Y90HP312888,2023-03-01T00:00:00Z,2023-02-01T00:00:00Z\n" +
+		"other@example.com,WVWAA71K08W201030,2023-04-01T00:00:00Z,2023-05-01T00:00:00Z\n"
+
+	request, _ := http.NewRequestWithContext(ctx, "POST", "", strings.NewReader(body))
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request).AnyTimes()
+	mockContext.EXPECT().JSON(http.StatusOK, model.RentalImportReport{
+		Created: 1,
+		Failed:  4,
+		Rows: []model.RentalImportResult{
+			{Row: 2, Status: model.CREATED, Vin: "WVWAA71K08W201030", CustomerId: exampleCustomerID},
+			{Row: 3, Status: model.CONFLICT, Vin: "WVWAA71K08W201030", CustomerId: exampleCustomerID,
+				Message: "conflicting rental exists"},
+			{Row: 4, Status: model.CARNOTFOUND, Vin: "1FVNY5Y90HP312888", CustomerId: exampleCustomerID,
+				Message: "car not found"},
+			{Row: 5, Status: model.INVALID, Vin: "1FVNY5Y90HP312888", CustomerId: exampleCustomerID,
+				Message: "startDate must be before endDate"},
+			{Row: 6, Status: model.ERROR, Vin: "WVWAA71K08W201030", CustomerId: "other@example.com",
+				Message: "internal error"},
+		},
+	})
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	gomock.InOrder(
+		mockOperations.EXPECT().CreateRental(ctx, "WVWAA71K08W201030", exampleCustomerID, timePeriod).Return(nil),
+		mockOperations.EXPECT().CreateRental(ctx, "WVWAA71K08W201030", exampleCustomerID, timePeriod).
+			Return(rentalErrors.ErrConflictingRentalExists),
+		mockOperations.EXPECT().CreateRental(ctx, "1FVNY5Y90HP312888", exampleCustomerID, timePeriod).
+			Return(rentalErrors.ErrCarNotFound),
+		mockOperations.EXPECT().CreateRental(ctx, "WVWAA71K08W201030", "other@example.com", gomock.Any()).
+			Return(errors.New("operations error")),
+	)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.ImportRentals(mockContext)
+	assert.Nil(t, err)
+}
+
+func TestController_ImportRentals_invalidFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	body := "vin,startDate,endDate\nWVWAA71K08W201030,2023-02-01T00:00:00Z,2023-03-01T00:00:00Z\n"
+
+	request, _ := http.NewRequestWithContext(ctx, "POST", "", strings.NewReader(body))
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.ImportRentals(mockContext)
+
+	assert.Equal(t, newProblemError(http.StatusBadRequest, model.ErrorCodeINVALIDRENTALIMPORT,
+		"invalid rental import: the header row must contain the column customerId"), err)
+}
+
+func TestController_CreateCalendarSubscription_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	token := "Qm7Ax0tWbLk2ZsPfR4uNcYe9JhVd3oGi"
+
+	// the URL does not depend on the host the request was sent to
+	request, _ := http.NewRequestWithContext(ctx, "POST", "http://internal:8080/calendarSubscriptions", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request).AnyTimes()
+	mockContext.EXPECT().JSON(http.StatusCreated, model.CalendarSubscription{
+		Url: "https://rentals.example.com/api/calendars/" + token + "/rentals.ics",
+	})
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().CreateCalendarToken(ctx, exampleCustomerID).Return(token, nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.CreateCalendarSubscription(mockContext,
+		model.CreateCalendarSubscriptionParams{CustomerId: exampleCustomerID})
+	assert.Nil(t, err)
+}
+
+func TestController_GetSubscribedRentalCalendar_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	now := time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC)
+	token := "Qm7Ax0tWbLk2ZsPfR4uNcYe9JhVd3oGi"
+
+	request, _ := http.NewRequestWithContext(ctx, "GET", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+	mockContext.EXPECT().Blob(http.StatusOK, "text/calendar; charset=utf-8", encodeCalendar(customerRentalsShort, now))
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().GetOverviewByCalendarToken(ctx, token).Return(&customerRentalsShort, nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.GetSubscribedRentalCalendar(mockContext, token)
+	assert.Nil(t, err)
+}
+
+func TestController_GetSubscribedRentalCalendar_unknownToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	token := "Qm7Ax0tWbLk2ZsPfR4uNcYe9JhVd3oGi"
+
+	request, _ := http.NewRequestWithContext(ctx, "GET", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().GetOverviewByCalendarToken(ctx, token).Return(nil, rentalErrors.ErrCalendarNotFound)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.GetSubscribedRentalCalendar(mockContext, token)
+	assert.ErrorIs(t, err, rentalErrors.ErrCalendarNotFound)
+}
+
 func TestController_GrantTrunkAccess_success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -538,16 +828,16 @@ func TestController_GrantTrunkAccess_success(t *testing.T) {
 
 	mockContext := mocks.NewMockContext(ctrl)
 	mockContext.EXPECT().Request().Return(request)
-	mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, trunkAccess.ValidityPeriod).Return(nil)
+	mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, trunkAccessGrant).Return(nil)
 	mockContext.EXPECT().JSON(http.StatusCreated, &trunkAccess)
 
 	mockOperations := mocks.NewMockIOperations(ctrl)
-	mockOperations.EXPECT().GrantTrunkAccess(ctx, "rentalId", trunkAccess.ValidityPeriod).
+	mockOperations.EXPECT().GrantTrunkAccess(ctx, "rentalId", trunkAccess.ValidityPeriod, nil).
 		Return(&trunkAccess, nil)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GrantTrunkAccess(mockContext, "rentalId")
 
 	assert.Nil(t, err)
@@ -558,15 +848,74 @@ func TestController_GrantTrinkAccess_invalidTimePeriod(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockContext := mocks.NewMockContext(ctrl)
-	mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, invalidTimePeriod).Return(nil)
+	mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, invalidTrunkAccessGrant).Return(nil)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.GrantTrunkAccess(mockContext, "rentalId")
+
+	assert.Equal(t, errInvalidTimePeriod, err)
+}
+
+func TestController_GrantTrunkAccess_success_recurring(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	request, _ := http.NewRequestWithContext(ctx, "POST", "", nil)
+
+	recurringTrunkAccess := model.TrunkAccess{
+		Token:          trunkAccess.Token,
+		ValidityPeriod: trunkAccess.ValidityPeriod,
+		Recurrence:     recurringTrunkAccessGrant.Recurrence,
+	}
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+	mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, recurringTrunkAccessGrant).Return(nil)
+	mockContext.EXPECT().JSON(http.StatusCreated, &recurringTrunkAccess)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().GrantTrunkAccess(ctx, "rentalId", trunkAccess.ValidityPeriod,
+		recurringTrunkAccessGrant.Recurrence).Return(&recurringTrunkAccess, nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.GrantTrunkAccess(mockContext, "rentalId")
+
+	assert.Nil(t, err)
+}
+
+func TestController_GrantTrunkAccess_invalidRecurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	grant := model.TrunkAccessGrant{
+		StartDate: trunkAccess.ValidityPeriod.StartDate,
+		EndDate:   trunkAccess.ValidityPeriod.EndDate,
+		Recurrence: &model.Recurrence{
+			DaysOfWeek: []model.Weekday{model.MONDAY},
+			StartTime:  "08:00",
+			EndTime:    "12:00",
+			TimeZone:   "Middle/Earth",
+		},
+	}
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, grant).Return(nil)
 
 	mockOperations := mocks.NewMockIOperations(ctrl)
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GrantTrunkAccess(mockContext, "rentalId")
 
-	assert.Equal(t, echo.NewHTTPError(http.StatusBadRequest, "startDate must be before endDate"), err)
+	assert.Equal(t, newProblemError(http.StatusBadRequest, model.ErrorCodeINVALIDRECURRENCE,
+		"invalid recurrence: unknown time zone \"Middle/Earth\""), err)
 }
 
 func TestController_GrantTrunkAccess_rentalNotFound(t *testing.T) {
@@ -579,18 +928,18 @@ func TestController_GrantTrunkAccess_rentalNotFound(t *testing.T) {
 
 	mockContext := mocks.NewMockContext(ctrl)
 	mockContext.EXPECT().Request().Return(request)
-	mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, trunkAccess.ValidityPeriod).Return(nil)
+	mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, trunkAccessGrant).Return(nil)
 
 	mockOperations := mocks.NewMockIOperations(ctrl)
-	mockOperations.EXPECT().GrantTrunkAccess(ctx, "rentalId", trunkAccess.ValidityPeriod).
+	mockOperations.EXPECT().GrantTrunkAccess(ctx, "rentalId", trunkAccess.ValidityPeriod, nil).
 		Return(nil, rentalErrors.ErrRentalNotFound)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GrantTrunkAccess(mockContext, "rentalId")
 
-	assert.Equal(t, echo.NewHTTPError(http.StatusNotFound, "rental not found"), err)
+	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
 }
 
 func TestController_GrantTrunkAccess_rentalNotActive(t *testing.T) {
@@ -603,18 +952,18 @@ func TestController_GrantTrunkAccess_rentalNotActive(t *testing.T) {
 
 	mockContext := mocks.NewMockContext(ctrl)
 	mockContext.EXPECT().Request().Return(request)
-	mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, trunkAccess.ValidityPeriod).Return(nil)
+	mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, trunkAccessGrant).Return(nil)
 
 	mockOperations := mocks.NewMockIOperations(ctrl)
-	mockOperations.EXPECT().GrantTrunkAccess(ctx, "rentalId", trunkAccess.ValidityPeriod).
+	mockOperations.EXPECT().GrantTrunkAccess(ctx, "rentalId", trunkAccess.ValidityPeriod, nil).
 		Return(nil, rentalErrors.ErrRentalNotActive)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GrantTrunkAccess(mockContext, "rentalId")
 
-	assert.Equal(t, echo.NewHTTPError(http.StatusForbidden, "rental not active"), err)
+	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotActive)
 }
 
 func TestController_GrantTrunkAccess_rentalNotOverlapping(t *testing.T) {
@@ -627,18 +976,18 @@ func TestController_GrantTrunkAccess_rentalNotOverlapping(t *testing.T) {
 
 	mockContext := mocks.NewMockContext(ctrl)
 	mockContext.EXPECT().Request().Return(request)
-	mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, trunkAccess.ValidityPeriod).Return(nil)
+	mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, trunkAccessGrant).Return(nil)
 
 	mockOperations := mocks.NewMockIOperations(ctrl)
-	mockOperations.EXPECT().GrantTrunkAccess(ctx, "rentalId", trunkAccess.ValidityPeriod).
+	mockOperations.EXPECT().GrantTrunkAccess(ctx, "rentalId", trunkAccess.ValidityPeriod, nil).
 		Return(nil, rentalErrors.ErrRentalNotOverlapping)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GrantTrunkAccess(mockContext, "rentalId")
 
-	assert.Equal(t, echo.NewHTTPError(http.StatusForbidden, "rental not overlapping"), err)
+	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotOverlapping)
 }
 
 func TestController_GrantTrunkAccess_resourceConflict(t *testing.T) {
@@ -651,18 +1000,18 @@ func TestController_GrantTrunkAccess_resourceConflict(t *testing.T) {
 
 	mockContext := mocks.NewMockContext(ctrl)
 	mockContext.EXPECT().Request().Return(request)
-	mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, trunkAccess.ValidityPeriod).Return(nil)
+	mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, trunkAccessGrant).Return(nil)
 
 	mockOperations := mocks.NewMockIOperations(ctrl)
-	mockOperations.EXPECT().GrantTrunkAccess(ctx, "rentalId", trunkAccess.ValidityPeriod).
+	mockOperations.EXPECT().GrantTrunkAccess(ctx, "rentalId", trunkAccess.ValidityPeriod, nil).
 		Return(nil, rentalErrors.ErrResourceConflict)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GrantTrunkAccess(mockContext, "rentalId")
 
-	assert.Equal(t, echo.NewHTTPError(http.StatusServiceUnavailable, "failed to grant trunk access"), err)
+	assert.ErrorIs(t, err, rentalErrors.ErrResourceConflict)
 }
 
 func TestController_GrantTrunkAccess_operationsError(t *testing.T) {
@@ -675,16 +1024,16 @@ func TestController_GrantTrunkAccess_operationsError(t *testing.T) {
 
 	mockContext := mocks.NewMockContext(ctrl)
 	mockContext.EXPECT().Request().Return(request)
-	mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, trunkAccess.ValidityPeriod).Return(nil)
+	mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, trunkAccessGrant).Return(nil)
 
 	mockOperations := mocks.NewMockIOperations(ctrl)
 	operationsError := errors.New("operations error")
-	mockOperations.EXPECT().GrantTrunkAccess(ctx, "rentalId", trunkAccess.ValidityPeriod).
+	mockOperations.EXPECT().GrantTrunkAccess(ctx, "rentalId", trunkAccess.ValidityPeriod, nil).
 		Return(nil, operationsError)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GrantTrunkAccess(mockContext, "rentalId")
 
 	assert.ErrorIs(t, err, operationsError)
@@ -707,7 +1056,7 @@ func TestController_GetRentalStatus_success(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GetRentalStatus(mockContext, rentalCustomerShort1.Id)
 	assert.Nil(t, err)
 }
@@ -728,10 +1077,10 @@ func TestController_GetRentalStatus_RentalNotFound(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GetRentalStatus(mockContext, rentalCustomerShort1.Id)
 
-	assert.Equal(t, echo.NewHTTPError(http.StatusNotFound, "rentalId not found"), err)
+	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
 }
 
 func TestController_GetRentalStatus_operationsError(t *testing.T) {
@@ -751,7 +1100,7 @@ func TestController_GetRentalStatus_operationsError(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GetRentalStatus(mockContext, rentalCustomerShort1.Id)
 	assert.ErrorIs(t, err, operationsError)
 }
@@ -775,7 +1124,7 @@ func TestController_GetLockState_success(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GetLockState(mockContext, testdata.VinCar, model.GetLockStateParams{TrunkAccessToken: testdata.TrunkAccessToken})
 	assert.Nil(t, err)
 }
@@ -797,9 +1146,9 @@ func TestController_GetLockState_TrunkAccessDenied(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GetLockState(mockContext, testdata.VinCar, model.GetLockStateParams{TrunkAccessToken: testdata.TrunkAccessToken})
-	assert.Equal(t, echo.NewHTTPError(http.StatusForbidden, "trunk access denied"), err)
+	assert.ErrorIs(t, err, rentalErrors.ErrTrunkAccessDenied)
 }
 
 func TestController_GetLockState_operationsError(t *testing.T) {
@@ -819,7 +1168,7 @@ func TestController_GetLockState_operationsError(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.GetLockState(mockContext, testdata.VinCar, model.GetLockStateParams{TrunkAccessToken: testdata.TrunkAccessToken})
 	assert.ErrorIs(t, err, operationsError)
 }
@@ -842,7 +1191,7 @@ func TestController_SetLockState_customerId_success(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.SetLockState(mockContext, testdata.VinCar, model.SetLockStateParams{
 		CustomerId:       &exampleCustomerID,
 		TrunkAccessToken: nil,
@@ -868,7 +1217,7 @@ func TestController_SetLockState_trunkAccessToken_success(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	token := testdata.TrunkAccessToken
 	err := controller.SetLockState(mockContext, testdata.VinCar, model.SetLockStateParams{
 		CustomerId:       nil,
@@ -894,12 +1243,12 @@ func TestController_SetLockState_customerId_TrunkAccessDenied(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.SetLockState(mockContext, testdata.VinCar, model.SetLockStateParams{
 		CustomerId:       &exampleCustomerID,
 		TrunkAccessToken: nil,
 	})
-	assert.Equal(t, echo.NewHTTPError(http.StatusForbidden, "trunk access denied"), err)
+	assert.ErrorIs(t, err, rentalErrors.ErrTrunkAccessDenied)
 }
 
 func TestController_SetLockState_trunkAccessToken_TrunkAccessDenied(t *testing.T) {
@@ -919,13 +1268,13 @@ func TestController_SetLockState_trunkAccessToken_TrunkAccessDenied(t *testing.T
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	token := testdata.TrunkAccessToken
 	err := controller.SetLockState(mockContext, testdata.VinCar, model.SetLockStateParams{
 		CustomerId:       nil,
 		TrunkAccessToken: &token,
 	})
-	assert.Equal(t, echo.NewHTTPError(http.StatusForbidden, "trunk access denied"), err)
+	assert.ErrorIs(t, err, rentalErrors.ErrTrunkAccessDenied)
 }
 
 func TestController_SetLockState_NoParameter(t *testing.T) {
@@ -938,12 +1287,12 @@ func TestController_SetLockState_NoParameter(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.SetLockState(mockContext, testdata.VinCar, model.SetLockStateParams{
 		CustomerId:       nil,
 		TrunkAccessToken: nil,
 	})
-	assert.Equal(t, echo.NewHTTPError(http.StatusBadRequest, "either customerId or trunkAccessToken must be specified"), err)
+	assert.Equal(t, errMissingLockStateActor, err)
 }
 
 func TestController_SetLockState_TooManyParameters(t *testing.T) {
@@ -956,13 +1305,13 @@ func TestController_SetLockState_TooManyParameters(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	token := testdata.TrunkAccessToken
 	err := controller.SetLockState(mockContext, testdata.VinCar, model.SetLockStateParams{
 		CustomerId:       &exampleCustomerID,
 		TrunkAccessToken: &token,
 	})
-	assert.Equal(t, echo.NewHTTPError(http.StatusBadRequest, "only one of customerId or trunkAccessToken can be specified"), err)
+	assert.Equal(t, errAmbiguousLockStateActor, err)
 }
 
 func TestController_SetLockState_customerId_OperationsError(t *testing.T) {
@@ -983,7 +1332,7 @@ func TestController_SetLockState_customerId_OperationsError(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	err := controller.SetLockState(mockContext, testdata.VinCar, model.SetLockStateParams{
 		CustomerId:       &exampleCustomerID,
 		TrunkAccessToken: nil,
@@ -1009,7 +1358,7 @@ func TestController_SetLockState_trunkAccessToken_OperationsError(t *testing.T)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	controller := NewController(mockOperations, mockTime)
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
 	token := testdata.TrunkAccessToken
 	err := controller.SetLockState(mockContext, testdata.VinCar, model.SetLockStateParams{
 		CustomerId:       nil,
@@ -1017,3 +1366,552 @@ func TestController_SetLockState_trunkAccessToken_OperationsError(t *testing.T)
 	})
 	assert.ErrorIs(t, operationsError, err)
 }
+
+var trunkAccessLog = []model.TrunkAccessLogEntry{
+	{
+		Vin:       carBase2.Vin,
+		RentalId:  &rentalCustomerShort2.Id,
+		ActorType: model.TOKEN,
+		Actor:     "bumr",
+		Action:    model.GETLOCKSTATE,
+		Outcome:   model.GRANTED,
+		Timestamp: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	},
+}
+
+func TestController_GetCarTrunkAccessLog_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	request, _ := http.NewRequestWithContext(ctx, "GET", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+	mockContext.EXPECT().JSON(http.StatusOK, trunkAccessLog)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().GetTrunkAccessLogOfCar(ctx, carBase2.Vin).Return(&trunkAccessLog, nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.GetCarTrunkAccessLog(mockContext, carBase2.Vin)
+	assert.Nil(t, err)
+}
+
+func TestController_GetCarTrunkAccessLog_CarNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	request, _ := http.NewRequestWithContext(ctx, "GET", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().GetTrunkAccessLogOfCar(ctx, carBase2.Vin).Return(nil, rentalErrors.ErrCarNotFound)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.GetCarTrunkAccessLog(mockContext, carBase2.Vin)
+
+	assert.ErrorIs(t, err, rentalErrors.ErrCarNotFound)
+}
+
+func TestController_GetCarTrunkAccessLog_operationsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	request, _ := http.NewRequestWithContext(ctx, "GET", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+
+	operationsError := errors.New("operations error")
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().GetTrunkAccessLogOfCar(ctx, carBase2.Vin).Return(nil, operationsError)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.GetCarTrunkAccessLog(mockContext, carBase2.Vin)
+
+	assert.ErrorIs(t, err, operationsError)
+}
+
+func TestController_GetRentalTrunkAccessLog_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	request, _ := http.NewRequestWithContext(ctx, "GET", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+	mockContext.EXPECT().JSON(http.StatusOK, trunkAccessLog)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().GetTrunkAccessLogOfRental(ctx, rentalCustomerShort2.Id).Return(&trunkAccessLog, nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.GetRentalTrunkAccessLog(mockContext, rentalCustomerShort2.Id)
+	assert.Nil(t, err)
+}
+
+func TestController_GetRentalTrunkAccessLog_RentalNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	request, _ := http.NewRequestWithContext(ctx, "GET", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().GetTrunkAccessLogOfRental(ctx, rentalCustomerShort2.Id).
+		Return(nil, rentalErrors.ErrRentalNotFound)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.GetRentalTrunkAccessLog(mockContext, rentalCustomerShort2.Id)
+
+	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
+}
+
+func TestController_SetAutoRelock_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	autoRelock := model.AutoRelock{TimeoutSeconds: 300}
+
+	request, _ := http.NewRequestWithContext(ctx, "PUT", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+	mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, autoRelock).Return(nil)
+	mockContext.EXPECT().NoContent(http.StatusNoContent)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().SetAutoRelock(ctx, rentalCustomerShort2.Id, autoRelock).Return(nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.SetAutoRelock(mockContext, rentalCustomerShort2.Id)
+
+	assert.Nil(t, err)
+}
+
+func TestController_SetAutoRelock_rentalNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	autoRelock := model.AutoRelock{TimeoutSeconds: 0}
+
+	request, _ := http.NewRequestWithContext(ctx, "PUT", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+	mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, autoRelock).Return(nil)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().SetAutoRelock(ctx, rentalCustomerShort2.Id, autoRelock).
+		Return(rentalErrors.ErrRentalNotFound)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.SetAutoRelock(mockContext, rentalCustomerShort2.Id)
+
+	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
+}
+
+func TestController_CancelRental_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	request, _ := http.NewRequestWithContext(ctx, "POST", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+	mockContext.EXPECT().NoContent(http.StatusNoContent)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().CancelRental(ctx, rentalCustomerShort2.Id).Return(nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.CancelRental(mockContext, rentalCustomerShort2.Id)
+
+	assert.Nil(t, err)
+}
+
+func TestController_CancelRental_notUpcoming(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	request, _ := http.NewRequestWithContext(ctx, "POST", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().CancelRental(ctx, rentalCustomerShort2.Id).Return(rentalErrors.ErrRentalNotUpcoming)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.CancelRental(mockContext, rentalCustomerShort2.Id)
+
+	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotUpcoming)
+}
+
+var webhookRegistration = model.WebhookRegistration{
+	Url:        "https://billing.example.com/hooks/rentals",
+	EventTypes: []model.RentalEventType{model.RentalCreated},
+	Secret:     "whsec-0123456789abcdef",
+}
+
+var webhook = model.Webhook{
+	Id:         "Ck3XlmXtlq0xGpH9",
+	Url:        webhookRegistration.Url,
+	EventTypes: webhookRegistration.EventTypes,
+	Secret:     webhookRegistration.Secret,
+	CreatedAt:  time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC),
+}
+
+func TestController_RegisterWebhook_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	request, _ := http.NewRequestWithContext(ctx, "POST", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+	mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, webhookRegistration).Return(nil)
+	mockContext.EXPECT().JSON(http.StatusCreated, &webhook)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().RegisterWebhook(ctx, webhookRegistration).Return(&webhook, nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.RegisterWebhook(mockContext)
+
+	assert.Nil(t, err)
+}
+
+func TestController_RegisterWebhook_invalidUrl(t *testing.T) {
+	for _, invalidUrl := range []string{"ftp://billing.example.com/hooks", "/hooks/rentals", "https://", "billing"} {
+		t.Run(invalidUrl, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			registration := webhookRegistration
+			registration.Url = invalidUrl
+
+			mockContext := mocks.NewMockContext(ctrl)
+			mockContext.EXPECT().Bind(gomock.Any()).SetArg(0, registration).Return(nil)
+
+			mockOperations := mocks.NewMockIOperations(ctrl)
+			mockTime := mocks.NewMockITimeProvider(ctrl)
+
+			controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+			err := controller.RegisterWebhook(mockContext)
+
+			assert.Equal(t, errInvalidWebhookUrl, err)
+		})
+	}
+}
+
+func TestController_GetWebhooks_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	webhooks := []model.Webhook{webhook}
+
+	request, _ := http.NewRequestWithContext(ctx, "GET", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+	mockContext.EXPECT().JSON(http.StatusOK, webhooks)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().GetWebhooks(ctx).Return(&webhooks, nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.GetWebhooks(mockContext)
+
+	assert.Nil(t, err)
+}
+
+func TestController_DeleteWebhook_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	request, _ := http.NewRequestWithContext(ctx, "DELETE", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+	mockContext.EXPECT().NoContent(http.StatusNoContent)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().DeleteWebhook(ctx, webhook.Id).Return(nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.DeleteWebhook(mockContext, webhook.Id)
+
+	assert.Nil(t, err)
+}
+
+func TestController_DeleteWebhook_webhookNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	request, _ := http.NewRequestWithContext(ctx, "DELETE", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().DeleteWebhook(ctx, webhook.Id).Return(rentalErrors.ErrWebhookNotFound)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.DeleteWebhook(mockContext, webhook.Id)
+
+	assert.ErrorIs(t, err, rentalErrors.ErrWebhookNotFound)
+}
+
+func TestController_GetWebhookDeliveries_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	deliveries := []model.WebhookDelivery{{
+		Id:        "Xo0kGm4GUtbN2r3h" + webhook.Id,
+		WebhookId: webhook.Id,
+		Event:     model.RentalEvent{EventId: "Xo0kGm4GUtbN2r3h", Type: model.RentalCreated},
+		State:     model.PENDING,
+		CreatedAt: webhook.CreatedAt,
+	}}
+
+	request, _ := http.NewRequestWithContext(ctx, "GET", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+	mockContext.EXPECT().JSON(http.StatusOK, deliveries)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().GetWebhookDeliveries(ctx, webhook.Id).Return(&deliveries, nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.GetWebhookDeliveries(mockContext, webhook.Id)
+
+	assert.Nil(t, err)
+}
+
+func TestController_GetWebhookDeliveries_webhookNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	request, _ := http.NewRequestWithContext(ctx, "GET", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().GetWebhookDeliveries(ctx, webhook.Id).Return(nil, rentalErrors.ErrWebhookNotFound)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.GetWebhookDeliveries(mockContext, webhook.Id)
+
+	assert.ErrorIs(t, err, rentalErrors.ErrWebhookNotFound)
+}
+
+var liveState = model.DynamicData{
+	DoorsLockState:      model.LOCKED,
+	EngineState:         model.OFF,
+	FuelLevelPercentage: 80,
+	TrunkLockState:      model.UNLOCKED,
+}
+
+func TestController_StreamCarState_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	states := make(chan model.DynamicData, 2)
+	states <- liveState
+	states <- model.DynamicData{DoorsLockState: model.UNLOCKED, EngineState: model.ON, TrunkLockState: model.LOCKED}
+	close(states)
+
+	request, _ := http.NewRequestWithContext(ctx, "GET", "", nil)
+	recorder := httptest.NewRecorder()
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+	mockContext.EXPECT().Response().Return(echo.NewResponse(recorder, echo.New()))
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().WatchCarState(ctx, rentalCustomerShort2.Id).Return((<-chan model.DynamicData)(states), nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.StreamCarState(mockContext, rentalCustomerShort2.Id)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/event-stream", recorder.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "event: carState\n"+
+		`data: {"doorsLockState":"LOCKED","engineState":"OFF","fuelLevelPercentage":80,`+
+		`"position":{"latitude":0,"longitude":0},"trunkLockState":"UNLOCKED"}`+"\n\n"+
+		"event: carState\n"+
+		`data: {"doorsLockState":"UNLOCKED","engineState":"ON","fuelLevelPercentage":0,`+
+		`"position":{"latitude":0,"longitude":0},"trunkLockState":"LOCKED"}`+"\n\n"+
+		"event: rentalExpired\ndata: {}\n\n", recorder.Body.String())
+}
+
+func TestController_StreamCarState_clientGone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	states := make(chan model.DynamicData)
+	close(states)
+
+	request, _ := http.NewRequestWithContext(ctx, "GET", "", nil)
+	recorder := httptest.NewRecorder()
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+	mockContext.EXPECT().Response().Return(echo.NewResponse(recorder, echo.New()))
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().WatchCarState(ctx, rentalCustomerShort2.Id).Return((<-chan model.DynamicData)(states), nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.StreamCarState(mockContext, rentalCustomerShort2.Id)
+
+	assert.Nil(t, err)
+	// the rental has not expired, so no event is sent
+	assert.Empty(t, recorder.Body.String())
+}
+
+func TestController_StreamCarState_shutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	streams, endStreams := context.WithCancel(context.Background())
+	endStreams()
+	// the rental is still active, so the stream would never end by itself
+	states := make(chan model.DynamicData)
+
+	request, _ := http.NewRequestWithContext(ctx, "GET", "", nil)
+	recorder := httptest.NewRecorder()
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+	mockContext.EXPECT().Response().Return(echo.NewResponse(recorder, echo.New()))
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().WatchCarState(ctx, rentalCustomerShort2.Id).Return((<-chan model.DynamicData)(states), nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, streams)
+	err := controller.StreamCarState(mockContext, rentalCustomerShort2.Id)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Empty(t, recorder.Body.String())
+}
+
+func TestController_StreamCarState_rentalNotActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	request, _ := http.NewRequestWithContext(ctx, "GET", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().WatchCarState(ctx, rentalCustomerShort2.Id).Return(nil, rentalErrors.ErrRentalNotActive)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.StreamCarState(mockContext, rentalCustomerShort2.Id)
+
+	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotActive)
+}
+
+func TestController_StreamCarState_rentalNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	request, _ := http.NewRequestWithContext(ctx, "GET", "", nil)
+
+	mockContext := mocks.NewMockContext(ctrl)
+	mockContext.EXPECT().Request().Return(request)
+
+	mockOperations := mocks.NewMockIOperations(ctrl)
+	mockOperations.EXPECT().WatchCarState(ctx, rentalCustomerShort2.Id).Return(nil, rentalErrors.ErrRentalNotFound)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	controller := NewController(mockOperations, mockTime, examplePublicBaseUrl, context.Background())
+	err := controller.StreamCarState(mockContext, rentalCustomerShort2.Id)
+
+	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
+}
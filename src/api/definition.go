@@ -12,6 +12,12 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// CreateCalendarSubscription Create a Calendar Subscription URL for a Customer’s Rentals
+	// (POST /calendarSubscriptions)
+	CreateCalendarSubscription(ctx echo.Context, params model.CreateCalendarSubscriptionParams) error
+	// GetSubscribedRentalCalendar Get the Rentals of a Calendar Subscription as iCalendar
+	// (GET /calendars/{calendarToken}/rentals.ics)
+	GetSubscribedRentalCalendar(ctx echo.Context, calendarToken model.CalendarTokenParam) error
 	// GetAvailableCars Get Available Cars in a Time Period
 	// (GET /cars)
 	GetAvailableCars(ctx echo.Context, params model.GetAvailableCarsParams) error
@@ -30,15 +36,51 @@ type ServerInterface interface {
 	// SetLockState Set the Trunk Lock State of the Car
 	// (PUT /cars/{vin}/trunk)
 	SetLockState(ctx echo.Context, vin model.VinParam, params model.SetLockStateParams) error
+	// GetCarTrunkAccessLog Get the Trunk Access Log of a Car
+	// (GET /cars/{vin}/trunkAccessLog)
+	GetCarTrunkAccessLog(ctx echo.Context, vin model.VinParam) error
+	// ImportRentals Bulk-Create Rentals from CSV
+	// (POST /rentalImports)
+	ImportRentals(ctx echo.Context) error
 	// GetOverview Get an Overview of a Customer’s Rentals
 	// (GET /rentals)
 	GetOverview(ctx echo.Context, params model.GetOverviewParams) error
+	// ExportRentals Export All Rentals in a Time Period as CSV
+	// (GET /rentals.csv)
+	ExportRentals(ctx echo.Context, params model.ExportRentalsParams) error
+	// GetRentalCalendar Get a Customer’s Rentals as iCalendar
+	// (GET /rentals.ics)
+	GetRentalCalendar(ctx echo.Context, params model.GetRentalCalendarParams) error
 	// GetRentalStatus Get the Status of the Rental and the Car
 	// (GET /rentals/{rentalId})
 	GetRentalStatus(ctx echo.Context, rentalId model.RentalIdParam) error
+	// SetAutoRelock Set the Auto Relock Timeout of a Rental
+	// (PUT /rentals/{rentalId}/autoRelock)
+	SetAutoRelock(ctx echo.Context, rentalId model.RentalIdParam) error
+	// CancelRental Cancel an Upcoming Rental
+	// (POST /rentals/{rentalId}/cancellation)
+	CancelRental(ctx echo.Context, rentalId model.RentalIdParam) error
+	// StreamCarState Stream the Live State of the Rented Car
+	// (GET /rentals/{rentalId}/carState)
+	StreamCarState(ctx echo.Context, rentalId model.RentalIdParam) error
+	// GetRentalTrunkAccessLog Get the Trunk Access Log of a Rental
+	// (GET /rentals/{rentalId}/trunkAccessLog)
+	GetRentalTrunkAccessLog(ctx echo.Context, rentalId model.RentalIdParam) error
 	// GrantTrunkAccess Create a New Token to Access the Trunk
 	// (POST /rentals/{rentalId}/trunkTokens)
 	GrantTrunkAccess(ctx echo.Context, rentalId model.RentalIdParam) error
+	// GetWebhooks Get All Webhook Subscriptions
+	// (GET /webhooks)
+	GetWebhooks(ctx echo.Context) error
+	// RegisterWebhook Subscribe a URL to Rental Events
+	// (POST /webhooks)
+	RegisterWebhook(ctx echo.Context) error
+	// DeleteWebhook Cancel a Webhook Subscription
+	// (DELETE /webhooks/{webhookId})
+	DeleteWebhook(ctx echo.Context, webhookId model.WebhookIdParam) error
+	// GetWebhookDeliveries Get the Deliveries of a Webhook
+	// (GET /webhooks/{webhookId}/deliveries)
+	GetWebhookDeliveries(ctx echo.Context, webhookId model.WebhookIdParam) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -46,6 +88,40 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// CreateCalendarSubscription converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateCalendarSubscription(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params model.CreateCalendarSubscriptionParams
+	// ------------- Required query parameter "customerId" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "customerId", ctx.QueryParams(), &params.CustomerId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter customerId: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.CreateCalendarSubscription(ctx, params)
+	return err
+}
+
+// GetSubscribedRentalCalendar converts echo context to params.
+func (w *ServerInterfaceWrapper) GetSubscribedRentalCalendar(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "calendarToken" -------------
+	var calendarToken model.CalendarTokenParam
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "calendarToken", runtime.ParamLocationPath, ctx.Param("calendarToken"), &calendarToken)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter calendarToken: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetSubscribedRentalCalendar(ctx, calendarToken)
+	return err
+}
+
 // GetAvailableCars converts echo context to params.
 func (w *ServerInterfaceWrapper) GetAvailableCars(ctx echo.Context) error {
 	var err error
@@ -178,6 +254,31 @@ func (w *ServerInterfaceWrapper) SetLockState(ctx echo.Context) error {
 	return err
 }
 
+// GetCarTrunkAccessLog converts echo context to params.
+func (w *ServerInterfaceWrapper) GetCarTrunkAccessLog(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "vin" -------------
+	var vin model.VinParam
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "vin", runtime.ParamLocationPath, ctx.Param("vin"), &vin)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter vin: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetCarTrunkAccessLog(ctx, vin)
+	return err
+}
+
+// ImportRentals converts echo context to params.
+func (w *ServerInterfaceWrapper) ImportRentals(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ImportRentals(ctx)
+	return err
+}
+
 // GetOverview converts echo context to params.
 func (w *ServerInterfaceWrapper) GetOverview(ctx echo.Context) error {
 	var err error
@@ -196,6 +297,42 @@ func (w *ServerInterfaceWrapper) GetOverview(ctx echo.Context) error {
 	return err
 }
 
+// ExportRentals converts echo context to params.
+func (w *ServerInterfaceWrapper) ExportRentals(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params model.ExportRentalsParams
+	// ------------- Required query parameter "timePeriod" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "timePeriod", ctx.QueryParams(), &params.TimePeriod)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter timePeriod: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ExportRentals(ctx, params)
+	return err
+}
+
+// GetRentalCalendar converts echo context to params.
+func (w *ServerInterfaceWrapper) GetRentalCalendar(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params model.GetRentalCalendarParams
+	// ------------- Required query parameter "customerId" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "customerId", ctx.QueryParams(), &params.CustomerId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter customerId: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetRentalCalendar(ctx, params)
+	return err
+}
+
 // GetRentalStatus converts echo context to params.
 func (w *ServerInterfaceWrapper) GetRentalStatus(ctx echo.Context) error {
 	var err error
@@ -212,6 +349,70 @@ func (w *ServerInterfaceWrapper) GetRentalStatus(ctx echo.Context) error {
 	return err
 }
 
+// SetAutoRelock converts echo context to params.
+func (w *ServerInterfaceWrapper) SetAutoRelock(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "rentalId" -------------
+	var rentalId model.RentalIdParam
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "rentalId", runtime.ParamLocationPath, ctx.Param("rentalId"), &rentalId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rentalId: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.SetAutoRelock(ctx, rentalId)
+	return err
+}
+
+// CancelRental converts echo context to params.
+func (w *ServerInterfaceWrapper) CancelRental(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "rentalId" -------------
+	var rentalId model.RentalIdParam
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "rentalId", runtime.ParamLocationPath, ctx.Param("rentalId"), &rentalId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rentalId: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.CancelRental(ctx, rentalId)
+	return err
+}
+
+// StreamCarState converts echo context to params.
+func (w *ServerInterfaceWrapper) StreamCarState(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "rentalId" -------------
+	var rentalId model.RentalIdParam
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "rentalId", runtime.ParamLocationPath, ctx.Param("rentalId"), &rentalId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rentalId: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.StreamCarState(ctx, rentalId)
+	return err
+}
+
+// GetRentalTrunkAccessLog converts echo context to params.
+func (w *ServerInterfaceWrapper) GetRentalTrunkAccessLog(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "rentalId" -------------
+	var rentalId model.RentalIdParam
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "rentalId", runtime.ParamLocationPath, ctx.Param("rentalId"), &rentalId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rentalId: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetRentalTrunkAccessLog(ctx, rentalId)
+	return err
+}
+
 // GrantTrunkAccess converts echo context to params.
 func (w *ServerInterfaceWrapper) GrantTrunkAccess(ctx echo.Context) error {
 	var err error
@@ -228,6 +429,56 @@ func (w *ServerInterfaceWrapper) GrantTrunkAccess(ctx echo.Context) error {
 	return err
 }
 
+// GetWebhooks converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWebhooks(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetWebhooks(ctx)
+	return err
+}
+
+// RegisterWebhook converts echo context to params.
+func (w *ServerInterfaceWrapper) RegisterWebhook(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.RegisterWebhook(ctx)
+	return err
+}
+
+// DeleteWebhook converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteWebhook(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId model.WebhookIdParam
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "webhookId", runtime.ParamLocationPath, ctx.Param("webhookId"), &webhookId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter webhookId: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DeleteWebhook(ctx, webhookId)
+	return err
+}
+
+// GetWebhookDeliveries converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWebhookDeliveries(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId model.WebhookIdParam
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "webhookId", runtime.ParamLocationPath, ctx.Param("webhookId"), &webhookId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter webhookId: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetWebhookDeliveries(ctx, webhookId)
+	return err
+}
+
 // EchoRouter is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -256,14 +507,28 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.POST(baseURL+"/calendarSubscriptions", wrapper.CreateCalendarSubscription)
+	router.GET(baseURL+"/calendars/:calendarToken/rentals.ics", wrapper.GetSubscribedRentalCalendar)
 	router.GET(baseURL+"/cars", wrapper.GetAvailableCars)
 	router.GET(baseURL+"/cars/:vin", wrapper.GetCar)
 	router.GET(baseURL+"/cars/:vin/rentalStatus", wrapper.GetNextRental)
 	router.POST(baseURL+"/cars/:vin/rentals", wrapper.CreateRental)
 	router.GET(baseURL+"/cars/:vin/trunk", wrapper.GetLockState)
 	router.PUT(baseURL+"/cars/:vin/trunk", wrapper.SetLockState)
+	router.GET(baseURL+"/cars/:vin/trunkAccessLog", wrapper.GetCarTrunkAccessLog)
+	router.POST(baseURL+"/rentalImports", wrapper.ImportRentals)
 	router.GET(baseURL+"/rentals", wrapper.GetOverview)
+	router.GET(baseURL+"/rentals.csv", wrapper.ExportRentals)
+	router.GET(baseURL+"/rentals.ics", wrapper.GetRentalCalendar)
 	router.GET(baseURL+"/rentals/:rentalId", wrapper.GetRentalStatus)
+	router.PUT(baseURL+"/rentals/:rentalId/autoRelock", wrapper.SetAutoRelock)
+	router.POST(baseURL+"/rentals/:rentalId/cancellation", wrapper.CancelRental)
+	router.GET(baseURL+"/rentals/:rentalId/carState", wrapper.StreamCarState)
+	router.GET(baseURL+"/rentals/:rentalId/trunkAccessLog", wrapper.GetRentalTrunkAccessLog)
 	router.POST(baseURL+"/rentals/:rentalId/trunkTokens", wrapper.GrantTrunkAccess)
+	router.GET(baseURL+"/webhooks", wrapper.GetWebhooks)
+	router.POST(baseURL+"/webhooks", wrapper.RegisterWebhook)
+	router.DELETE(baseURL+"/webhooks/:webhookId", wrapper.DeleteWebhook)
+	router.GET(baseURL+"/webhooks/:webhookId/deliveries", wrapper.GetWebhookDeliveries)
 
 }
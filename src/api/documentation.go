@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/invopop/yaml"
+	"github.com/labstack/echo/v4"
+	swaggerFiles "github.com/swaggo/files/v2"
+	"net/http"
+	"runtime/debug"
+	"strings"
+)
+
+// Version is the version of this build. Set it when building, e.g. with
+// -ldflags "-X RentalManagement/api.Version=1.4.2". Builds without it are versioned by their VCS revision.
+var Version = ""
+
+// documentationPaths are the paths of the documentation routes, which are no operations of the specification
+var documentationPaths = []string{"/openapi.yaml", "/openapi.json", "/docs"}
+
+// swaggerInitializer configures the embedded Swagger UI to show the specification of this deployment
+const swaggerInitializer = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: "/openapi.json",
+    dom_id: '#swagger-ui',
+    deepLinking: true,
+    presets: [
+      SwaggerUIBundle.presets.apis,
+      SwaggerUIStandalonePreset
+    ],
+    plugins: [
+      SwaggerUIBundle.plugins.DownloadUrl
+    ],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+// AddOpenApiDocumentation adds the routes documenting the API to the echo server: the OpenAPI specification of
+// RentalManagement at /openapi.yaml and /openapi.json and a Swagger UI to explore it at /docs/. The info.version of
+// the served specification is the version of this build, so that clients can discover the exact contract a
+// deployment runs.
+func AddOpenApiDocumentation(e *echo.Echo) error {
+	swagger, err := openapi3.NewLoader().LoadFromData(openApiData)
+	if err != nil {
+		return err
+	}
+	swagger.Info.Version = buildVersion(swagger.Info.Version)
+
+	jsonSpecification, err := json.Marshal(swagger)
+	if err != nil {
+		return err
+	}
+	yamlSpecification, err := yaml.JSONToYAML(jsonSpecification)
+	if err != nil {
+		return err
+	}
+
+	e.GET("/openapi.json", func(ctx echo.Context) error {
+		return ctx.Blob(http.StatusOK, echo.MIMEApplicationJSON, jsonSpecification)
+	})
+	e.GET("/openapi.yaml", func(ctx echo.Context) error {
+		return ctx.Blob(http.StatusOK, "application/yaml", yamlSpecification)
+	})
+
+	e.GET("/docs", func(ctx echo.Context) error {
+		return ctx.Redirect(http.StatusMovedPermanently, "/docs/")
+	})
+	e.GET("/docs/swagger-initializer.js", func(ctx echo.Context) error {
+		return ctx.Blob(http.StatusOK, echo.MIMEApplicationJavaScript, []byte(swaggerInitializer))
+	})
+	e.GET("/docs/*", echo.WrapHandler(http.StripPrefix("/docs/", http.FileServer(http.FS(swaggerFiles.FS)))))
+
+	return nil
+}
+
+// isDocumentationRequest reports whether the request is for a documentation route, so that it is not validated
+// against the specification
+func isDocumentationRequest(ctx echo.Context) bool {
+	for _, path := range documentationPaths {
+		if ctx.Request().URL.Path == path || strings.HasPrefix(ctx.Request().URL.Path, path+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// buildVersion returns the version of this build. Without a Version set when building, it is the version of the
+// specification with the VCS revision of the build as build metadata, e.g. 1.0.0+0a1b2c3d4e5f.
+func buildVersion(specificationVersion string) string {
+	if Version != "" {
+		return Version
+	}
+
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return specificationVersion
+	}
+	revision, modified := "", false
+	for _, setting := range buildInfo.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		}
+	}
+	if revision == "" {
+		return specificationVersion
+	}
+
+	if len(revision) > 12 {
+		revision = revision[:12]
+	}
+	if modified {
+		revision += ".dirty"
+	}
+	return specificationVersion + "+" + revision
+}
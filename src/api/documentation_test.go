@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newDocumentationApp returns an app that validates requests and serves the documentation of the API
+func newDocumentationApp(t *testing.T) *echo.Echo {
+	app := echo.New()
+	app.HTTPErrorHandler = HandleError
+	assert.Nil(t, AddOpenApiValidationMiddleware(app))
+	assert.Nil(t, AddOpenApiDocumentation(app))
+	return app
+}
+
+func serve(app *echo.Echo, path string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	app.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	return recorder
+}
+
+func TestAddOpenApiDocumentation_specification(t *testing.T) {
+	Version = "1.4.2"
+	defer func() { Version = "" }()
+	app := newDocumentationApp(t)
+
+	for _, path := range []string{"/openapi.json", "/openapi.yaml"} {
+		t.Run(path, func(t *testing.T) {
+			recorder := serve(app, path)
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			swagger, err := openapi3.NewLoader().LoadFromData(recorder.Body.Bytes())
+			assert.Nil(t, err)
+			assert.Nil(t, swagger.Validate(context.Background()))
+			assert.Equal(t, "1.4.2", swagger.Info.Version)
+			assert.NotNil(t, swagger.Paths.Find("/rentals/{rentalId}"))
+		})
+	}
+}
+
+// linkedVersionVariable is set when the test binary is built with the version set by the linker
+const linkedVersionVariable = "TEST_LINKED_VERSION"
+
+func TestAddOpenApiDocumentation_linkedVersion(t *testing.T) {
+	linkedVersion := os.Getenv(linkedVersionVariable)
+	if linkedVersion == "" {
+		if testing.Short() {
+			t.Skip("building the tests with a linked version takes too long")
+		}
+		// run this test again in a test binary built with the linker flag of the Docker and CI builds
+		command := exec.Command("go", "test", "-count=1", "-run", "^TestAddOpenApiDocumentation_linkedVersion$",
+			"-ldflags", "-X RentalManagement/api.Version=1.4.2-linked", ".")
+		command.Env = append(os.Environ(), linkedVersionVariable+"=1.4.2-linked")
+		output, err := command.CombinedOutput()
+		assert.Nil(t, err, string(output))
+		return
+	}
+
+	recorder := serve(newDocumentationApp(t), "/openapi.json")
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	swagger, err := openapi3.NewLoader().LoadFromData(recorder.Body.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, linkedVersion, swagger.Info.Version)
+}
+
+func TestAddOpenApiDocumentation_swaggerUi(t *testing.T) {
+	app := newDocumentationApp(t)
+
+	recorder := serve(app, "/docs")
+	assert.Equal(t, http.StatusMovedPermanently, recorder.Code)
+	assert.Equal(t, "/docs/", recorder.Header().Get(echo.HeaderLocation))
+
+	recorder = serve(app, "/docs/")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "swagger-ui")
+
+	recorder = serve(app, "/docs/swagger-initializer.js")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `url: "/openapi.json"`)
+}
+
+func TestBuildVersion(t *testing.T) {
+	assert.Regexp(t, `^1\.0\.0(\+[0-9a-f]{1,12}(\.dirty)?)?$`, buildVersion("1.0.0"))
+
+	Version = "1.4.2"
+	defer func() { Version = "" }()
+	assert.Equal(t, "1.4.2", buildVersion("1.0.0"))
+}
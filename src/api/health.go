@@ -0,0 +1,132 @@
+package api
+
+import (
+	"RentalManagement/logging"
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// The paths of the probes of the orchestrator, which are no operations of the specification
+const (
+	livenessPath  = "/healthz"
+	readinessPath = "/readyz"
+)
+
+// readinessTimeout is the time every dependency has to respond to a readiness check, so that a probe does not hang
+// on an unavailable dependency
+const readinessTimeout = 2 * time.Second
+
+// HealthStatus is the status of RentalManagement or of one of its dependencies
+type HealthStatus string
+
+// Defines values for HealthStatus.
+const (
+	HealthStatusUp           HealthStatus = "UP"
+	HealthStatusDown         HealthStatus = "DOWN"
+	HealthStatusShuttingDown HealthStatus = "SHUTTING_DOWN"
+)
+
+// HealthReport is the response of the liveness and readiness probes
+type HealthReport struct {
+	Status HealthStatus `json:"status"`
+	// Dependencies are the reports of the dependencies by their names. Only the readiness probe checks them.
+	Dependencies map[string]DependencyReport `json:"dependencies,omitempty"`
+}
+
+// DependencyReport is the result of checking a dependency. Why a dependency is down is logged but not reported, as
+// the probes are not authenticated.
+type DependencyReport struct {
+	Status    HealthStatus `json:"status"`
+	LatencyMs int64        `json:"latencyMs"`
+}
+
+// DependencyCheck checks whether a dependency is available, e.g. by pinging it
+type DependencyCheck func(ctx context.Context) error
+
+// Health reports whether RentalManagement is alive and ready to serve requests
+type Health struct {
+	checks       map[string]DependencyCheck
+	shuttingDown atomic.Bool
+}
+
+// NewHealth creates the health of RentalManagement, which is ready as long as all the given dependency checks
+// succeed and it is not shutting down
+func NewHealth(checks map[string]DependencyCheck) *Health {
+	return &Health{checks: checks}
+}
+
+// StartShutdown makes the readiness probe fail from now on, so that the orchestrator routes no new requests to this
+// instance while it finishes the requests in flight
+func (h *Health) StartShutdown() {
+	h.shuttingDown.Store(true)
+}
+
+// AddHealthEndpoints adds the probes of the orchestrator to the echo server: the liveness probe at /healthz, which
+// succeeds as long as the server responds, and the readiness probe at /readyz, which checks every dependency and
+// responds with 503 Service Unavailable if one of them is down or the server is shutting down. Both respond with a
+// HealthReport and do not require authentication.
+func AddHealthEndpoints(e *echo.Echo, health *Health) {
+	e.GET(livenessPath, func(ctx echo.Context) error {
+		return ctx.JSON(http.StatusOK, HealthReport{Status: HealthStatusUp})
+	})
+
+	e.GET(readinessPath, func(ctx echo.Context) error {
+		if health.shuttingDown.Load() {
+			return ctx.JSON(http.StatusServiceUnavailable, HealthReport{Status: HealthStatusShuttingDown})
+		}
+
+		report := health.check(ctx.Request().Context())
+		if report.Status != HealthStatusUp {
+			return ctx.JSON(http.StatusServiceUnavailable, report)
+		}
+		return ctx.JSON(http.StatusOK, report)
+	})
+}
+
+// isHealthRequest reports whether the request is for a probe, so that it is not validated against the specification
+func isHealthRequest(ctx echo.Context) bool {
+	return ctx.Request().URL.Path == livenessPath || ctx.Request().URL.Path == readinessPath
+}
+
+// check checks all dependencies concurrently
+func (h *Health) check(ctx context.Context) HealthReport {
+	report := HealthReport{Status: HealthStatusUp, Dependencies: map[string]DependencyReport{}}
+	var mutex sync.Mutex
+	var waitGroup sync.WaitGroup
+
+	for name, check := range h.checks {
+		waitGroup.Add(1)
+		go func(name string, check DependencyCheck) {
+			defer waitGroup.Done()
+			dependencyReport := runCheck(ctx, name, check)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			report.Dependencies[name] = dependencyReport
+			if dependencyReport.Status != HealthStatusUp {
+				report.Status = HealthStatusDown
+			}
+		}(name, check)
+	}
+
+	waitGroup.Wait()
+	return report
+}
+
+func runCheck(ctx context.Context, name string, check DependencyCheck) DependencyReport {
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	report := DependencyReport{Status: HealthStatusUp, LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		report.Status = HealthStatusDown
+		logging.FromContext(ctx).Warn("dependency unavailable", "dependency", name, logging.KeyError, err)
+	}
+	return report
+}
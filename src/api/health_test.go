@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newHealthApp returns an app that serves the probes of the given health
+func newHealthApp(health *Health) *echo.Echo {
+	app := echo.New()
+	app.HTTPErrorHandler = HandleError
+	AddHealthEndpoints(app, health)
+	return app
+}
+
+func decodeHealthReport(t *testing.T, recorder *httptest.ResponseRecorder) HealthReport {
+	var report HealthReport
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+	return report
+}
+
+func TestAddHealthEndpoints_alive(t *testing.T) {
+	app := newHealthApp(NewHealth(map[string]DependencyCheck{
+		"database": func(ctx context.Context) error { return errors.New("connection refused") },
+	}))
+
+	recorder := serve(app, livenessPath)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, HealthReport{Status: HealthStatusUp}, decodeHealthReport(t, recorder))
+}
+
+func TestAddHealthEndpoints_ready(t *testing.T) {
+	app := newHealthApp(NewHealth(map[string]DependencyCheck{
+		"database": func(ctx context.Context) error { return nil },
+		"car":      func(ctx context.Context) error { return nil },
+	}))
+
+	recorder := serve(app, readinessPath)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	report := decodeHealthReport(t, recorder)
+	assert.Equal(t, HealthStatusUp, report.Status)
+	assert.Equal(t, HealthStatusUp, report.Dependencies["database"].Status)
+	assert.Equal(t, HealthStatusUp, report.Dependencies["car"].Status)
+}
+
+func TestAddHealthEndpoints_dependencyDown(t *testing.T) {
+	app := newHealthApp(NewHealth(map[string]DependencyCheck{
+		"database": func(ctx context.Context) error { return nil },
+		"car":      func(ctx context.Context) error { return errors.New("connection refused") },
+	}))
+
+	recorder := serve(app, readinessPath)
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	report := decodeHealthReport(t, recorder)
+	assert.Equal(t, HealthStatusDown, report.Status)
+	assert.Equal(t, HealthStatusUp, report.Dependencies["database"].Status)
+	assert.Equal(t, HealthStatusDown, report.Dependencies["car"].Status)
+	assert.NotContains(t, recorder.Body.String(), "connection refused")
+}
+
+func TestAddHealthEndpoints_dependencyTimeout(t *testing.T) {
+	app := newHealthApp(NewHealth(map[string]DependencyCheck{
+		"car": func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}))
+
+	recorder := serve(app, readinessPath)
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	report := decodeHealthReport(t, recorder)
+	assert.Equal(t, HealthStatusDown, report.Dependencies["car"].Status)
+	assert.GreaterOrEqual(t, report.Dependencies["car"].LatencyMs, readinessTimeout.Milliseconds())
+}
+
+func TestHealth_StartShutdown(t *testing.T) {
+	health := NewHealth(nil)
+	app := newHealthApp(health)
+	assert.Equal(t, http.StatusOK, serve(app, readinessPath).Code)
+
+	health.StartShutdown()
+
+	recorder := serve(app, readinessPath)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, HealthReport{Status: HealthStatusShuttingDown}, decodeHealthReport(t, recorder))
+	assert.Equal(t, http.StatusOK, serve(app, livenessPath).Code)
+}
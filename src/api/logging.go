@@ -0,0 +1,55 @@
+package api
+
+import (
+	"RentalManagement/logging"
+	"RentalManagement/util"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+// requestIdLength is the length of the request IDs generated for requests without a valid one
+const requestIdLength = 20
+
+// AddLoggingMiddleware adds middleware to the echo server that assigns every request an ID and logs the request when
+// it has been handled. The ID is taken from the X-Request-ID header of the request if it is valid and generated
+// otherwise. It is responded with in the X-Request-ID header and the problems, forwarded to Car and contained in every
+// log line of the request, as the logger of the request context adds it.
+//
+// The middleware must be added after the tracing middleware, so that the log lines contain the trace ID, and before
+// the middleware that logs or rejects requests, e.g. authentication and validation, so that their log lines contain
+// the request ID and rejected requests are logged with the status codes of their problems.
+func AddLoggingMiddleware(e *echo.Echo) {
+	e.Use(logRequests)
+}
+
+func logRequests(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		start := time.Now()
+		request := ctx.Request()
+
+		requestId := request.Header.Get(logging.HeaderRequestId)
+		if !logging.IsValidRequestId(requestId) {
+			requestId = util.GenerateRandomString(requestIdLength)
+		}
+		requestCtx := logging.WithRequestId(request.Context(), requestId)
+		if spanContext := trace.SpanContextFromContext(requestCtx); spanContext.HasTraceID() {
+			requestCtx = logging.With(requestCtx, logging.KeyTraceId, spanContext.TraceID().String())
+		}
+		ctx.SetRequest(request.WithContext(requestCtx))
+		ctx.Response().Header().Set(logging.HeaderRequestId, requestId)
+
+		// let the error handler respond so that the status code of the problem is logged
+		if err := next(ctx); err != nil {
+			ctx.Error(err)
+		}
+
+		logging.FromContext(requestCtx).Info("request handled",
+			logging.KeyMethod, request.Method,
+			logging.KeyPath, request.URL.Path,
+			logging.KeyStatus, ctx.Response().Status,
+			logging.KeyDurationMs, time.Since(start).Milliseconds(),
+		)
+		return nil
+	}
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"RentalManagement/logging"
+	"RentalManagement/logic/rentalErrors"
+	"bytes"
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/exp/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newLoggingApp returns an app that logs its requests as JSON to the returned buffer and responds with the error of
+// the given handler
+func newLoggingApp(t *testing.T, handler echo.HandlerFunc) (*echo.Echo, *bytes.Buffer) {
+	previousLogger := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(previousLogger) })
+	buffer := &bytes.Buffer{}
+	slog.SetDefault(slog.New(slog.NewJSONHandler(buffer, nil)))
+
+	app := echo.New()
+	app.HTTPErrorHandler = HandleError
+	AddLoggingMiddleware(app)
+	app.GET("/rentals/:rentalId", handler)
+	return app, buffer
+}
+
+// decodeLogLines returns the JSON log lines written to the buffer
+func decodeLogLines(t *testing.T, buffer *bytes.Buffer) []map[string]any {
+	var lines []map[string]any
+	for _, data := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		var line map[string]any
+		assert.Nil(t, json.Unmarshal([]byte(data), &line))
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestAddLoggingMiddleware_propagatesRequestId(t *testing.T) {
+	var handlerRequestId string
+	app, buffer := newLoggingApp(t, func(ctx echo.Context) error {
+		handlerRequestId = logging.RequestIdFromContext(ctx.Request().Context())
+		return ctx.NoContent(http.StatusNoContent)
+	})
+	request := httptest.NewRequest(http.MethodGet, rentalStatusPath, nil)
+	request.Header.Set(logging.HeaderRequestId, "8vV3nWq0LzQm5tYb2KcX")
+	recorder := httptest.NewRecorder()
+
+	app.ServeHTTP(recorder, request)
+
+	assert.Equal(t, "8vV3nWq0LzQm5tYb2KcX", recorder.Header().Get(logging.HeaderRequestId))
+	assert.Equal(t, "8vV3nWq0LzQm5tYb2KcX", handlerRequestId)
+	lines := decodeLogLines(t, buffer)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, "request handled", lines[0]["msg"])
+	assert.Equal(t, "8vV3nWq0LzQm5tYb2KcX", lines[0][logging.KeyRequestId])
+	assert.Equal(t, rentalStatusPath, lines[0][logging.KeyPath])
+	assert.Equal(t, float64(http.StatusNoContent), lines[0][logging.KeyStatus])
+}
+
+func TestAddLoggingMiddleware_replacesInvalidRequestId(t *testing.T) {
+	app, _ := newLoggingApp(t, func(ctx echo.Context) error {
+		return ctx.NoContent(http.StatusNoContent)
+	})
+	request := httptest.NewRequest(http.MethodGet, rentalStatusPath, nil)
+	request.Header.Set(logging.HeaderRequestId, "forged id")
+	recorder := httptest.NewRecorder()
+
+	app.ServeHTTP(recorder, request)
+
+	requestId := recorder.Header().Get(logging.HeaderRequestId)
+	assert.NotEqual(t, "forged id", requestId)
+	assert.True(t, logging.IsValidRequestId(requestId))
+}
+
+func TestAddLoggingMiddleware_problem(t *testing.T) {
+	app, buffer := newLoggingApp(t, func(ctx echo.Context) error {
+		return rentalErrors.ErrRentalNotFound
+	})
+
+	recorder := serve(app, rentalStatusPath)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	requestId := recorder.Header().Get(logging.HeaderRequestId)
+	assert.True(t, logging.IsValidRequestId(requestId))
+	assert.Equal(t, requestId, decodeProblem(t, recorder).RequestId)
+	assert.Equal(t, float64(http.StatusNotFound), decodeLogLines(t, buffer)[0][logging.KeyStatus])
+}
+
+func TestAddLoggingMiddleware_internalError(t *testing.T) {
+	app, buffer := newLoggingApp(t, func(ctx echo.Context) error {
+		return rentalErrors.ErrDomainAssertion
+	})
+
+	recorder := serve(app, rentalStatusPath)
+
+	lines := decodeLogLines(t, buffer)
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "request failed", lines[0]["msg"])
+	assert.Equal(t, recorder.Header().Get(logging.HeaderRequestId), lines[0][logging.KeyRequestId])
+	assert.Equal(t, decodeProblem(t, recorder).TraceId, lines[0][logging.KeyTraceId])
+	assert.Equal(t, float64(http.StatusInternalServerError), lines[1][logging.KeyStatus])
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"RentalManagement/metrics"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"strconv"
+	"time"
+)
+
+// unknownOperation is the operation ID of requests that do not match any operation of the specification, e.g. those
+// for the documentation or with an unknown path
+const unknownOperation = "unknown"
+
+// AddMetricsMiddleware adds middleware to the echo server that records the count and latency of the requests per
+// OpenAPI operation ID. The metrics are exposed by the server of metrics.NewServer, not by the echo server.
+//
+// The middleware must be added before the middleware that may reject requests, e.g. authentication and validation,
+// so that rejected requests are recorded with the status codes of their problems. Middleware added before it, like
+// the logging middleware, sees the response as recorded, since the error handler has responded already.
+func AddMetricsMiddleware(e *echo.Echo) error {
+	swagger, err := openapi3.NewLoader().LoadFromData(openApiData)
+	if err != nil {
+		return err
+	}
+
+	// map the method and the (echo) path of the routes to the IDs of their operations
+	operationIds := map[string]string{}
+	for path, pathItem := range swagger.Paths {
+		echoPath := pathParameterPattern.ReplaceAllString(path, ":$1")
+		for method, operation := range pathItem.Operations() {
+			operationIds[method+" "+echoPath] = operation.OperationID
+		}
+	}
+
+	e.Use(recordMetrics(operationIds))
+
+	return nil
+}
+
+func recordMetrics(operationIds map[string]string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			start := time.Now()
+
+			// let the error handler respond so that the status code of the problem is recorded
+			if err := next(ctx); err != nil {
+				ctx.Error(err)
+			}
+
+			method := ctx.Request().Method
+			operationId, known := operationIds[method+" "+ctx.Path()]
+			if !known {
+				operationId = unknownOperation
+			}
+			metrics.HttpRequests.WithLabelValues(operationId, method, strconv.Itoa(ctx.Response().Status)).Inc()
+			metrics.HttpRequestDuration.WithLabelValues(operationId, method).Observe(time.Since(start).Seconds())
+			return nil
+		}
+	}
+}
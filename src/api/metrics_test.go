@@ -0,0 +1,66 @@
+package api
+
+import (
+	"RentalManagement/logic/rentalErrors"
+	"RentalManagement/metrics"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMetricsApp(t *testing.T) *echo.Echo {
+	app := echo.New()
+	app.HTTPErrorHandler = HandleError
+	assert.Nil(t, AddMetricsMiddleware(app))
+	app.GET("/rentals/:rentalId", func(ctx echo.Context) error {
+		return rentalErrors.ErrRentalNotFound
+	})
+	return app
+}
+
+func TestAddMetricsMiddleware_operation(t *testing.T) {
+	app := newMetricsApp(t)
+	requests := metrics.HttpRequests.WithLabelValues("getRentalStatus", http.MethodGet, "404")
+	requestCount := testutil.ToFloat64(requests)
+
+	recorder := serve(app, rentalStatusPath)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Equal(t, requestCount+1, testutil.ToFloat64(requests))
+}
+
+func TestAddMetricsMiddleware_unknownOperation(t *testing.T) {
+	app := newMetricsApp(t)
+	requests := metrics.HttpRequests.WithLabelValues(unknownOperation, http.MethodGet, "404")
+	requestCount := testutil.ToFloat64(requests)
+
+	serve(app, "/unknown")
+
+	assert.Equal(t, requestCount+1, testutil.ToFloat64(requests))
+}
+
+func TestAddMetricsMiddleware_exposition(t *testing.T) {
+	app := newMetricsApp(t)
+	serve(app, rentalStatusPath)
+
+	recorder := httptest.NewRecorder()
+	metrics.NewServer("").Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, metrics.Path, nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(),
+		`rentalmanagement_http_requests_total{method="GET",operation="getRentalStatus",status="404"}`)
+	assert.Contains(t, recorder.Body.String(), "rentalmanagement_http_request_duration_seconds_bucket")
+	assert.Contains(t, recorder.Body.String(), "go_goroutines")
+}
+
+func TestAddMetricsMiddleware_notExposedByApp(t *testing.T) {
+	app := newMetricsApp(t)
+
+	recorder := serve(app, metrics.Path)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
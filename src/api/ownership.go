@@ -0,0 +1,60 @@
+package api
+
+import (
+	"RentalManagement/logic/model"
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+var errRentalOfOtherCustomer = newProblemError(http.StatusForbidden, model.ErrorCodeCUSTOMERMISMATCH,
+	"the rental belongs to another customer")
+
+// rentalsOfAllCustomersRoles are the roles allowed to operate on the rentals of every customer
+var rentalsOfAllCustomersRoles = []Role{RoleFleetManager, RoleAdmin}
+
+// RentalCustomers looks up the customers renting the rentals
+type RentalCustomers interface {
+	// GetRentalCustomer returns the ID of the customer renting the rental with the given rentalId
+	GetRentalCustomer(ctx context.Context, rentalId model.RentalId) (model.CustomerId, error)
+}
+
+// AddRentalOwnershipMiddleware adds middleware to the echo server that restricts the operations on a rental, i.e. the
+// routes with a rentalId path parameter, to the customer renting it. Fleet managers and admins may operate on the
+// rentals of every customer.
+//
+// The middleware relies on the identity established by the authentication middleware, so it must be added after
+// that. Requests of other customers are rejected with 403, requests for unknown rentals with 404.
+func AddRentalOwnershipMiddleware(e *echo.Echo, rentals RentalCustomers) {
+	e.Use(authorizeRentalOwner(rentals))
+}
+
+func authorizeRentalOwner(rentals RentalCustomers) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			rentalId := ctx.Param("rentalId")
+			if rentalId == "" {
+				return next(ctx)
+			}
+
+			identity, authenticated := ctx.Get(identityContextKey).(*Identity)
+			if !authenticated {
+				ctx.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+				return errAuthenticationRequired
+			}
+			if identity.HasAnyRole(rentalsOfAllCustomersRoles) {
+				return next(ctx)
+			}
+
+			customerId, err := rentals.GetRentalCustomer(ctx.Request().Context(), rentalId)
+			if err != nil {
+				return err
+			}
+			if identity.CustomerId == "" || identity.CustomerId != customerId {
+				return errRentalOfOtherCustomer
+			}
+
+			return next(ctx)
+		}
+	}
+}
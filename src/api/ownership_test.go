@@ -0,0 +1,131 @@
+package api
+
+import (
+	"RentalManagement/logic/rentalErrors"
+	"RentalManagement/mocks"
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const otherCustomerID = "other@example.com"
+
+// newOwnershipApp returns an echo instance with authentication and rental ownership middleware that serves the
+// routes of the API with the given operations
+func newOwnershipApp(t *testing.T, config *TestAuthConfig, operations *mocks.MockIOperations) *echo.Echo {
+	app := echo.New()
+	app.HTTPErrorHandler = HandleError
+	verifier, err := NewTokenVerifier(config)
+	assert.Nil(t, err)
+	AddAuthenticationMiddleware(app, verifier)
+	AddRentalOwnershipMiddleware(app, operations)
+
+	ctrl := gomock.NewController(t)
+	RegisterHandlers(app, NewController(operations, mocks.NewMockITimeProvider(ctrl), examplePublicBaseUrl,
+		context.Background()))
+	return app
+}
+
+func serveOwnership(app *echo.Echo, method string, target string, authorization string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, target, strings.NewReader("{}"))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, authorization)
+	recorder := httptest.NewRecorder()
+	app.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestRentalOwnership_otherCustomer(t *testing.T) {
+	issuer := newTestTokenIssuer(t)
+
+	tests := []struct {
+		method string
+		target string
+	}{
+		{http.MethodGet, "/rentals/" + exampleRental.Id},
+		{http.MethodPut, "/rentals/" + exampleRental.Id + "/autoRelock"},
+		{http.MethodGet, "/rentals/" + exampleRental.Id + "/carState"},
+		{http.MethodGet, "/rentals/" + exampleRental.Id + "/trunkAccessLog"},
+		{http.MethodPost, "/rentals/" + exampleRental.Id + "/trunkTokens"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.method+" "+test.target, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			operations := mocks.NewMockIOperations(ctrl)
+			operations.EXPECT().GetRentalCustomer(gomock.Any(), exampleRental.Id).Return(exampleCustomerID, nil)
+			app := newOwnershipApp(t, testAuthConfig(issuer), operations)
+
+			response := serveOwnership(app, test.method, test.target, issuer.BearerToken(otherCustomerID))
+
+			assert.Equal(t, http.StatusForbidden, response.Code)
+			assert.Contains(t, response.Body.String(), `"code":"CUSTOMER_MISMATCH"`)
+		})
+	}
+}
+
+func TestRentalOwnership_allowed(t *testing.T) {
+	issuer := newTestTokenIssuer(t)
+
+	tests := []struct {
+		name          string
+		authorization string
+	}{
+		{"renting customer", issuer.BearerToken(exampleCustomerID)},
+		{"fleet manager", issuer.StaffBearerToken("fleetManager")},
+		{"admin", issuer.StaffBearerToken("admin")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			operations := mocks.NewMockIOperations(ctrl)
+			operations.EXPECT().GetRentalCustomer(gomock.Any(), exampleRental.Id).Return(exampleCustomerID, nil).
+				MaxTimes(1)
+			operations.EXPECT().GetRentalStatus(gomock.Any(), exampleRental.Id).Return(&exampleRental, nil)
+			app := newOwnershipApp(t, testAuthConfig(issuer), operations)
+
+			response := serveOwnership(app, http.MethodGet, "/rentals/"+exampleRental.Id, test.authorization)
+
+			assert.Equal(t, http.StatusOK, response.Code)
+		})
+	}
+}
+
+func TestRentalOwnership_staffWithoutCustomerRole(t *testing.T) {
+	issuer := newTestTokenIssuer(t)
+	ctrl := gomock.NewController(t)
+	operations := mocks.NewMockIOperations(ctrl)
+	operations.EXPECT().GetRentalCustomer(gomock.Any(), exampleRental.Id).Return(exampleCustomerID, nil)
+	app := newOwnershipApp(t, testAuthConfig(issuer), operations)
+
+	response := serveOwnership(app, http.MethodGet, "/rentals/"+exampleRental.Id, issuer.StaffBearerToken("service"))
+
+	assert.Equal(t, http.StatusForbidden, response.Code)
+}
+
+func TestRentalOwnership_rentalNotFound(t *testing.T) {
+	issuer := newTestTokenIssuer(t)
+	ctrl := gomock.NewController(t)
+	operations := mocks.NewMockIOperations(ctrl)
+	operations.EXPECT().GetRentalCustomer(gomock.Any(), exampleRental.Id).Return("", rentalErrors.ErrRentalNotFound)
+	app := newOwnershipApp(t, testAuthConfig(issuer), operations)
+
+	response := serveOwnership(app, http.MethodGet, "/rentals/"+exampleRental.Id, issuer.BearerToken(otherCustomerID))
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
+func TestRentalOwnership_unauthenticated(t *testing.T) {
+	issuer := newTestTokenIssuer(t)
+	app := newOwnershipApp(t, testAuthConfig(issuer), mocks.NewMockIOperations(gomock.NewController(t)))
+
+	response := serveOwnership(app, http.MethodGet, "/rentals/"+exampleRental.Id, "")
+
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"RentalManagement/logging"
+	"RentalManagement/logic/model"
+	"RentalManagement/logic/rentalErrors"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+)
+
+const problemContentType = "application/problem+json"
+
+// problemError is an error of a request that is responded with a problem of the given status and code
+type problemError struct {
+	status int
+	code   model.ErrorCode
+	detail string
+}
+
+func (e *problemError) Error() string {
+	return e.detail
+}
+
+func newProblemError(status int, code model.ErrorCode, detail string) error {
+	return &problemError{status: status, code: code, detail: detail}
+}
+
+// rentalErrorProblems maps the rentalErrors returned by the operations to the status and code of their problem. The
+// detail of the problem is the message of the error.
+var rentalErrorProblems = []struct {
+	err    error
+	status int
+	code   model.ErrorCode
+}{
+	{rentalErrors.ErrCarNotFound, http.StatusNotFound, model.ErrorCodeCARNOTFOUND},
+	{rentalErrors.ErrRentalNotFound, http.StatusNotFound, model.ErrorCodeRENTALNOTFOUND},
+	{rentalErrors.ErrWebhookNotFound, http.StatusNotFound, model.ErrorCodeWEBHOOKNOTFOUND},
+	{rentalErrors.ErrCalendarNotFound, http.StatusNotFound, model.ErrorCodeCALENDARNOTFOUND},
+	{rentalErrors.ErrConflictingRentalExists, http.StatusConflict, model.ErrorCodeCONFLICTINGRENTAL},
+	{rentalErrors.ErrRentalNotActive, http.StatusForbidden, model.ErrorCodeRENTALNOTACTIVE},
+	{rentalErrors.ErrRentalNotUpcoming, http.StatusForbidden, model.ErrorCodeRENTALNOTUPCOMING},
+	{rentalErrors.ErrRentalNotOverlapping, http.StatusForbidden, model.ErrorCodeRENTALNOTOVERLAPPING},
+	{rentalErrors.ErrTrunkAccessDenied, http.StatusForbidden, model.ErrorCodeTRUNKACCESSDENIED},
+	{rentalErrors.ErrResourceConflict, http.StatusServiceUnavailable, model.ErrorCodeRESOURCECONFLICT},
+	{rentalErrors.ErrDomainAssertion, http.StatusInternalServerError, model.ErrorCodeDOMAINSERVICEERROR},
+}
+
+// statusCodes are the codes of the problems of HTTP errors raised by Echo and its middleware. A missing role is
+// reported with errMissingRole, so a 403 of Echo may have any other reason.
+var statusCodes = map[int]model.ErrorCode{
+	http.StatusBadRequest:       model.ErrorCodeINVALIDREQUEST,
+	http.StatusUnauthorized:     model.ErrorCodeAUTHENTICATIONREQUIRED,
+	http.StatusForbidden:        model.ErrorCodeFORBIDDEN,
+	http.StatusNotFound:         model.ErrorCodeNOTFOUND,
+	http.StatusMethodNotAllowed: model.ErrorCodeMETHODNOTALLOWED,
+}
+
+// HandleError is the error handler of the echo server. It responds with a problem (RFC 7807) for every error returned
+// by a handler or middleware, so that clients can rely on the code of the problem instead of its detail. Unexpected
+// errors are logged and responded with a generic problem, so that no internals are leaked.
+func HandleError(err error, ctx echo.Context) {
+	logger := logging.FromContext(ctx.Request().Context())
+	traceId := traceIdOf(ctx.Request())
+	if !trace.SpanContextFromContext(ctx.Request().Context()).HasTraceID() {
+		// the logger of a request with a span has its trace ID already
+		logger = logger.With(logging.KeyTraceId, traceId)
+	}
+	problem := problemOf(err)
+	if problem.Status >= http.StatusInternalServerError {
+		logger.Error("request failed", logging.KeyError, err)
+	}
+
+	// the status has been sent already, e.g. when a stream fails
+	if ctx.Response().Committed {
+		return
+	}
+
+	problem.Type = "about:blank"
+	problem.Title = http.StatusText(problem.Status)
+	problem.Instance = ctx.Request().URL.Path
+	problem.TraceId = traceId
+	problem.RequestId = logging.RequestIdFromContext(ctx.Request().Context())
+
+	var responseErr error
+	if ctx.Request().Method == http.MethodHead {
+		responseErr = ctx.NoContent(problem.Status)
+	} else {
+		ctx.Response().Header().Set(echo.HeaderContentType, problemContentType)
+		responseErr = ctx.JSON(problem.Status, problem)
+	}
+	if responseErr != nil {
+		logger.Error("responding with a problem failed", logging.KeyError, responseErr)
+	}
+}
+
+// problemOf returns the status, code and detail of the problem of an error
+func problemOf(err error) model.Problem {
+	var problem *problemError
+	if errors.As(err, &problem) {
+		return model.Problem{Status: problem.status, Code: problem.code, Detail: problem.detail}
+	}
+
+	for _, rentalErrorProblem := range rentalErrorProblems {
+		if errors.Is(err, rentalErrorProblem.err) {
+			return model.Problem{
+				Status: rentalErrorProblem.status,
+				Code:   rentalErrorProblem.code,
+				Detail: rentalErrorProblem.err.Error(),
+			}
+		}
+	}
+
+	var httpError *echo.HTTPError
+	if errors.As(err, &httpError) && httpError.Code < http.StatusInternalServerError {
+		code, known := statusCodes[httpError.Code]
+		if !known {
+			code = model.ErrorCodeREQUESTREJECTED
+		}
+		return model.Problem{Status: httpError.Code, Code: code, Detail: fmt.Sprint(httpError.Message)}
+	}
+
+	return model.Problem{
+		Status: http.StatusInternalServerError,
+		Code:   model.ErrorCodeINTERNALERROR,
+		Detail: "internal server error",
+	}
+}
+
+// traceIdOf returns the trace ID of the span of the request, which continues the W3C trace context of the caller if
+// there is one. Requests without a span get a new random trace ID, so that every problem can be found in the logs.
+func traceIdOf(request *http.Request) string {
+	if spanContext := trace.SpanContextFromContext(request.Context()); spanContext.HasTraceID() {
+		return spanContext.TraceID().String()
+	}
+
+	traceId := make([]byte, 16)
+	if _, err := rand.Read(traceId); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(traceId)
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"RentalManagement/logic/model"
+	"RentalManagement/logic/rentalErrors"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/propagation"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// handleError lets HandleError respond to a request with the given method, path and traceparent header, whose trace
+// context is continued as the tracing middleware does
+func handleError(err error, method string, path string, traceparent string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, nil)
+	if traceparent != "" {
+		request.Header.Set("traceparent", traceparent)
+		request = request.WithContext(propagation.TraceContext{}.Extract(request.Context(),
+			propagation.HeaderCarrier(request.Header)))
+	}
+	recorder := httptest.NewRecorder()
+
+	HandleError(err, echo.New().NewContext(request, recorder))
+	return recorder
+}
+
+func decodeProblem(t *testing.T, recorder *httptest.ResponseRecorder) model.Problem {
+	assert.Equal(t, problemContentType, recorder.Header().Get(echo.HeaderContentType))
+
+	var problem model.Problem
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+	return problem
+}
+
+func TestHandleError_rentalError(t *testing.T) {
+	err := fmt.Errorf("looking up the car failed: %w", rentalErrors.ErrCarNotFound)
+
+	recorder := handleError(err, http.MethodGet, "/cars/WVWAA71K08W201030", traceparent)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Equal(t, model.Problem{
+		Type:     "about:blank",
+		Title:    "Not Found",
+		Status:   http.StatusNotFound,
+		Detail:   "car not found",
+		Instance: "/cars/WVWAA71K08W201030",
+		Code:     model.ErrorCodeCARNOTFOUND,
+		TraceId:  "4bf92f3577b34da6a3ce929d0e0e4736",
+	}, decodeProblem(t, recorder))
+}
+
+func TestHandleError_problemError(t *testing.T) {
+	recorder := handleError(errPastTimePeriod, http.MethodPost, "/cars/WVWAA71K08W201030/rentals", traceparent)
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+	problem := decodeProblem(t, recorder)
+	assert.Equal(t, model.ErrorCodePASTTIMEPERIOD, problem.Code)
+	assert.Equal(t, "startDate must be in the future", problem.Detail)
+}
+
+func TestHandleError_missingRole(t *testing.T) {
+	recorder := handleError(errMissingRole, http.MethodGet, "/rentals.csv", traceparent)
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+	assert.Equal(t, model.ErrorCodeMISSINGROLE, decodeProblem(t, recorder).Code)
+}
+
+func TestHandleError_httpErrors(t *testing.T) {
+	tests := []struct {
+		err          error
+		expectedCode model.ErrorCode
+	}{
+		{echo.NewHTTPError(http.StatusBadRequest, "invalid vin"), model.ErrorCodeINVALIDREQUEST},
+		{echo.ErrForbidden, model.ErrorCodeFORBIDDEN},
+		{echo.ErrNotFound, model.ErrorCodeNOTFOUND},
+		{echo.ErrMethodNotAllowed, model.ErrorCodeMETHODNOTALLOWED},
+		{echo.ErrStatusRequestEntityTooLarge, model.ErrorCodeREQUESTREJECTED},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.expectedCode), func(t *testing.T) {
+			recorder := handleError(test.err, http.MethodGet, "/cars", traceparent)
+
+			assert.Equal(t, test.err.(*echo.HTTPError).Code, recorder.Code)
+			assert.Equal(t, test.expectedCode, decodeProblem(t, recorder).Code)
+		})
+	}
+}
+
+func TestHandleError_unexpectedError(t *testing.T) {
+	recorder := handleError(errors.New("database unavailable"), http.MethodGet, "/rentals", "")
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	problem := decodeProblem(t, recorder)
+	assert.Equal(t, model.ErrorCodeINTERNALERROR, problem.Code)
+	assert.NotContains(t, problem.Detail, "database")
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{32}$`), problem.TraceId)
+}
+
+func TestHandleError_internalHttpError(t *testing.T) {
+	recorder := handleError(echo.ErrInternalServerError, http.MethodGet, "/rentals", traceparent)
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Equal(t, model.ErrorCodeINTERNALERROR, decodeProblem(t, recorder).Code)
+}
+
+func TestHandleError_head(t *testing.T) {
+	recorder := handleError(rentalErrors.ErrRentalNotFound, http.MethodHead, "/rentals/kskgnvsl", traceparent)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Empty(t, recorder.Body.Bytes())
+}
+
+func TestHandleError_invalidTraceparent(t *testing.T) {
+	recorder := handleError(rentalErrors.ErrRentalNotFound, http.MethodGet, "/rentals/kskgnvsl",
+		"00-invalid-00f067aa0ba902b7-01")
+
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{32}$`), decodeProblem(t, recorder).TraceId)
+}
+
+func TestTraceIdOf_noSpan(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/rentals", nil)
+
+	traceId := traceIdOf(request)
+
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{32}$`), traceId)
+	assert.NotEqual(t, traceIdOf(request), traceId)
+}
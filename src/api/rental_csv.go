@@ -0,0 +1,158 @@
+package api
+
+import (
+	"RentalManagement/logic/model"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// csvContentType is the media type of the rental export
+const csvContentType = "text/csv; charset=utf-8"
+
+// maxImportRows is the maximum number of data rows of a rental import
+const maxImportRows = 1000
+
+// maxExportPeriod is the maximum length of the time period of a rental export. The rentals of the period are loaded
+// and sorted in memory, so the period is bounded to keep the memory usage of an export in check.
+const maxExportPeriod = 366 * 24 * time.Hour
+
+// rentalCsvHeader is the header row of the rental export. An export can be imported again as is, the columns that
+// are not needed for the import are ignored.
+var rentalCsvHeader = []string{"vin", "rentalId", "customerId", "startDate", "endDate", "state"}
+
+// importCsvColumns are the columns a rental import must contain, in any order
+var importCsvColumns = []string{"vin", "customerId", "startDate", "endDate"}
+
+// vinPattern is the pattern of the vin schema of the OpenAPI specification
+var vinPattern = regexp.MustCompile(`^[A-HJ-NPR-Z0-9]{13}[0-9]{4}$`)
+
+var errTooManyImportRows = fmt.Errorf("the file must not contain more than %d rows", maxImportRows)
+
+// rentalImportRow is a data row of a rental import. If the row is invalid, err describes why.
+type rentalImportRow struct {
+	line       int
+	vin        model.Vin
+	customerId model.CustomerId
+	timePeriod model.TimePeriod
+	err        error
+}
+
+// writeRentalsCsv writes the rentals as CSV with a header row. The car and the customer of each rental must be set.
+// Dates are written in RFC 3339 format in UTC.
+func writeRentalsCsv(w io.Writer, rentals []model.Rental) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(rentalCsvHeader); err != nil {
+		return err
+	}
+	for _, rental := range rentals {
+		err := writer.Write([]string{
+			rental.Car.Vin,
+			rental.Id,
+			rental.Customer.CustomerId,
+			rental.RentalPeriod.StartDate.UTC().Format(time.RFC3339),
+			rental.RentalPeriod.EndDate.UTC().Format(time.RFC3339),
+			string(rental.State),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// readRentalImportCsv reads the data rows of a rental import. The columns are identified by the header row, so their
+// order does not matter and additional columns are ignored. An error is returned if the file itself cannot be
+// imported, invalid rows are returned with their error set instead.
+func readRentalImportCsv(r io.Reader) ([]rentalImportRow, error) {
+	reader := csv.NewReader(r)
+	// rows are checked against the header below to report the line of a row with a missing column
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("the file must contain a header row")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range importCsvColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("the header row must contain the column %s", name)
+		}
+	}
+
+	var rows []rentalImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == maxImportRows {
+			return nil, errTooManyImportRows
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, parseRentalImportRow(line, record, len(header), columns))
+	}
+}
+
+func parseRentalImportRow(line int, record []string, headerLength int, columns map[string]int) rentalImportRow {
+	value := func(name string) string {
+		if columns[name] >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[columns[name]])
+	}
+
+	row := rentalImportRow{
+		line:       line,
+		vin:        value("vin"),
+		customerId: value("customerId"),
+	}
+
+	if len(record) != headerLength {
+		row.err = fmt.Errorf("the row must have %d columns like the header row", headerLength)
+		return row
+	}
+	if !vinPattern.MatchString(row.vin) {
+		row.err = errors.New("invalid vin")
+		return row
+	}
+	if address, err := mail.ParseAddress(row.customerId); err != nil || address.Address != row.customerId {
+		row.err = errors.New("invalid customerId")
+		return row
+	}
+
+	startDate, err := time.Parse(time.RFC3339, value("startDate"))
+	if err != nil {
+		row.err = errors.New("startDate must be an RFC 3339 date-time")
+		return row
+	}
+	endDate, err := time.Parse(time.RFC3339, value("endDate"))
+	if err != nil {
+		row.err = errors.New("endDate must be an RFC 3339 date-time")
+		return row
+	}
+	row.timePeriod = model.TimePeriod{StartDate: startDate, EndDate: endDate}
+	if isInvalidTimePeriod(row.timePeriod) {
+		row.err = errors.New(errInvalidTimePeriod.Error())
+	}
+	return row
+}
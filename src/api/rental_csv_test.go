@@ -0,0 +1,114 @@
+package api
+
+import (
+	"RentalManagement/logic/model"
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+var importTimePeriod = model.TimePeriod{
+	StartDate: time.Date(2023, 3, 3, 9, 0, 0, 0, time.UTC),
+	EndDate:   time.Date(2023, 3, 5, 18, 30, 0, 0, time.FixedZone("CET", 3600)),
+}
+
+func TestWriteRentalsCsv(t *testing.T) {
+	rental := model.Rental{
+		State:        model.UPCOMING,
+		Car:          &model.Car{Vin: "WVWAA71K08W201030"},
+		Customer:     &model.Customer{CustomerId: "customer@example.com"},
+		Id:           "rZ6IIwcD",
+		RentalPeriod: importTimePeriod,
+	}
+
+	var csv bytes.Buffer
+	err := writeRentalsCsv(&csv, []model.Rental{rental})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "vin,rentalId,customerId,startDate,endDate,state\n"+
+		"WVWAA71K08W201030,rZ6IIwcD,customer@example.com,2023-03-03T09:00:00Z,2023-03-05T17:30:00Z,UPCOMING\n",
+		csv.String())
+}
+
+func TestWriteRentalsCsv_noRentals(t *testing.T) {
+	var csv bytes.Buffer
+	err := writeRentalsCsv(&csv, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "vin,rentalId,customerId,startDate,endDate,state\n", csv.String())
+}
+
+func TestReadRentalImportCsv_exportedFile(t *testing.T) {
+	rows, err := readRentalImportCsv(strings.NewReader("vin,rentalId,customerId,startDate,endDate,state\n" +
+		"WVWAA71K08W201030,rZ6IIwcD,customer@example.com,2023-03-03T09:00:00Z,2023-03-05T18:30:00+01:00,UPCOMING\n"))
+
+	assert.Nil(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, 2, rows[0].line)
+	assert.Equal(t, "WVWAA71K08W201030", rows[0].vin)
+	assert.Equal(t, "customer@example.com", rows[0].customerId)
+	assert.True(t, rows[0].timePeriod.StartDate.Equal(importTimePeriod.StartDate))
+	assert.True(t, rows[0].timePeriod.EndDate.Equal(importTimePeriod.EndDate))
+	assert.Nil(t, rows[0].err)
+}
+
+func TestReadRentalImportCsv_invalidRows(t *testing.T) {
+	rows, err := readRentalImportCsv(strings.NewReader("vin,customerId,startDate,endDate,note\n" +
+		"WVWAA71K08W20103,customer@example.com,2023-03-03T09:00:00Z,2023-03-05T18:30:00Z,\n" +
+		"WVWAA71K08W201030,customer,2023-03-03T09:00:00Z,2023-03-05T18:30:00Z,\n" +
+		"WVWAA71K08W201030,customer@example.com,2023-03-03,2023-03-05T18:30:00Z,\n" +
+		"WVWAA71K08W201030,customer@example.com,2023-03-03T09:00:00Z,tomorrow,\n" +
+		"WVWAA71K08W201030,customer@example.com,2023-03-05T18:30:00Z,2023-03-03T09:00:00Z,\n" +
+		"WVWAA71K08W201030,customer@example.com,2023-03-03T09:00:00Z,2023-03-05T18:30:00Z\n" +
+		"WVWAA71K08W201030,customer@example.com,2023-03-03T09:00:00Z,2023-03-05T18:30:00Z,\"multi\nline\"\n" +
+		"WVWAA71K08W201030,customer@example.com,2023-03-03T09:00:00Z,2023-03-05T18:30:00Z,\n"))
+
+	assert.Nil(t, err)
+	var lines []int
+	var errs []error
+	for _, row := range rows {
+		lines = append(lines, row.line)
+		errs = append(errs, row.err)
+	}
+	assert.Equal(t, []int{2, 3, 4, 5, 6, 7, 8, 10}, lines)
+	assert.Equal(t, []error{
+		errors.New("invalid vin"),
+		errors.New("invalid customerId"),
+		errors.New("startDate must be an RFC 3339 date-time"),
+		errors.New("endDate must be an RFC 3339 date-time"),
+		errors.New("startDate must be before endDate"),
+		errors.New("the row must have 5 columns like the header row"),
+		nil,
+		nil,
+	}, errs)
+}
+
+func TestReadRentalImportCsv_missingColumn(t *testing.T) {
+	rows, err := readRentalImportCsv(strings.NewReader("vin,customerId,startDate\n"))
+
+	assert.Nil(t, rows)
+	assert.EqualError(t, err, "the header row must contain the column endDate")
+}
+
+func TestReadRentalImportCsv_empty(t *testing.T) {
+	rows, err := readRentalImportCsv(strings.NewReader(""))
+
+	assert.Nil(t, rows)
+	assert.EqualError(t, err, "the file must contain a header row")
+}
+
+func TestReadRentalImportCsv_tooManyRows(t *testing.T) {
+	row := "WVWAA71K08W201030,customer@example.com,2023-03-03T09:00:00Z,2023-03-05T18:30:00Z\n"
+	csv := "vin,customerId,startDate,endDate\n" + strings.Repeat(row, maxImportRows)
+
+	rows, err := readRentalImportCsv(strings.NewReader(csv))
+	assert.Nil(t, err)
+	assert.Len(t, rows, maxImportRows)
+
+	rows, err = readRentalImportCsv(strings.NewReader(csv + row))
+	assert.Nil(t, rows)
+	assert.ErrorIs(t, err, errTooManyImportRows)
+}
@@ -0,0 +1,184 @@
+package api
+
+import (
+	"RentalManagement/logging"
+	"bytes"
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/exp/slog"
+	"io"
+	"net/http"
+)
+
+// ResponseValidation is the handling of responses that do not match the OpenAPI specification
+type ResponseValidation string
+
+// Defines values for ResponseValidation.
+const (
+	// ResponseValidationOff does not validate responses
+	ResponseValidationOff ResponseValidation = ""
+	// ResponseValidationLog logs invalid responses but sends them unchanged
+	ResponseValidationLog ResponseValidation = "log"
+	// ResponseValidationFail logs invalid responses and replaces them with an internal error
+	ResponseValidationFail ResponseValidation = "fail"
+)
+
+func init() {
+	// calendar feeds are documented as strings
+	openapi3filter.RegisterBodyDecoder("text/calendar", openapi3filter.FileBodyDecoder)
+}
+
+// AddOpenApiResponseValidationMiddleware adds middleware to the echo server that validates every response to an
+// operation, including the problems of failed requests, against the schema documented for its status code in the
+// OpenAPI specification of RentalManagement. It is meant for development and testing, where drift between the
+// handlers and the specification should be noticed, as every response is buffered until it has been validated.
+// Streamed responses are sent as soon as they are flushed and are not validated.
+//
+// The middleware must be added before all other middleware but the metrics middleware, so that it sees their
+// responses as well.
+func AddOpenApiResponseValidationMiddleware(e *echo.Echo, validation ResponseValidation) error {
+	switch validation {
+	case ResponseValidationOff:
+		return nil
+	case ResponseValidationLog, ResponseValidationFail:
+	default:
+		return fmt.Errorf("unknown response validation %q", validation)
+	}
+
+	swagger, err := openapi3.NewLoader().LoadFromData(openApiData)
+	if err != nil {
+		return err
+	}
+	router, err := gorillamux.NewRouter(swagger)
+	if err != nil {
+		return err
+	}
+
+	e.Use(validateResponses(router, validation))
+
+	return nil
+}
+
+func validateResponses(router routers.Router, validation ResponseValidation) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			route, pathParams, err := router.FindRoute(ctx.Request())
+			if err != nil {
+				// there is no documented response to validate against
+				return next(ctx)
+			}
+
+			response := ctx.Response()
+			writer := response.Writer
+			recorder := &responseRecorder{ResponseWriter: writer}
+			response.Writer = recorder
+
+			// let the error handler respond within the middleware so that problems are validated as well
+			if err := next(ctx); err != nil {
+				ctx.Error(err)
+			}
+			response.Writer = writer
+			if recorder.streamed {
+				return nil
+			}
+			if isUndocumentedServerError(route, recorder.statusCode()) {
+				return recorder.send()
+			}
+
+			err = openapi3filter.ValidateResponse(ctx.Request().Context(), &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: &openapi3filter.RequestValidationInput{
+					Request:    ctx.Request(),
+					PathParams: pathParams,
+					Route:      route,
+				},
+				Status:  recorder.statusCode(),
+				Header:  writer.Header(),
+				Body:    io.NopCloser(bytes.NewReader(recorder.body.Bytes())),
+				Options: &openapi3filter.Options{IncludeResponseStatus: true},
+			})
+			if err == nil {
+				return recorder.send()
+			}
+
+			if validation == ResponseValidationLog {
+				logging.FromContext(ctx.Request().Context()).Warn("response does not match the specification",
+					logging.KeyMethod, ctx.Request().Method, logging.KeyPath, ctx.Request().URL.Path, logging.KeyError, err)
+				return recorder.send()
+			}
+
+			// discard the invalid response and respond with an internal error instead
+			for key := range writer.Header() {
+				writer.Header().Del(key)
+			}
+			response.Committed = false
+			response.Status = http.StatusOK
+			response.Size = 0
+			ctx.Error(fmt.Errorf("response does not match the specification: %w", err))
+			return nil
+		}
+	}
+}
+
+// isUndocumentedServerError reports whether the status is a server error the operation does not document. Unexpected
+// errors are not part of the contract of an operation, so their problems are not validated.
+func isUndocumentedServerError(route *routers.Route, status int) bool {
+	return status >= http.StatusInternalServerError && route.Operation.Responses.Get(status) == nil
+}
+
+// responseRecorder buffers a response until it has been validated. Once flushed, the response is streamed and written
+// through to the client.
+type responseRecorder struct {
+	http.ResponseWriter
+	status   int
+	body     bytes.Buffer
+	streamed bool
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.streamed {
+		r.ResponseWriter.WriteHeader(status)
+		return
+	}
+	r.status = status
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	if r.streamed {
+		return r.ResponseWriter.Write(data)
+	}
+	return r.body.Write(data)
+}
+
+func (r *responseRecorder) Flush() {
+	if !r.streamed {
+		r.streamed = true
+		if err := r.send(); err != nil {
+			slog.Error("streaming the response failed", logging.KeyError, err)
+		}
+	}
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+func (r *responseRecorder) statusCode() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+// send writes the buffered response to the client
+func (r *responseRecorder) send() error {
+	r.ResponseWriter.WriteHeader(r.statusCode())
+	_, err := r.body.WriteTo(r.ResponseWriter)
+	return err
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"RentalManagement/logic/model"
+	"RentalManagement/logic/rentalErrors"
+	"RentalManagement/testdata"
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const rentalStatusPath = "/rentals/kskgnvsl"
+
+// newResponseValidationApp returns an app that validates the responses of the given handler for the rental status
+func newResponseValidationApp(t *testing.T, validation ResponseValidation, handler echo.HandlerFunc) *echo.Echo {
+	app := echo.New()
+	app.HTTPErrorHandler = HandleError
+	assert.Nil(t, AddOpenApiResponseValidationMiddleware(app, validation))
+	app.GET("/rentals/:rentalId", handler)
+	return app
+}
+
+func invalidRental(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, model.Rental{Id: "kskgnvsl", State: model.UPCOMING})
+}
+
+func TestAddOpenApiResponseValidationMiddleware_valid(t *testing.T) {
+	var rental model.Rental
+	assert.Nil(t, json.Unmarshal([]byte(testdata.CustomerRentalUpcoming), &rental))
+	rental.Id = "kskgnvsl"
+	app := newResponseValidationApp(t, ResponseValidationFail, func(ctx echo.Context) error {
+		return ctx.JSON(http.StatusOK, rental)
+	})
+
+	recorder := serve(app, rentalStatusPath)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var responseRental model.Rental
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &responseRental))
+	assert.Equal(t, rental, responseRental)
+}
+
+func TestAddOpenApiResponseValidationMiddleware_problem(t *testing.T) {
+	app := newResponseValidationApp(t, ResponseValidationFail, func(ctx echo.Context) error {
+		return rentalErrors.ErrRentalNotFound
+	})
+
+	recorder := serve(app, rentalStatusPath)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Equal(t, model.ErrorCodeRENTALNOTFOUND, decodeProblem(t, recorder).Code)
+}
+
+func TestAddOpenApiResponseValidationMiddleware_invalidFails(t *testing.T) {
+	app := newResponseValidationApp(t, ResponseValidationFail, invalidRental)
+
+	recorder := serve(app, rentalStatusPath)
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Equal(t, model.ErrorCodeINTERNALERROR, decodeProblem(t, recorder).Code)
+}
+
+func TestAddOpenApiResponseValidationMiddleware_undocumentedStatusFails(t *testing.T) {
+	app := newResponseValidationApp(t, ResponseValidationFail, func(ctx echo.Context) error {
+		return ctx.NoContent(http.StatusAccepted)
+	})
+
+	recorder := serve(app, rentalStatusPath)
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
+
+func TestAddOpenApiResponseValidationMiddleware_invalidLogged(t *testing.T) {
+	app := newResponseValidationApp(t, ResponseValidationLog, invalidRental)
+
+	recorder := serve(app, rentalStatusPath)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"id":"kskgnvsl"`)
+}
+
+func TestAddOpenApiResponseValidationMiddleware_streamed(t *testing.T) {
+	app := newResponseValidationApp(t, ResponseValidationFail, func(ctx echo.Context) error {
+		ctx.Response().Header().Set(echo.HeaderContentType, "text/event-stream")
+		ctx.Response().WriteHeader(http.StatusOK)
+		ctx.Response().Flush()
+		_, err := ctx.Response().Write([]byte("data: {}\n\n"))
+		return err
+	})
+
+	recorder := serve(app, rentalStatusPath)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "data: {}\n\n", recorder.Body.String())
+}
+
+func TestAddOpenApiResponseValidationMiddleware_unknownValidation(t *testing.T) {
+	assert.NotNil(t, AddOpenApiResponseValidationMiddleware(echo.New(), "strict"))
+}
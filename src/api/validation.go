@@ -4,6 +4,7 @@ import (
 	_ "embed"
 	"github.com/deepmap/oapi-codegen/pkg/middleware"
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/labstack/echo/v4"
 )
 
@@ -11,14 +12,23 @@ import (
 var openApiData []byte
 
 // AddOpenApiValidationMiddleware adds validation middleware to the echo server. It uses the OpenAPI specification of
-// RentalManagement to validate API requests.
+// RentalManagement to validate API requests. Requests for the documentation of the API and of the probes are not
+// validated.
 func AddOpenApiValidationMiddleware(e *echo.Echo) error {
 	swagger, err := openapi3.NewLoader().LoadFromData(openApiData)
 	if err != nil {
 		return err
 	}
 
-	e.Use(middleware.OapiRequestValidator(swagger))
+	e.Use(middleware.OapiRequestValidatorWithOptions(swagger, &middleware.Options{
+		Skipper: func(ctx echo.Context) bool {
+			return isDocumentationRequest(ctx) || isHealthRequest(ctx)
+		},
+		Options: openapi3filter.Options{
+			// bearer tokens are verified by the authentication middleware
+			AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		},
+	}))
 
 	return nil
 }
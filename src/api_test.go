@@ -1,16 +1,22 @@
 package main
 
 import (
+	"RentalManagement/api"
 	"RentalManagement/environment"
 	"RentalManagement/infrastructure/database"
 	"RentalManagement/infrastructure/database/db"
 	"RentalManagement/logic/model"
+	"RentalManagement/logic/outbox"
+	"RentalManagement/logic/webhook"
 	"RentalManagement/testdata"
 	"RentalManagement/testhelpers"
+	"RentalManagement/util"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"sort"
 	"strings"
 	"testing"
@@ -27,26 +33,55 @@ const carServerUrl = "https://carservice.kit.edu"
 type ApiTestSuite struct {
 	suite.Suite
 	dbConnection       db.IConnection
-	collection         string
+	collections        []string
 	app                *echo.Echo
 	recordingFormatter *testhelpers.RecordingFormatter
+	tokenIssuer        *testhelpers.TokenIssuer
 }
 
 func (suite *ApiTestSuite) SetupSuite() {
-	environment.SetupTestingEnvironment(carServerUrl)
+	var err error
+	suite.tokenIssuer, err = testhelpers.NewTokenIssuer(suite.T().TempDir())
+	if err != nil {
+		suite.T().Fatal(err.Error())
+	}
+
+	environment.SetupTestingEnvironment(carServerUrl, suite.tokenIssuer.JwksFile)
 
 	// generate a collection name so that concurrent executions do not interfere
 	collectionPrefix := fmt.Sprintf("test-%d-", time.Now().Unix())
 	environment.GetEnvironment().SetAppCollectionPrefix(collectionPrefix)
-	suite.collection = collectionPrefix + database.CollectionBaseName
+	// fail on any drift between the handlers and the OpenAPI specification
+	environment.GetEnvironment().SetResponseValidation(string(api.ResponseValidationFail))
+	suite.collections = []string{
+		collectionPrefix + database.CollectionBaseName,
+		collectionPrefix + database.TrunkAccessLogCollectionBaseName,
+		collectionPrefix + database.RelockJobCollectionBaseName,
+		collectionPrefix + database.OutboxCollectionBaseName,
+		collectionPrefix + database.WebhookCollectionBaseName,
+		collectionPrefix + database.WebhookDeliveryCollectionBaseName,
+		collectionPrefix + database.ReminderCollectionBaseName,
+		collectionPrefix + database.CalendarSubscriptionCollectionBaseName,
+	}
 
-	var err error
 	suite.dbConnection, err = db.NewDbConnection(environment.GetEnvironment())
 	if err != nil {
 		suite.handleDbConnectionError(err)
 	}
 
-	suite.app, err = newApp(suite.dbConnection)
+	operationsInstance, err := newOperations(suite.dbConnection)
+	if err != nil {
+		suite.T().Fatal(err.Error())
+	}
+	verifier, err := api.NewTokenVerifier(environment.GetEnvironment())
+	if err != nil {
+		suite.T().Fatal(err.Error())
+	}
+	health, err := newHealth(suite.dbConnection)
+	if err != nil {
+		suite.T().Fatal(err.Error())
+	}
+	suite.app, err = newApp(operationsInstance, verifier, health)
 	if err != nil {
 		suite.T().Fatal(err.Error())
 	}
@@ -90,9 +125,11 @@ func (suite *ApiTestSuite) TearDownTest() {
 	diagramFormatter := apitest.SequenceDiagram()
 	diagramFormatter.Format(suite.recordingFormatter.GetRecorder())
 
-	// clear the collection after each test
-	if err := suite.dbConnection.DropCollection(context.Background(), suite.collection); err != nil {
-		suite.T().Fatal(err)
+	// clear the collections after each test
+	for _, collection := range suite.collections {
+		if err := suite.dbConnection.DropCollection(context.Background(), collection); err != nil {
+			suite.T().Fatal(err)
+		}
 	}
 }
 
@@ -101,6 +138,15 @@ func TestApiTestSuite(t *testing.T) {
 }
 
 func (suite *ApiTestSuite) newApiTestWithMocks(mocks []*apitest.Mock) *apitest.APITest {
+	return suite.newAnonymousApiTestWithMocks(mocks).Intercept(suite.authenticateAsAdmin)
+}
+
+func (suite *ApiTestSuite) newApiTestWithCarMock() *apitest.APITest {
+	return suite.newApiTestWithMocks(suite.newCarMock())
+}
+
+// newAnonymousApiTestWithMocks does not authenticate requests that do not authenticate on their own
+func (suite *ApiTestSuite) newAnonymousApiTestWithMocks(mocks []*apitest.Mock) *apitest.APITest {
 	return apitest.New().
 		Mocks(mocks...).
 		Debug().
@@ -108,8 +154,16 @@ func (suite *ApiTestSuite) newApiTestWithMocks(mocks []*apitest.Mock) *apitest.A
 		Report(suite.recordingFormatter)
 }
 
-func (suite *ApiTestSuite) newApiTestWithCarMock() *apitest.APITest {
-	return suite.newApiTestWithMocks(suite.newCarMock())
+func (suite *ApiTestSuite) newAnonymousApiTestWithCarMock() *apitest.APITest {
+	return suite.newAnonymousApiTestWithMocks(suite.newCarMock())
+}
+
+// authenticateAsAdmin authenticates requests that do not authenticate on their own as an administrator,
+// so that tests of the operations do not need to care about roles
+func (suite *ApiTestSuite) authenticateAsAdmin(request *http.Request) {
+	if request.Header.Get(echo.HeaderAuthorization) == "" {
+		request.Header.Set(echo.HeaderAuthorization, suite.tokenIssuer.StaffBearerToken("admin"))
+	}
 }
 
 func (suite *ApiTestSuite) newCarMock() []*apitest.Mock {
@@ -136,6 +190,7 @@ func (suite *ApiTestSuite) createRentalForCustomer(vin string, body string, cust
 	suite.newApiTestWithCarMock().
 		Post("/cars/"+vin+"/rentals").
 		Query("customerId", customerId).
+		Header("Authorization", suite.tokenIssuer.BearerToken(customerId)).
 		JSON(body).
 		Expect(suite.T()).
 		Status(http.StatusCreated).
@@ -152,6 +207,7 @@ func (suite *ApiTestSuite) getRentalOverview(customerId model.CustomerId) []mode
 	suite.newApiTestWithCarMock().
 		Get("/rentals").
 		Query("customerId", customerId).
+		Header("Authorization", suite.tokenIssuer.BearerToken(customerId)).
 		Expect(suite.T()).
 		Status(http.StatusOK).
 		Assert(mapOverviewToRentals(&rentals)).
@@ -220,12 +276,48 @@ func (suite *ApiTestSuite) TestCreateRental_success_closeTimePeriod() {
 	suite.createRental(testdata.VinCar, testdata.TimePeriod2123)
 }
 
+func (suite *ApiTestSuite) TestCreateRental_success_rentalEventPending() {
+	suite.createRental(testdata.VinCar, testdata.TimePeriod2122)
+	rentalId := suite.getRentalOverview("example@customer.cust")[0].Id
+
+	crudInstance := database.NewICRUD(suite.dbConnection, environment.GetEnvironment(), util.TimeProvider{})
+	events, err := crudInstance.GetPendingRentalEvents(context.Background())
+
+	suite.Nil(err)
+	suite.Len(*events, 1)
+	suite.Equal(model.RentalCreated, (*events)[0].Type)
+	suite.Equal(rentalId, (*events)[0].RentalId)
+	suite.Equal(testdata.VinCar, (*events)[0].Vin)
+	suite.Equal("example@customer.cust", (*events)[0].CustomerId)
+}
+
+func (suite *ApiTestSuite) TestCreateRental_conflictingRentalsExist_noRentalEvent() {
+	suite.createRental(testdata.VinCar, testdata.TimePeriod2122)
+
+	suite.newApiTestWithCarMock().
+		Post("/cars/"+testdata.VinCar+"/rentals").
+		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
+		JSON(testdata.TimePeriod2122).
+		Expect(suite.T()).
+		Status(http.StatusConflict).
+		End()
+
+	// the rejected rental is not reported
+	crudInstance := database.NewICRUD(suite.dbConnection, environment.GetEnvironment(), util.TimeProvider{})
+	events, err := crudInstance.GetPendingRentalEvents(context.Background())
+
+	suite.Nil(err)
+	suite.Len(*events, 1)
+}
+
 func (suite *ApiTestSuite) TestCreateRental_conflictingRentalsExist() {
 	suite.createRental(testdata.VinCar, testdata.TimePeriod2122)
 
 	suite.newApiTestWithCarMock().
 		Post("/cars/"+testdata.VinCar+"/rentals").
 		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
 		JSON(testdata.TimePeriod2122).
 		Expect(suite.T()).
 		Status(http.StatusConflict).
@@ -238,6 +330,7 @@ func (suite *ApiTestSuite) TestCreateRental_conflictingRentalsExist_overlappingP
 	suite.newApiTestWithCarMock().
 		Post("/cars/"+testdata.VinCar+"/rentals").
 		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
 		JSON(testdata.TimePeriod2122To23).
 		Expect(suite.T()).
 		Status(http.StatusConflict).
@@ -250,6 +343,7 @@ func (suite *ApiTestSuite) TestCreateRental_conflictingRentalsExist_overlappingF
 	suite.newApiTestWithCarMock().
 		Post("/cars/"+testdata.VinCar+"/rentals").
 		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
 		JSON(testdata.TimePeriod2122To23).
 		Expect(suite.T()).
 		Status(http.StatusConflict).
@@ -260,6 +354,7 @@ func (suite *ApiTestSuite) TestCreateRental_carNotFound() {
 	suite.newApiTestWithCarMock().
 		Post("/cars/"+testdata.UnknownVin+"/rentals").
 		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
 		JSON(testdata.TimePeriod2122).
 		Expect(suite.T()).
 		Status(http.StatusNotFound).
@@ -270,6 +365,7 @@ func (suite *ApiTestSuite) TestCreateRental_semanticallyInvalidTimePeriod() {
 	suite.newApiTestWithCarMock().
 		Post("/cars/"+testdata.VinCar+"/rentals").
 		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
 		JSON(testdata.TimePeriodSemanticInvalid).
 		Expect(suite.T()).
 		Status(http.StatusBadRequest).
@@ -280,6 +376,7 @@ func (suite *ApiTestSuite) TestCreateRental_syntacticallyInvalidTimePeriod() {
 	suite.newApiTestWithCarMock().
 		Post("/cars/"+testdata.VinCar+"/rentals").
 		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
 		JSON(testdata.TimePeriodSyntaxInvalid).
 		Expect(suite.T()).
 		Status(http.StatusBadRequest).
@@ -291,6 +388,7 @@ func (suite *ApiTestSuite) TestCreateRental_syntacticallyInvalidTimePeriod() {
 	suite.newApiTestWithCarMock().
 		Post("/cars/"+testdata.VinCar+"/rentals").
 		Query("customerId", "invalid").
+		Header("Authorization", suite.tokenIssuer.BearerToken("invalid")).
 		JSON(testdata.TimePeriod2122).
 		Expect(suite.T()).
 		Status(http.StatusBadRequest).
@@ -301,6 +399,7 @@ func (suite *ApiTestSuite) TestCreateRental_invalidVin() {
 	suite.newApiTestWithCarMock().
 		Post("/cars/invalid/rentals").
 		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
 		JSON(testdata.TimePeriod2122).
 		Expect(suite.T()).
 		Status(http.StatusBadRequest).
@@ -311,6 +410,7 @@ func (suite *ApiTestSuite) TestCreateRental_past() {
 	suite.newApiTestWithCarMock().
 		Post("/cars/"+testdata.VinCar+"/rentals").
 		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
 		JSON(testdata.TimePeriod1900).
 		Expect(suite.T()).
 		Status(http.StatusForbidden).
@@ -321,6 +421,7 @@ func (suite *ApiTestSuite) TestCreateRental_beginPast() {
 	suite.newApiTestWithCarMock().
 		Post("/cars/"+testdata.VinCar+"/rentals").
 		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
 		JSON(testdata.TimePeriodLong).
 		Expect(suite.T()).
 		Status(http.StatusForbidden).
@@ -477,10 +578,52 @@ func (suite *ApiTestSuite) TestGetCar_invalidVin() {
 		End()
 }
 
+func (suite *ApiTestSuite) TestHealth_alive() {
+	suite.newAnonymousApiTestWithMocks(nil).
+		Get("/healthz").
+		Expect(suite.T()).
+		Status(http.StatusOK).
+		Body(`{"status": "UP"}`).
+		End()
+}
+
+func (suite *ApiTestSuite) TestHealth_ready() {
+	var report api.HealthReport
+	suite.newAnonymousApiTestWithCarMock().
+		Get("/readyz").
+		Expect(suite.T()).
+		Status(http.StatusOK).
+		Assert(decodeBody(&report)).
+		End()
+
+	assert.Equal(suite.T(), api.HealthStatusUp, report.Status)
+	assert.Equal(suite.T(), api.HealthStatusUp, report.Dependencies["mongodb"].Status)
+	assert.Equal(suite.T(), api.HealthStatusUp, report.Dependencies["car"].Status)
+}
+
+func (suite *ApiTestSuite) TestHealth_carUnavailable() {
+	var report api.HealthReport
+	suite.newAnonymousApiTestWithMocks([]*apitest.Mock{
+		apitest.NewMock().
+			Get(environment.GetEnvironment().GetCarServerUrl() + "/cars").
+			RespondWith().Status(http.StatusBadGateway).End(),
+	}).
+		Get("/readyz").
+		Expect(suite.T()).
+		Status(http.StatusServiceUnavailable).
+		Assert(decodeBody(&report)).
+		End()
+
+	assert.Equal(suite.T(), api.HealthStatusDown, report.Status)
+	assert.Equal(suite.T(), api.HealthStatusUp, report.Dependencies["mongodb"].Status)
+	assert.Equal(suite.T(), api.HealthStatusDown, report.Dependencies["car"].Status)
+}
+
 func (suite *ApiTestSuite) TestGetRentalOverview_success_noRentals() {
 	suite.newApiTestWithCarMock().
 		Get("/rentals").
 		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
 		Expect(suite.T()).
 		Status(http.StatusOK).
 		Body(testdata.EmptyArray).
@@ -502,6 +645,7 @@ func (suite *ApiTestSuite) TestGetRentalOverview_success_oneRental() {
 	suite.newApiTestWithCarMock().
 		Get("/rentals").
 		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
 		Expect(suite.T()).
 		Status(http.StatusOK).
 		Assert(returnsRentalArray([]model.Rental{
@@ -546,6 +690,7 @@ func (suite *ApiTestSuite) TestGetRentalOverview_success_twoRentals() {
 	suite.newApiTestWithCarMock().
 		Get("/rentals").
 		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
 		Expect(suite.T()).
 		Status(http.StatusOK).
 		Assert(returnsRentalArray([]model.Rental{
@@ -611,6 +756,7 @@ func (suite *ApiTestSuite) TestGetRentalOverview_expiredRental() {
 	suite.newApiTestWithCarMock().
 		Get("/rentals").
 		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
 		Expect(suite.T()).
 		Status(http.StatusOK).
 		Assert(returnsRentalArray([]model.Rental{
@@ -631,6 +777,7 @@ func (suite *ApiTestSuite) TestGetRentalOverview_expiredRental() {
 	suite.newApiTestWithCarMock().
 		Get("/rentals").
 		Query("customerId", "waytoolongcustomerid").
+		Header("Authorization", suite.tokenIssuer.BearerToken("waytoolongcustomerid")).
 		Expect(suite.T()).
 		Status(http.StatusBadRequest).
 		End()
@@ -965,6 +1112,24 @@ func (suite *ApiTestSuite) TestGrantTrunkAccess_invalidTimePeriod2() {
 		End()
 }
 
+func (suite *ApiTestSuite) TestGrantTrunkAccess_unknownTimeZone() {
+	suite.newApiTestWithCarMock().
+		Post("/rentals/rentalId/trunkTokens").
+		JSON(testdata.TrunkAccessGrantUnknownTimeZone).
+		Expect(suite.T()).
+		Status(http.StatusBadRequest).
+		End()
+}
+
+func (suite *ApiTestSuite) TestGrantTrunkAccess_invalidRecurrence() {
+	suite.newApiTestWithCarMock().
+		Post("/rentals/rentalId/trunkTokens").
+		JSON(testdata.TrunkAccessGrantSyntaxInvalid).
+		Expect(suite.T()).
+		Status(http.StatusBadRequest).
+		End()
+}
+
 func (suite *ApiTestSuite) TestGrantTrunkAccess_missingTimePeriod() {
 	suite.newApiTestWithCarMock().
 		Post("/rentals/rentalId/trunkTokens").
@@ -1240,6 +1405,92 @@ func (suite *ApiTestSuite) TestGetLockState_success() {
 		End()
 }
 
+func (suite *ApiTestSuite) grantRecurringTrunkAccess(rentalId model.RentalId, recurrence model.Recurrence) string {
+	grant := model.TrunkAccessGrant{
+		StartDate:  time.Now().UTC().Round(time.Millisecond),
+		EndDate:    time.Date(2123, 1, 1, 0, 0, 0, 0, time.UTC),
+		Recurrence: &recurrence,
+	}
+
+	var trunkAccess model.TrunkAccess
+	suite.newApiTestWithCarMock().
+		Post("/rentals/" + rentalId + "/trunkTokens").
+		JSON(grant).
+		Expect(suite.T()).
+		Status(http.StatusCreated).
+		Assert(mapGrantedToTrunkAccess(&trunkAccess)).
+		End()
+
+	assert.Equal(suite.T(), &recurrence, trunkAccess.Recurrence)
+	return trunkAccess.Token
+}
+
+func weekdayOf(t time.Time) model.Weekday {
+	return model.Weekday(strings.ToUpper(t.Weekday().String()))
+}
+
+func (suite *ApiTestSuite) TestGetLockState_success_recurring() {
+	timePeriodRental := model.TimePeriod{
+		StartDate: time.Now().Add(10 * time.Millisecond).UTC().Round(time.Millisecond),
+		EndDate:   time.Date(2123, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	marshalledTime, _ := json.Marshal(timePeriodRental)
+
+	suite.createRentalForCustomer(testdata.VinCar, string(marshalledTime), "customer.example@customer.mail")
+
+	time.Sleep(10 * time.Millisecond)
+
+	rentalId := suite.getRentalOverview("customer.example@customer.mail")[0].Id
+
+	token := suite.grantRecurringTrunkAccess(rentalId, model.Recurrence{
+		DaysOfWeek: []model.Weekday{
+			model.MONDAY, model.TUESDAY, model.WEDNESDAY, model.THURSDAY, model.FRIDAY, model.SATURDAY, model.SUNDAY,
+		},
+		StartTime: "00:00",
+		EndTime:   "24:00",
+		TimeZone:  "UTC",
+	})
+
+	suite.newApiTestWithCarMock().
+		Get("/cars/"+testdata.VinCar+"/trunk").
+		Query("trunkAccessToken", token).
+		Expect(suite.T()).
+		Status(http.StatusOK).
+		Body(testdata.Unlocked).
+		End()
+}
+
+func (suite *ApiTestSuite) TestGetLockState_outsideRecurrence() {
+	timePeriodRental := model.TimePeriod{
+		StartDate: time.Now().Add(10 * time.Millisecond).UTC().Round(time.Millisecond),
+		EndDate:   time.Date(2123, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	marshalledTime, _ := json.Marshal(timePeriodRental)
+
+	suite.createRentalForCustomer(testdata.VinCar, string(marshalledTime), "customer.example@customer.mail")
+
+	time.Sleep(10 * time.Millisecond)
+
+	rentalId := suite.getRentalOverview("customer.example@customer.mail")[0].Id
+
+	// the token is only valid tomorrow (and on the same weekday in the following weeks)
+	token := suite.grantRecurringTrunkAccess(rentalId, model.Recurrence{
+		DaysOfWeek: []model.Weekday{weekdayOf(time.Now().UTC().AddDate(0, 0, 1))},
+		StartTime:  "00:00",
+		EndTime:    "24:00",
+		TimeZone:   "UTC",
+	})
+
+	suite.newApiTestWithCarMock().
+		Get("/cars/"+testdata.VinCar+"/trunk").
+		Query("trunkAccessToken", token).
+		Expect(suite.T()).
+		Status(http.StatusForbidden).
+		End()
+}
+
 func (suite *ApiTestSuite) TestGetLockState_useTokenWithOtherCar() {
 	timePeriod := model.TimePeriod{
 		StartDate: time.Now().Add(10 * time.Millisecond).UTC().Round(time.Millisecond),
@@ -1402,6 +1653,7 @@ func (suite *ApiTestSuite) TestSetLockState_customerId_success() {
 	suite.newApiTestWithCarMock().
 		Put("/cars/"+testdata.VinCar+"/trunk").
 		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
 		JSON(testdata.Locked).
 		Expect(suite.T()).
 		Status(http.StatusNoContent).
@@ -1420,6 +1672,7 @@ func (suite *ApiTestSuite) TestSetLockState_tooManyParameters() {
 	suite.newApiTestWithCarMock().
 		Put("/cars/"+testdata.VinCar+"/trunk").
 		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
 		Query("trunkAccessToken", testdata.TrunkAccessToken).
 		JSON(testdata.Locked).
 		Expect(suite.T()).
@@ -1431,6 +1684,7 @@ func (suite *ApiTestSuite) TestSetLockState_customerId_noRentalsOfCustomer() {
 	suite.newApiTestWithCarMock().
 		Put("/cars/"+testdata.VinCar+"/trunk").
 		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
 		JSON(testdata.Locked).
 		Expect(suite.T()).
 		Status(http.StatusForbidden).
@@ -1443,6 +1697,7 @@ func (suite *ApiTestSuite) TestSetLockState_customerId_rentalUpcoming() {
 	suite.newApiTestWithCarMock().
 		Put("/cars/"+testdata.VinCar+"/trunk").
 		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
 		JSON(testdata.Locked).
 		Expect(suite.T()).
 		Status(http.StatusForbidden).
@@ -1464,6 +1719,7 @@ func (suite *ApiTestSuite) TestSetLockState_customerId_rentalExpired() {
 	suite.newApiTestWithCarMock().
 		Put("/cars/"+testdata.VinCar+"/trunk").
 		Query("customerId", "customer.example@customer.mail").
+		Header("Authorization", suite.tokenIssuer.BearerToken("customer.example@customer.mail")).
 		JSON(testdata.Locked).
 		Expect(suite.T()).
 		Status(http.StatusForbidden).
@@ -1474,6 +1730,7 @@ func (suite *ApiTestSuite) TestSetLockState_customerId_invalidVin() {
 	suite.newApiTestWithCarMock().
 		Put("/cars/invalidVin/trunk").
 		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
 		JSON(testdata.Locked).
 		Expect(suite.T()).
 		Status(http.StatusBadRequest).
@@ -1485,6 +1742,7 @@ func (suite *ApiTestSuite) TestSetLockState_customerId_invalidVin() {
 	suite.newApiTestWithCarMock().
 		Put("/cars/"+testdata.VinCar+"/trunk").
 		Query("customerId", "invalidCustomerId").
+		Header("Authorization", suite.tokenIssuer.BearerToken("invalidCustomerId")).
 		JSON(testdata.Locked).
 		Expect(suite.T()).
 		Status(http.StatusBadRequest).
@@ -1495,6 +1753,7 @@ func (suite *ApiTestSuite) TestSetLockState_customerId_invalidLockState() {
 	suite.newApiTestWithCarMock().
 		Put("/cars/"+testdata.VinCar+"/trunk").
 		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
 		JSON("").
 		Expect(suite.T()).
 		Status(http.StatusBadRequest).
@@ -1641,3 +1900,637 @@ func (suite *ApiTestSuite) TestSetLockState_trunkAccessToken_invalidLockState()
 		Status(http.StatusBadRequest).
 		End()
 }
+
+func returnsTrunkAccessLog(
+	expectedEntries []model.TrunkAccessLogEntry, t *testing.T,
+) func(res *http.Response, _ *http.Request) error {
+	return func(res *http.Response, _ *http.Request) error {
+		defer func() { _ = res.Body.Close() }()
+
+		var entries []model.TrunkAccessLogEntry
+		if err := json.NewDecoder(res.Body).Decode(&entries); err != nil {
+			return err
+		}
+
+		// the timestamps are set by the server
+		for i := range entries {
+			assert.False(t, entries[i].Timestamp.IsZero())
+			entries[i].Timestamp = time.Time{}
+		}
+
+		assert.Equal(t, expectedEntries, entries)
+
+		return nil
+	}
+}
+
+func (suite *ApiTestSuite) TestGetTrunkAccessLog_success() {
+	periodFromNow := model.TimePeriod{
+		StartDate: time.Now().Add(10 * time.Millisecond).UTC().Round(time.Millisecond),
+		EndDate:   time.Date(2123, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	marshalledPeriodFromNow, _ := json.Marshal(periodFromNow)
+
+	suite.createRentalForCustomer(testdata.VinCar, string(marshalledPeriodFromNow), "example@customer.cust")
+
+	time.Sleep(10 * time.Millisecond)
+
+	rentalId := suite.getRentalOverview("example@customer.cust")[0].Id
+
+	suite.newApiTestWithCarMock().
+		Put("/cars/"+testdata.VinCar+"/trunk").
+		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
+		JSON(testdata.Locked).
+		Expect(suite.T()).
+		Status(http.StatusNoContent).
+		End()
+
+	suite.newApiTestWithCarMock().
+		Put("/cars/"+testdata.VinCar+"/trunk").
+		Query("customerId", "other@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("other@customer.cust")).
+		JSON(testdata.Locked).
+		Expect(suite.T()).
+		Status(http.StatusForbidden).
+		End()
+
+	locked := model.LOCKED
+	grantedEntry := model.TrunkAccessLogEntry{
+		Vin:       testdata.VinCar,
+		RentalId:  &rentalId,
+		ActorType: model.CUSTOMER,
+		Actor:     "example@customer.cust",
+		Action:    model.SETLOCKSTATE,
+		LockState: &locked,
+		Outcome:   model.GRANTED,
+	}
+	deniedEntry := model.TrunkAccessLogEntry{
+		Vin:       testdata.VinCar,
+		ActorType: model.CUSTOMER,
+		Actor:     "other@customer.cust",
+		Action:    model.SETLOCKSTATE,
+		LockState: &locked,
+		Outcome:   model.DENIED,
+	}
+
+	suite.newApiTestWithCarMock().
+		Get("/rentals/" + rentalId + "/trunkAccessLog").
+		Expect(suite.T()).
+		Status(http.StatusOK).
+		Assert(returnsTrunkAccessLog([]model.TrunkAccessLogEntry{grantedEntry}, suite.T())).
+		End()
+
+	suite.newApiTestWithCarMock().
+		Get("/cars/" + testdata.VinCar + "/trunkAccessLog").
+		Expect(suite.T()).
+		Status(http.StatusOK).
+		Assert(returnsTrunkAccessLog([]model.TrunkAccessLogEntry{grantedEntry, deniedEntry}, suite.T())).
+		End()
+}
+
+func (suite *ApiTestSuite) TestGetTrunkAccessLog_success_empty() {
+	suite.newApiTestWithCarMock().
+		Get("/cars/" + testdata.VinCar + "/trunkAccessLog").
+		Expect(suite.T()).
+		Status(http.StatusOK).
+		Body(testdata.EmptyArray).
+		End()
+}
+
+func (suite *ApiTestSuite) TestGetTrunkAccessLog_unknownVin() {
+	suite.newApiTestWithCarMock().
+		Get("/cars/" + testdata.UnknownVin + "/trunkAccessLog").
+		Expect(suite.T()).
+		Status(http.StatusNotFound).
+		End()
+}
+
+func (suite *ApiTestSuite) TestGetTrunkAccessLog_unknownRentalId() {
+	suite.newApiTestWithCarMock().
+		Get("/rentals/unkownid/trunkAccessLog").
+		Expect(suite.T()).
+		Status(http.StatusNotFound).
+		End()
+}
+
+func (suite *ApiTestSuite) TestSetAutoRelock_success() {
+	suite.createRentalForCustomer(testdata.VinCar, testdata.TimePeriod2122, "example@customer.cust")
+
+	rentalId := suite.getRentalOverview("example@customer.cust")[0].Id
+
+	suite.newApiTestWithCarMock().
+		Put("/rentals/" + rentalId + "/autoRelock").
+		JSON(`{"timeoutSeconds": 120}`).
+		Expect(suite.T()).
+		Status(http.StatusNoContent).
+		End()
+
+	suite.newApiTestWithCarMock().
+		Get("/rentals/" + rentalId).
+		Expect(suite.T()).
+		Status(http.StatusOK).
+		Assert(func(res *http.Response, _ *http.Request) error {
+			defer func() { _ = res.Body.Close() }()
+
+			var rental model.Rental
+			if err := json.NewDecoder(res.Body).Decode(&rental); err != nil {
+				return err
+			}
+
+			assert.Equal(suite.T(), &model.AutoRelock{TimeoutSeconds: 120}, rental.AutoRelock)
+			return nil
+		}).
+		End()
+}
+
+func (suite *ApiTestSuite) TestSetAutoRelock_negativeTimeout() {
+	suite.newApiTestWithCarMock().
+		Put("/rentals/rentalId/autoRelock").
+		JSON(`{"timeoutSeconds": -1}`).
+		Expect(suite.T()).
+		Status(http.StatusBadRequest).
+		End()
+}
+
+func (suite *ApiTestSuite) TestSetAutoRelock_unknownRentalId() {
+	suite.newApiTestWithCarMock().
+		Put("/rentals/unkownid/autoRelock").
+		JSON(`{"timeoutSeconds": 120}`).
+		Expect(suite.T()).
+		Status(http.StatusNotFound).
+		End()
+}
+
+func (suite *ApiTestSuite) TestCancelRental_success() {
+	suite.createRentalForCustomer(testdata.VinCar, testdata.TimePeriod2122, "example@customer.cust")
+
+	rentalId := suite.getRentalOverview("example@customer.cust")[0].Id
+
+	suite.newApiTestWithCarMock().
+		Post("/rentals/" + rentalId + "/cancellation").
+		Expect(suite.T()).
+		Status(http.StatusNoContent).
+		End()
+
+	// the rental is not upcoming anymore
+	suite.newApiTestWithCarMock().
+		Post("/rentals/" + rentalId + "/cancellation").
+		Expect(suite.T()).
+		Status(http.StatusForbidden).
+		End()
+
+	assert.Equal(suite.T(), model.CANCELLED, suite.getRentalOverview("example@customer.cust")[0].State)
+}
+
+func (suite *ApiTestSuite) TestCancelRental_unknownRentalId() {
+	suite.newApiTestWithCarMock().
+		Post("/rentals/unkownid/cancellation").
+		Expect(suite.T()).
+		Status(http.StatusNotFound).
+		End()
+}
+
+func (suite *ApiTestSuite) TestGetRentalOverview_unauthenticated() {
+	suite.newAnonymousApiTestWithCarMock().
+		Get("/rentals").
+		Query("customerId", "example@customer.cust").
+		Expect(suite.T()).
+		Status(http.StatusUnauthorized).
+		End()
+}
+
+func (suite *ApiTestSuite) TestGetRentalOverview_invalidToken() {
+	suite.newApiTestWithCarMock().
+		Get("/rentals").
+		Query("customerId", "example@customer.cust").
+		Header("Authorization", "Bearer invalid").
+		Expect(suite.T()).
+		Status(http.StatusUnauthorized).
+		End()
+}
+
+func (suite *ApiTestSuite) TestGetRentalOverview_otherCustomer() {
+	suite.createRental(testdata.VinCar, testdata.TimePeriod2122)
+
+	suite.newApiTestWithCarMock().
+		Get("/rentals").
+		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("other@customer.cust")).
+		Expect(suite.T()).
+		Status(http.StatusForbidden).
+		End()
+}
+
+func (suite *ApiTestSuite) TestCreateRental_otherCustomer() {
+	suite.newApiTestWithCarMock().
+		Post("/cars/"+testdata.VinCar+"/rentals").
+		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("other@customer.cust")).
+		JSON(testdata.TimePeriod2122).
+		Expect(suite.T()).
+		Status(http.StatusForbidden).
+		End()
+
+	// the rental must not have been created
+	suite.newApiTestWithCarMock().
+		Get("/rentals").
+		Query("customerId", "example@customer.cust").
+		Header("Authorization", suite.tokenIssuer.BearerToken("example@customer.cust")).
+		Expect(suite.T()).
+		Status(http.StatusOK).
+		Body(testdata.EmptyArray).
+		End()
+}
+
+func (suite *ApiTestSuite) TestSetLockState_customerId_unauthenticated() {
+	suite.newAnonymousApiTestWithCarMock().
+		Put("/cars/"+testdata.VinCar+"/trunk").
+		Query("customerId", "example@customer.cust").
+		JSON(testdata.Unlocked).
+		Expect(suite.T()).
+		Status(http.StatusUnauthorized).
+		End()
+}
+
+func (suite *ApiTestSuite) TestGetNextRental_customer() {
+	suite.newApiTestWithCarMock().
+		Get("/cars/"+testdata.VinCar+"/rentalStatus").
+		Header(echo.HeaderAuthorization, suite.tokenIssuer.BearerToken("example@customer.cust")).
+		Expect(suite.T()).
+		Status(http.StatusForbidden).
+		End()
+}
+
+func (suite *ApiTestSuite) TestGetNextRental_fleetManager() {
+	suite.newApiTestWithCarMock().
+		Get("/cars/"+testdata.VinCar+"/rentalStatus").
+		Header(echo.HeaderAuthorization, suite.tokenIssuer.StaffBearerToken("fleetManager")).
+		Expect(suite.T()).
+		Status(http.StatusNoContent).
+		End()
+}
+
+func (suite *ApiTestSuite) TestGetNextRental_unauthenticated() {
+	suite.newAnonymousApiTestWithCarMock().
+		Get("/cars/" + testdata.VinCar + "/rentalStatus").
+		Expect(suite.T()).
+		Status(http.StatusUnauthorized).
+		End()
+}
+
+func (suite *ApiTestSuite) TestGetTrunkAccessLog_customer() {
+	suite.newApiTestWithCarMock().
+		Get("/cars/"+testdata.VinCar+"/trunkAccessLog").
+		Header(echo.HeaderAuthorization, suite.tokenIssuer.BearerToken("example@customer.cust")).
+		Expect(suite.T()).
+		Status(http.StatusForbidden).
+		End()
+}
+
+func (suite *ApiTestSuite) TestGetLockState_anonymous() {
+	// trunk access tokens are checked by the operation, so the request reaches it without authentication
+	suite.newAnonymousApiTestWithCarMock().
+		Get("/cars/"+testdata.VinCar+"/trunk").
+		Query("trunkAccessToken", testdata.TrunkAccessToken).
+		Expect(suite.T()).
+		Status(http.StatusForbidden).
+		End()
+}
+
+// registerWebhook registers a webhook for rentalCreated events and returns it
+func (suite *ApiTestSuite) registerWebhook() model.Webhook {
+	var registeredWebhook model.Webhook
+	suite.newApiTestWithCarMock().
+		Post("/webhooks").
+		JSON(testdata.WebhookRegistration).
+		Expect(suite.T()).
+		Status(http.StatusCreated).
+		Assert(decodeBody(&registeredWebhook)).
+		End()
+	return registeredWebhook
+}
+
+func decodeBody[T any](target *T) func(*http.Response, *http.Request) error {
+	return func(res *http.Response, _ *http.Request) error {
+		defer func() { _ = res.Body.Close() }()
+		return json.NewDecoder(res.Body).Decode(target)
+	}
+}
+
+func (suite *ApiTestSuite) TestRegisterWebhook_success() {
+	registeredWebhook := suite.registerWebhook()
+
+	suite.Len(registeredWebhook.Id, 16)
+	suite.Equal("https://billing.example.com/hooks/rentals", registeredWebhook.Url)
+	suite.Equal([]model.RentalEventType{model.RentalCreated}, registeredWebhook.EventTypes)
+
+	// the secret is never returned
+	suite.newApiTestWithCarMock().
+		Get("/webhooks").
+		Expect(suite.T()).
+		Status(http.StatusOK).
+		Assert(func(res *http.Response, _ *http.Request) error {
+			var webhooks []map[string]any
+			err := decodeBody(&webhooks)(res, nil)
+			suite.Len(webhooks, 1)
+			suite.Equal(registeredWebhook.Id, webhooks[0]["id"])
+			suite.NotContains(webhooks[0], "secret")
+			return err
+		}).
+		End()
+}
+
+func (suite *ApiTestSuite) TestRegisterWebhook_invalidUrl() {
+	suite.newApiTestWithCarMock().
+		Post("/webhooks").
+		JSON(strings.Replace(testdata.WebhookRegistration, "https://", "ftp://", 1)).
+		Expect(suite.T()).
+		Status(http.StatusBadRequest).
+		End()
+}
+
+func (suite *ApiTestSuite) TestRegisterWebhook_shortSecret() {
+	suite.newApiTestWithCarMock().
+		Post("/webhooks").
+		JSON(strings.Replace(testdata.WebhookRegistration, "whsec-0123456789abcdef", "secret", 1)).
+		Expect(suite.T()).
+		Status(http.StatusBadRequest).
+		End()
+}
+
+func (suite *ApiTestSuite) TestRegisterWebhook_fleetManager() {
+	suite.newApiTestWithCarMock().
+		Post("/webhooks").
+		Header(echo.HeaderAuthorization, suite.tokenIssuer.StaffBearerToken("fleetManager")).
+		JSON(testdata.WebhookRegistration).
+		Expect(suite.T()).
+		Status(http.StatusForbidden).
+		End()
+}
+
+func (suite *ApiTestSuite) TestDeleteWebhook_success() {
+	registeredWebhook := suite.registerWebhook()
+
+	suite.newApiTestWithCarMock().
+		Delete("/webhooks/" + registeredWebhook.Id).
+		Expect(suite.T()).
+		Status(http.StatusNoContent).
+		End()
+
+	suite.newApiTestWithCarMock().
+		Get("/webhooks").
+		Expect(suite.T()).
+		Status(http.StatusOK).
+		Body(testdata.EmptyArray).
+		End()
+}
+
+func (suite *ApiTestSuite) TestDeleteWebhook_unknownWebhookId() {
+	suite.newApiTestWithCarMock().
+		Delete("/webhooks/" + testdata.UnknownWebhookId).
+		Expect(suite.T()).
+		Status(http.StatusNotFound).
+		End()
+}
+
+func (suite *ApiTestSuite) TestGetWebhookDeliveries_success() {
+	registeredWebhook := suite.registerWebhook()
+	suite.createRental(testdata.VinCar, testdata.TimePeriod2122)
+
+	// publish the rental event to the webhooks as the relay of the application does
+	crudInstance := database.NewICRUD(suite.dbConnection, environment.GetEnvironment(), util.TimeProvider{})
+	relay := outbox.NewRelay(webhook.NewDispatcher(crudInstance, util.TimeProvider{}), crudInstance)
+	suite.Nil(relay.PublishPending(context.Background()))
+
+	var deliveries []model.WebhookDelivery
+	suite.newApiTestWithCarMock().
+		Get("/webhooks/" + registeredWebhook.Id + "/deliveries").
+		Expect(suite.T()).
+		Status(http.StatusOK).
+		Assert(decodeBody(&deliveries)).
+		End()
+
+	suite.Len(deliveries, 1)
+	suite.Equal(model.PENDING, deliveries[0].State)
+	suite.Equal(model.RentalCreated, deliveries[0].Event.Type)
+	suite.Equal(testdata.VinCar, deliveries[0].Event.Vin)
+	suite.Equal(0, deliveries[0].Attempts)
+}
+
+func (suite *ApiTestSuite) TestGetWebhookDeliveries_unknownWebhookId() {
+	suite.newApiTestWithCarMock().
+		Get("/webhooks/" + testdata.UnknownWebhookId + "/deliveries").
+		Expect(suite.T()).
+		Status(http.StatusNotFound).
+		End()
+}
+
+func (suite *ApiTestSuite) TestGetWebhookDeliveries_invalidWebhookId() {
+	suite.newApiTestWithCarMock().
+		Get("/webhooks/invalid/deliveries").
+		Expect(suite.T()).
+		Status(http.StatusBadRequest).
+		End()
+}
+
+func (suite *ApiTestSuite) TestStreamCarState_upcomingRental() {
+	suite.createRentalForCustomer(testdata.VinCar, testdata.TimePeriod2122, "example@customer.cust")
+
+	rentalId := suite.getRentalOverview("example@customer.cust")[0].Id
+
+	suite.newApiTestWithCarMock().
+		Get("/rentals/" + rentalId + "/carState").
+		Expect(suite.T()).
+		Status(http.StatusForbidden).
+		End()
+}
+
+func (suite *ApiTestSuite) TestStreamCarState_unknownRentalId() {
+	suite.newApiTestWithCarMock().
+		Get("/rentals/unkownid/carState").
+		Expect(suite.T()).
+		Status(http.StatusNotFound).
+		End()
+}
+
+func (suite *ApiTestSuite) TestGetRentalCalendar_success() {
+	suite.createRentalForCustomer(testdata.VinCar, testdata.TimePeriod2122, "example@customer.cust")
+	rentalId := suite.getRentalOverview("example@customer.cust")[0].Id
+
+	suite.newApiTestWithCarMock().
+		Get("/rentals.ics").
+		Query("customerId", "example@customer.cust").
+		Header(echo.HeaderAuthorization, suite.tokenIssuer.BearerToken("example@customer.cust")).
+		Expect(suite.T()).
+		Status(http.StatusOK).
+		Header(echo.HeaderContentType, "text/calendar; charset=utf-8").
+		Assert(func(res *http.Response, _ *http.Request) error {
+			body, err := io.ReadAll(res.Body)
+			suite.Contains(string(body), "BEGIN:VCALENDAR\r\n")
+			suite.Contains(string(body), "UID:"+rentalId+"@rentalmanagement\r\n")
+			suite.Contains(string(body), "STATUS:CONFIRMED\r\n")
+			return err
+		}).
+		End()
+}
+
+func (suite *ApiTestSuite) TestCalendarSubscription_success() {
+	suite.createRentalForCustomer(testdata.VinCar, testdata.TimePeriod2122, "example@customer.cust")
+	rentalId := suite.getRentalOverview("example@customer.cust")[0].Id
+
+	var subscription model.CalendarSubscription
+	suite.newApiTestWithCarMock().
+		Post("/calendarSubscriptions").
+		Query("customerId", "example@customer.cust").
+		Header(echo.HeaderAuthorization, suite.tokenIssuer.BearerToken("example@customer.cust")).
+		Expect(suite.T()).
+		Status(http.StatusCreated).
+		Assert(decodeBody(&subscription)).
+		End()
+
+	subscriptionUrl, err := url.Parse(subscription.Url)
+	suite.Nil(err)
+
+	// calendar clients poll the URL without credentials
+	suite.newAnonymousApiTestWithCarMock().
+		Get(subscriptionUrl.Path).
+		Expect(suite.T()).
+		Status(http.StatusOK).
+		Header(echo.HeaderContentType, "text/calendar; charset=utf-8").
+		Assert(func(res *http.Response, _ *http.Request) error {
+			body, err := io.ReadAll(res.Body)
+			suite.Contains(string(body), "UID:"+rentalId+"@rentalmanagement\r\n")
+			return err
+		}).
+		End()
+
+	// a new subscription replaces the previous one
+	suite.newApiTestWithCarMock().
+		Post("/calendarSubscriptions").
+		Query("customerId", "example@customer.cust").
+		Header(echo.HeaderAuthorization, suite.tokenIssuer.BearerToken("example@customer.cust")).
+		Expect(suite.T()).
+		Status(http.StatusCreated).
+		End()
+
+	suite.newAnonymousApiTestWithCarMock().
+		Get(subscriptionUrl.Path).
+		Expect(suite.T()).
+		Status(http.StatusNotFound).
+		End()
+}
+
+func (suite *ApiTestSuite) TestGetSubscribedRentalCalendar_unknownToken() {
+	suite.newAnonymousApiTestWithCarMock().
+		Get("/calendars/" + testdata.UnknownCalendarToken + "/rentals.ics").
+		Expect(suite.T()).
+		Status(http.StatusNotFound).
+		End()
+}
+
+func (suite *ApiTestSuite) TestGetSubscribedRentalCalendar_invalidToken() {
+	suite.newAnonymousApiTestWithCarMock().
+		Get("/calendars/invalid/rentals.ics").
+		Expect(suite.T()).
+		Status(http.StatusBadRequest).
+		End()
+}
+
+func (suite *ApiTestSuite) TestImportRentals_success() {
+	body := "vin,customerId,startDate,endDate\n" +
+		testdata.VinCar + ",example@customer.cust,2122-01-01T00:00:00Z,2123-01-01T00:00:00Z\n" +
+		testdata.VinCar + ",other@customer.cust,2122-06-01T00:00:00Z,2122-07-01T00:00:00Z\n" +
+		testdata.UnknownVin + ",example@customer.cust,2122-01-01T00:00:00Z,2123-01-01T00:00:00Z\n" +
+		testdata.VinCar2 + ",example@customer.cust,2123-01-01T00:00:00Z,2122-01-01T00:00:00Z\n"
+
+	// every row of a known car checks that the car exists
+	mocks := append(suite.newCarMock(), apitest.NewMock().
+		Get(environment.GetEnvironment().GetCarServerUrl()+"/cars/"+testdata.VinCar).
+		RespondWith().Status(http.StatusOK).JSON(testdata.ExampleCar).End())
+
+	var report model.RentalImportReport
+	suite.newApiTestWithMocks(mocks).
+		Post("/rentalImports").
+		Header(echo.HeaderAuthorization, suite.tokenIssuer.StaffBearerToken("fleetManager")).
+		ContentType("text/csv").
+		Body(body).
+		Expect(suite.T()).
+		Status(http.StatusOK).
+		Assert(decodeBody(&report)).
+		End()
+
+	suite.Equal(1, report.Created)
+	suite.Equal(3, report.Failed)
+	var statuses []model.RentalImportStatus
+	for _, row := range report.Rows {
+		statuses = append(statuses, row.Status)
+	}
+	suite.Equal([]model.RentalImportStatus{model.CREATED, model.CONFLICT, model.CARNOTFOUND, model.INVALID}, statuses)
+
+	rentals := suite.getRentalOverview("example@customer.cust")
+	suite.Len(rentals, 1)
+}
+
+func (suite *ApiTestSuite) TestImportRentals_missingColumn() {
+	suite.newApiTestWithCarMock().
+		Post("/rentalImports").
+		ContentType("text/csv").
+		Body("vin,startDate,endDate\n" + testdata.VinCar + ",2122-01-01T00:00:00Z,2123-01-01T00:00:00Z\n").
+		Expect(suite.T()).
+		Status(http.StatusBadRequest).
+		End()
+}
+
+func (suite *ApiTestSuite) TestImportRentals_customer() {
+	suite.newApiTestWithCarMock().
+		Post("/rentalImports").
+		Header(echo.HeaderAuthorization, suite.tokenIssuer.BearerToken("example@customer.cust")).
+		ContentType("text/csv").
+		Body("vin,customerId,startDate,endDate\n").
+		Expect(suite.T()).
+		Status(http.StatusForbidden).
+		End()
+}
+
+func (suite *ApiTestSuite) TestExportRentals_success() {
+	suite.createRentalForCustomer(testdata.VinCar, testdata.TimePeriod2122, "example@customer.cust")
+	suite.createRentalForCustomer(testdata.VinCar2, testdata.TimePeriod2150, "other@customer.cust")
+	rentalId := suite.getRentalOverview("example@customer.cust")[0].Id
+
+	suite.newApiTestWithCarMock().
+		Get("/rentals.csv").
+		Query("startDate", "2122-06-01T00:00:00Z").
+		Query("endDate", "2122-07-01T00:00:00Z").
+		Header(echo.HeaderAuthorization, suite.tokenIssuer.StaffBearerToken("fleetManager")).
+		Expect(suite.T()).
+		Status(http.StatusOK).
+		Header(echo.HeaderContentType, "text/csv; charset=utf-8").
+		Body("vin,rentalId,customerId,startDate,endDate,state\n" +
+			testdata.VinCar + "," + rentalId + ",example@customer.cust,2122-01-01T00:00:00Z,2123-01-01T00:00:00Z," +
+			"UPCOMING\n").
+		End()
+}
+
+func (suite *ApiTestSuite) TestExportRentals_periodTooLong() {
+	suite.newApiTestWithCarMock().
+		Get("/rentals.csv").
+		Query("startDate", "2122-01-01T00:00:00Z").
+		Query("endDate", "2123-06-01T00:00:00Z").
+		Header(echo.HeaderAuthorization, suite.tokenIssuer.StaffBearerToken("fleetManager")).
+		Expect(suite.T()).
+		Status(http.StatusBadRequest).
+		End()
+}
+
+func (suite *ApiTestSuite) TestExportRentals_customer() {
+	suite.newApiTestWithCarMock().
+		Get("/rentals.csv").
+		Query("startDate", "2122-06-01T00:00:00Z").
+		Query("endDate", "2122-07-01T00:00:00Z").
+		Header(echo.HeaderAuthorization, suite.tokenIssuer.BearerToken("example@customer.cust")).
+		Expect(suite.T()).
+		Status(http.StatusForbidden).
+		End()
+}
@@ -0,0 +1,14 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// WithBearerToken authenticates every request of the client with the given bearer token (JWT)
+func WithBearerToken(token string) ClientOption {
+	return WithRequestEditorFn(func(_ context.Context, request *http.Request) error {
+		request.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
@@ -0,0 +1,2956 @@
+// Package client provides primitives to interact with the openapi HTTP API of RentalManagement.
+package client
+
+import (
+	"RentalManagement/logic/model"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+)
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// CreateCalendarSubscription request
+	CreateCalendarSubscription(ctx context.Context, params *model.CreateCalendarSubscriptionParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSubscribedRentalCalendar request
+	GetSubscribedRentalCalendar(ctx context.Context, calendarToken model.CalendarTokenParam, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAvailableCars request
+	GetAvailableCars(ctx context.Context, params *model.GetAvailableCarsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCar request
+	GetCar(ctx context.Context, vin model.VinParam, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetNextRental request
+	GetNextRental(ctx context.Context, vin model.VinParam, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateRental request with any body
+	CreateRentalWithBody(ctx context.Context, vin model.VinParam, params *model.CreateRentalParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateRental(ctx context.Context, vin model.VinParam, params *model.CreateRentalParams, body model.TimePeriod, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLockState request
+	GetLockState(ctx context.Context, vin model.VinParam, params *model.GetLockStateParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetLockState request with any body
+	SetLockStateWithBody(ctx context.Context, vin model.VinParam, params *model.SetLockStateParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetLockState(ctx context.Context, vin model.VinParam, params *model.SetLockStateParams, body model.LockStateObject, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCarTrunkAccessLog request
+	GetCarTrunkAccessLog(ctx context.Context, vin model.VinParam, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ImportRentals request with any body
+	ImportRentalsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetOverview request
+	GetOverview(ctx context.Context, params *model.GetOverviewParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ExportRentals request
+	ExportRentals(ctx context.Context, params *model.ExportRentalsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRentalCalendar request
+	GetRentalCalendar(ctx context.Context, params *model.GetRentalCalendarParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRentalStatus request
+	GetRentalStatus(ctx context.Context, rentalId model.RentalIdParam, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetAutoRelock request with any body
+	SetAutoRelockWithBody(ctx context.Context, rentalId model.RentalIdParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetAutoRelock(ctx context.Context, rentalId model.RentalIdParam, body model.SetAutoRelockJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CancelRental request
+	CancelRental(ctx context.Context, rentalId model.RentalIdParam, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StreamCarState request
+	StreamCarState(ctx context.Context, rentalId model.RentalIdParam, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRentalTrunkAccessLog request
+	GetRentalTrunkAccessLog(ctx context.Context, rentalId model.RentalIdParam, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GrantTrunkAccess request with any body
+	GrantTrunkAccessWithBody(ctx context.Context, rentalId model.RentalIdParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	GrantTrunkAccess(ctx context.Context, rentalId model.RentalIdParam, body model.GrantTrunkAccessJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWebhooks request
+	GetWebhooks(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RegisterWebhook request with any body
+	RegisterWebhookWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RegisterWebhook(ctx context.Context, body model.RegisterWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteWebhook request
+	DeleteWebhook(ctx context.Context, webhookId model.WebhookIdParam, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWebhookDeliveries request
+	GetWebhookDeliveries(ctx context.Context, webhookId model.WebhookIdParam, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) CreateCalendarSubscription(ctx context.Context, params *model.CreateCalendarSubscriptionParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateCalendarSubscriptionRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSubscribedRentalCalendar(ctx context.Context, calendarToken model.CalendarTokenParam, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSubscribedRentalCalendarRequest(c.Server, calendarToken)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAvailableCars(ctx context.Context, params *model.GetAvailableCarsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAvailableCarsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCar(ctx context.Context, vin model.VinParam, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCarRequest(c.Server, vin)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetNextRental(ctx context.Context, vin model.VinParam, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetNextRentalRequest(c.Server, vin)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateRentalWithBody(ctx context.Context, vin model.VinParam, params *model.CreateRentalParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateRentalRequestWithBody(c.Server, vin, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateRental(ctx context.Context, vin model.VinParam, params *model.CreateRentalParams, body model.TimePeriod, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateRentalRequest(c.Server, vin, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetLockState(ctx context.Context, vin model.VinParam, params *model.GetLockStateParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLockStateRequest(c.Server, vin, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetLockStateWithBody(ctx context.Context, vin model.VinParam, params *model.SetLockStateParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetLockStateRequestWithBody(c.Server, vin, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetLockState(ctx context.Context, vin model.VinParam, params *model.SetLockStateParams, body model.LockStateObject, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetLockStateRequest(c.Server, vin, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCarTrunkAccessLog(ctx context.Context, vin model.VinParam, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCarTrunkAccessLogRequest(c.Server, vin)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ImportRentalsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportRentalsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetOverview(ctx context.Context, params *model.GetOverviewParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOverviewRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExportRentals(ctx context.Context, params *model.ExportRentalsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportRentalsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetRentalCalendar(ctx context.Context, params *model.GetRentalCalendarParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRentalCalendarRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetRentalStatus(ctx context.Context, rentalId model.RentalIdParam, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRentalStatusRequest(c.Server, rentalId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetAutoRelockWithBody(ctx context.Context, rentalId model.RentalIdParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetAutoRelockRequestWithBody(c.Server, rentalId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetAutoRelock(ctx context.Context, rentalId model.RentalIdParam, body model.SetAutoRelockJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetAutoRelockRequest(c.Server, rentalId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CancelRental(ctx context.Context, rentalId model.RentalIdParam, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCancelRentalRequest(c.Server, rentalId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StreamCarState(ctx context.Context, rentalId model.RentalIdParam, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStreamCarStateRequest(c.Server, rentalId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetRentalTrunkAccessLog(ctx context.Context, rentalId model.RentalIdParam, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRentalTrunkAccessLogRequest(c.Server, rentalId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GrantTrunkAccessWithBody(ctx context.Context, rentalId model.RentalIdParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGrantTrunkAccessRequestWithBody(c.Server, rentalId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GrantTrunkAccess(ctx context.Context, rentalId model.RentalIdParam, body model.GrantTrunkAccessJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGrantTrunkAccessRequest(c.Server, rentalId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetWebhooks(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWebhooksRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterWebhookWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterWebhookRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterWebhook(ctx context.Context, body model.RegisterWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterWebhookRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, webhookId model.WebhookIdParam, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteWebhookRequest(c.Server, webhookId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetWebhookDeliveries(ctx context.Context, webhookId model.WebhookIdParam, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWebhookDeliveriesRequest(c.Server, webhookId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewCreateCalendarSubscriptionRequest generates requests for CreateCalendarSubscription
+func NewCreateCalendarSubscriptionRequest(server string, params *model.CreateCalendarSubscriptionParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/calendarSubscriptions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "customerId", runtime.ParamLocationQuery, params.CustomerId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSubscribedRentalCalendarRequest generates requests for GetSubscribedRentalCalendar
+func NewGetSubscribedRentalCalendarRequest(server string, calendarToken model.CalendarTokenParam) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "calendarToken", runtime.ParamLocationPath, calendarToken)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/calendars/%s/rentals.ics", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAvailableCarsRequest generates requests for GetAvailableCars
+func NewGetAvailableCarsRequest(server string, params *model.GetAvailableCarsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cars")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "timePeriod", runtime.ParamLocationQuery, params.TimePeriod); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCarRequest generates requests for GetCar
+func NewGetCarRequest(server string, vin model.VinParam) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "vin", runtime.ParamLocationPath, vin)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cars/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetNextRentalRequest generates requests for GetNextRental
+func NewGetNextRentalRequest(server string, vin model.VinParam) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "vin", runtime.ParamLocationPath, vin)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cars/%s/rentalStatus", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateRentalRequest calls the generic CreateRental builder with application/json body
+func NewCreateRentalRequest(server string, vin model.VinParam, params *model.CreateRentalParams, body model.TimePeriod) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateRentalRequestWithBody(server, vin, params, "application/json", bodyReader)
+}
+
+// NewCreateRentalRequestWithBody generates requests for CreateRental with any type of body
+func NewCreateRentalRequestWithBody(server string, vin model.VinParam, params *model.CreateRentalParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "vin", runtime.ParamLocationPath, vin)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cars/%s/rentals", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "customerId", runtime.ParamLocationQuery, params.CustomerId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetLockStateRequest generates requests for GetLockState
+func NewGetLockStateRequest(server string, vin model.VinParam, params *model.GetLockStateParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "vin", runtime.ParamLocationPath, vin)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cars/%s/trunk", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "trunkAccessToken", runtime.ParamLocationQuery, params.TrunkAccessToken); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetLockStateRequest calls the generic SetLockState builder with application/json body
+func NewSetLockStateRequest(server string, vin model.VinParam, params *model.SetLockStateParams, body model.LockStateObject) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetLockStateRequestWithBody(server, vin, params, "application/json", bodyReader)
+}
+
+// NewSetLockStateRequestWithBody generates requests for SetLockState with any type of body
+func NewSetLockStateRequestWithBody(server string, vin model.VinParam, params *model.SetLockStateParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "vin", runtime.ParamLocationPath, vin)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cars/%s/trunk", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.CustomerId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "customerId", runtime.ParamLocationQuery, *params.CustomerId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.TrunkAccessToken != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "trunkAccessToken", runtime.ParamLocationQuery, *params.TrunkAccessToken); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetCarTrunkAccessLogRequest generates requests for GetCarTrunkAccessLog
+func NewGetCarTrunkAccessLogRequest(server string, vin model.VinParam) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "vin", runtime.ParamLocationPath, vin)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cars/%s/trunkAccessLog", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewImportRentalsRequestWithBody generates requests for ImportRentals with any type of body
+func NewImportRentalsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rentalImports")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetOverviewRequest generates requests for GetOverview
+func NewGetOverviewRequest(server string, params *model.GetOverviewParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rentals")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "customerId", runtime.ParamLocationQuery, params.CustomerId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewExportRentalsRequest generates requests for ExportRentals
+func NewExportRentalsRequest(server string, params *model.ExportRentalsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rentals.csv")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "timePeriod", runtime.ParamLocationQuery, params.TimePeriod); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRentalCalendarRequest generates requests for GetRentalCalendar
+func NewGetRentalCalendarRequest(server string, params *model.GetRentalCalendarParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rentals.ics")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "customerId", runtime.ParamLocationQuery, params.CustomerId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRentalStatusRequest generates requests for GetRentalStatus
+func NewGetRentalStatusRequest(server string, rentalId model.RentalIdParam) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "rentalId", runtime.ParamLocationPath, rentalId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rentals/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetAutoRelockRequest calls the generic SetAutoRelock builder with application/json body
+func NewSetAutoRelockRequest(server string, rentalId model.RentalIdParam, body model.SetAutoRelockJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetAutoRelockRequestWithBody(server, rentalId, "application/json", bodyReader)
+}
+
+// NewSetAutoRelockRequestWithBody generates requests for SetAutoRelock with any type of body
+func NewSetAutoRelockRequestWithBody(server string, rentalId model.RentalIdParam, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "rentalId", runtime.ParamLocationPath, rentalId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rentals/%s/autoRelock", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCancelRentalRequest generates requests for CancelRental
+func NewCancelRentalRequest(server string, rentalId model.RentalIdParam) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "rentalId", runtime.ParamLocationPath, rentalId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rentals/%s/cancellation", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewStreamCarStateRequest generates requests for StreamCarState
+func NewStreamCarStateRequest(server string, rentalId model.RentalIdParam) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "rentalId", runtime.ParamLocationPath, rentalId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rentals/%s/carState", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRentalTrunkAccessLogRequest generates requests for GetRentalTrunkAccessLog
+func NewGetRentalTrunkAccessLogRequest(server string, rentalId model.RentalIdParam) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "rentalId", runtime.ParamLocationPath, rentalId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rentals/%s/trunkAccessLog", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGrantTrunkAccessRequest calls the generic GrantTrunkAccess builder with application/json body
+func NewGrantTrunkAccessRequest(server string, rentalId model.RentalIdParam, body model.GrantTrunkAccessJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewGrantTrunkAccessRequestWithBody(server, rentalId, "application/json", bodyReader)
+}
+
+// NewGrantTrunkAccessRequestWithBody generates requests for GrantTrunkAccess with any type of body
+func NewGrantTrunkAccessRequestWithBody(server string, rentalId model.RentalIdParam, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "rentalId", runtime.ParamLocationPath, rentalId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rentals/%s/trunkTokens", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetWebhooksRequest generates requests for GetWebhooks
+func NewGetWebhooksRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRegisterWebhookRequest calls the generic RegisterWebhook builder with application/json body
+func NewRegisterWebhookRequest(server string, body model.RegisterWebhookJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRegisterWebhookRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewRegisterWebhookRequestWithBody generates requests for RegisterWebhook with any type of body
+func NewRegisterWebhookRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteWebhookRequest generates requests for DeleteWebhook
+func NewDeleteWebhookRequest(server string, webhookId model.WebhookIdParam) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "webhookId", runtime.ParamLocationPath, webhookId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetWebhookDeliveriesRequest generates requests for GetWebhookDeliveries
+func NewGetWebhookDeliveriesRequest(server string, webhookId model.WebhookIdParam) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "webhookId", runtime.ParamLocationPath, webhookId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks/%s/deliveries", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// CreateCalendarSubscription request
+	CreateCalendarSubscriptionWithResponse(ctx context.Context, params *model.CreateCalendarSubscriptionParams, reqEditors ...RequestEditorFn) (*CreateCalendarSubscriptionResponse, error)
+
+	// GetSubscribedRentalCalendar request
+	GetSubscribedRentalCalendarWithResponse(ctx context.Context, calendarToken model.CalendarTokenParam, reqEditors ...RequestEditorFn) (*GetSubscribedRentalCalendarResponse, error)
+
+	// GetAvailableCars request
+	GetAvailableCarsWithResponse(ctx context.Context, params *model.GetAvailableCarsParams, reqEditors ...RequestEditorFn) (*GetAvailableCarsResponse, error)
+
+	// GetCar request
+	GetCarWithResponse(ctx context.Context, vin model.VinParam, reqEditors ...RequestEditorFn) (*GetCarResponse, error)
+
+	// GetNextRental request
+	GetNextRentalWithResponse(ctx context.Context, vin model.VinParam, reqEditors ...RequestEditorFn) (*GetNextRentalResponse, error)
+
+	// CreateRental request with any body
+	CreateRentalWithBodyWithResponse(ctx context.Context, vin model.VinParam, params *model.CreateRentalParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateRentalResponse, error)
+
+	CreateRentalWithResponse(ctx context.Context, vin model.VinParam, params *model.CreateRentalParams, body model.TimePeriod, reqEditors ...RequestEditorFn) (*CreateRentalResponse, error)
+
+	// GetLockState request
+	GetLockStateWithResponse(ctx context.Context, vin model.VinParam, params *model.GetLockStateParams, reqEditors ...RequestEditorFn) (*GetLockStateResponse, error)
+
+	// SetLockState request with any body
+	SetLockStateWithBodyWithResponse(ctx context.Context, vin model.VinParam, params *model.SetLockStateParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetLockStateResponse, error)
+
+	SetLockStateWithResponse(ctx context.Context, vin model.VinParam, params *model.SetLockStateParams, body model.LockStateObject, reqEditors ...RequestEditorFn) (*SetLockStateResponse, error)
+
+	// GetCarTrunkAccessLog request
+	GetCarTrunkAccessLogWithResponse(ctx context.Context, vin model.VinParam, reqEditors ...RequestEditorFn) (*GetCarTrunkAccessLogResponse, error)
+
+	// ImportRentals request with any body
+	ImportRentalsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportRentalsResponse, error)
+
+	// GetOverview request
+	GetOverviewWithResponse(ctx context.Context, params *model.GetOverviewParams, reqEditors ...RequestEditorFn) (*GetOverviewResponse, error)
+
+	// ExportRentals request
+	ExportRentalsWithResponse(ctx context.Context, params *model.ExportRentalsParams, reqEditors ...RequestEditorFn) (*ExportRentalsResponse, error)
+
+	// GetRentalCalendar request
+	GetRentalCalendarWithResponse(ctx context.Context, params *model.GetRentalCalendarParams, reqEditors ...RequestEditorFn) (*GetRentalCalendarResponse, error)
+
+	// GetRentalStatus request
+	GetRentalStatusWithResponse(ctx context.Context, rentalId model.RentalIdParam, reqEditors ...RequestEditorFn) (*GetRentalStatusResponse, error)
+
+	// SetAutoRelock request with any body
+	SetAutoRelockWithBodyWithResponse(ctx context.Context, rentalId model.RentalIdParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetAutoRelockResponse, error)
+
+	SetAutoRelockWithResponse(ctx context.Context, rentalId model.RentalIdParam, body model.SetAutoRelockJSONRequestBody, reqEditors ...RequestEditorFn) (*SetAutoRelockResponse, error)
+
+	// CancelRental request
+	CancelRentalWithResponse(ctx context.Context, rentalId model.RentalIdParam, reqEditors ...RequestEditorFn) (*CancelRentalResponse, error)
+
+	// StreamCarState request
+	StreamCarStateWithResponse(ctx context.Context, rentalId model.RentalIdParam, reqEditors ...RequestEditorFn) (*StreamCarStateResponse, error)
+
+	// GetRentalTrunkAccessLog request
+	GetRentalTrunkAccessLogWithResponse(ctx context.Context, rentalId model.RentalIdParam, reqEditors ...RequestEditorFn) (*GetRentalTrunkAccessLogResponse, error)
+
+	// GrantTrunkAccess request with any body
+	GrantTrunkAccessWithBodyWithResponse(ctx context.Context, rentalId model.RentalIdParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*GrantTrunkAccessResponse, error)
+
+	GrantTrunkAccessWithResponse(ctx context.Context, rentalId model.RentalIdParam, body model.GrantTrunkAccessJSONRequestBody, reqEditors ...RequestEditorFn) (*GrantTrunkAccessResponse, error)
+
+	// GetWebhooks request
+	GetWebhooksWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWebhooksResponse, error)
+
+	// RegisterWebhook request with any body
+	RegisterWebhookWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterWebhookResponse, error)
+
+	RegisterWebhookWithResponse(ctx context.Context, body model.RegisterWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterWebhookResponse, error)
+
+	// DeleteWebhook request
+	DeleteWebhookWithResponse(ctx context.Context, webhookId model.WebhookIdParam, reqEditors ...RequestEditorFn) (*DeleteWebhookResponse, error)
+
+	// GetWebhookDeliveries request
+	GetWebhookDeliveriesWithResponse(ctx context.Context, webhookId model.WebhookIdParam, reqEditors ...RequestEditorFn) (*GetWebhookDeliveriesResponse, error)
+}
+
+type CreateCalendarSubscriptionResponse struct {
+	Body                       []byte
+	HTTPResponse               *http.Response
+	ParsedCalendarSubscription *model.CalendarSubscription
+	ParsedError                *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateCalendarSubscriptionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateCalendarSubscriptionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSubscribedRentalCalendarResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	ParsedError  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSubscribedRentalCalendarResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSubscribedRentalCalendarResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAvailableCarsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	ParsedCars   *[]model.CarAvailable
+	ParsedError  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAvailableCarsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAvailableCarsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCarResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	ParsedCar    *model.Car
+	ParsedError  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCarResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCarResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetNextRentalResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	ParsedRental *model.Rental
+	ParsedError  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetNextRentalResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetNextRentalResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateRentalResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	ParsedError  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateRentalResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateRentalResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLockStateResponse struct {
+	Body            []byte
+	HTTPResponse    *http.Response
+	ParsedLockState *model.LockStateObject
+	ParsedError     *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLockStateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLockStateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetLockStateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	ParsedError  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SetLockStateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetLockStateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCarTrunkAccessLogResponse struct {
+	Body                 []byte
+	HTTPResponse         *http.Response
+	ParsedTrunkAccessLog *[]model.TrunkAccessLogEntry
+	ParsedError          *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCarTrunkAccessLogResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCarTrunkAccessLogResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ImportRentalsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	ParsedReport *model.RentalImportReport
+	ParsedError  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ImportRentalsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ImportRentalsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetOverviewResponse struct {
+	Body          []byte
+	HTTPResponse  *http.Response
+	ParsedRentals *[]model.Rental
+	ParsedError   *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOverviewResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOverviewResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportRentalsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	ParsedError  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportRentalsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportRentalsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRentalCalendarResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	ParsedError  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRentalCalendarResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRentalCalendarResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRentalStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	ParsedRental *model.Rental
+	ParsedError  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRentalStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRentalStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetAutoRelockResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	ParsedError  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SetAutoRelockResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetAutoRelockResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CancelRentalResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	ParsedError  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CancelRentalResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CancelRentalResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StreamCarStateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	ParsedError  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r StreamCarStateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StreamCarStateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRentalTrunkAccessLogResponse struct {
+	Body                 []byte
+	HTTPResponse         *http.Response
+	ParsedTrunkAccessLog *[]model.TrunkAccessLogEntry
+	ParsedError          *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRentalTrunkAccessLogResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRentalTrunkAccessLogResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GrantTrunkAccessResponse struct {
+	Body              []byte
+	HTTPResponse      *http.Response
+	ParsedTrunkAccess *model.TrunkAccess
+	ParsedError       *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GrantTrunkAccessResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GrantTrunkAccessResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWebhooksResponse struct {
+	Body           []byte
+	HTTPResponse   *http.Response
+	ParsedWebhooks *[]model.Webhook
+	ParsedError    *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWebhooksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWebhooksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RegisterWebhookResponse struct {
+	Body          []byte
+	HTTPResponse  *http.Response
+	ParsedWebhook *model.Webhook
+	ParsedError   *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RegisterWebhookResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RegisterWebhookResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteWebhookResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	ParsedError  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteWebhookResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteWebhookResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWebhookDeliveriesResponse struct {
+	Body             []byte
+	HTTPResponse     *http.Response
+	ParsedDeliveries *[]model.WebhookDelivery
+	ParsedError      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWebhookDeliveriesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWebhookDeliveriesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// CreateCalendarSubscriptionWithResponse request returning *CreateCalendarSubscriptionResponse
+func (c *ClientWithResponses) CreateCalendarSubscriptionWithResponse(ctx context.Context, params *model.CreateCalendarSubscriptionParams, reqEditors ...RequestEditorFn) (*CreateCalendarSubscriptionResponse, error) {
+	rsp, err := c.CreateCalendarSubscription(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateCalendarSubscriptionResponse(rsp)
+}
+
+// GetSubscribedRentalCalendarWithResponse request returning *GetSubscribedRentalCalendarResponse
+func (c *ClientWithResponses) GetSubscribedRentalCalendarWithResponse(ctx context.Context, calendarToken model.CalendarTokenParam, reqEditors ...RequestEditorFn) (*GetSubscribedRentalCalendarResponse, error) {
+	rsp, err := c.GetSubscribedRentalCalendar(ctx, calendarToken, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSubscribedRentalCalendarResponse(rsp)
+}
+
+// GetAvailableCarsWithResponse request returning *GetAvailableCarsResponse
+func (c *ClientWithResponses) GetAvailableCarsWithResponse(ctx context.Context, params *model.GetAvailableCarsParams, reqEditors ...RequestEditorFn) (*GetAvailableCarsResponse, error) {
+	rsp, err := c.GetAvailableCars(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAvailableCarsResponse(rsp)
+}
+
+// GetCarWithResponse request returning *GetCarResponse
+func (c *ClientWithResponses) GetCarWithResponse(ctx context.Context, vin model.VinParam, reqEditors ...RequestEditorFn) (*GetCarResponse, error) {
+	rsp, err := c.GetCar(ctx, vin, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCarResponse(rsp)
+}
+
+// GetNextRentalWithResponse request returning *GetNextRentalResponse
+func (c *ClientWithResponses) GetNextRentalWithResponse(ctx context.Context, vin model.VinParam, reqEditors ...RequestEditorFn) (*GetNextRentalResponse, error) {
+	rsp, err := c.GetNextRental(ctx, vin, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetNextRentalResponse(rsp)
+}
+
+// CreateRentalWithBodyWithResponse request with arbitrary body returning *CreateRentalResponse
+func (c *ClientWithResponses) CreateRentalWithBodyWithResponse(ctx context.Context, vin model.VinParam, params *model.CreateRentalParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateRentalResponse, error) {
+	rsp, err := c.CreateRentalWithBody(ctx, vin, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateRentalResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateRentalWithResponse(ctx context.Context, vin model.VinParam, params *model.CreateRentalParams, body model.TimePeriod, reqEditors ...RequestEditorFn) (*CreateRentalResponse, error) {
+	rsp, err := c.CreateRental(ctx, vin, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateRentalResponse(rsp)
+}
+
+// GetLockStateWithResponse request returning *GetLockStateResponse
+func (c *ClientWithResponses) GetLockStateWithResponse(ctx context.Context, vin model.VinParam, params *model.GetLockStateParams, reqEditors ...RequestEditorFn) (*GetLockStateResponse, error) {
+	rsp, err := c.GetLockState(ctx, vin, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLockStateResponse(rsp)
+}
+
+// SetLockStateWithBodyWithResponse request with arbitrary body returning *SetLockStateResponse
+func (c *ClientWithResponses) SetLockStateWithBodyWithResponse(ctx context.Context, vin model.VinParam, params *model.SetLockStateParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetLockStateResponse, error) {
+	rsp, err := c.SetLockStateWithBody(ctx, vin, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetLockStateResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetLockStateWithResponse(ctx context.Context, vin model.VinParam, params *model.SetLockStateParams, body model.LockStateObject, reqEditors ...RequestEditorFn) (*SetLockStateResponse, error) {
+	rsp, err := c.SetLockState(ctx, vin, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetLockStateResponse(rsp)
+}
+
+// GetCarTrunkAccessLogWithResponse request returning *GetCarTrunkAccessLogResponse
+func (c *ClientWithResponses) GetCarTrunkAccessLogWithResponse(ctx context.Context, vin model.VinParam, reqEditors ...RequestEditorFn) (*GetCarTrunkAccessLogResponse, error) {
+	rsp, err := c.GetCarTrunkAccessLog(ctx, vin, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCarTrunkAccessLogResponse(rsp)
+}
+
+// ImportRentalsWithBodyWithResponse request with arbitrary body returning *ImportRentalsResponse
+func (c *ClientWithResponses) ImportRentalsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportRentalsResponse, error) {
+	rsp, err := c.ImportRentalsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportRentalsResponse(rsp)
+}
+
+// GetOverviewWithResponse request returning *GetOverviewResponse
+func (c *ClientWithResponses) GetOverviewWithResponse(ctx context.Context, params *model.GetOverviewParams, reqEditors ...RequestEditorFn) (*GetOverviewResponse, error) {
+	rsp, err := c.GetOverview(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOverviewResponse(rsp)
+}
+
+// ExportRentalsWithResponse request returning *ExportRentalsResponse
+func (c *ClientWithResponses) ExportRentalsWithResponse(ctx context.Context, params *model.ExportRentalsParams, reqEditors ...RequestEditorFn) (*ExportRentalsResponse, error) {
+	rsp, err := c.ExportRentals(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportRentalsResponse(rsp)
+}
+
+// GetRentalCalendarWithResponse request returning *GetRentalCalendarResponse
+func (c *ClientWithResponses) GetRentalCalendarWithResponse(ctx context.Context, params *model.GetRentalCalendarParams, reqEditors ...RequestEditorFn) (*GetRentalCalendarResponse, error) {
+	rsp, err := c.GetRentalCalendar(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRentalCalendarResponse(rsp)
+}
+
+// GetRentalStatusWithResponse request returning *GetRentalStatusResponse
+func (c *ClientWithResponses) GetRentalStatusWithResponse(ctx context.Context, rentalId model.RentalIdParam, reqEditors ...RequestEditorFn) (*GetRentalStatusResponse, error) {
+	rsp, err := c.GetRentalStatus(ctx, rentalId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRentalStatusResponse(rsp)
+}
+
+// SetAutoRelockWithBodyWithResponse request with arbitrary body returning *SetAutoRelockResponse
+func (c *ClientWithResponses) SetAutoRelockWithBodyWithResponse(ctx context.Context, rentalId model.RentalIdParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetAutoRelockResponse, error) {
+	rsp, err := c.SetAutoRelockWithBody(ctx, rentalId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetAutoRelockResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetAutoRelockWithResponse(ctx context.Context, rentalId model.RentalIdParam, body model.SetAutoRelockJSONRequestBody, reqEditors ...RequestEditorFn) (*SetAutoRelockResponse, error) {
+	rsp, err := c.SetAutoRelock(ctx, rentalId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetAutoRelockResponse(rsp)
+}
+
+// CancelRentalWithResponse request returning *CancelRentalResponse
+func (c *ClientWithResponses) CancelRentalWithResponse(ctx context.Context, rentalId model.RentalIdParam, reqEditors ...RequestEditorFn) (*CancelRentalResponse, error) {
+	rsp, err := c.CancelRental(ctx, rentalId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCancelRentalResponse(rsp)
+}
+
+// StreamCarStateWithResponse request returning *StreamCarStateResponse
+func (c *ClientWithResponses) StreamCarStateWithResponse(ctx context.Context, rentalId model.RentalIdParam, reqEditors ...RequestEditorFn) (*StreamCarStateResponse, error) {
+	rsp, err := c.StreamCarState(ctx, rentalId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStreamCarStateResponse(rsp)
+}
+
+// GetRentalTrunkAccessLogWithResponse request returning *GetRentalTrunkAccessLogResponse
+func (c *ClientWithResponses) GetRentalTrunkAccessLogWithResponse(ctx context.Context, rentalId model.RentalIdParam, reqEditors ...RequestEditorFn) (*GetRentalTrunkAccessLogResponse, error) {
+	rsp, err := c.GetRentalTrunkAccessLog(ctx, rentalId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRentalTrunkAccessLogResponse(rsp)
+}
+
+// GrantTrunkAccessWithBodyWithResponse request with arbitrary body returning *GrantTrunkAccessResponse
+func (c *ClientWithResponses) GrantTrunkAccessWithBodyWithResponse(ctx context.Context, rentalId model.RentalIdParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*GrantTrunkAccessResponse, error) {
+	rsp, err := c.GrantTrunkAccessWithBody(ctx, rentalId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGrantTrunkAccessResponse(rsp)
+}
+
+func (c *ClientWithResponses) GrantTrunkAccessWithResponse(ctx context.Context, rentalId model.RentalIdParam, body model.GrantTrunkAccessJSONRequestBody, reqEditors ...RequestEditorFn) (*GrantTrunkAccessResponse, error) {
+	rsp, err := c.GrantTrunkAccess(ctx, rentalId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGrantTrunkAccessResponse(rsp)
+}
+
+// GetWebhooksWithResponse request returning *GetWebhooksResponse
+func (c *ClientWithResponses) GetWebhooksWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWebhooksResponse, error) {
+	rsp, err := c.GetWebhooks(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWebhooksResponse(rsp)
+}
+
+// RegisterWebhookWithBodyWithResponse request with arbitrary body returning *RegisterWebhookResponse
+func (c *ClientWithResponses) RegisterWebhookWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterWebhookResponse, error) {
+	rsp, err := c.RegisterWebhookWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterWebhookResponse(rsp)
+}
+
+func (c *ClientWithResponses) RegisterWebhookWithResponse(ctx context.Context, body model.RegisterWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterWebhookResponse, error) {
+	rsp, err := c.RegisterWebhook(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterWebhookResponse(rsp)
+}
+
+// DeleteWebhookWithResponse request returning *DeleteWebhookResponse
+func (c *ClientWithResponses) DeleteWebhookWithResponse(ctx context.Context, webhookId model.WebhookIdParam, reqEditors ...RequestEditorFn) (*DeleteWebhookResponse, error) {
+	rsp, err := c.DeleteWebhook(ctx, webhookId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteWebhookResponse(rsp)
+}
+
+// GetWebhookDeliveriesWithResponse request returning *GetWebhookDeliveriesResponse
+func (c *ClientWithResponses) GetWebhookDeliveriesWithResponse(ctx context.Context, webhookId model.WebhookIdParam, reqEditors ...RequestEditorFn) (*GetWebhookDeliveriesResponse, error) {
+	rsp, err := c.GetWebhookDeliveries(ctx, webhookId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWebhookDeliveriesResponse(rsp)
+}
+
+// ParseCreateCalendarSubscriptionResponse parses an HTTP response from a CreateCalendarSubscriptionWithResponse call
+func ParseCreateCalendarSubscriptionResponse(rsp *http.Response) (*CreateCalendarSubscriptionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateCalendarSubscriptionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest model.CalendarSubscription
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ParsedCalendarSubscription = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSubscribedRentalCalendarResponse parses an HTTP response from a GetSubscribedRentalCalendarWithResponse call
+func ParseGetSubscribedRentalCalendarResponse(rsp *http.Response) (*GetSubscribedRentalCalendarResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSubscribedRentalCalendarResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAvailableCarsResponse parses an HTTP response from a GetAvailableCarsWithResponse call
+func ParseGetAvailableCarsResponse(rsp *http.Response) (*GetAvailableCarsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAvailableCarsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []model.CarAvailable
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ParsedCars = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCarResponse parses an HTTP response from a GetCarWithResponse call
+func ParseGetCarResponse(rsp *http.Response) (*GetCarResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCarResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest model.Car
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ParsedCar = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseGetNextRentalResponse parses an HTTP response from a GetNextRentalWithResponse call
+func ParseGetNextRentalResponse(rsp *http.Response) (*GetNextRentalResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetNextRentalResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest model.Rental
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ParsedRental = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateRentalResponse parses an HTTP response from a CreateRentalWithResponse call
+func ParseCreateRentalResponse(rsp *http.Response) (*CreateRentalResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateRentalResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseGetLockStateResponse parses an HTTP response from a GetLockStateWithResponse call
+func ParseGetLockStateResponse(rsp *http.Response) (*GetLockStateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLockStateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest model.LockStateObject
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ParsedLockState = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseSetLockStateResponse parses an HTTP response from a SetLockStateWithResponse call
+func ParseSetLockStateResponse(rsp *http.Response) (*SetLockStateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetLockStateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCarTrunkAccessLogResponse parses an HTTP response from a GetCarTrunkAccessLogWithResponse call
+func ParseGetCarTrunkAccessLogResponse(rsp *http.Response) (*GetCarTrunkAccessLogResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCarTrunkAccessLogResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []model.TrunkAccessLogEntry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ParsedTrunkAccessLog = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseImportRentalsResponse parses an HTTP response from a ImportRentalsWithResponse call
+func ParseImportRentalsResponse(rsp *http.Response) (*ImportRentalsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ImportRentalsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest model.RentalImportReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ParsedReport = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseGetOverviewResponse parses an HTTP response from a GetOverviewWithResponse call
+func ParseGetOverviewResponse(rsp *http.Response) (*GetOverviewResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOverviewResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []model.Rental
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ParsedRentals = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseExportRentalsResponse parses an HTTP response from a ExportRentalsWithResponse call
+func ParseExportRentalsResponse(rsp *http.Response) (*ExportRentalsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExportRentalsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRentalCalendarResponse parses an HTTP response from a GetRentalCalendarWithResponse call
+func ParseGetRentalCalendarResponse(rsp *http.Response) (*GetRentalCalendarResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRentalCalendarResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRentalStatusResponse parses an HTTP response from a GetRentalStatusWithResponse call
+func ParseGetRentalStatusResponse(rsp *http.Response) (*GetRentalStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRentalStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest model.Rental
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ParsedRental = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseSetAutoRelockResponse parses an HTTP response from a SetAutoRelockWithResponse call
+func ParseSetAutoRelockResponse(rsp *http.Response) (*SetAutoRelockResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetAutoRelockResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseCancelRentalResponse parses an HTTP response from a CancelRentalWithResponse call
+func ParseCancelRentalResponse(rsp *http.Response) (*CancelRentalResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CancelRentalResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseStreamCarStateResponse parses an HTTP response from a StreamCarStateWithResponse call
+func ParseStreamCarStateResponse(rsp *http.Response) (*StreamCarStateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StreamCarStateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRentalTrunkAccessLogResponse parses an HTTP response from a GetRentalTrunkAccessLogWithResponse call
+func ParseGetRentalTrunkAccessLogResponse(rsp *http.Response) (*GetRentalTrunkAccessLogResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRentalTrunkAccessLogResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []model.TrunkAccessLogEntry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ParsedTrunkAccessLog = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseGrantTrunkAccessResponse parses an HTTP response from a GrantTrunkAccessWithResponse call
+func ParseGrantTrunkAccessResponse(rsp *http.Response) (*GrantTrunkAccessResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GrantTrunkAccessResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest model.TrunkAccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ParsedTrunkAccess = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseGetWebhooksResponse parses an HTTP response from a GetWebhooksWithResponse call
+func ParseGetWebhooksResponse(rsp *http.Response) (*GetWebhooksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetWebhooksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []model.Webhook
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ParsedWebhooks = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseRegisterWebhookResponse parses an HTTP response from a RegisterWebhookWithResponse call
+func ParseRegisterWebhookResponse(rsp *http.Response) (*RegisterWebhookResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RegisterWebhookResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest model.Webhook
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ParsedWebhook = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteWebhookResponse parses an HTTP response from a DeleteWebhookWithResponse call
+func ParseDeleteWebhookResponse(rsp *http.Response) (*DeleteWebhookResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteWebhookResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
+
+// ParseGetWebhookDeliveriesResponse parses an HTTP response from a GetWebhookDeliveriesWithResponse call
+func ParseGetWebhookDeliveriesResponse(rsp *http.Response) (*GetWebhookDeliveriesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetWebhookDeliveriesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []model.WebhookDelivery
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ParsedDeliveries = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode >= 400:
+		response.ParsedError = parseError(rsp.StatusCode, bodyBytes)
+
+	}
+
+	return response, nil
+}
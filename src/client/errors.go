@@ -0,0 +1,66 @@
+package client
+
+import (
+	"RentalManagement/logic/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// The errors an Error matches with errors.Is depending on its status code
+var (
+	ErrInvalidRequest  = errors.New("invalid request")
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrForbidden       = errors.New("forbidden")
+	ErrNotFound        = errors.New("not found")
+	ErrConflict        = errors.New("conflict")
+	ErrServer          = errors.New("server error")
+)
+
+// Error is an error response of RentalManagement, whose body is a problem (RFC 7807) of the OpenAPI specification.
+// Use errors.Is with the errors above to check for a class of errors, or compare the Code for a specific one.
+type Error struct {
+	StatusCode int             `json:"status"`
+	Code       model.ErrorCode `json:"code"`
+	Title      string          `json:"title"`
+	Detail     string          `json:"detail"`
+	Instance   string          `json:"instance"`
+	TraceId    string          `json:"traceId"`
+	RequestId  string          `json:"requestId"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("RentalManagement responded with %d %s: %s (trace %s, request %s)", e.StatusCode, e.Code,
+		e.Detail, e.TraceId, e.RequestId)
+}
+
+// Is reports whether the error belongs to the class of errors of the target
+func (e *Error) Is(target error) bool {
+	switch {
+	case e.StatusCode == http.StatusBadRequest:
+		return target == ErrInvalidRequest
+	case e.StatusCode == http.StatusUnauthorized:
+		return target == ErrUnauthenticated
+	case e.StatusCode == http.StatusForbidden:
+		return target == ErrForbidden
+	case e.StatusCode == http.StatusNotFound:
+		return target == ErrNotFound
+	case e.StatusCode == http.StatusConflict:
+		return target == ErrConflict
+	case e.StatusCode >= http.StatusInternalServerError:
+		return target == ErrServer
+	}
+	return false
+}
+
+// parseError returns the error of an error response. If the body is no problem, e.g. because a proxy responded, the
+// body is used as detail.
+func parseError(statusCode int, body []byte) *Error {
+	parsedError := &Error{}
+	if err := json.Unmarshal(body, parsedError); err != nil || parsedError.Code == "" {
+		parsedError = &Error{Detail: string(body)}
+	}
+	parsedError.StatusCode = statusCode
+	return parsedError
+}
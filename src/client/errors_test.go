@@ -0,0 +1,42 @@
+package client
+
+import (
+	"RentalManagement/logic/model"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseError_problem(t *testing.T) {
+	err := parseError(http.StatusNotFound, []byte(`{"type":"about:blank","title":"Not Found","status":404,`+
+		`"detail":"rental not found","instance":"/rentals/kskgnvsl","code":"RENTAL_NOT_FOUND","traceId":"4bf92f35"}`))
+
+	assert.Equal(t, &Error{
+		StatusCode: http.StatusNotFound,
+		Code:       model.ErrorCodeRENTALNOTFOUND,
+		Title:      "Not Found",
+		Detail:     "rental not found",
+		Instance:   "/rentals/kskgnvsl",
+		TraceId:    "4bf92f35",
+	}, err)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.False(t, errors.Is(err, ErrConflict))
+}
+
+func TestParseError_otherBody(t *testing.T) {
+	err := parseError(http.StatusBadGateway, []byte(`{"message":"upstream unavailable"}`))
+
+	assert.Equal(t, &Error{StatusCode: http.StatusBadGateway, Detail: `{"message":"upstream unavailable"}`}, err)
+	assert.True(t, errors.Is(err, ErrServer))
+}
+
+func TestError_unknownStatusCode(t *testing.T) {
+	err := &Error{StatusCode: http.StatusTeapot, Detail: "teapot"}
+
+	for _, target := range []error{ErrInvalidRequest, ErrUnauthenticated, ErrForbidden, ErrNotFound, ErrConflict,
+		ErrServer} {
+		assert.False(t, errors.Is(err, target))
+	}
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"RentalManagement/api"
+	"RentalManagement/client"
+	"RentalManagement/environment"
+	"RentalManagement/logging"
+	"RentalManagement/logic/model"
+	"RentalManagement/logic/rentalErrors"
+	"RentalManagement/mocks"
+	"RentalManagement/testdata"
+	"RentalManagement/testhelpers"
+	"RentalManagement/tracing"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const clientCustomerId = "customer@example.com"
+const clientRentalId = "kskgnvsl"
+
+var clientRentalPeriod = model.TimePeriod{
+	StartDate: time.Date(2123, 2, 1, 0, 0, 0, 0, time.UTC),
+	EndDate:   time.Date(2123, 3, 1, 0, 0, 0, 0, time.UTC),
+}
+
+// ClientTestSuite runs the client against the app, whose operations are mocked
+type ClientTestSuite struct {
+	suite.Suite
+	tokenIssuer *testhelpers.TokenIssuer
+	verifier    *api.TokenVerifier
+	operations  *mocks.MockIOperations
+	server      *httptest.Server
+}
+
+func (suite *ClientTestSuite) SetupSuite() {
+	var err error
+	suite.tokenIssuer, err = testhelpers.NewTokenIssuer(suite.T().TempDir())
+	if err != nil {
+		suite.T().Fatal(err.Error())
+	}
+
+	environment.SetupTestingEnvironment(carServerUrl, suite.tokenIssuer.JwksFile)
+	environment.GetEnvironment().SetResponseValidation(string(api.ResponseValidationFail))
+	if _, err := tracing.Setup(environment.GetEnvironment()); err != nil {
+		suite.T().Fatal(err.Error())
+	}
+
+	suite.verifier, err = api.NewTokenVerifier(environment.GetEnvironment())
+	if err != nil {
+		suite.T().Fatal(err.Error())
+	}
+}
+
+func (suite *ClientTestSuite) SetupTest() {
+	suite.operations = mocks.NewMockIOperations(gomock.NewController(suite.T()))
+
+	app, err := newApp(suite.operations, suite.verifier, api.NewHealth(nil))
+	if err != nil {
+		suite.T().Fatal(err.Error())
+	}
+	suite.server = httptest.NewServer(app)
+}
+
+func (suite *ClientTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+// newClient returns a client authenticated with the given value of an Authorization header
+func (suite *ClientTestSuite) newClient(authorization string) *client.ClientWithResponses {
+	var options []client.ClientOption
+	if authorization != "" {
+		options = append(options, client.WithBearerToken(strings.TrimPrefix(authorization, "Bearer ")))
+	}
+
+	rentalManagement, err := client.NewClientWithResponses(suite.server.URL, options...)
+	if err != nil {
+		suite.T().Fatal(err.Error())
+	}
+	return rentalManagement
+}
+
+func (suite *ClientTestSuite) TestGetAvailableCars_success() {
+	cars := []model.CarAvailable{{Vin: testdata.VinCar, Brand: "Audi", Model: "A3", NumberOfSeats: 5}}
+	suite.operations.EXPECT().GetAvailableCars(gomock.Any(), clientRentalPeriod).Return(&cars, nil)
+
+	response, err := suite.newClient(suite.tokenIssuer.BearerToken(clientCustomerId)).GetAvailableCarsWithResponse(
+		context.Background(), &model.GetAvailableCarsParams{TimePeriod: clientRentalPeriod})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, response.StatusCode())
+	assert.Nil(suite.T(), response.ParsedError)
+	assert.Equal(suite.T(), cars, *response.ParsedCars)
+}
+
+func (suite *ClientTestSuite) TestGetAvailableCars_unauthenticated() {
+	response, err := suite.newClient("").GetAvailableCarsWithResponse(
+		context.Background(), &model.GetAvailableCarsParams{TimePeriod: clientRentalPeriod})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusUnauthorized, response.StatusCode())
+	assert.True(suite.T(), errors.Is(response.ParsedError, client.ErrUnauthenticated))
+}
+
+func (suite *ClientTestSuite) TestCreateRental_success() {
+	suite.operations.EXPECT().CreateRental(gomock.Any(), testdata.VinCar, clientCustomerId, clientRentalPeriod).
+		Return(nil)
+
+	response, err := suite.newClient(suite.tokenIssuer.BearerToken(clientCustomerId)).CreateRentalWithResponse(
+		context.Background(), testdata.VinCar, &model.CreateRentalParams{CustomerId: clientCustomerId},
+		clientRentalPeriod)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusCreated, response.StatusCode())
+	assert.Nil(suite.T(), response.ParsedError)
+}
+
+func (suite *ClientTestSuite) TestCreateRental_conflict() {
+	suite.operations.EXPECT().CreateRental(gomock.Any(), testdata.VinCar, clientCustomerId, clientRentalPeriod).
+		Return(rentalErrors.ErrConflictingRentalExists)
+
+	response, err := suite.newClient(suite.tokenIssuer.BearerToken(clientCustomerId)).CreateRentalWithResponse(
+		context.Background(), testdata.VinCar, &model.CreateRentalParams{CustomerId: clientCustomerId},
+		clientRentalPeriod)
+
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), errors.Is(response.ParsedError, client.ErrConflict))
+	assert.Equal(suite.T(), model.ErrorCodeCONFLICTINGRENTAL, response.ParsedError.Code)
+}
+
+func (suite *ClientTestSuite) TestCreateRental_invalidVin() {
+	response, err := suite.newClient(suite.tokenIssuer.BearerToken(clientCustomerId)).CreateRentalWithResponse(
+		context.Background(), "invalid", &model.CreateRentalParams{CustomerId: clientCustomerId},
+		clientRentalPeriod)
+
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), errors.Is(response.ParsedError, client.ErrInvalidRequest))
+}
+
+func (suite *ClientTestSuite) TestGetOverview_otherCustomer() {
+	response, err := suite.newClient(suite.tokenIssuer.BearerToken("other@example.com")).GetOverviewWithResponse(
+		context.Background(), &model.GetOverviewParams{CustomerId: clientCustomerId})
+
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), errors.Is(response.ParsedError, client.ErrForbidden))
+}
+
+func (suite *ClientTestSuite) TestGetRentalStatus_success() {
+	var rental model.Rental
+	if err := json.Unmarshal([]byte(testdata.CustomerRentalUpcoming), &rental); err != nil {
+		suite.T().Fatal(err.Error())
+	}
+	rental.Id = clientRentalId
+	suite.operations.EXPECT().GetRentalStatus(gomock.Any(), clientRentalId).Return(&rental, nil)
+
+	response, err := suite.newClient(suite.tokenIssuer.StaffBearerToken(string(api.RoleFleetManager))).
+		GetRentalStatusWithResponse(context.Background(), clientRentalId)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, response.StatusCode())
+	assert.Equal(suite.T(), rental, *response.ParsedRental)
+}
+
+func (suite *ClientTestSuite) TestGetRentalStatus_notFound() {
+	suite.operations.EXPECT().GetRentalStatus(gomock.Any(), clientRentalId).
+		Return(nil, rentalErrors.ErrRentalNotFound)
+
+	response, err := suite.newClient(suite.tokenIssuer.StaffBearerToken(string(api.RoleFleetManager))).
+		GetRentalStatusWithResponse(context.Background(), clientRentalId)
+
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), errors.Is(response.ParsedError, client.ErrNotFound))
+	assert.False(suite.T(), errors.Is(response.ParsedError, client.ErrForbidden))
+}
+
+func (suite *ClientTestSuite) TestGetRentalStatus_traceId() {
+	suite.operations.EXPECT().GetRentalStatus(gomock.Any(), clientRentalId).
+		Return(nil, rentalErrors.ErrRentalNotFound)
+	withTraceparent := func(ctx context.Context, request *http.Request) error {
+		request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		return nil
+	}
+
+	response, err := suite.newClient(suite.tokenIssuer.StaffBearerToken(string(api.RoleFleetManager))).
+		GetRentalStatusWithResponse(context.Background(), clientRentalId, withTraceparent)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "4bf92f3577b34da6a3ce929d0e0e4736", response.ParsedError.TraceId)
+}
+
+func (suite *ClientTestSuite) TestGetRentalStatus_requestId() {
+	suite.operations.EXPECT().GetRentalStatus(gomock.Any(), clientRentalId).
+		Return(nil, rentalErrors.ErrRentalNotFound)
+	withRequestId := func(ctx context.Context, request *http.Request) error {
+		request.Header.Set(logging.HeaderRequestId, "8vV3nWq0LzQm5tYb2KcX")
+		return nil
+	}
+
+	response, err := suite.newClient(suite.tokenIssuer.StaffBearerToken(string(api.RoleFleetManager))).
+		GetRentalStatusWithResponse(context.Background(), clientRentalId, withRequestId)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "8vV3nWq0LzQm5tYb2KcX", response.HTTPResponse.Header.Get(logging.HeaderRequestId))
+	assert.Equal(suite.T(), "8vV3nWq0LzQm5tYb2KcX", response.ParsedError.RequestId)
+}
+
+func (suite *ClientTestSuite) TestGetRentalStatus_serverError() {
+	suite.operations.EXPECT().GetRentalStatus(gomock.Any(), clientRentalId).
+		Return(nil, errors.New("database unavailable"))
+
+	response, err := suite.newClient(suite.tokenIssuer.StaffBearerToken(string(api.RoleFleetManager))).
+		GetRentalStatusWithResponse(context.Background(), clientRentalId)
+
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), errors.Is(response.ParsedError, client.ErrServer))
+}
+
+func (suite *ClientTestSuite) TestGetLockState_success() {
+	lockState := model.UNLOCKED
+	suite.operations.EXPECT().GetLockState(gomock.Any(), testdata.VinCar, "aBcDeFgHiJkLmNoPqRsTuVwX").
+		Return(&lockState, nil)
+
+	response, err := suite.newClient("").GetLockStateWithResponse(context.Background(), testdata.VinCar,
+		&model.GetLockStateParams{TrunkAccessToken: "aBcDeFgHiJkLmNoPqRsTuVwX"})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, response.StatusCode())
+	assert.Equal(suite.T(), model.UNLOCKED, response.ParsedLockState.TrunkLockState)
+}
+
+func TestClientTestSuite(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
@@ -0,0 +1,75 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// readConfigFile reads the values of a YAML (.yaml, .yml) or TOML (.toml) config file by their variable names, e.g.
+// RM_CAR_SERVER: http://car:8001. Lists are joined to comma-separated values.
+func readConfigFile(file string) (map[string]string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading the config file failed: %w", err)
+	}
+
+	var document map[string]any
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &document)
+	case ".toml":
+		err = toml.Unmarshal(data, &document)
+	default:
+		return nil, fmt.Errorf("the config file %s is neither YAML (.yaml, .yml) nor TOML (.toml)", file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing the config file failed: %w", err)
+	}
+
+	values := map[string]string{}
+	for name, value := range document {
+		stringValue, err := configFileValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s in the config file %w", name, err)
+		}
+		values[name] = stringValue
+	}
+	return values, nil
+}
+
+// configFileValue returns the value of a variable in the config file as it would be set as environment variable
+func configFileValue(value any) (string, error) {
+	switch typedValue := value.(type) {
+	case nil:
+		return "", nil
+	case []any:
+		elements := make([]string, len(typedValue))
+		for i, element := range typedValue {
+			elementValue, err := configFileValue(element)
+			if err != nil {
+				return "", err
+			}
+			elements[i] = elementValue
+		}
+		return strings.Join(elements, ","), nil
+	case map[string]any:
+		return "", errors.New("must be a value or a list, not a table")
+	default:
+		return fmt.Sprint(typedValue), nil
+	}
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
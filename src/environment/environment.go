@@ -6,36 +6,108 @@ var (
 	environment *Environment
 )
 
+// LoadEnvironment reads and validates the environment configuration, so that GetEnvironment returns it.
+// The configuration is read from the environment variables and the config file RM_CONFIG_FILE if it is set.
+// If the configuration is invalid, a ConfigurationError reporting all of its problems is returned.
+func LoadEnvironment() error {
+	loadedEnvironment, err := readEnvironment()
+	if err != nil {
+		return err
+	}
+	environment = loadedEnvironment
+	return nil
+}
+
 // GetEnvironment returns the environment configuration.
-// If the environment configuration has not been read yet, it will be read from the environment variables.
-// If any error occurs while reading the environment configuration, the program will panic.
-// If the environment configuration has already been read, the cached value will be returned.
+// If the environment configuration has not been loaded yet, it will be loaded with LoadEnvironment.
+// If the environment configuration is invalid, the program will panic.
+// If the environment configuration has already been loaded, the cached value will be returned.
 func GetEnvironment() *Environment {
 	if environment == nil {
-		environment = readEnvironment()
+		if err := LoadEnvironment(); err != nil {
+			panic(err)
+		}
 	}
 	return environment
 }
 
+// testingPublicBaseUrl is the public base URL of the testing environment
+const testingPublicBaseUrl = "http://localhost"
+
 // SetupTestingEnvironment sets up the testing environment configuration.
-func SetupTestingEnvironment(carServerUrl string) {
+// Tokens are verified with the keys of the given JWKS file.
+// If the environment configuration is invalid, the program will panic.
+func SetupTestingEnvironment(carServerUrl string, jwksFile string) {
 	setCarServerUrl(carServerUrl)
-	environment = readEnvironment()
+	setPublicBaseUrl(testingPublicBaseUrl)
+	setAuthJwksFile(jwksFile)
+	if err := LoadEnvironment(); err != nil {
+		panic(err)
+	}
 }
 
 type Environment struct {
-	mongoDbConnectionString string
+	mongoDbConnectionString *secret
 	mongoDbDatabase         string
 	appExposePort           int
 	appCollectionPrefix     string
 	carServerUrl            string
+	publicBaseUrl           string
 	requestTimeout          time.Duration
 	appAllowOrigins         []string
 	isLocalSetupMode        bool
+	trunkAutoRelockTimeout  time.Duration
+	trunkAutoRelockInterval time.Duration
+	authJwksUrl             string
+	authJwksFile            string
+	authIssuer              string
+	authAudience            string
+	authCustomerClaim       string
+	authRolesClaim          string
+	eventBroker             string
+	eventBrokerFile         string
+	outboxRelayInterval     time.Duration
+	webhookMaxAttempts      int
+	webhookRetryDelay       time.Duration
+	webhookInterval         time.Duration
+	carStateInterval        time.Duration
+	reminderSender          string
+	reminderFile            string
+	reminderStartOffsets    []time.Duration
+	reminderEndOffsets      []time.Duration
+	reminderInterval        time.Duration
+	smtpHost                string
+	smtpPort                int
+	smtpUsername            string
+	smtpPassword            *secret
+	smtpFrom                string
+	grpcPort                int
+	metricsPort             int
+	responseValidation      string
+	tracingExporter         string
+	logFormat               string
+	shutdownTimeout         time.Duration
+	secretRefreshInterval   time.Duration
+	tlsCertFile             string
+	tlsKeyFile              string
+	carTlsCertFile          string
+	carTlsKeyFile           string
+	carTlsCaFile            string
+
+	// settings are the effective values of all variables with their sources
+	settings []Setting
 }
 
+// GetMongoDbConnectionString returns the current connection string of the database. If it is read from a file, it
+// changes as soon as the file has changed.
 func (e *Environment) GetMongoDbConnectionString() string {
-	return e.mongoDbConnectionString
+	return e.mongoDbConnectionString.get()
+}
+
+// IsMongoDbConnectionStringFromFile reports whether the connection string of the database is read from a file, i.e.
+// whether it may change while the application is running.
+func (e *Environment) IsMongoDbConnectionStringFromFile() bool {
+	return e.mongoDbConnectionString.file != ""
 }
 
 func (e *Environment) GetMongoDbDatabase() string {
@@ -60,6 +132,12 @@ func (e *Environment) GetCarServerUrl() string {
 	return e.carServerUrl
 }
 
+// GetPublicBaseUrl returns the URL under which clients reach the REST API, e.g. https://rentals.example.com. URLs
+// that are handed out to clients, like calendar subscription URLs, are built from it.
+func (e *Environment) GetPublicBaseUrl() string {
+	return e.publicBaseUrl
+}
+
 func (e *Environment) GetRequestTimeout() time.Duration {
 	return e.requestTimeout
 }
@@ -71,3 +149,206 @@ func (e *Environment) GetAppAllowOrigins() []string {
 func (e *Environment) IsLocalSetupMode() bool {
 	return e.isLocalSetupMode
 }
+
+// GetTrunkAutoRelockTimeout returns the default duration after which an unlocked trunk is locked again.
+// A duration of zero disables the auto relock unless a rental overrides it.
+func (e *Environment) GetTrunkAutoRelockTimeout() time.Duration {
+	return e.trunkAutoRelockTimeout
+}
+
+// GetTrunkAutoRelockInterval returns the interval in which due relock jobs are processed.
+func (e *Environment) GetTrunkAutoRelockInterval() time.Duration {
+	return e.trunkAutoRelockInterval
+}
+
+// GetAuthJwksUrl returns the URL of the JSON Web Key Set used to verify bearer tokens (usually the jwks_uri of the
+// OpenID provider) or an empty string if the keys are read from a file.
+func (e *Environment) GetAuthJwksUrl() string {
+	return e.authJwksUrl
+}
+
+// GetAuthJwksFile returns the path of a file containing the JSON Web Key Set used to verify bearer tokens
+// or an empty string if the keys are fetched from a URL.
+func (e *Environment) GetAuthJwksFile() string {
+	return e.authJwksFile
+}
+
+// GetAuthIssuer returns the required issuer of bearer tokens or an empty string if any issuer is accepted.
+func (e *Environment) GetAuthIssuer() string {
+	return e.authIssuer
+}
+
+// GetAuthAudience returns the required audience of bearer tokens or an empty string if any audience is accepted.
+func (e *Environment) GetAuthAudience() string {
+	return e.authAudience
+}
+
+// GetAuthCustomerClaim returns the name of the token claim that contains the customer ID.
+func (e *Environment) GetAuthCustomerClaim() string {
+	return e.authCustomerClaim
+}
+
+// GetAuthRolesClaim returns the path of the token claim that contains the roles of the user.
+// Nested claims are separated by dots.
+func (e *Environment) GetAuthRolesClaim() string {
+	return e.authRolesClaim
+}
+
+// GetEventBroker returns the name of the broker rental events are published to (stdout, file or memory).
+func (e *Environment) GetEventBroker() string {
+	return e.eventBroker
+}
+
+// GetEventBrokerFile returns the path of the file rental events are appended to if the file broker is used.
+func (e *Environment) GetEventBrokerFile() string {
+	return e.eventBrokerFile
+}
+
+// GetOutboxRelayInterval returns the interval in which pending rental events are published.
+func (e *Environment) GetOutboxRelayInterval() time.Duration {
+	return e.outboxRelayInterval
+}
+
+// GetWebhookMaxAttempts returns the number of attempts after which a webhook delivery is given up.
+func (e *Environment) GetWebhookMaxAttempts() int {
+	return e.webhookMaxAttempts
+}
+
+// GetWebhookRetryDelay returns the delay after the first failed attempt of a webhook delivery.
+// It is doubled after every further attempt.
+func (e *Environment) GetWebhookRetryDelay() time.Duration {
+	return e.webhookRetryDelay
+}
+
+// GetWebhookInterval returns the interval in which due webhook deliveries are posted.
+func (e *Environment) GetWebhookInterval() time.Duration {
+	return e.webhookInterval
+}
+
+// GetCarStateInterval returns the interval in which the state of cars with live subscribers is pulled from the Car
+// server.
+func (e *Environment) GetCarStateInterval() time.Duration {
+	return e.carStateInterval
+}
+
+// GetReminderSender returns the name of the sender reminders are sent with.
+// If it is empty, no reminders are sent.
+func (e *Environment) GetReminderSender() string {
+	return e.reminderSender
+}
+
+// GetReminderFile returns the path of the file the file sender appends the reminders to.
+func (e *Environment) GetReminderFile() string {
+	return e.reminderFile
+}
+
+// GetReminderStartOffsets returns how long before the start of a rental its customer is reminded.
+func (e *Environment) GetReminderStartOffsets() []time.Duration {
+	return e.reminderStartOffsets
+}
+
+// GetReminderEndOffsets returns how long before the end of a rental its customer is reminded.
+func (e *Environment) GetReminderEndOffsets() []time.Duration {
+	return e.reminderEndOffsets
+}
+
+// GetReminderInterval returns the interval in which due reminders are sent.
+func (e *Environment) GetReminderInterval() time.Duration {
+	return e.reminderInterval
+}
+
+// GetSmtpHost returns the host of the SMTP server the smtp sender sends reminders through.
+func (e *Environment) GetSmtpHost() string {
+	return e.smtpHost
+}
+
+// GetSmtpPort returns the port of the SMTP server.
+func (e *Environment) GetSmtpPort() int {
+	return e.smtpPort
+}
+
+// GetSmtpUsername returns the username for the SMTP server. If it is empty, no authentication is attempted.
+func (e *Environment) GetSmtpUsername() string {
+	return e.smtpUsername
+}
+
+// GetSmtpPassword returns the current password for the SMTP server. If it is read from a file, it changes as soon as
+// the file has changed.
+func (e *Environment) GetSmtpPassword() string {
+	return e.smtpPassword.get()
+}
+
+// GetSmtpFrom returns the sender address of the reminder emails.
+func (e *Environment) GetSmtpFrom() string {
+	return e.smtpFrom
+}
+
+// GetGrpcPort returns the port of the gRPC API. If it is 0, the gRPC API is disabled.
+func (e *Environment) GetGrpcPort() int {
+	return e.grpcPort
+}
+
+// GetMetricsPort returns the port the metrics are exposed on for Prometheus. If it is 0, the metrics are not exposed.
+func (e *Environment) GetMetricsPort() int {
+	return e.metricsPort
+}
+
+// GetResponseValidation returns how responses that do not match the OpenAPI specification are handled: "log", "fail"
+// or "" to not validate responses
+func (e *Environment) GetResponseValidation() string {
+	return e.responseValidation
+}
+
+func (e *Environment) SetResponseValidation(responseValidation string) {
+	e.responseValidation = responseValidation
+}
+
+// GetTracingExporter returns the exporter of the spans: "otlp", "stdout" or "" to not export any spans
+func (e *Environment) GetTracingExporter() string {
+	return e.tracingExporter
+}
+
+// GetLogFormat returns the format of the log lines: "json" or "text"
+func (e *Environment) GetLogFormat() string {
+	return e.logFormat
+}
+
+// GetShutdownTimeout returns the time each phase of a shutdown has, e.g. the requests in flight to finish after a
+// shutdown has been requested.
+func (e *Environment) GetShutdownTimeout() time.Duration {
+	return e.shutdownTimeout
+}
+
+// GetSecretRefreshInterval returns the interval in which the application checks whether secrets read from files
+// have changed.
+func (e *Environment) GetSecretRefreshInterval() time.Duration {
+	return e.secretRefreshInterval
+}
+
+// GetTlsCertFile returns the path of the certificate the REST API is served with over TLS or an empty string if it is
+// served without TLS.
+func (e *Environment) GetTlsCertFile() string {
+	return e.tlsCertFile
+}
+
+// GetTlsKeyFile returns the path of the private key of the certificate of the REST API.
+func (e *Environment) GetTlsKeyFile() string {
+	return e.tlsKeyFile
+}
+
+// GetCarTlsCertFile returns the path of the client certificate presented to the Car server or an empty string if no
+// client certificate is presented.
+func (e *Environment) GetCarTlsCertFile() string {
+	return e.carTlsCertFile
+}
+
+// GetCarTlsKeyFile returns the path of the private key of the client certificate presented to the Car server.
+func (e *Environment) GetCarTlsKeyFile() string {
+	return e.carTlsKeyFile
+}
+
+// GetCarTlsCaFile returns the path of the CA certificates the Car server is verified with or an empty string if the
+// system certificates are used.
+func (e *Environment) GetCarTlsCaFile() string {
+	return e.carTlsCaFile
+}
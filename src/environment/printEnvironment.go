@@ -0,0 +1,82 @@
+package environment
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Source is where the value of a variable has been read from
+type Source string
+
+// Defines values for Source.
+const (
+	SourceEnvironment Source = "environment"
+	SourceFile        Source = "config file"
+	SourceLocalSetup  Source = "local setup"
+	SourceDefault     Source = "default"
+)
+
+// Setting is the effective value of a variable of the configuration
+type Setting struct {
+	Name   string
+	Value  string
+	Source Source
+}
+
+// secretVariables are the variables whose values are redacted when the configuration is printed
+var secretVariables = map[string]bool{
+	envSmtpPassword: true,
+}
+
+// urlVariables are the variables whose values are URLs that may contain a password, which is redacted when the
+// configuration is printed
+var urlVariables = map[string]bool{
+	envMongoDbConnectionString: true,
+}
+
+const redacted = "xxxxx"
+
+// ConfigurationError reports all problems of a configuration
+type ConfigurationError struct {
+	Problems []string
+}
+
+func (e *ConfigurationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// PrintConfiguration writes the effective configuration to the writer, with every variable in the format of a YAML
+// config file and commented with its source. Secrets are redacted.
+func (e *Environment) PrintConfiguration(writer io.Writer) error {
+	for _, setting := range e.settings {
+		_, err := fmt.Fprintf(writer, "%s: %s # %s\n", setting.Name, strconv.Quote(redact(setting)), setting.Source)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// redact returns the value of the setting with any secret replaced
+func redact(setting Setting) string {
+	if setting.Value == "" {
+		return ""
+	}
+	if secretVariables[setting.Name] {
+		return redacted
+	}
+	if urlVariables[setting.Name] {
+		parsedUrl, err := url.Parse(setting.Value)
+		if err != nil {
+			return redacted
+		}
+		if _, hasPassword := parsedUrl.User.Password(); hasPassword {
+			parsedUrl.User = url.UserPassword(parsedUrl.User.Username(), redacted)
+		}
+		return parsedUrl.String()
+	}
+	return setting.Value
+}
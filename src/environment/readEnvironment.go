@@ -16,16 +16,74 @@ const (
 	envAppExposePort           = "RM_EXPOSE_PORT"
 	envAppCollectionPrefix     = "RM_COLLECTION_PREFIX"
 	envCarServerUrl            = "RM_CAR_SERVER"
+	envPublicBaseUrl           = "RM_PUBLIC_BASE_URL"
 	envRequestTimeout          = "RM_REQUEST_TIMEOUT"
 	envAppAllowOrigins         = "RM_ALLOW_ORIGINS"
 	envLocalSetupMode          = "RM_LOCAL_SETUP"
+	envTrunkAutoRelockTimeout  = "RM_TRUNK_AUTO_RELOCK_TIMEOUT"
+	envTrunkAutoRelockInterval = "RM_TRUNK_AUTO_RELOCK_INTERVAL"
+	envAuthJwksUrl             = "RM_AUTH_JWKS_URL"
+	envAuthJwksFile            = "RM_AUTH_JWKS_FILE"
+	envAuthIssuer              = "RM_AUTH_ISSUER"
+	envAuthAudience            = "RM_AUTH_AUDIENCE"
+	envAuthCustomerClaim       = "RM_AUTH_CUSTOMER_CLAIM"
+	envAuthRolesClaim          = "RM_AUTH_ROLES_CLAIM"
+	envEventBroker             = "RM_EVENT_BROKER"
+	envEventBrokerFile         = "RM_EVENT_BROKER_FILE"
+	envOutboxRelayInterval     = "RM_OUTBOX_RELAY_INTERVAL"
+	envWebhookMaxAttempts      = "RM_WEBHOOK_MAX_ATTEMPTS"
+	envWebhookRetryDelay       = "RM_WEBHOOK_RETRY_DELAY"
+	envWebhookInterval         = "RM_WEBHOOK_INTERVAL"
+	envCarStateInterval        = "RM_CAR_STATE_INTERVAL"
+	envReminderSender          = "RM_REMINDER_SENDER"
+	envReminderFile            = "RM_REMINDER_FILE"
+	envReminderStartOffsets    = "RM_REMINDER_START_OFFSETS"
+	envReminderEndOffsets      = "RM_REMINDER_END_OFFSETS"
+	envReminderInterval        = "RM_REMINDER_INTERVAL"
+	envSmtpHost                = "RM_SMTP_HOST"
+	envSmtpPort                = "RM_SMTP_PORT"
+	envSmtpUsername            = "RM_SMTP_USERNAME"
+	envSmtpPassword            = "RM_SMTP_PASSWORD"
+	envSmtpFrom                = "RM_SMTP_FROM"
+	envGrpcPort                = "RM_GRPC_PORT"
+	envMetricsPort             = "RM_METRICS_PORT"
+	envResponseValidation      = "RM_RESPONSE_VALIDATION"
+	envTracingExporter         = "RM_TRACING_EXPORTER"
+	envLogFormat               = "RM_LOG_FORMAT"
+	envShutdownTimeout         = "RM_SHUTDOWN_TIMEOUT"
+	envConfigFile              = "RM_CONFIG_FILE"
+	envSecretRefreshInterval   = "RM_SECRET_REFRESH_INTERVAL"
+	envTlsCertFile             = "RM_TLS_CERT_FILE"
+	envTlsKeyFile              = "RM_TLS_KEY_FILE"
+	envCarTlsCertFile          = "RM_CAR_TLS_CERT_FILE"
+	envCarTlsKeyFile           = "RM_CAR_TLS_KEY_FILE"
+	envCarTlsCaFile            = "RM_CAR_TLS_CA_FILE"
 
-	defaultAppExposePort       = 80
-	defaultAppCollectionPrefix = ""
-	defaultRequestTimeout      = 5 * time.Second
+	defaultAppExposePort           = 80
+	defaultAppCollectionPrefix     = ""
+	defaultRequestTimeout          = 5 * time.Second
+	defaultTrunkAutoRelockTimeout  = 0 * time.Second
+	defaultTrunkAutoRelockInterval = 10 * time.Second
+	defaultAuthCustomerClaim       = "sub"
+	defaultAuthRolesClaim          = "roles"
+	defaultEventBroker             = "stdout"
+	defaultOutboxRelayInterval     = 5 * time.Second
+	defaultWebhookMaxAttempts      = 8
+	defaultWebhookRetryDelay       = 30 * time.Second
+	defaultWebhookInterval         = 5 * time.Second
+	defaultCarStateInterval        = 5 * time.Second
+	defaultReminderInterval        = time.Minute
+	defaultSmtpPort                = 587
+	defaultGrpcPort                = 9090
+	defaultMetricsPort             = 9464
+	defaultLogFormat               = "json"
+	defaultShutdownTimeout         = 30 * time.Second
+	defaultSecretRefreshInterval   = 10 * time.Second
 )
 
 var defaultAppAllowOrigins []string
+var defaultReminderStartOffsets = []time.Duration{24 * time.Hour, time.Hour}
+var defaultReminderEndOffsets = []time.Duration{time.Hour}
 
 func ptr[T any](v T) *T {
 	return &v
@@ -34,129 +92,221 @@ func ptr[T any](v T) *T {
 //go:embed localSetup.env
 var localSetup string
 
-// readEnvironment reads the correct environment configuration (also considering local setup mode)
-func readEnvironment() *Environment {
-	if getBooleanEnvVariable(envLocalSetupMode) {
+// readEnvironment reads the configuration from the environment variables, which override the values of the config
+// file if one is configured, which override the values of the local setup mode if it is enabled. All problems of the
+// configuration are reported at once as a ConfigurationError.
+func readEnvironment() (*Environment, error) {
+	reader := &environmentReader{}
+
+	if file := os.Getenv(envConfigFile); file != "" {
+		values, err := readConfigFile(file)
+		if err != nil {
+			return nil, &ConfigurationError{Problems: []string{err.Error()}}
+		}
+		reader.file = values
+	}
+
+	// an invalid value is reported when reading the configuration
+	if localSetupMode, _ := reader.lookup(envLocalSetupMode); localSetupMode == "true" {
 		fmt.Println("Using local setup mode.")
 
 		localSetupMap, err := godotenv.Unmarshal(localSetup)
 		if err != nil {
 			panic("Invalid local setup environment variables. This is a bug.")
 		}
+		reader.localSetup = localSetupMap
+	}
 
-		// Unfortunately, godotenv does not support reading environment variables from a string
-		// directly to the environment. Therefore, we have to use this workaround.
-		for key, value := range localSetupMap {
-			if os.Getenv(key) != "" {
-				// do not overwrite existing environment variables
-				continue
-			}
-			_ = os.Setenv(key, value)
-		}
+	environment := reader.readEnvironment()
+	reader.checkFileNames()
+	validate(environment, reader)
+
+	if len(reader.problems) > 0 {
+		return nil, &ConfigurationError{Problems: reader.problems}
 	}
-	return readEnvironmentFromEnv()
+	environment.settings = reader.settings
+	return environment, nil
 }
 
-// readEnvironmentFromEnv reads the environment configuration from actual environment variables
-// If any of the required environment variables is not set, the program will panic.
-func readEnvironmentFromEnv() *Environment {
+// readEnvironment reads every configuration value
+func (r *environmentReader) readEnvironment() *Environment {
 	return &Environment{
-		mongoDbConnectionString: getStringEnvVariable(envMongoDbConnectionString, nil),
-		mongoDbDatabase:         getStringEnvVariable(envMongoDbDatabase, nil),
-		appExposePort:           getIntegerEnvVariable(envAppExposePort, ptr(defaultAppExposePort)),
-		appCollectionPrefix:     getStringEnvVariable(envAppCollectionPrefix, ptr(defaultAppCollectionPrefix)),
-		carServerUrl:            getStringEnvVariable(envCarServerUrl, nil),
-		requestTimeout:          getDurationEnvVariable(envRequestTimeout, ptr(defaultRequestTimeout)),
-		appAllowOrigins:         getStringArrayEnvVariable(envAppAllowOrigins, ptr(defaultAppAllowOrigins)),
-		isLocalSetupMode:        getBooleanEnvVariable(envLocalSetupMode),
+		mongoDbConnectionString: r.getSecret(envMongoDbConnectionString, nil),
+		mongoDbDatabase:         r.getString(envMongoDbDatabase, nil),
+		appExposePort:           r.getInteger(envAppExposePort, ptr(defaultAppExposePort)),
+		appCollectionPrefix:     r.getString(envAppCollectionPrefix, ptr(defaultAppCollectionPrefix)),
+		carServerUrl:            r.getString(envCarServerUrl, nil),
+		publicBaseUrl:           r.getString(envPublicBaseUrl, nil),
+		requestTimeout:          r.getDuration(envRequestTimeout, ptr(defaultRequestTimeout)),
+		appAllowOrigins:         r.getStringArray(envAppAllowOrigins, ptr(defaultAppAllowOrigins)),
+		isLocalSetupMode:        r.getBoolean(envLocalSetupMode),
+		trunkAutoRelockTimeout:  r.getDuration(envTrunkAutoRelockTimeout, ptr(defaultTrunkAutoRelockTimeout)),
+		trunkAutoRelockInterval: r.getDuration(envTrunkAutoRelockInterval, ptr(defaultTrunkAutoRelockInterval)),
+		authJwksUrl:             r.getString(envAuthJwksUrl, ptr("")),
+		authJwksFile:            r.getString(envAuthJwksFile, ptr("")),
+		authIssuer:              r.getString(envAuthIssuer, ptr("")),
+		authAudience:            r.getString(envAuthAudience, ptr("")),
+		authCustomerClaim:       r.getString(envAuthCustomerClaim, ptr(defaultAuthCustomerClaim)),
+		authRolesClaim:          r.getString(envAuthRolesClaim, ptr(defaultAuthRolesClaim)),
+		eventBroker:             r.getString(envEventBroker, ptr(defaultEventBroker)),
+		eventBrokerFile:         r.getString(envEventBrokerFile, ptr("")),
+		outboxRelayInterval:     r.getDuration(envOutboxRelayInterval, ptr(defaultOutboxRelayInterval)),
+		webhookMaxAttempts:      r.getInteger(envWebhookMaxAttempts, ptr(defaultWebhookMaxAttempts)),
+		webhookRetryDelay:       r.getDuration(envWebhookRetryDelay, ptr(defaultWebhookRetryDelay)),
+		webhookInterval:         r.getDuration(envWebhookInterval, ptr(defaultWebhookInterval)),
+		carStateInterval:        r.getDuration(envCarStateInterval, ptr(defaultCarStateInterval)),
+		reminderSender:          r.getString(envReminderSender, ptr("")),
+		reminderFile:            r.getString(envReminderFile, ptr("")),
+		reminderStartOffsets:    r.getDurationArray(envReminderStartOffsets, ptr(defaultReminderStartOffsets)),
+		reminderEndOffsets:      r.getDurationArray(envReminderEndOffsets, ptr(defaultReminderEndOffsets)),
+		reminderInterval:        r.getDuration(envReminderInterval, ptr(defaultReminderInterval)),
+		smtpHost:                r.getString(envSmtpHost, ptr("")),
+		smtpPort:                r.getInteger(envSmtpPort, ptr(defaultSmtpPort)),
+		smtpUsername:            r.getString(envSmtpUsername, ptr("")),
+		smtpPassword:            r.getSecret(envSmtpPassword, ptr("")),
+		smtpFrom:                r.getString(envSmtpFrom, ptr("")),
+		grpcPort:                r.getInteger(envGrpcPort, ptr(defaultGrpcPort)),
+		metricsPort:             r.getInteger(envMetricsPort, ptr(defaultMetricsPort)),
+		responseValidation:      r.getString(envResponseValidation, ptr("")),
+		tracingExporter:         r.getString(envTracingExporter, ptr("")),
+		logFormat:               r.getString(envLogFormat, ptr(defaultLogFormat)),
+		shutdownTimeout:         r.getDuration(envShutdownTimeout, ptr(defaultShutdownTimeout)),
+		secretRefreshInterval:   r.getDuration(envSecretRefreshInterval, ptr(defaultSecretRefreshInterval)),
+		tlsCertFile:             r.getString(envTlsCertFile, ptr("")),
+		tlsKeyFile:              r.getString(envTlsKeyFile, ptr("")),
+		carTlsCertFile:          r.getString(envCarTlsCertFile, ptr("")),
+		carTlsKeyFile:           r.getString(envCarTlsKeyFile, ptr("")),
+		carTlsCaFile:            r.getString(envCarTlsCaFile, ptr("")),
 	}
 }
 
-// getStringEnvVariable returns the string value of the environment variable with the given name.
-// You can specify a default value that is returned if the environment variable is not set,
-// set defaultValue to nil to disable this feature.
-// If defaultValue is nil and the environment variable is not set, the program will panic.
-func getStringEnvVariable(variableName string, defaultValue *string) string {
-	stringValue := os.Getenv(variableName)
-	if stringValue != "" {
-		return stringValue
+// environmentReader reads the configuration values from their sources. Instead of stopping at the first invalid
+// value, it collects the problems, so that all of them can be reported at once.
+type environmentReader struct {
+	// file holds the values of the config file by their variable names
+	file map[string]string
+	// localSetup holds the values of the local setup mode by their variable names
+	localSetup map[string]string
+
+	settings []Setting
+	problems []string
+	// invalid holds the names of the variables with a problem, so that they are not validated any further
+	invalid map[string]bool
+}
+
+// lookup returns the value of the variable with the given name from the first source that sets it
+func (r *environmentReader) lookup(variableName string) (string, Source) {
+	if value := os.Getenv(variableName); value != "" {
+		return value, SourceEnvironment
+	}
+	if value := r.file[variableName]; value != "" {
+		return value, SourceFile
+	}
+	if value := r.localSetup[variableName]; value != "" {
+		return value, SourceLocalSetup
 	}
+	return "", SourceDefault
+}
 
-	if defaultValue != nil {
-		return *defaultValue
+func (r *environmentReader) addProblem(format string, args ...any) {
+	r.problems = append(r.problems, fmt.Sprintf(format, args...))
+}
+
+// addInvalid adds a problem of the variable with the given name
+func (r *environmentReader) addInvalid(variableName string, format string, args ...any) {
+	if r.invalid == nil {
+		r.invalid = map[string]bool{}
+	}
+	r.invalid[variableName] = true
+	r.addProblem(variableName+" "+format, args...)
+}
+
+// getString returns the string value of the variable with the given name.
+// You can specify a default value that is returned if the variable is not set,
+// set defaultValue to nil to disable this feature.
+// If defaultValue is nil and the variable is not set, a problem is added.
+func (r *environmentReader) getString(variableName string, defaultValue *string) string {
+	value, source := r.lookup(variableName)
+	if source == SourceDefault {
+		if defaultValue == nil {
+			r.addInvalid(variableName, "is not set")
+			return ""
+		}
+		value = *defaultValue
 	}
-	panic("Environment variable not set: " + variableName)
+
+	r.settings = append(r.settings, Setting{Name: variableName, Value: value, Source: source})
+	return value
 }
 
-// getIntegerEnvVariable returns the integer value of the environment variable with the given name.
-// You can specify a default value that is returned if the environment variable is not set,
+// getInteger returns the integer value of the variable with the given name.
+// You can specify a default value that is returned if the variable is not set,
 // set defaultValue to nil to disable this feature.
-// If defaultValue is nil and the environment variable is not set, the program will panic.
-// If the environment variable is not a valid integer value, the program will panic.
-func getIntegerEnvVariable(variableName string, defaultValue *int) int {
+// If defaultValue is nil and the variable is not set, or the variable is not a valid integer value, a problem is
+// added.
+func (r *environmentReader) getInteger(variableName string, defaultValue *int) int {
 	var stringValue string
 	if defaultValue != nil {
-		defaultValueString := strconv.Itoa(*defaultValue)
-		stringValue = getStringEnvVariable(variableName, &defaultValueString)
+		stringValue = r.getString(variableName, ptr(strconv.Itoa(*defaultValue)))
 	} else {
-		stringValue = getStringEnvVariable(variableName, nil)
+		stringValue = r.getString(variableName, nil)
+	}
+	if stringValue == "" {
+		return 0
 	}
 
 	intValue, err := strconv.Atoi(stringValue)
 	if err != nil {
-		panic(fmt.Sprintf("Invalid value for integer environment variable \"%s\": %s",
-			variableName, stringValue))
+		r.addInvalid(variableName, "is not an integer: %q", stringValue)
 	}
 	return intValue
 }
 
-// getBooleanEnvVariable returns the boolean value of the environment variable with the given name.
-// If the environment variable is not set, false is returned.
-// If the environment variable is not a valid boolean value, the program will panic.
-func getBooleanEnvVariable(variableName string) bool {
-	stringValue := os.Getenv(variableName)
-	if stringValue == "" || stringValue == "false" {
+// getBoolean returns the boolean value of the variable with the given name.
+// If the variable is not set, false is returned.
+// If the variable is not a valid boolean value, a problem is added.
+func (r *environmentReader) getBoolean(variableName string) bool {
+	stringValue := r.getString(variableName, ptr("false"))
+	if stringValue == "false" {
 		return false
 	}
-
 	if stringValue == "true" {
 		return true
 	}
 
-	panic(fmt.Sprintf("Invalid value for boolean environment variable \"%s\": %s",
-		variableName, stringValue))
+	r.addInvalid(variableName, "is neither true nor false: %q", stringValue)
+	return false
 }
 
-// getDurationEnvVariable returns the duration value of the environment variable with the given name.
-// You can specify a default value that is returned if the environment variable is not set,
+// getDuration returns the duration value of the variable with the given name.
+// You can specify a default value that is returned if the variable is not set,
 // set defaultValue to nil to disable this feature.
-// If defaultValue is nil and the environment variable is not set, the program will panic.
-// If the environment variable is not a valid duration value, the program will panic.
-func getDurationEnvVariable(variableName string, defaultValue *time.Duration) time.Duration {
+// If defaultValue is nil and the variable is not set, or the variable is not a valid duration value, a problem is
+// added.
+func (r *environmentReader) getDuration(variableName string, defaultValue *time.Duration) time.Duration {
 	var stringValue string
 	if defaultValue != nil {
-		defaultValueString := defaultValue.String()
-		stringValue = getStringEnvVariable(variableName, &defaultValueString)
+		stringValue = r.getString(variableName, ptr(defaultValue.String()))
 	} else {
-		stringValue = getStringEnvVariable(variableName, nil)
+		stringValue = r.getString(variableName, nil)
+	}
+	if stringValue == "" {
+		return 0
 	}
 
 	durationValue, err := time.ParseDuration(stringValue)
 	if err != nil {
-		panic(fmt.Sprintf("Invalid value for duration environment variable \"%s\": %s",
-			variableName, stringValue))
+		r.addInvalid(variableName, "is not a duration like 30s or 5m: %q", stringValue)
 	}
 	return durationValue
 }
 
-// getStringArrayEnvVariable returns the string array value of the environment variable with the given name.
+// getStringArray returns the string array value of the variable with the given name.
 // The string array value is parsed from a comma-separated string.
-// You can specify a default value that is returned if the environment variable is not set.
-// nil (empty slice) is supported as default value but not as environment variable value.
-// If the environment variable is not a valid string array value, the program will panic.
-func getStringArrayEnvVariable(variableName string, defaultValue *[]string) []string {
-	defaultValueString := strings.Join(*defaultValue, ",")
-	stringValue := getStringEnvVariable(variableName, &defaultValueString)
+// You can specify a default value that is returned if the variable is not set.
+// nil (empty slice) is supported as default value but not as variable value.
+func (r *environmentReader) getStringArray(variableName string, defaultValue *[]string) []string {
+	stringValue := r.getString(variableName, ptr(strings.Join(*defaultValue, ",")))
 
 	if stringValue == "" {
 		// empty slice
@@ -165,3 +315,45 @@ func getStringArrayEnvVariable(variableName string, defaultValue *[]string) []st
 
 	return strings.Split(stringValue, ",")
 }
+
+// getDurationArray returns the duration array value of the variable with the given name.
+// The duration array value is parsed from a comma-separated string.
+// You can specify a default value that is returned if the variable is not set.
+// nil (empty slice) is supported as default value but not as variable value.
+// If any element is not a valid duration value, a problem is added.
+func (r *environmentReader) getDurationArray(variableName string, defaultValue *[]time.Duration) []time.Duration {
+	defaultValueStrings := make([]string, len(*defaultValue))
+	for i, duration := range *defaultValue {
+		defaultValueStrings[i] = duration.String()
+	}
+
+	stringValues := r.getStringArray(variableName, &defaultValueStrings)
+	durationValues := make([]time.Duration, len(stringValues))
+	for i, stringValue := range stringValues {
+		durationValue, err := time.ParseDuration(strings.TrimSpace(stringValue))
+		if err != nil {
+			r.addInvalid(variableName, "is not a list of durations like 24h,1h: %q", strings.Join(stringValues, ","))
+			return nil
+		}
+		durationValues[i] = durationValue
+	}
+	return durationValues
+}
+
+// checkFileNames adds a problem for every name in the config file that is no variable, e.g. because of a typo
+func (r *environmentReader) checkFileNames() {
+	known := map[string]bool{}
+	for _, setting := range r.settings {
+		known[setting.Name] = true
+	}
+	// a secret variable is overridden by its variant with fileSuffix and vice versa
+	for _, name := range secretVariableNames {
+		known[name] = true
+		known[name+fileSuffix] = true
+	}
+	for _, name := range sortedKeys(r.file) {
+		if !known[name] {
+			r.addProblem("%s in the config file is unknown", name)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package environment
+
+import (
+	"RentalManagement/logging"
+	"golang.org/x/exp/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fileSuffix is appended to the name of a secret variable to get the name of the variable that sets the file the
+// secret is read from, e.g. MONGODB_CONNECTION_STRING_FILE
+const fileSuffix = "_FILE"
+
+// secretVariableNames are the names of the variables that may be read from a file
+var secretVariableNames = []string{envMongoDbConnectionString, envSmtpPassword}
+
+// secret is the value of a secret variable, which is either set directly or read from a file. The file is read again
+// whenever it has changed, so that rotated credentials take effect without a restart.
+type secret struct {
+	// file is the path of the file the value is read from or an empty string if the value is set directly
+	file string
+
+	mutex   sync.Mutex
+	value   string
+	modTime time.Time
+	size    int64
+}
+
+// get returns the current value of the secret. If the file of the secret cannot be read anymore, the previous value
+// is kept.
+func (s *secret) get() string {
+	if s.file == "" {
+		return s.value
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := s.refresh(); err != nil {
+		slog.Warn("reading the secret file failed, keeping the previous value", "file", s.file, logging.KeyError, err)
+	}
+	return s.value
+}
+
+// refresh reads the file of the secret again if its modification time or size has changed.
+// The mutex must be held by the caller.
+func (s *secret) refresh() error {
+	info, err := os.Stat(s.file)
+	if err != nil {
+		return err
+	}
+	if info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.file)
+	if err != nil {
+		return err
+	}
+	// editors and most secret stores end the file with a line break, which is no part of the secret
+	s.value = strings.TrimRight(string(data), "\r\n")
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	return nil
+}
+
+// sourcePrecedence ranks the sources by which of them overrides the others
+var sourcePrecedence = map[Source]int{
+	SourceDefault:     0,
+	SourceLocalSetup:  1,
+	SourceFile:        2,
+	SourceEnvironment: 3,
+}
+
+// getSecret returns the secret value of the variable with the given name. If the variable with the name plus
+// fileSuffix is set, the value is read from the file it names instead, and read again whenever the file changes.
+// You can specify a default value that is returned if neither variable is set,
+// set defaultValue to nil to disable this feature.
+// If both variables are set, the one of the source that overrides the other is used.
+// If both variables are set by the same source, the file cannot be read, or defaultValue is nil and neither variable
+// is set, a problem is added.
+func (r *environmentReader) getSecret(variableName string, defaultValue *string) *secret {
+	fileVariableName := variableName + fileSuffix
+	file, fileSource := r.lookup(fileVariableName)
+	_, source := r.lookup(variableName)
+	if fileSource == SourceDefault || sourcePrecedence[source] > sourcePrecedence[fileSource] {
+		return &secret{value: r.getString(variableName, defaultValue)}
+	}
+	if source == fileSource {
+		r.addInvalid(variableName, "must not be set together with %s", fileVariableName)
+		return &secret{}
+	}
+
+	r.settings = append(r.settings, Setting{Name: fileVariableName, Value: file, Source: fileSource})
+	s := &secret{file: file}
+	if err := s.refresh(); err != nil {
+		r.addInvalid(variableName, "cannot be read from %s: %v", fileVariableName, err)
+	}
+	return s
+}
@@ -0,0 +1,157 @@
+package environment
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// The values of the variables that select an implementation or a mode
+var (
+	eventBrokers        = []string{"stdout", "file", "memory"}
+	reminderSenders     = []string{"", "smtp", "file"}
+	responseValidations = []string{"", "log", "fail"}
+	tracingExporters    = []string{"", "otlp", "stdout"}
+	logFormats          = []string{"json", "text"}
+)
+
+// validate adds a problem to the reader for every value of the environment that cannot work, so that the
+// configuration fails at the start instead of when the value is used
+func validate(e *Environment, r *environmentReader) {
+	validateUrl(r, envMongoDbConnectionString, e.mongoDbConnectionString.get(), "mongodb", "mongodb+srv")
+	validatePort(r, envAppExposePort, e.appExposePort, false)
+	validateUrl(r, envCarServerUrl, e.carServerUrl, "http", "https")
+	validateUrl(r, envPublicBaseUrl, e.publicBaseUrl, "http", "https")
+	for _, origin := range e.appAllowOrigins {
+		validateOrigin(r, origin)
+	}
+
+	validatePositive(r, envRequestTimeout, e.requestTimeout)
+	if !r.invalid[envTrunkAutoRelockTimeout] && e.trunkAutoRelockTimeout < 0 {
+		r.addProblem("%s must not be negative: %s", envTrunkAutoRelockTimeout, e.trunkAutoRelockTimeout)
+	}
+	validatePositive(r, envTrunkAutoRelockInterval, e.trunkAutoRelockInterval)
+	validatePositive(r, envOutboxRelayInterval, e.outboxRelayInterval)
+	validatePositive(r, envWebhookRetryDelay, e.webhookRetryDelay)
+	validatePositive(r, envWebhookInterval, e.webhookInterval)
+	validatePositive(r, envCarStateInterval, e.carStateInterval)
+	validatePositive(r, envReminderInterval, e.reminderInterval)
+	validatePositive(r, envShutdownTimeout, e.shutdownTimeout)
+	validatePositive(r, envSecretRefreshInterval, e.secretRefreshInterval)
+	for _, offset := range append(append([]time.Duration{}, e.reminderStartOffsets...), e.reminderEndOffsets...) {
+		if offset < 0 {
+			r.addProblem("reminder offsets must not be negative: %s", offset)
+		}
+	}
+
+	if e.authJwksUrl == "" && e.authJwksFile == "" {
+		r.addProblem("either %s or %s must be set", envAuthJwksUrl, envAuthJwksFile)
+	}
+	if e.authJwksUrl != "" {
+		validateUrl(r, envAuthJwksUrl, e.authJwksUrl, "http", "https")
+	}
+
+	validateOneOf(r, envEventBroker, e.eventBroker, eventBrokers)
+	if e.eventBroker == "file" && e.eventBrokerFile == "" {
+		r.addProblem("%s must be set for the file broker", envEventBrokerFile)
+	}
+	if !r.invalid[envWebhookMaxAttempts] && e.webhookMaxAttempts < 1 {
+		r.addProblem("%s must be at least 1: %d", envWebhookMaxAttempts, e.webhookMaxAttempts)
+	}
+
+	validateOneOf(r, envReminderSender, e.reminderSender, reminderSenders)
+	if e.reminderSender == "file" && e.reminderFile == "" {
+		r.addProblem("%s must be set for the file sender", envReminderFile)
+	}
+	if e.reminderSender == "smtp" && (e.smtpHost == "" || e.smtpFrom == "") {
+		r.addProblem("%s and %s must be set for the smtp sender", envSmtpHost, envSmtpFrom)
+	}
+	validatePort(r, envSmtpPort, e.smtpPort, false)
+
+	validatePair(r, envTlsCertFile, e.tlsCertFile, envTlsKeyFile, e.tlsKeyFile)
+	validatePair(r, envCarTlsCertFile, e.carTlsCertFile, envCarTlsKeyFile, e.carTlsKeyFile)
+	if (e.carTlsCertFile != "" || e.carTlsCaFile != "") && !r.invalid[envCarServerUrl] &&
+		!strings.HasPrefix(e.carServerUrl, "https://") {
+		r.addProblem("%s must start with https:// if %s or %s is set", envCarServerUrl, envCarTlsCertFile,
+			envCarTlsCaFile)
+	}
+
+	validatePort(r, envGrpcPort, e.grpcPort, true)
+	validatePort(r, envMetricsPort, e.metricsPort, true)
+	if e.metricsPort != 0 && (e.metricsPort == e.appExposePort || e.metricsPort == e.grpcPort) {
+		r.addProblem("%s must differ from %s and %s: %d", envMetricsPort, envAppExposePort, envGrpcPort, e.metricsPort)
+	}
+	validateOneOf(r, envResponseValidation, e.responseValidation, responseValidations)
+	validateOneOf(r, envTracingExporter, e.tracingExporter, tracingExporters)
+	validateOneOf(r, envLogFormat, e.logFormat, logFormats)
+}
+
+// validateUrl adds a problem if the value is no absolute URL with a host and one of the given schemes
+func validateUrl(r *environmentReader, variableName string, value string, schemes ...string) {
+	if r.invalid[variableName] {
+		return
+	}
+	parsedUrl, err := url.Parse(value)
+	if err != nil || parsedUrl.Host == "" || !contains(schemes, parsedUrl.Scheme) {
+		r.addProblem("%s must be a URL starting with %s://", variableName, strings.Join(schemes, ":// or "))
+	}
+}
+
+// validateOrigin adds a problem if the value is neither * nor an origin like https://example.com:8443
+func validateOrigin(r *environmentReader, origin string) {
+	if origin == "*" {
+		return
+	}
+	parsedUrl, err := url.Parse(origin)
+	if err != nil || parsedUrl.Host == "" || !contains([]string{"http", "https"}, parsedUrl.Scheme) ||
+		parsedUrl.Path != "" || parsedUrl.RawQuery != "" || parsedUrl.Fragment != "" {
+		r.addProblem("%s must contain * or origins like https://example.com: %q", envAppAllowOrigins, origin)
+	}
+}
+
+// validatePort adds a problem if the value is no port. Port 0 disables a server if disableable is true.
+func validatePort(r *environmentReader, variableName string, value int, disableable bool) {
+	if r.invalid[variableName] || value == 0 && disableable {
+		return
+	}
+	if value < 1 || value > 65535 {
+		r.addProblem("%s must be a port between 1 and 65535: %d", variableName, value)
+	}
+}
+
+// validatePair adds a problem if only one of two variables that must be set together is set
+func validatePair(r *environmentReader, variableName string, value string, otherVariableName string,
+	otherValue string) {
+	if (value == "") != (otherValue == "") {
+		r.addProblem("%s and %s must be set together", variableName, otherVariableName)
+	}
+}
+
+func validatePositive(r *environmentReader, variableName string, value time.Duration) {
+	if !r.invalid[variableName] && value <= 0 {
+		r.addProblem("%s must be positive: %s", variableName, value)
+	}
+}
+
+func validateOneOf(r *environmentReader, variableName string, value string, values []string) {
+	if !contains(values, value) {
+		r.addProblem("%s must be one of %s: %q", variableName, strings.Join(quoteAll(values), ", "), value)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+func quoteAll(values []string) []string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = `"` + value + `"`
+	}
+	return quoted
+}
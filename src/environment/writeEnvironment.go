@@ -5,3 +5,11 @@ import "os"
 func setCarServerUrl(carServerUrl string) {
 	_ = os.Setenv(envCarServerUrl, carServerUrl)
 }
+
+func setPublicBaseUrl(publicBaseUrl string) {
+	_ = os.Setenv(envPublicBaseUrl, publicBaseUrl)
+}
+
+func setAuthJwksFile(jwksFile string) {
+	_ = os.Setenv(envAuthJwksFile, jwksFile)
+}
@@ -0,0 +1,129 @@
+package grpcapi
+
+import (
+	"RentalManagement/api"
+	"RentalManagement/grpcapi/pb"
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authorizationMetadataKey is the metadata key of the bearer token, the counterpart of the Authorization header
+const authorizationMetadataKey = "authorization"
+
+// methodOperations maps the methods to the IDs of the corresponding operations of the REST API, whose roles are
+// allowed to call them
+var methodOperations = map[string]string{
+	pb.RentalManagement_GetAvailableCars_FullMethodName: "getAvailableCars",
+	pb.RentalManagement_GetCar_FullMethodName:           "getCar",
+	pb.RentalManagement_CreateRental_FullMethodName:     "createRental",
+	pb.RentalManagement_GetNextRental_FullMethodName:    "getNextRental",
+	pb.RentalManagement_GetOverview_FullMethodName:      "getOverview",
+	pb.RentalManagement_GetRentalStatus_FullMethodName:  "getRentalStatus",
+	pb.RentalManagement_SetAutoRelock_FullMethodName:    "setAutoRelock",
+	pb.RentalManagement_CancelRental_FullMethodName:     "cancelRental",
+	pb.RentalManagement_GrantTrunkAccess_FullMethodName: "grantTrunkAccess",
+	pb.RentalManagement_GetLockState_FullMethodName:     "getLockState",
+	pb.RentalManagement_SetLockState_FullMethodName:     "setLockState",
+}
+
+type identityContextKey struct{}
+
+// newMethodRoles maps the methods to the roles allowed to call them, which are the roles of the corresponding
+// operations in the OpenAPI specification. Methods without an entry may be called without authentication.
+func newMethodRoles() (map[string][]api.Role, error) {
+	operationRoles, err := api.OperationRoles()
+	if err != nil {
+		return nil, err
+	}
+
+	methodRoles := map[string][]api.Role{}
+	for method, operationId := range methodOperations {
+		roles, known := operationRoles[operationId]
+		if !known {
+			return nil, fmt.Errorf("the operation %s of method %s is not in the OpenAPI specification", operationId,
+				method)
+		}
+		if roles != nil {
+			methodRoles[method] = roles
+		}
+	}
+	return methodRoles, nil
+}
+
+// authenticate returns an interceptor that verifies the bearer token of a call and authorizes the call by the roles of
+// its method. Calls with an invalid bearer token are rejected with Unauthenticated. Calls of restricted methods are
+// rejected with Unauthenticated without a token and with PermissionDenied if the caller has none of the roles.
+func authenticate(verifier *api.TokenVerifier, methodRoles map[string][]api.Role) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (any, error) {
+
+		var authorization string
+		if values := metadata.ValueFromIncomingContext(ctx, authorizationMetadataKey); len(values) > 0 {
+			authorization = values[0]
+		}
+
+		identity, err := verifier.Verify(authorization)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid bearer token: "+err.Error())
+		}
+		if identity != nil {
+			ctx = context.WithValue(ctx, identityContextKey{}, identity)
+		}
+
+		if roles, restricted := methodRoles[info.FullMethod]; restricted {
+			if identity == nil {
+				return nil, status.Error(codes.Unauthenticated, "authentication required")
+			}
+			if !identity.HasAnyRole(roles) {
+				return nil, status.Error(codes.PermissionDenied, "missing role to perform the operation")
+			}
+		}
+
+		return handler(ctx, request)
+	}
+}
+
+// requireCustomer checks that the caller is the customer with the given ID or an admin, like the REST API does for
+// requests with a customerId query parameter
+func requireCustomer(ctx context.Context, customerId string) error {
+	identity, authenticated := ctx.Value(identityContextKey{}).(*api.Identity)
+	if !authenticated {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if identity.HasAnyRole([]api.Role{api.RoleAdmin}) {
+		return nil
+	}
+	if identity.CustomerId == "" || identity.CustomerId != customerId {
+		return status.Error(codes.PermissionDenied, "customerId does not match the authenticated customer")
+	}
+	return nil
+}
+
+// rentalsOfAllCustomersRoles are the roles allowed to call the methods on the rentals of every customer, like in the
+// REST API
+var rentalsOfAllCustomersRoles = []api.Role{api.RoleFleetManager, api.RoleAdmin}
+
+// requireRentalCustomer checks that the caller is the customer renting the rental with the given ID or may operate on
+// the rentals of every customer
+func requireRentalCustomer(ctx context.Context, rentals api.RentalCustomers, rentalId string) error {
+	identity, authenticated := ctx.Value(identityContextKey{}).(*api.Identity)
+	if !authenticated {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if identity.HasAnyRole(rentalsOfAllCustomersRoles) {
+		return nil
+	}
+
+	customerId, err := rentals.GetRentalCustomer(ctx, rentalId)
+	if err != nil {
+		return toStatus(ctx, err)
+	}
+	if identity.CustomerId == "" || identity.CustomerId != customerId {
+		return status.Error(codes.PermissionDenied, "the rental belongs to another customer")
+	}
+	return nil
+}
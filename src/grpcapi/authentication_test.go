@@ -0,0 +1,31 @@
+package grpcapi
+
+import (
+	"RentalManagement/api"
+	"RentalManagement/grpcapi/pb"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMethodRoles(t *testing.T) {
+	methodRoles, err := newMethodRoles()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []api.Role{api.RoleFleetManager, api.RoleService, api.RoleAdmin},
+		methodRoles[pb.RentalManagement_GetNextRental_FullMethodName])
+	assert.Equal(t, []api.Role{api.RoleCustomer, api.RoleAdmin},
+		methodRoles[pb.RentalManagement_CreateRental_FullMethodName])
+	assert.Equal(t, []api.Role{api.RoleCustomer, api.RoleFleetManager, api.RoleService, api.RoleAdmin},
+		methodRoles[pb.RentalManagement_GetCar_FullMethodName])
+	// trunk access tokens are checked by the operations
+	assert.NotContains(t, methodRoles, pb.RentalManagement_GetLockState_FullMethodName)
+	assert.NotContains(t, methodRoles, pb.RentalManagement_SetLockState_FullMethodName)
+}
+
+func TestMethodOperations_everyMethod(t *testing.T) {
+	for _, method := range pb.RentalManagement_ServiceDesc.Methods {
+		fullMethod := "/" + pb.RentalManagement_ServiceDesc.ServiceName + "/" + method.MethodName
+		assert.Contains(t, methodOperations, fullMethod)
+	}
+}
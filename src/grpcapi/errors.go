@@ -0,0 +1,46 @@
+package grpcapi
+
+import (
+	"RentalManagement/logging"
+	"RentalManagement/logic/rentalErrors"
+	"context"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorCodes maps the rentalErrors to the gRPC status codes corresponding to the HTTP status codes of the REST API
+var errorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{rentalErrors.ErrCarNotFound, codes.NotFound},
+	{rentalErrors.ErrRentalNotFound, codes.NotFound},
+	{rentalErrors.ErrWebhookNotFound, codes.NotFound},
+	{rentalErrors.ErrCalendarNotFound, codes.NotFound},
+	{rentalErrors.ErrConflictingRentalExists, codes.AlreadyExists},
+	{rentalErrors.ErrRentalNotActive, codes.FailedPrecondition},
+	{rentalErrors.ErrRentalNotUpcoming, codes.FailedPrecondition},
+	{rentalErrors.ErrRentalNotOverlapping, codes.FailedPrecondition},
+	{rentalErrors.ErrTrunkAccessDenied, codes.PermissionDenied},
+	{rentalErrors.ErrResourceConflict, codes.Aborted},
+}
+
+// toStatus converts an error returned by the operations to a gRPC status error. The messages of unexpected errors are
+// logged with the logger of the call instead of being returned to the caller.
+func toStatus(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, errorCode := range errorCodes {
+		if errors.Is(err, errorCode.err) {
+			return status.Error(errorCode.code, errorCode.err.Error())
+		}
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	logging.FromContext(ctx).Error("gRPC request failed", logging.KeyError, err)
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,57 @@
+package grpcapi
+
+import (
+	"RentalManagement/logging"
+	"RentalManagement/util"
+	"context"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// requestIdMetadataKey is the metadata key of the request ID, the counterpart of the X-Request-ID header
+const requestIdMetadataKey = "x-request-id"
+
+// requestIdLength is the length of the request IDs generated for calls without a valid one, as in the REST API
+const requestIdLength = 20
+
+// logCalls returns an interceptor that assigns every call an ID and logs the call when it has been handled, like the
+// logging middleware of the REST API. The ID is taken from the x-request-id metadata of the call if it is valid and
+// generated otherwise. It is responded with in the x-request-id header metadata, forwarded to Car and contained in
+// every log line of the call.
+//
+// The interceptor must be chained after the tracing interceptor, so that the log lines contain the trace ID, and
+// before the interceptors that may reject calls, so that rejected calls are logged as well.
+func logCalls() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (any, error) {
+
+		start := time.Now()
+
+		var requestId string
+		if values := metadata.ValueFromIncomingContext(ctx, requestIdMetadataKey); len(values) > 0 {
+			requestId = values[0]
+		}
+		if !logging.IsValidRequestId(requestId) {
+			requestId = util.GenerateRandomString(requestIdLength)
+		}
+		ctx = logging.WithRequestId(ctx, requestId)
+		if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+			ctx = logging.With(ctx, logging.KeyTraceId, spanContext.TraceID().String())
+		}
+		if err := grpc.SetHeader(ctx, metadata.Pairs(requestIdMetadataKey, requestId)); err != nil {
+			logging.FromContext(ctx).Warn("responding with the request ID failed", logging.KeyError, err)
+		}
+
+		response, err := handler(ctx, request)
+
+		logging.FromContext(ctx).Info("call handled",
+			logging.KeyMethod, info.FullMethod,
+			logging.KeyStatus, status.Code(err).String(),
+			logging.KeyDurationMs, time.Since(start).Milliseconds(),
+		)
+		return response, err
+	}
+}
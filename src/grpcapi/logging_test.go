@@ -0,0 +1,89 @@
+package grpcapi
+
+import (
+	"RentalManagement/grpcapi/pb"
+	"RentalManagement/logging"
+	"RentalManagement/logic/model"
+	"RentalManagement/mocks"
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"golang.org/x/exp/slog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// captureLogLines lets the default logger write JSON to the returned buffer for the duration of the test
+func captureLogLines(t *testing.T) *bytes.Buffer {
+	previousLogger := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(previousLogger) })
+	buffer := &bytes.Buffer{}
+	slog.SetDefault(slog.New(slog.NewJSONHandler(buffer, nil)))
+	return buffer
+}
+
+// decodeLogLines returns the JSON log lines written to the buffer
+func decodeLogLines(t *testing.T, buffer *bytes.Buffer) []map[string]any {
+	var lines []map[string]any
+	for _, data := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		var line map[string]any
+		assert.Nil(t, json.Unmarshal([]byte(data), &line))
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestLogCalls_propagatesRequestId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	operations := mocks.NewMockIOperations(ctrl)
+	client, tokenIssuer := newTestClient(t, operations)
+	buffer := captureLogLines(t)
+
+	var operationsRequestId string
+	operations.EXPECT().GetAvailableCars(gomock.Any(), rentalPeriod).DoAndReturn(
+		func(ctx context.Context, _ model.TimePeriod) (*[]model.CarAvailable, error) {
+			operationsRequestId = logging.RequestIdFromContext(ctx)
+			return &[]model.CarAvailable{}, nil
+		})
+
+	ctx := metadata.AppendToOutgoingContext(withToken(tokenIssuer.BearerToken(customerId)),
+		requestIdMetadataKey, "8vV3nWq0LzQm5tYb2KcX")
+	var header metadata.MD
+	_, err := client.GetAvailableCars(ctx, &pb.GetAvailableCarsRequest{TimePeriod: mapTimePeriodToPb(rentalPeriod)},
+		grpc.Header(&header))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"8vV3nWq0LzQm5tYb2KcX"}, header.Get(requestIdMetadataKey))
+	assert.Equal(t, "8vV3nWq0LzQm5tYb2KcX", operationsRequestId)
+	lines := decodeLogLines(t, buffer)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, "call handled", lines[0]["msg"])
+	assert.Equal(t, "8vV3nWq0LzQm5tYb2KcX", lines[0][logging.KeyRequestId])
+	assert.Equal(t, pb.RentalManagement_GetAvailableCars_FullMethodName, lines[0][logging.KeyMethod])
+	assert.Equal(t, "OK", lines[0][logging.KeyStatus])
+}
+
+func TestLogCalls_rejectedCall(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client, _ := newTestClient(t, mocks.NewMockIOperations(ctrl))
+	buffer := captureLogLines(t)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), requestIdMetadataKey, "invalid request id")
+	var header metadata.MD
+	_, err := client.GetAvailableCars(ctx, &pb.GetAvailableCarsRequest{TimePeriod: mapTimePeriodToPb(rentalPeriod)},
+		grpc.Header(&header))
+
+	assert.NotNil(t, err)
+	requestIds := header.Get(requestIdMetadataKey)
+	assert.Len(t, requestIds, 1)
+	assert.Len(t, requestIds[0], requestIdLength)
+	lines := decodeLogLines(t, buffer)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, requestIds[0], lines[0][logging.KeyRequestId])
+	assert.Equal(t, "Unauthenticated", lines[0][logging.KeyStatus])
+}
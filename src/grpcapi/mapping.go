@@ -0,0 +1,182 @@
+package grpcapi
+
+import (
+	"RentalManagement/grpcapi/pb"
+	"RentalManagement/logic/model"
+	"errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var lockStates = map[model.LockState]pb.LockState{
+	model.LOCKED:   pb.LockState_LOCK_STATE_LOCKED,
+	model.UNLOCKED: pb.LockState_LOCK_STATE_UNLOCKED,
+}
+
+var engineStates = map[model.DynamicDataEngineState]pb.EngineState{
+	model.OFF: pb.EngineState_ENGINE_STATE_OFF,
+	model.ON:  pb.EngineState_ENGINE_STATE_ON,
+}
+
+var rentalStates = map[model.State]pb.RentalState{
+	model.ACTIVE:    pb.RentalState_RENTAL_STATE_ACTIVE,
+	model.UPCOMING:  pb.RentalState_RENTAL_STATE_UPCOMING,
+	model.EXPIRED:   pb.RentalState_RENTAL_STATE_EXPIRED,
+	model.CANCELLED: pb.RentalState_RENTAL_STATE_CANCELLED,
+}
+
+var weekdays = map[model.Weekday]pb.Weekday{
+	model.MONDAY:    pb.Weekday_WEEKDAY_MONDAY,
+	model.TUESDAY:   pb.Weekday_WEEKDAY_TUESDAY,
+	model.WEDNESDAY: pb.Weekday_WEEKDAY_WEDNESDAY,
+	model.THURSDAY:  pb.Weekday_WEEKDAY_THURSDAY,
+	model.FRIDAY:    pb.Weekday_WEEKDAY_FRIDAY,
+	model.SATURDAY:  pb.Weekday_WEEKDAY_SATURDAY,
+	model.SUNDAY:    pb.Weekday_WEEKDAY_SUNDAY,
+}
+
+// mapTimePeriodFromPb returns an error if the time period or any of its dates is missing or invalid
+func mapTimePeriodFromPb(timePeriod *pb.TimePeriod) (model.TimePeriod, error) {
+	if timePeriod == nil {
+		return model.TimePeriod{}, errors.New("time period is required")
+	}
+	if err := timePeriod.GetStartDate().CheckValid(); err != nil {
+		return model.TimePeriod{}, errors.New("invalid startDate")
+	}
+	if err := timePeriod.GetEndDate().CheckValid(); err != nil {
+		return model.TimePeriod{}, errors.New("invalid endDate")
+	}
+	return model.TimePeriod{
+		StartDate: timePeriod.GetStartDate().AsTime(),
+		EndDate:   timePeriod.GetEndDate().AsTime(),
+	}, nil
+}
+
+func mapTimePeriodToPb(timePeriod model.TimePeriod) *pb.TimePeriod {
+	return &pb.TimePeriod{
+		StartDate: timestamppb.New(timePeriod.StartDate),
+		EndDate:   timestamppb.New(timePeriod.EndDate),
+	}
+}
+
+// mapLockStateFromPb returns nil if the lock state is unspecified or unknown
+func mapLockStateFromPb(lockState pb.LockState) *model.LockState {
+	for modelLockState, pbLockState := range lockStates {
+		if pbLockState == lockState {
+			return &modelLockState
+		}
+	}
+	return nil
+}
+
+// mapRecurrenceFromPb returns nil if no recurrence is given and an error if any weekday is unspecified or unknown
+func mapRecurrenceFromPb(recurrence *pb.Recurrence) (*model.Recurrence, error) {
+	if recurrence == nil {
+		return nil, nil
+	}
+
+	daysOfWeek := make([]model.Weekday, 0, len(recurrence.GetDaysOfWeek()))
+	for _, day := range recurrence.GetDaysOfWeek() {
+		weekday, known := weekdayFromPb(day)
+		if !known {
+			return nil, errors.New("invalid day of week")
+		}
+		daysOfWeek = append(daysOfWeek, weekday)
+	}
+
+	return &model.Recurrence{
+		DaysOfWeek: daysOfWeek,
+		StartTime:  recurrence.GetStartTime(),
+		EndTime:    recurrence.GetEndTime(),
+		TimeZone:   recurrence.GetTimeZone(),
+	}, nil
+}
+
+func weekdayFromPb(day pb.Weekday) (model.Weekday, bool) {
+	for modelWeekday, pbWeekday := range weekdays {
+		if pbWeekday == day {
+			return modelWeekday, true
+		}
+	}
+	return "", false
+}
+
+func mapRecurrenceToPb(recurrence *model.Recurrence) *pb.Recurrence {
+	if recurrence == nil {
+		return nil
+	}
+
+	daysOfWeek := make([]pb.Weekday, 0, len(recurrence.DaysOfWeek))
+	for _, day := range recurrence.DaysOfWeek {
+		daysOfWeek = append(daysOfWeek, weekdays[day])
+	}
+
+	return &pb.Recurrence{
+		DaysOfWeek: daysOfWeek,
+		StartTime:  recurrence.StartTime,
+		EndTime:    recurrence.EndTime,
+		TimeZone:   recurrence.TimeZone,
+	}
+}
+
+func mapTrunkAccessToPb(trunkAccess *model.TrunkAccess) *pb.TrunkAccess {
+	if trunkAccess == nil {
+		return nil
+	}
+	return &pb.TrunkAccess{
+		Token:          trunkAccess.Token,
+		ValidityPeriod: mapTimePeriodToPb(trunkAccess.ValidityPeriod),
+		Recurrence:     mapRecurrenceToPb(trunkAccess.Recurrence),
+	}
+}
+
+func mapCarAvailableToPb(car model.CarAvailable) *pb.CarAvailable {
+	return &pb.CarAvailable{
+		Vin:           car.Vin,
+		Brand:         car.Brand,
+		Model:         car.Model,
+		NumberOfSeats: int32(car.NumberOfSeats),
+	}
+}
+
+func mapCarToPb(car *model.Car) *pb.Car {
+	if car == nil {
+		return nil
+	}
+
+	pbCar := &pb.Car{Vin: car.Vin, Brand: car.Brand, Model: car.Model}
+	if car.DynamicData != nil {
+		pbCar.DynamicData = &pb.DynamicData{
+			DoorsLockState:      lockStates[car.DynamicData.DoorsLockState],
+			EngineState:         engineStates[car.DynamicData.EngineState],
+			FuelLevelPercentage: int32(car.DynamicData.FuelLevelPercentage),
+			Position: &pb.Position{
+				Latitude:  car.DynamicData.Position.Latitude,
+				Longitude: car.DynamicData.Position.Longitude,
+			},
+			TrunkLockState: lockStates[car.DynamicData.TrunkLockState],
+		}
+	}
+	return pbCar
+}
+
+// mapRentalToPb maps the fields of the rental that are set, so the view of the rental is kept
+func mapRentalToPb(rental *model.Rental) *pb.Rental {
+	if rental == nil {
+		return nil
+	}
+
+	pbRental := &pb.Rental{
+		Id:           rental.Id,
+		State:        rentalStates[rental.State],
+		Car:          mapCarToPb(rental.Car),
+		RentalPeriod: mapTimePeriodToPb(rental.RentalPeriod),
+		Token:        mapTrunkAccessToPb(rental.Token),
+	}
+	if rental.Customer != nil {
+		pbRental.CustomerId = rental.Customer.CustomerId
+	}
+	if rental.AutoRelock != nil {
+		pbRental.AutoRelock = &pb.AutoRelock{TimeoutSeconds: int32(rental.AutoRelock.TimeoutSeconds)}
+	}
+	return pbRental
+}
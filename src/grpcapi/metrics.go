@@ -0,0 +1,27 @@
+package grpcapi
+
+import (
+	"RentalManagement/metrics"
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// recordMetrics returns an interceptor that records the count and latency of the calls per method, like the metrics
+// middleware of the REST API.
+//
+// The interceptor must be chained before the interceptors that may reject calls, so that rejected calls are recorded
+// with their status codes.
+func recordMetrics() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (any, error) {
+
+		start := time.Now()
+		response, err := handler(ctx, request)
+
+		metrics.GrpcRequests.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+		metrics.GrpcRequestDuration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+		return response, err
+	}
+}
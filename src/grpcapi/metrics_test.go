@@ -0,0 +1,27 @@
+package grpcapi
+
+import (
+	"RentalManagement/grpcapi/pb"
+	"RentalManagement/metrics"
+	"RentalManagement/mocks"
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordMetrics_rejectedCall(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client, _ := newTestClient(t, mocks.NewMockIOperations(ctrl))
+	requests := metrics.GrpcRequests.WithLabelValues(pb.RentalManagement_GetAvailableCars_FullMethodName,
+		"Unauthenticated")
+	requestCount := testutil.ToFloat64(requests)
+
+	_, err := client.GetAvailableCars(context.Background(),
+		&pb.GetAvailableCarsRequest{TimePeriod: mapTimePeriodToPb(rentalPeriod)})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, requestCount+1, testutil.ToFloat64(requests))
+}
@@ -0,0 +1,2379 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: rental_management.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LockState int32
+
+const (
+	LockState_LOCK_STATE_UNSPECIFIED LockState = 0
+	LockState_LOCK_STATE_LOCKED      LockState = 1
+	LockState_LOCK_STATE_UNLOCKED    LockState = 2
+)
+
+// Enum value maps for LockState.
+var (
+	LockState_name = map[int32]string{
+		0: "LOCK_STATE_UNSPECIFIED",
+		1: "LOCK_STATE_LOCKED",
+		2: "LOCK_STATE_UNLOCKED",
+	}
+	LockState_value = map[string]int32{
+		"LOCK_STATE_UNSPECIFIED": 0,
+		"LOCK_STATE_LOCKED":      1,
+		"LOCK_STATE_UNLOCKED":    2,
+	}
+)
+
+func (x LockState) Enum() *LockState {
+	p := new(LockState)
+	*p = x
+	return p
+}
+
+func (x LockState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LockState) Descriptor() protoreflect.EnumDescriptor {
+	return file_rental_management_proto_enumTypes[0].Descriptor()
+}
+
+func (LockState) Type() protoreflect.EnumType {
+	return &file_rental_management_proto_enumTypes[0]
+}
+
+func (x LockState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LockState.Descriptor instead.
+func (LockState) EnumDescriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{0}
+}
+
+type EngineState int32
+
+const (
+	EngineState_ENGINE_STATE_UNSPECIFIED EngineState = 0
+	EngineState_ENGINE_STATE_OFF         EngineState = 1
+	EngineState_ENGINE_STATE_ON          EngineState = 2
+)
+
+// Enum value maps for EngineState.
+var (
+	EngineState_name = map[int32]string{
+		0: "ENGINE_STATE_UNSPECIFIED",
+		1: "ENGINE_STATE_OFF",
+		2: "ENGINE_STATE_ON",
+	}
+	EngineState_value = map[string]int32{
+		"ENGINE_STATE_UNSPECIFIED": 0,
+		"ENGINE_STATE_OFF":         1,
+		"ENGINE_STATE_ON":          2,
+	}
+)
+
+func (x EngineState) Enum() *EngineState {
+	p := new(EngineState)
+	*p = x
+	return p
+}
+
+func (x EngineState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EngineState) Descriptor() protoreflect.EnumDescriptor {
+	return file_rental_management_proto_enumTypes[1].Descriptor()
+}
+
+func (EngineState) Type() protoreflect.EnumType {
+	return &file_rental_management_proto_enumTypes[1]
+}
+
+func (x EngineState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EngineState.Descriptor instead.
+func (EngineState) EnumDescriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{1}
+}
+
+type RentalState int32
+
+const (
+	RentalState_RENTAL_STATE_UNSPECIFIED RentalState = 0
+	RentalState_RENTAL_STATE_ACTIVE      RentalState = 1
+	RentalState_RENTAL_STATE_UPCOMING    RentalState = 2
+	RentalState_RENTAL_STATE_EXPIRED     RentalState = 3
+	RentalState_RENTAL_STATE_CANCELLED   RentalState = 4
+)
+
+// Enum value maps for RentalState.
+var (
+	RentalState_name = map[int32]string{
+		0: "RENTAL_STATE_UNSPECIFIED",
+		1: "RENTAL_STATE_ACTIVE",
+		2: "RENTAL_STATE_UPCOMING",
+		3: "RENTAL_STATE_EXPIRED",
+		4: "RENTAL_STATE_CANCELLED",
+	}
+	RentalState_value = map[string]int32{
+		"RENTAL_STATE_UNSPECIFIED": 0,
+		"RENTAL_STATE_ACTIVE":      1,
+		"RENTAL_STATE_UPCOMING":    2,
+		"RENTAL_STATE_EXPIRED":     3,
+		"RENTAL_STATE_CANCELLED":   4,
+	}
+)
+
+func (x RentalState) Enum() *RentalState {
+	p := new(RentalState)
+	*p = x
+	return p
+}
+
+func (x RentalState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RentalState) Descriptor() protoreflect.EnumDescriptor {
+	return file_rental_management_proto_enumTypes[2].Descriptor()
+}
+
+func (RentalState) Type() protoreflect.EnumType {
+	return &file_rental_management_proto_enumTypes[2]
+}
+
+func (x RentalState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RentalState.Descriptor instead.
+func (RentalState) EnumDescriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{2}
+}
+
+type Weekday int32
+
+const (
+	Weekday_WEEKDAY_UNSPECIFIED Weekday = 0
+	Weekday_WEEKDAY_MONDAY      Weekday = 1
+	Weekday_WEEKDAY_TUESDAY     Weekday = 2
+	Weekday_WEEKDAY_WEDNESDAY   Weekday = 3
+	Weekday_WEEKDAY_THURSDAY    Weekday = 4
+	Weekday_WEEKDAY_FRIDAY      Weekday = 5
+	Weekday_WEEKDAY_SATURDAY    Weekday = 6
+	Weekday_WEEKDAY_SUNDAY      Weekday = 7
+)
+
+// Enum value maps for Weekday.
+var (
+	Weekday_name = map[int32]string{
+		0: "WEEKDAY_UNSPECIFIED",
+		1: "WEEKDAY_MONDAY",
+		2: "WEEKDAY_TUESDAY",
+		3: "WEEKDAY_WEDNESDAY",
+		4: "WEEKDAY_THURSDAY",
+		5: "WEEKDAY_FRIDAY",
+		6: "WEEKDAY_SATURDAY",
+		7: "WEEKDAY_SUNDAY",
+	}
+	Weekday_value = map[string]int32{
+		"WEEKDAY_UNSPECIFIED": 0,
+		"WEEKDAY_MONDAY":      1,
+		"WEEKDAY_TUESDAY":     2,
+		"WEEKDAY_WEDNESDAY":   3,
+		"WEEKDAY_THURSDAY":    4,
+		"WEEKDAY_FRIDAY":      5,
+		"WEEKDAY_SATURDAY":    6,
+		"WEEKDAY_SUNDAY":      7,
+	}
+)
+
+func (x Weekday) Enum() *Weekday {
+	p := new(Weekday)
+	*p = x
+	return p
+}
+
+func (x Weekday) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Weekday) Descriptor() protoreflect.EnumDescriptor {
+	return file_rental_management_proto_enumTypes[3].Descriptor()
+}
+
+func (Weekday) Type() protoreflect.EnumType {
+	return &file_rental_management_proto_enumTypes[3]
+}
+
+func (x Weekday) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Weekday.Descriptor instead.
+func (Weekday) EnumDescriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{3}
+}
+
+type TimePeriod struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+}
+
+func (x *TimePeriod) Reset() {
+	*x = TimePeriod{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimePeriod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimePeriod) ProtoMessage() {}
+
+func (x *TimePeriod) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimePeriod.ProtoReflect.Descriptor instead.
+func (*TimePeriod) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TimePeriod) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *TimePeriod) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+type CarAvailable struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vin           string `protobuf:"bytes,1,opt,name=vin,proto3" json:"vin,omitempty"`
+	Brand         string `protobuf:"bytes,2,opt,name=brand,proto3" json:"brand,omitempty"`
+	Model         string `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	NumberOfSeats int32  `protobuf:"varint,4,opt,name=number_of_seats,json=numberOfSeats,proto3" json:"number_of_seats,omitempty"`
+}
+
+func (x *CarAvailable) Reset() {
+	*x = CarAvailable{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CarAvailable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CarAvailable) ProtoMessage() {}
+
+func (x *CarAvailable) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CarAvailable.ProtoReflect.Descriptor instead.
+func (*CarAvailable) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CarAvailable) GetVin() string {
+	if x != nil {
+		return x.Vin
+	}
+	return ""
+}
+
+func (x *CarAvailable) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *CarAvailable) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *CarAvailable) GetNumberOfSeats() int32 {
+	if x != nil {
+		return x.NumberOfSeats
+	}
+	return 0
+}
+
+type Position struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Latitude  float32 `protobuf:"fixed32,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float32 `protobuf:"fixed32,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+}
+
+func (x *Position) Reset() {
+	*x = Position{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Position) ProtoMessage() {}
+
+func (x *Position) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Position) GetLatitude() float32 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Position) GetLongitude() float32 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+type DynamicData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DoorsLockState      LockState   `protobuf:"varint,1,opt,name=doors_lock_state,json=doorsLockState,proto3,enum=rentalmanagement.v1.LockState" json:"doors_lock_state,omitempty"`
+	EngineState         EngineState `protobuf:"varint,2,opt,name=engine_state,json=engineState,proto3,enum=rentalmanagement.v1.EngineState" json:"engine_state,omitempty"`
+	FuelLevelPercentage int32       `protobuf:"varint,3,opt,name=fuel_level_percentage,json=fuelLevelPercentage,proto3" json:"fuel_level_percentage,omitempty"`
+	Position            *Position   `protobuf:"bytes,4,opt,name=position,proto3" json:"position,omitempty"`
+	TrunkLockState      LockState   `protobuf:"varint,5,opt,name=trunk_lock_state,json=trunkLockState,proto3,enum=rentalmanagement.v1.LockState" json:"trunk_lock_state,omitempty"`
+}
+
+func (x *DynamicData) Reset() {
+	*x = DynamicData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DynamicData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DynamicData) ProtoMessage() {}
+
+func (x *DynamicData) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DynamicData.ProtoReflect.Descriptor instead.
+func (*DynamicData) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DynamicData) GetDoorsLockState() LockState {
+	if x != nil {
+		return x.DoorsLockState
+	}
+	return LockState_LOCK_STATE_UNSPECIFIED
+}
+
+func (x *DynamicData) GetEngineState() EngineState {
+	if x != nil {
+		return x.EngineState
+	}
+	return EngineState_ENGINE_STATE_UNSPECIFIED
+}
+
+func (x *DynamicData) GetFuelLevelPercentage() int32 {
+	if x != nil {
+		return x.FuelLevelPercentage
+	}
+	return 0
+}
+
+func (x *DynamicData) GetPosition() *Position {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *DynamicData) GetTrunkLockState() LockState {
+	if x != nil {
+		return x.TrunkLockState
+	}
+	return LockState_LOCK_STATE_UNSPECIFIED
+}
+
+// Car is a car. The technical specification is only offered by the REST API.
+type Car struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vin   string `protobuf:"bytes,1,opt,name=vin,proto3" json:"vin,omitempty"`
+	Brand string `protobuf:"bytes,2,opt,name=brand,proto3" json:"brand,omitempty"`
+	Model string `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	// dynamic_data is only set if the state of the car has been requested.
+	DynamicData *DynamicData `protobuf:"bytes,4,opt,name=dynamic_data,json=dynamicData,proto3" json:"dynamic_data,omitempty"`
+}
+
+func (x *Car) Reset() {
+	*x = Car{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Car) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Car) ProtoMessage() {}
+
+func (x *Car) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Car.ProtoReflect.Descriptor instead.
+func (*Car) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Car) GetVin() string {
+	if x != nil {
+		return x.Vin
+	}
+	return ""
+}
+
+func (x *Car) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *Car) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *Car) GetDynamicData() *DynamicData {
+	if x != nil {
+		return x.DynamicData
+	}
+	return nil
+}
+
+// Recurrence is a weekly recurring time of day range.
+type Recurrence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DaysOfWeek []Weekday `protobuf:"varint,1,rep,packed,name=days_of_week,json=daysOfWeek,proto3,enum=rentalmanagement.v1.Weekday" json:"days_of_week,omitempty"`
+	// start_time is the beginning of the time of day range (HH:MM).
+	StartTime string `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// end_time is the end of the time of day range (HH:MM), 24:00 denotes the end of the day.
+	EndTime string `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// time_zone is the IANA time zone the time of day range refers to.
+	TimeZone string `protobuf:"bytes,4,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+}
+
+func (x *Recurrence) Reset() {
+	*x = Recurrence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Recurrence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recurrence) ProtoMessage() {}
+
+func (x *Recurrence) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recurrence.ProtoReflect.Descriptor instead.
+func (*Recurrence) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Recurrence) GetDaysOfWeek() []Weekday {
+	if x != nil {
+		return x.DaysOfWeek
+	}
+	return nil
+}
+
+func (x *Recurrence) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *Recurrence) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+func (x *Recurrence) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type TrunkAccess struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token          string      `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ValidityPeriod *TimePeriod `protobuf:"bytes,2,opt,name=validity_period,json=validityPeriod,proto3" json:"validity_period,omitempty"`
+	// recurrence restricts the token to its occurrences within the validity period, if set.
+	Recurrence *Recurrence `protobuf:"bytes,3,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+}
+
+func (x *TrunkAccess) Reset() {
+	*x = TrunkAccess{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrunkAccess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrunkAccess) ProtoMessage() {}
+
+func (x *TrunkAccess) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrunkAccess.ProtoReflect.Descriptor instead.
+func (*TrunkAccess) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TrunkAccess) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *TrunkAccess) GetValidityPeriod() *TimePeriod {
+	if x != nil {
+		return x.ValidityPeriod
+	}
+	return nil
+}
+
+func (x *TrunkAccess) GetRecurrence() *Recurrence {
+	if x != nil {
+		return x.Recurrence
+	}
+	return nil
+}
+
+type AutoRelock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// timeout_seconds is the number of seconds after which an unlocked trunk is locked again, 0 disables auto relock.
+	TimeoutSeconds int32 `protobuf:"varint,1,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+}
+
+func (x *AutoRelock) Reset() {
+	*x = AutoRelock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AutoRelock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoRelock) ProtoMessage() {}
+
+func (x *AutoRelock) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoRelock.ProtoReflect.Descriptor instead.
+func (*AutoRelock) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AutoRelock) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+// Rental is a rental of a car. Which fields are set depends on the operation, like in the REST API.
+type Rental struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	State        RentalState  `protobuf:"varint,2,opt,name=state,proto3,enum=rentalmanagement.v1.RentalState" json:"state,omitempty"`
+	Car          *Car         `protobuf:"bytes,3,opt,name=car,proto3" json:"car,omitempty"`
+	CustomerId   string       `protobuf:"bytes,4,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	RentalPeriod *TimePeriod  `protobuf:"bytes,5,opt,name=rental_period,json=rentalPeriod,proto3" json:"rental_period,omitempty"`
+	Token        *TrunkAccess `protobuf:"bytes,6,opt,name=token,proto3" json:"token,omitempty"`
+	AutoRelock   *AutoRelock  `protobuf:"bytes,7,opt,name=auto_relock,json=autoRelock,proto3" json:"auto_relock,omitempty"`
+}
+
+func (x *Rental) Reset() {
+	*x = Rental{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Rental) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rental) ProtoMessage() {}
+
+func (x *Rental) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rental.ProtoReflect.Descriptor instead.
+func (*Rental) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Rental) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Rental) GetState() RentalState {
+	if x != nil {
+		return x.State
+	}
+	return RentalState_RENTAL_STATE_UNSPECIFIED
+}
+
+func (x *Rental) GetCar() *Car {
+	if x != nil {
+		return x.Car
+	}
+	return nil
+}
+
+func (x *Rental) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *Rental) GetRentalPeriod() *TimePeriod {
+	if x != nil {
+		return x.RentalPeriod
+	}
+	return nil
+}
+
+func (x *Rental) GetToken() *TrunkAccess {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *Rental) GetAutoRelock() *AutoRelock {
+	if x != nil {
+		return x.AutoRelock
+	}
+	return nil
+}
+
+type GetAvailableCarsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TimePeriod *TimePeriod `protobuf:"bytes,1,opt,name=time_period,json=timePeriod,proto3" json:"time_period,omitempty"`
+}
+
+func (x *GetAvailableCarsRequest) Reset() {
+	*x = GetAvailableCarsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAvailableCarsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailableCarsRequest) ProtoMessage() {}
+
+func (x *GetAvailableCarsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailableCarsRequest.ProtoReflect.Descriptor instead.
+func (*GetAvailableCarsRequest) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetAvailableCarsRequest) GetTimePeriod() *TimePeriod {
+	if x != nil {
+		return x.TimePeriod
+	}
+	return nil
+}
+
+type GetAvailableCarsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cars []*CarAvailable `protobuf:"bytes,1,rep,name=cars,proto3" json:"cars,omitempty"`
+}
+
+func (x *GetAvailableCarsResponse) Reset() {
+	*x = GetAvailableCarsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAvailableCarsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailableCarsResponse) ProtoMessage() {}
+
+func (x *GetAvailableCarsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailableCarsResponse.ProtoReflect.Descriptor instead.
+func (*GetAvailableCarsResponse) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetAvailableCarsResponse) GetCars() []*CarAvailable {
+	if x != nil {
+		return x.Cars
+	}
+	return nil
+}
+
+type GetCarRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vin string `protobuf:"bytes,1,opt,name=vin,proto3" json:"vin,omitempty"`
+}
+
+func (x *GetCarRequest) Reset() {
+	*x = GetCarRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCarRequest) ProtoMessage() {}
+
+func (x *GetCarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCarRequest.ProtoReflect.Descriptor instead.
+func (*GetCarRequest) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetCarRequest) GetVin() string {
+	if x != nil {
+		return x.Vin
+	}
+	return ""
+}
+
+type CreateRentalRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vin          string      `protobuf:"bytes,1,opt,name=vin,proto3" json:"vin,omitempty"`
+	CustomerId   string      `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	RentalPeriod *TimePeriod `protobuf:"bytes,3,opt,name=rental_period,json=rentalPeriod,proto3" json:"rental_period,omitempty"`
+}
+
+func (x *CreateRentalRequest) Reset() {
+	*x = CreateRentalRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateRentalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRentalRequest) ProtoMessage() {}
+
+func (x *CreateRentalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRentalRequest.ProtoReflect.Descriptor instead.
+func (*CreateRentalRequest) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateRentalRequest) GetVin() string {
+	if x != nil {
+		return x.Vin
+	}
+	return ""
+}
+
+func (x *CreateRentalRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *CreateRentalRequest) GetRentalPeriod() *TimePeriod {
+	if x != nil {
+		return x.RentalPeriod
+	}
+	return nil
+}
+
+type GetNextRentalRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vin string `protobuf:"bytes,1,opt,name=vin,proto3" json:"vin,omitempty"`
+}
+
+func (x *GetNextRentalRequest) Reset() {
+	*x = GetNextRentalRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNextRentalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNextRentalRequest) ProtoMessage() {}
+
+func (x *GetNextRentalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNextRentalRequest.ProtoReflect.Descriptor instead.
+func (*GetNextRentalRequest) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetNextRentalRequest) GetVin() string {
+	if x != nil {
+		return x.Vin
+	}
+	return ""
+}
+
+type GetNextRentalResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// rental is not set if the car has neither an active nor an upcoming rental.
+	Rental *Rental `protobuf:"bytes,1,opt,name=rental,proto3" json:"rental,omitempty"`
+}
+
+func (x *GetNextRentalResponse) Reset() {
+	*x = GetNextRentalResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNextRentalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNextRentalResponse) ProtoMessage() {}
+
+func (x *GetNextRentalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNextRentalResponse.ProtoReflect.Descriptor instead.
+func (*GetNextRentalResponse) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetNextRentalResponse) GetRental() *Rental {
+	if x != nil {
+		return x.Rental
+	}
+	return nil
+}
+
+type GetOverviewRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CustomerId string `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+}
+
+func (x *GetOverviewRequest) Reset() {
+	*x = GetOverviewRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOverviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOverviewRequest) ProtoMessage() {}
+
+func (x *GetOverviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOverviewRequest.ProtoReflect.Descriptor instead.
+func (*GetOverviewRequest) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetOverviewRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type GetOverviewResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rentals []*Rental `protobuf:"bytes,1,rep,name=rentals,proto3" json:"rentals,omitempty"`
+}
+
+func (x *GetOverviewResponse) Reset() {
+	*x = GetOverviewResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOverviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOverviewResponse) ProtoMessage() {}
+
+func (x *GetOverviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOverviewResponse.ProtoReflect.Descriptor instead.
+func (*GetOverviewResponse) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetOverviewResponse) GetRentals() []*Rental {
+	if x != nil {
+		return x.Rentals
+	}
+	return nil
+}
+
+type GetRentalStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RentalId string `protobuf:"bytes,1,opt,name=rental_id,json=rentalId,proto3" json:"rental_id,omitempty"`
+}
+
+func (x *GetRentalStatusRequest) Reset() {
+	*x = GetRentalStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRentalStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRentalStatusRequest) ProtoMessage() {}
+
+func (x *GetRentalStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRentalStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetRentalStatusRequest) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetRentalStatusRequest) GetRentalId() string {
+	if x != nil {
+		return x.RentalId
+	}
+	return ""
+}
+
+type SetAutoRelockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RentalId   string      `protobuf:"bytes,1,opt,name=rental_id,json=rentalId,proto3" json:"rental_id,omitempty"`
+	AutoRelock *AutoRelock `protobuf:"bytes,2,opt,name=auto_relock,json=autoRelock,proto3" json:"auto_relock,omitempty"`
+}
+
+func (x *SetAutoRelockRequest) Reset() {
+	*x = SetAutoRelockRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetAutoRelockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAutoRelockRequest) ProtoMessage() {}
+
+func (x *SetAutoRelockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAutoRelockRequest.ProtoReflect.Descriptor instead.
+func (*SetAutoRelockRequest) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetAutoRelockRequest) GetRentalId() string {
+	if x != nil {
+		return x.RentalId
+	}
+	return ""
+}
+
+func (x *SetAutoRelockRequest) GetAutoRelock() *AutoRelock {
+	if x != nil {
+		return x.AutoRelock
+	}
+	return nil
+}
+
+type CancelRentalRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RentalId string `protobuf:"bytes,1,opt,name=rental_id,json=rentalId,proto3" json:"rental_id,omitempty"`
+}
+
+func (x *CancelRentalRequest) Reset() {
+	*x = CancelRentalRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelRentalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelRentalRequest) ProtoMessage() {}
+
+func (x *CancelRentalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelRentalRequest.ProtoReflect.Descriptor instead.
+func (*CancelRentalRequest) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CancelRentalRequest) GetRentalId() string {
+	if x != nil {
+		return x.RentalId
+	}
+	return ""
+}
+
+type GrantTrunkAccessRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RentalId       string      `protobuf:"bytes,1,opt,name=rental_id,json=rentalId,proto3" json:"rental_id,omitempty"`
+	ValidityPeriod *TimePeriod `protobuf:"bytes,2,opt,name=validity_period,json=validityPeriod,proto3" json:"validity_period,omitempty"`
+	Recurrence     *Recurrence `protobuf:"bytes,3,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+}
+
+func (x *GrantTrunkAccessRequest) Reset() {
+	*x = GrantTrunkAccessRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GrantTrunkAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantTrunkAccessRequest) ProtoMessage() {}
+
+func (x *GrantTrunkAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantTrunkAccessRequest.ProtoReflect.Descriptor instead.
+func (*GrantTrunkAccessRequest) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GrantTrunkAccessRequest) GetRentalId() string {
+	if x != nil {
+		return x.RentalId
+	}
+	return ""
+}
+
+func (x *GrantTrunkAccessRequest) GetValidityPeriod() *TimePeriod {
+	if x != nil {
+		return x.ValidityPeriod
+	}
+	return nil
+}
+
+func (x *GrantTrunkAccessRequest) GetRecurrence() *Recurrence {
+	if x != nil {
+		return x.Recurrence
+	}
+	return nil
+}
+
+type GetLockStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vin              string `protobuf:"bytes,1,opt,name=vin,proto3" json:"vin,omitempty"`
+	TrunkAccessToken string `protobuf:"bytes,2,opt,name=trunk_access_token,json=trunkAccessToken,proto3" json:"trunk_access_token,omitempty"`
+}
+
+func (x *GetLockStateRequest) Reset() {
+	*x = GetLockStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLockStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLockStateRequest) ProtoMessage() {}
+
+func (x *GetLockStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLockStateRequest.ProtoReflect.Descriptor instead.
+func (*GetLockStateRequest) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetLockStateRequest) GetVin() string {
+	if x != nil {
+		return x.Vin
+	}
+	return ""
+}
+
+func (x *GetLockStateRequest) GetTrunkAccessToken() string {
+	if x != nil {
+		return x.TrunkAccessToken
+	}
+	return ""
+}
+
+type GetLockStateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TrunkLockState LockState `protobuf:"varint,1,opt,name=trunk_lock_state,json=trunkLockState,proto3,enum=rentalmanagement.v1.LockState" json:"trunk_lock_state,omitempty"`
+}
+
+func (x *GetLockStateResponse) Reset() {
+	*x = GetLockStateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLockStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLockStateResponse) ProtoMessage() {}
+
+func (x *GetLockStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLockStateResponse.ProtoReflect.Descriptor instead.
+func (*GetLockStateResponse) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetLockStateResponse) GetTrunkLockState() LockState {
+	if x != nil {
+		return x.TrunkLockState
+	}
+	return LockState_LOCK_STATE_UNSPECIFIED
+}
+
+type SetLockStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vin            string    `protobuf:"bytes,1,opt,name=vin,proto3" json:"vin,omitempty"`
+	TrunkLockState LockState `protobuf:"varint,2,opt,name=trunk_lock_state,json=trunkLockState,proto3,enum=rentalmanagement.v1.LockState" json:"trunk_lock_state,omitempty"`
+	// actor is the customer of the active rental of the car or the holder of a trunk access token.
+	//
+	// Types that are assignable to Actor:
+	//	*SetLockStateRequest_CustomerId
+	//	*SetLockStateRequest_TrunkAccessToken
+	Actor isSetLockStateRequest_Actor `protobuf_oneof:"actor"`
+}
+
+func (x *SetLockStateRequest) Reset() {
+	*x = SetLockStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rental_management_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetLockStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLockStateRequest) ProtoMessage() {}
+
+func (x *SetLockStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_management_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLockStateRequest.ProtoReflect.Descriptor instead.
+func (*SetLockStateRequest) Descriptor() ([]byte, []int) {
+	return file_rental_management_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SetLockStateRequest) GetVin() string {
+	if x != nil {
+		return x.Vin
+	}
+	return ""
+}
+
+func (x *SetLockStateRequest) GetTrunkLockState() LockState {
+	if x != nil {
+		return x.TrunkLockState
+	}
+	return LockState_LOCK_STATE_UNSPECIFIED
+}
+
+func (m *SetLockStateRequest) GetActor() isSetLockStateRequest_Actor {
+	if m != nil {
+		return m.Actor
+	}
+	return nil
+}
+
+func (x *SetLockStateRequest) GetCustomerId() string {
+	if x, ok := x.GetActor().(*SetLockStateRequest_CustomerId); ok {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *SetLockStateRequest) GetTrunkAccessToken() string {
+	if x, ok := x.GetActor().(*SetLockStateRequest_TrunkAccessToken); ok {
+		return x.TrunkAccessToken
+	}
+	return ""
+}
+
+type isSetLockStateRequest_Actor interface {
+	isSetLockStateRequest_Actor()
+}
+
+type SetLockStateRequest_CustomerId struct {
+	CustomerId string `protobuf:"bytes,3,opt,name=customer_id,json=customerId,proto3,oneof"`
+}
+
+type SetLockStateRequest_TrunkAccessToken struct {
+	TrunkAccessToken string `protobuf:"bytes,4,opt,name=trunk_access_token,json=trunkAccessToken,proto3,oneof"`
+}
+
+func (*SetLockStateRequest_CustomerId) isSetLockStateRequest_Actor() {}
+
+func (*SetLockStateRequest_TrunkAccessToken) isSetLockStateRequest_Actor() {}
+
+var File_rental_management_proto protoreflect.FileDescriptor
+
+var file_rental_management_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x13, 0x72, 0x65, 0x6e, 0x74, 0x61,
+	0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x1a, 0x1b,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x7e, 0x0a, 0x0a,
+	0x54, 0x69, 0x6d, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x44, 0x61, 0x74, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x61, 0x74,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x22, 0x74, 0x0a, 0x0c,
+	0x43, 0x61, 0x72, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x76, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x76, 0x69, 0x6e, 0x12, 0x14,
+	0x0a, 0x05, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62,
+	0x72, 0x61, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x73, 0x65, 0x61, 0x74, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0d, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x53, 0x65, 0x61,
+	0x74, 0x73, 0x22, 0x44, 0x0a, 0x08, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a,
+	0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02,
+	0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f,
+	0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x6c,
+	0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x22, 0xd5, 0x02, 0x0a, 0x0b, 0x44, 0x79, 0x6e,
+	0x61, 0x6d, 0x69, 0x63, 0x44, 0x61, 0x74, 0x61, 0x12, 0x48, 0x0a, 0x10, 0x64, 0x6f, 0x6f, 0x72,
+	0x73, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x0e, 0x64, 0x6f, 0x6f, 0x72, 0x73, 0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x43, 0x0a, 0x0c, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61,
+	0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x6e, 0x67, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0b, 0x65, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x32, 0x0a, 0x15, 0x66, 0x75, 0x65, 0x6c, 0x5f,
+	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x66, 0x75, 0x65, 0x6c, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x48, 0x0a, 0x10, 0x74, 0x72, 0x75, 0x6e, 0x6b, 0x5f,
+	0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1e, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x0e, 0x74, 0x72, 0x75, 0x6e, 0x6b, 0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x22, 0x88, 0x01, 0x0a, 0x03, 0x43, 0x61, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x69, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x76, 0x69, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x72,
+	0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x72, 0x61, 0x6e, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x43, 0x0a, 0x0c, 0x64, 0x79, 0x6e, 0x61, 0x6d, 0x69,
+	0x63, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x72,
+	0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0b,
+	0x64, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x44, 0x61, 0x74, 0x61, 0x22, 0xa3, 0x01, 0x0a, 0x0a,
+	0x52, 0x65, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x3e, 0x0a, 0x0c, 0x64, 0x61,
+	0x79, 0x73, 0x5f, 0x6f, 0x66, 0x5f, 0x77, 0x65, 0x65, 0x6b, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e,
+	0x32, 0x1c, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x52, 0x0a,
+	0x64, 0x61, 0x79, 0x73, 0x4f, 0x66, 0x57, 0x65, 0x65, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x7a, 0x6f, 0x6e,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x5a, 0x6f, 0x6e,
+	0x65, 0x22, 0xae, 0x01, 0x0a, 0x0b, 0x54, 0x72, 0x75, 0x6e, 0x6b, 0x41, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x48, 0x0a, 0x0f, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x69, 0x74, 0x79, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f,
+	0x64, 0x52, 0x0e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x65, 0x72, 0x69, 0x6f,
+	0x64, 0x12, 0x3f, 0x0a, 0x0a, 0x72, 0x65, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x22, 0x35, 0x0a, 0x0a, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x6c, 0x6f, 0x63, 0x6b,
+	0x12, 0x27, 0x0a, 0x0f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0xdd, 0x02, 0x0a, 0x06, 0x52, 0x65,
+	0x6e, 0x74, 0x61, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x36, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2a, 0x0a, 0x03,
+	0x63, 0x61, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x72, 0x65, 0x6e, 0x74,
+	0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x61, 0x72, 0x52, 0x03, 0x63, 0x61, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x49, 0x64, 0x12, 0x44, 0x0a, 0x0d, 0x72, 0x65, 0x6e,
+	0x74, 0x61, 0x6c, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f,
+	0x64, 0x52, 0x0c, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12,
+	0x36, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x75, 0x6e, 0x6b, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x40, 0x0a, 0x0b, 0x61, 0x75, 0x74, 0x6f, 0x5f,
+	0x72, 0x65, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x72,
+	0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x0a, 0x61,
+	0x75, 0x74, 0x6f, 0x52, 0x65, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x5b, 0x0a, 0x17, 0x47, 0x65, 0x74,
+	0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x61, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x70, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x72, 0x65, 0x6e, 0x74,
+	0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65,
+	0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x22, 0x51, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x41, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x61, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x35, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61,
+	0x62, 0x6c, 0x65, 0x52, 0x04, 0x63, 0x61, 0x72, 0x73, 0x22, 0x21, 0x0a, 0x0d, 0x47, 0x65, 0x74,
+	0x43, 0x61, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x69,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x76, 0x69, 0x6e, 0x22, 0x8e, 0x01, 0x0a,
+	0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x76, 0x69, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x65, 0x72, 0x49, 0x64, 0x12, 0x44, 0x0a, 0x0d, 0x72, 0x65, 0x6e, 0x74, 0x61,
+	0x6c, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x52,
+	0x0c, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x22, 0x28, 0x0a,
+	0x14, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x76, 0x69, 0x6e, 0x22, 0x4c, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x4e, 0x65,
+	0x78, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x33, 0x0a, 0x06, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x06, 0x72,
+	0x65, 0x6e, 0x74, 0x61, 0x6c, 0x22, 0x35, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72,
+	0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x63,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x49, 0x64, 0x22, 0x4c, 0x0a, 0x13,
+	0x47, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x07, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6e, 0x74, 0x61,
+	0x6c, 0x52, 0x07, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x22, 0x35, 0x0a, 0x16, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x49,
+	0x64, 0x22, 0x75, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x6c, 0x6f,
+	0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x6e,
+	0x74, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65,
+	0x6e, 0x74, 0x61, 0x6c, 0x49, 0x64, 0x12, 0x40, 0x0a, 0x0b, 0x61, 0x75, 0x74, 0x6f, 0x5f, 0x72,
+	0x65, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x72, 0x65,
+	0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x0a, 0x61, 0x75,
+	0x74, 0x6f, 0x52, 0x65, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x32, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x49, 0x64, 0x22, 0xc1, 0x01, 0x0a,
+	0x17, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x54, 0x72, 0x75, 0x6e, 0x6b, 0x41, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x6e, 0x74,
+	0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x6e,
+	0x74, 0x61, 0x6c, 0x49, 0x64, 0x12, 0x48, 0x0a, 0x0f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x69, 0x74,
+	0x79, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x52,
+	0x0e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12,
+	0x3f, 0x0a, 0x0a, 0x72, 0x65, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x22, 0x55, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x69, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x76, 0x69, 0x6e, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x72, 0x75,
+	0x6e, 0x6b, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x72, 0x75, 0x6e, 0x6b, 0x41, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x60, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4c, 0x6f,
+	0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x48, 0x0a, 0x10, 0x74, 0x72, 0x75, 0x6e, 0x6b, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x72, 0x65, 0x6e, 0x74,
+	0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0e, 0x74, 0x72, 0x75, 0x6e, 0x6b,
+	0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0xcd, 0x01, 0x0a, 0x13, 0x53, 0x65,
+	0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x76, 0x69, 0x6e, 0x12, 0x48, 0x0a, 0x10, 0x74, 0x72, 0x75, 0x6e, 0x6b, 0x5f, 0x6c, 0x6f, 0x63,
+	0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e,
+	0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0e, 0x74,
+	0x72, 0x75, 0x6e, 0x6b, 0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x21, 0x0a,
+	0x0b, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x2e, 0x0a, 0x12, 0x74, 0x72, 0x75, 0x6e, 0x6b, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x10,
+	0x74, 0x72, 0x75, 0x6e, 0x6b, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x42, 0x07, 0x0a, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2a, 0x57, 0x0a, 0x09, 0x4c, 0x6f, 0x63,
+	0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x4c, 0x4f, 0x43, 0x4b, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x4c, 0x4f, 0x43, 0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45,
+	0x5f, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x4c, 0x4f, 0x43,
+	0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44,
+	0x10, 0x02, 0x2a, 0x56, 0x0a, 0x0b, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x14, 0x0a, 0x10, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f,
+	0x4f, 0x46, 0x46, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f,
+	0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x4f, 0x4e, 0x10, 0x02, 0x2a, 0x95, 0x01, 0x0a, 0x0b, 0x52,
+	0x65, 0x6e, 0x74, 0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x52, 0x45,
+	0x4e, 0x54, 0x41, 0x4c, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x52, 0x45, 0x4e, 0x54,
+	0x41, 0x4c, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10,
+	0x01, 0x12, 0x19, 0x0a, 0x15, 0x52, 0x45, 0x4e, 0x54, 0x41, 0x4c, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x45, 0x5f, 0x55, 0x50, 0x43, 0x4f, 0x4d, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14,
+	0x52, 0x45, 0x4e, 0x54, 0x41, 0x4c, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x45, 0x58, 0x50,
+	0x49, 0x52, 0x45, 0x44, 0x10, 0x03, 0x12, 0x1a, 0x0a, 0x16, 0x52, 0x45, 0x4e, 0x54, 0x41, 0x4c,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x4c, 0x45, 0x44,
+	0x10, 0x04, 0x2a, 0xb6, 0x01, 0x0a, 0x07, 0x57, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x12, 0x17,
+	0x0a, 0x13, 0x57, 0x45, 0x45, 0x4b, 0x44, 0x41, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x57, 0x45, 0x45, 0x4b, 0x44,
+	0x41, 0x59, 0x5f, 0x4d, 0x4f, 0x4e, 0x44, 0x41, 0x59, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x57,
+	0x45, 0x45, 0x4b, 0x44, 0x41, 0x59, 0x5f, 0x54, 0x55, 0x45, 0x53, 0x44, 0x41, 0x59, 0x10, 0x02,
+	0x12, 0x15, 0x0a, 0x11, 0x57, 0x45, 0x45, 0x4b, 0x44, 0x41, 0x59, 0x5f, 0x57, 0x45, 0x44, 0x4e,
+	0x45, 0x53, 0x44, 0x41, 0x59, 0x10, 0x03, 0x12, 0x14, 0x0a, 0x10, 0x57, 0x45, 0x45, 0x4b, 0x44,
+	0x41, 0x59, 0x5f, 0x54, 0x48, 0x55, 0x52, 0x53, 0x44, 0x41, 0x59, 0x10, 0x04, 0x12, 0x12, 0x0a,
+	0x0e, 0x57, 0x45, 0x45, 0x4b, 0x44, 0x41, 0x59, 0x5f, 0x46, 0x52, 0x49, 0x44, 0x41, 0x59, 0x10,
+	0x05, 0x12, 0x14, 0x0a, 0x10, 0x57, 0x45, 0x45, 0x4b, 0x44, 0x41, 0x59, 0x5f, 0x53, 0x41, 0x54,
+	0x55, 0x52, 0x44, 0x41, 0x59, 0x10, 0x06, 0x12, 0x12, 0x0a, 0x0e, 0x57, 0x45, 0x45, 0x4b, 0x44,
+	0x41, 0x59, 0x5f, 0x53, 0x55, 0x4e, 0x44, 0x41, 0x59, 0x10, 0x07, 0x32, 0x85, 0x08, 0x0a, 0x10,
+	0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x6f, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65,
+	0x43, 0x61, 0x72, 0x73, 0x12, 0x2c, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x76,
+	0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x61, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x76, 0x61, 0x69,
+	0x6c, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x61, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x46, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x43, 0x61, 0x72, 0x12, 0x22, 0x2e, 0x72, 0x65,
+	0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x18, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72, 0x12, 0x50, 0x0a, 0x0c, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x12, 0x28, 0x2e, 0x72, 0x65, 0x6e, 0x74,
+	0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x66, 0x0a, 0x0d, 0x47,
+	0x65, 0x74, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x12, 0x29, 0x2e, 0x72,
+	0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69,
+	0x65, 0x77, 0x12, 0x27, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72,
+	0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x72, 0x65,
+	0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5b, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6e, 0x74,
+	0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2b, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61,
+	0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6e, 0x74,
+	0x61, 0x6c, 0x12, 0x52, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x6c,
+	0x6f, 0x63, 0x6b, 0x12, 0x29, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x41, 0x75, 0x74,
+	0x6f, 0x52, 0x65, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x50, 0x0a, 0x0c, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x12, 0x28, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x62, 0x0a, 0x10, 0x47, 0x72, 0x61, 0x6e,
+	0x74, 0x54, 0x72, 0x75, 0x6e, 0x6b, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x2c, 0x2e, 0x72,
+	0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x54, 0x72, 0x75, 0x6e, 0x6b, 0x41, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x72, 0x65, 0x6e,
+	0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x72, 0x75, 0x6e, 0x6b, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x63, 0x0a, 0x0c,
+	0x47, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x28, 0x2e, 0x72,
+	0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x50, 0x0a, 0x0c, 0x53, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x28, 0x2e, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x42, 0x1d, 0x5a, 0x1b, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x4d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x61, 0x70, 0x69, 0x2f,
+	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rental_management_proto_rawDescOnce sync.Once
+	file_rental_management_proto_rawDescData = file_rental_management_proto_rawDesc
+)
+
+func file_rental_management_proto_rawDescGZIP() []byte {
+	file_rental_management_proto_rawDescOnce.Do(func() {
+		file_rental_management_proto_rawDescData = protoimpl.X.CompressGZIP(file_rental_management_proto_rawDescData)
+	})
+	return file_rental_management_proto_rawDescData
+}
+
+var file_rental_management_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_rental_management_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_rental_management_proto_goTypes = []interface{}{
+	(LockState)(0),                   // 0: rentalmanagement.v1.LockState
+	(EngineState)(0),                 // 1: rentalmanagement.v1.EngineState
+	(RentalState)(0),                 // 2: rentalmanagement.v1.RentalState
+	(Weekday)(0),                     // 3: rentalmanagement.v1.Weekday
+	(*TimePeriod)(nil),               // 4: rentalmanagement.v1.TimePeriod
+	(*CarAvailable)(nil),             // 5: rentalmanagement.v1.CarAvailable
+	(*Position)(nil),                 // 6: rentalmanagement.v1.Position
+	(*DynamicData)(nil),              // 7: rentalmanagement.v1.DynamicData
+	(*Car)(nil),                      // 8: rentalmanagement.v1.Car
+	(*Recurrence)(nil),               // 9: rentalmanagement.v1.Recurrence
+	(*TrunkAccess)(nil),              // 10: rentalmanagement.v1.TrunkAccess
+	(*AutoRelock)(nil),               // 11: rentalmanagement.v1.AutoRelock
+	(*Rental)(nil),                   // 12: rentalmanagement.v1.Rental
+	(*GetAvailableCarsRequest)(nil),  // 13: rentalmanagement.v1.GetAvailableCarsRequest
+	(*GetAvailableCarsResponse)(nil), // 14: rentalmanagement.v1.GetAvailableCarsResponse
+	(*GetCarRequest)(nil),            // 15: rentalmanagement.v1.GetCarRequest
+	(*CreateRentalRequest)(nil),      // 16: rentalmanagement.v1.CreateRentalRequest
+	(*GetNextRentalRequest)(nil),     // 17: rentalmanagement.v1.GetNextRentalRequest
+	(*GetNextRentalResponse)(nil),    // 18: rentalmanagement.v1.GetNextRentalResponse
+	(*GetOverviewRequest)(nil),       // 19: rentalmanagement.v1.GetOverviewRequest
+	(*GetOverviewResponse)(nil),      // 20: rentalmanagement.v1.GetOverviewResponse
+	(*GetRentalStatusRequest)(nil),   // 21: rentalmanagement.v1.GetRentalStatusRequest
+	(*SetAutoRelockRequest)(nil),     // 22: rentalmanagement.v1.SetAutoRelockRequest
+	(*CancelRentalRequest)(nil),      // 23: rentalmanagement.v1.CancelRentalRequest
+	(*GrantTrunkAccessRequest)(nil),  // 24: rentalmanagement.v1.GrantTrunkAccessRequest
+	(*GetLockStateRequest)(nil),      // 25: rentalmanagement.v1.GetLockStateRequest
+	(*GetLockStateResponse)(nil),     // 26: rentalmanagement.v1.GetLockStateResponse
+	(*SetLockStateRequest)(nil),      // 27: rentalmanagement.v1.SetLockStateRequest
+	(*timestamppb.Timestamp)(nil),    // 28: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),            // 29: google.protobuf.Empty
+}
+var file_rental_management_proto_depIdxs = []int32{
+	28, // 0: rentalmanagement.v1.TimePeriod.start_date:type_name -> google.protobuf.Timestamp
+	28, // 1: rentalmanagement.v1.TimePeriod.end_date:type_name -> google.protobuf.Timestamp
+	0,  // 2: rentalmanagement.v1.DynamicData.doors_lock_state:type_name -> rentalmanagement.v1.LockState
+	1,  // 3: rentalmanagement.v1.DynamicData.engine_state:type_name -> rentalmanagement.v1.EngineState
+	6,  // 4: rentalmanagement.v1.DynamicData.position:type_name -> rentalmanagement.v1.Position
+	0,  // 5: rentalmanagement.v1.DynamicData.trunk_lock_state:type_name -> rentalmanagement.v1.LockState
+	7,  // 6: rentalmanagement.v1.Car.dynamic_data:type_name -> rentalmanagement.v1.DynamicData
+	3,  // 7: rentalmanagement.v1.Recurrence.days_of_week:type_name -> rentalmanagement.v1.Weekday
+	4,  // 8: rentalmanagement.v1.TrunkAccess.validity_period:type_name -> rentalmanagement.v1.TimePeriod
+	9,  // 9: rentalmanagement.v1.TrunkAccess.recurrence:type_name -> rentalmanagement.v1.Recurrence
+	2,  // 10: rentalmanagement.v1.Rental.state:type_name -> rentalmanagement.v1.RentalState
+	8,  // 11: rentalmanagement.v1.Rental.car:type_name -> rentalmanagement.v1.Car
+	4,  // 12: rentalmanagement.v1.Rental.rental_period:type_name -> rentalmanagement.v1.TimePeriod
+	10, // 13: rentalmanagement.v1.Rental.token:type_name -> rentalmanagement.v1.TrunkAccess
+	11, // 14: rentalmanagement.v1.Rental.auto_relock:type_name -> rentalmanagement.v1.AutoRelock
+	4,  // 15: rentalmanagement.v1.GetAvailableCarsRequest.time_period:type_name -> rentalmanagement.v1.TimePeriod
+	5,  // 16: rentalmanagement.v1.GetAvailableCarsResponse.cars:type_name -> rentalmanagement.v1.CarAvailable
+	4,  // 17: rentalmanagement.v1.CreateRentalRequest.rental_period:type_name -> rentalmanagement.v1.TimePeriod
+	12, // 18: rentalmanagement.v1.GetNextRentalResponse.rental:type_name -> rentalmanagement.v1.Rental
+	12, // 19: rentalmanagement.v1.GetOverviewResponse.rentals:type_name -> rentalmanagement.v1.Rental
+	11, // 20: rentalmanagement.v1.SetAutoRelockRequest.auto_relock:type_name -> rentalmanagement.v1.AutoRelock
+	4,  // 21: rentalmanagement.v1.GrantTrunkAccessRequest.validity_period:type_name -> rentalmanagement.v1.TimePeriod
+	9,  // 22: rentalmanagement.v1.GrantTrunkAccessRequest.recurrence:type_name -> rentalmanagement.v1.Recurrence
+	0,  // 23: rentalmanagement.v1.GetLockStateResponse.trunk_lock_state:type_name -> rentalmanagement.v1.LockState
+	0,  // 24: rentalmanagement.v1.SetLockStateRequest.trunk_lock_state:type_name -> rentalmanagement.v1.LockState
+	13, // 25: rentalmanagement.v1.RentalManagement.GetAvailableCars:input_type -> rentalmanagement.v1.GetAvailableCarsRequest
+	15, // 26: rentalmanagement.v1.RentalManagement.GetCar:input_type -> rentalmanagement.v1.GetCarRequest
+	16, // 27: rentalmanagement.v1.RentalManagement.CreateRental:input_type -> rentalmanagement.v1.CreateRentalRequest
+	17, // 28: rentalmanagement.v1.RentalManagement.GetNextRental:input_type -> rentalmanagement.v1.GetNextRentalRequest
+	19, // 29: rentalmanagement.v1.RentalManagement.GetOverview:input_type -> rentalmanagement.v1.GetOverviewRequest
+	21, // 30: rentalmanagement.v1.RentalManagement.GetRentalStatus:input_type -> rentalmanagement.v1.GetRentalStatusRequest
+	22, // 31: rentalmanagement.v1.RentalManagement.SetAutoRelock:input_type -> rentalmanagement.v1.SetAutoRelockRequest
+	23, // 32: rentalmanagement.v1.RentalManagement.CancelRental:input_type -> rentalmanagement.v1.CancelRentalRequest
+	24, // 33: rentalmanagement.v1.RentalManagement.GrantTrunkAccess:input_type -> rentalmanagement.v1.GrantTrunkAccessRequest
+	25, // 34: rentalmanagement.v1.RentalManagement.GetLockState:input_type -> rentalmanagement.v1.GetLockStateRequest
+	27, // 35: rentalmanagement.v1.RentalManagement.SetLockState:input_type -> rentalmanagement.v1.SetLockStateRequest
+	14, // 36: rentalmanagement.v1.RentalManagement.GetAvailableCars:output_type -> rentalmanagement.v1.GetAvailableCarsResponse
+	8,  // 37: rentalmanagement.v1.RentalManagement.GetCar:output_type -> rentalmanagement.v1.Car
+	29, // 38: rentalmanagement.v1.RentalManagement.CreateRental:output_type -> google.protobuf.Empty
+	18, // 39: rentalmanagement.v1.RentalManagement.GetNextRental:output_type -> rentalmanagement.v1.GetNextRentalResponse
+	20, // 40: rentalmanagement.v1.RentalManagement.GetOverview:output_type -> rentalmanagement.v1.GetOverviewResponse
+	12, // 41: rentalmanagement.v1.RentalManagement.GetRentalStatus:output_type -> rentalmanagement.v1.Rental
+	29, // 42: rentalmanagement.v1.RentalManagement.SetAutoRelock:output_type -> google.protobuf.Empty
+	29, // 43: rentalmanagement.v1.RentalManagement.CancelRental:output_type -> google.protobuf.Empty
+	10, // 44: rentalmanagement.v1.RentalManagement.GrantTrunkAccess:output_type -> rentalmanagement.v1.TrunkAccess
+	26, // 45: rentalmanagement.v1.RentalManagement.GetLockState:output_type -> rentalmanagement.v1.GetLockStateResponse
+	29, // 46: rentalmanagement.v1.RentalManagement.SetLockState:output_type -> google.protobuf.Empty
+	36, // [36:47] is the sub-list for method output_type
+	25, // [25:36] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_rental_management_proto_init() }
+func file_rental_management_proto_init() {
+	if File_rental_management_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_rental_management_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimePeriod); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CarAvailable); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Position); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DynamicData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Car); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Recurrence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrunkAccess); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AutoRelock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Rental); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAvailableCarsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAvailableCarsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCarRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateRentalRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNextRentalRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNextRentalResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOverviewRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOverviewResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRentalStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetAutoRelockRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelRentalRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GrantTrunkAccessRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLockStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLockStateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rental_management_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLockStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_rental_management_proto_msgTypes[23].OneofWrappers = []interface{}{
+		(*SetLockStateRequest_CustomerId)(nil),
+		(*SetLockStateRequest_TrunkAccessToken)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rental_management_proto_rawDesc,
+			NumEnums:      4,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rental_management_proto_goTypes,
+		DependencyIndexes: file_rental_management_proto_depIdxs,
+		EnumInfos:         file_rental_management_proto_enumTypes,
+		MessageInfos:      file_rental_management_proto_msgTypes,
+	}.Build()
+	File_rental_management_proto = out.File
+	file_rental_management_proto_rawDesc = nil
+	file_rental_management_proto_goTypes = nil
+	file_rental_management_proto_depIdxs = nil
+}
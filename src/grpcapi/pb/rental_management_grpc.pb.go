@@ -0,0 +1,504 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: rental_management.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	RentalManagement_GetAvailableCars_FullMethodName = "/rentalmanagement.v1.RentalManagement/GetAvailableCars"
+	RentalManagement_GetCar_FullMethodName           = "/rentalmanagement.v1.RentalManagement/GetCar"
+	RentalManagement_CreateRental_FullMethodName     = "/rentalmanagement.v1.RentalManagement/CreateRental"
+	RentalManagement_GetNextRental_FullMethodName    = "/rentalmanagement.v1.RentalManagement/GetNextRental"
+	RentalManagement_GetOverview_FullMethodName      = "/rentalmanagement.v1.RentalManagement/GetOverview"
+	RentalManagement_GetRentalStatus_FullMethodName  = "/rentalmanagement.v1.RentalManagement/GetRentalStatus"
+	RentalManagement_SetAutoRelock_FullMethodName    = "/rentalmanagement.v1.RentalManagement/SetAutoRelock"
+	RentalManagement_CancelRental_FullMethodName     = "/rentalmanagement.v1.RentalManagement/CancelRental"
+	RentalManagement_GrantTrunkAccess_FullMethodName = "/rentalmanagement.v1.RentalManagement/GrantTrunkAccess"
+	RentalManagement_GetLockState_FullMethodName     = "/rentalmanagement.v1.RentalManagement/GetLockState"
+	RentalManagement_SetLockState_FullMethodName     = "/rentalmanagement.v1.RentalManagement/SetLockState"
+)
+
+// RentalManagementClient is the client API for RentalManagement service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RentalManagementClient interface {
+	// GetAvailableCars returns the cars that are available during the whole time period.
+	GetAvailableCars(ctx context.Context, in *GetAvailableCarsRequest, opts ...grpc.CallOption) (*GetAvailableCarsResponse, error)
+	// GetCar returns the static information on a car.
+	GetCar(ctx context.Context, in *GetCarRequest, opts ...grpc.CallOption) (*Car, error)
+	// CreateRental books a car for a customer. The rental must not start in the past.
+	CreateRental(ctx context.Context, in *CreateRentalRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetNextRental returns the active or next upcoming rental of a car, if any.
+	GetNextRental(ctx context.Context, in *GetNextRentalRequest, opts ...grpc.CallOption) (*GetNextRentalResponse, error)
+	// GetOverview returns the rentals of a customer including basic car data.
+	GetOverview(ctx context.Context, in *GetOverviewRequest, opts ...grpc.CallOption) (*GetOverviewResponse, error)
+	// GetRentalStatus returns a rental including the current state of the car.
+	GetRentalStatus(ctx context.Context, in *GetRentalStatusRequest, opts ...grpc.CallOption) (*Rental, error)
+	// SetAutoRelock sets the auto relock timeout of a rental.
+	SetAutoRelock(ctx context.Context, in *SetAutoRelockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// CancelRental cancels a rental that has not started yet. The rental is kept with the state CANCELLED.
+	CancelRental(ctx context.Context, in *CancelRentalRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GrantTrunkAccess creates a new trunk access token for an active rental, replacing the previous one.
+	GrantTrunkAccess(ctx context.Context, in *GrantTrunkAccessRequest, opts ...grpc.CallOption) (*TrunkAccess, error)
+	// GetLockState returns the trunk lock state of a car to the holder of a valid trunk access token.
+	GetLockState(ctx context.Context, in *GetLockStateRequest, opts ...grpc.CallOption) (*GetLockStateResponse, error)
+	// SetLockState locks or unlocks the trunk of a car for the customer of its active rental or the holder of a valid
+	// trunk access token.
+	SetLockState(ctx context.Context, in *SetLockStateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type rentalManagementClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRentalManagementClient(cc grpc.ClientConnInterface) RentalManagementClient {
+	return &rentalManagementClient{cc}
+}
+
+func (c *rentalManagementClient) GetAvailableCars(ctx context.Context, in *GetAvailableCarsRequest, opts ...grpc.CallOption) (*GetAvailableCarsResponse, error) {
+	out := new(GetAvailableCarsResponse)
+	err := c.cc.Invoke(ctx, RentalManagement_GetAvailableCars_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalManagementClient) GetCar(ctx context.Context, in *GetCarRequest, opts ...grpc.CallOption) (*Car, error) {
+	out := new(Car)
+	err := c.cc.Invoke(ctx, RentalManagement_GetCar_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalManagementClient) CreateRental(ctx context.Context, in *CreateRentalRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RentalManagement_CreateRental_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalManagementClient) GetNextRental(ctx context.Context, in *GetNextRentalRequest, opts ...grpc.CallOption) (*GetNextRentalResponse, error) {
+	out := new(GetNextRentalResponse)
+	err := c.cc.Invoke(ctx, RentalManagement_GetNextRental_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalManagementClient) GetOverview(ctx context.Context, in *GetOverviewRequest, opts ...grpc.CallOption) (*GetOverviewResponse, error) {
+	out := new(GetOverviewResponse)
+	err := c.cc.Invoke(ctx, RentalManagement_GetOverview_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalManagementClient) GetRentalStatus(ctx context.Context, in *GetRentalStatusRequest, opts ...grpc.CallOption) (*Rental, error) {
+	out := new(Rental)
+	err := c.cc.Invoke(ctx, RentalManagement_GetRentalStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalManagementClient) SetAutoRelock(ctx context.Context, in *SetAutoRelockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RentalManagement_SetAutoRelock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalManagementClient) CancelRental(ctx context.Context, in *CancelRentalRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RentalManagement_CancelRental_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalManagementClient) GrantTrunkAccess(ctx context.Context, in *GrantTrunkAccessRequest, opts ...grpc.CallOption) (*TrunkAccess, error) {
+	out := new(TrunkAccess)
+	err := c.cc.Invoke(ctx, RentalManagement_GrantTrunkAccess_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalManagementClient) GetLockState(ctx context.Context, in *GetLockStateRequest, opts ...grpc.CallOption) (*GetLockStateResponse, error) {
+	out := new(GetLockStateResponse)
+	err := c.cc.Invoke(ctx, RentalManagement_GetLockState_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalManagementClient) SetLockState(ctx context.Context, in *SetLockStateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RentalManagement_SetLockState_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RentalManagementServer is the server API for RentalManagement service.
+// All implementations must embed UnimplementedRentalManagementServer
+// for forward compatibility
+type RentalManagementServer interface {
+	// GetAvailableCars returns the cars that are available during the whole time period.
+	GetAvailableCars(context.Context, *GetAvailableCarsRequest) (*GetAvailableCarsResponse, error)
+	// GetCar returns the static information on a car.
+	GetCar(context.Context, *GetCarRequest) (*Car, error)
+	// CreateRental books a car for a customer. The rental must not start in the past.
+	CreateRental(context.Context, *CreateRentalRequest) (*emptypb.Empty, error)
+	// GetNextRental returns the active or next upcoming rental of a car, if any.
+	GetNextRental(context.Context, *GetNextRentalRequest) (*GetNextRentalResponse, error)
+	// GetOverview returns the rentals of a customer including basic car data.
+	GetOverview(context.Context, *GetOverviewRequest) (*GetOverviewResponse, error)
+	// GetRentalStatus returns a rental including the current state of the car.
+	GetRentalStatus(context.Context, *GetRentalStatusRequest) (*Rental, error)
+	// SetAutoRelock sets the auto relock timeout of a rental.
+	SetAutoRelock(context.Context, *SetAutoRelockRequest) (*emptypb.Empty, error)
+	// CancelRental cancels a rental that has not started yet. The rental is kept with the state CANCELLED.
+	CancelRental(context.Context, *CancelRentalRequest) (*emptypb.Empty, error)
+	// GrantTrunkAccess creates a new trunk access token for an active rental, replacing the previous one.
+	GrantTrunkAccess(context.Context, *GrantTrunkAccessRequest) (*TrunkAccess, error)
+	// GetLockState returns the trunk lock state of a car to the holder of a valid trunk access token.
+	GetLockState(context.Context, *GetLockStateRequest) (*GetLockStateResponse, error)
+	// SetLockState locks or unlocks the trunk of a car for the customer of its active rental or the holder of a valid
+	// trunk access token.
+	SetLockState(context.Context, *SetLockStateRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedRentalManagementServer()
+}
+
+// UnimplementedRentalManagementServer must be embedded to have forward compatible implementations.
+type UnimplementedRentalManagementServer struct {
+}
+
+func (UnimplementedRentalManagementServer) GetAvailableCars(context.Context, *GetAvailableCarsRequest) (*GetAvailableCarsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailableCars not implemented")
+}
+func (UnimplementedRentalManagementServer) GetCar(context.Context, *GetCarRequest) (*Car, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCar not implemented")
+}
+func (UnimplementedRentalManagementServer) CreateRental(context.Context, *CreateRentalRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRental not implemented")
+}
+func (UnimplementedRentalManagementServer) GetNextRental(context.Context, *GetNextRentalRequest) (*GetNextRentalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNextRental not implemented")
+}
+func (UnimplementedRentalManagementServer) GetOverview(context.Context, *GetOverviewRequest) (*GetOverviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOverview not implemented")
+}
+func (UnimplementedRentalManagementServer) GetRentalStatus(context.Context, *GetRentalStatusRequest) (*Rental, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRentalStatus not implemented")
+}
+func (UnimplementedRentalManagementServer) SetAutoRelock(context.Context, *SetAutoRelockRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAutoRelock not implemented")
+}
+func (UnimplementedRentalManagementServer) CancelRental(context.Context, *CancelRentalRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelRental not implemented")
+}
+func (UnimplementedRentalManagementServer) GrantTrunkAccess(context.Context, *GrantTrunkAccessRequest) (*TrunkAccess, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantTrunkAccess not implemented")
+}
+func (UnimplementedRentalManagementServer) GetLockState(context.Context, *GetLockStateRequest) (*GetLockStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLockState not implemented")
+}
+func (UnimplementedRentalManagementServer) SetLockState(context.Context, *SetLockStateRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLockState not implemented")
+}
+func (UnimplementedRentalManagementServer) mustEmbedUnimplementedRentalManagementServer() {}
+
+// UnsafeRentalManagementServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RentalManagementServer will
+// result in compilation errors.
+type UnsafeRentalManagementServer interface {
+	mustEmbedUnimplementedRentalManagementServer()
+}
+
+func RegisterRentalManagementServer(s grpc.ServiceRegistrar, srv RentalManagementServer) {
+	s.RegisterService(&RentalManagement_ServiceDesc, srv)
+}
+
+func _RentalManagement_GetAvailableCars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAvailableCarsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalManagementServer).GetAvailableCars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalManagement_GetAvailableCars_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalManagementServer).GetAvailableCars(ctx, req.(*GetAvailableCarsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalManagement_GetCar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalManagementServer).GetCar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalManagement_GetCar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalManagementServer).GetCar(ctx, req.(*GetCarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalManagement_CreateRental_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRentalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalManagementServer).CreateRental(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalManagement_CreateRental_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalManagementServer).CreateRental(ctx, req.(*CreateRentalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalManagement_GetNextRental_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNextRentalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalManagementServer).GetNextRental(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalManagement_GetNextRental_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalManagementServer).GetNextRental(ctx, req.(*GetNextRentalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalManagement_GetOverview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOverviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalManagementServer).GetOverview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalManagement_GetOverview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalManagementServer).GetOverview(ctx, req.(*GetOverviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalManagement_GetRentalStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRentalStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalManagementServer).GetRentalStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalManagement_GetRentalStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalManagementServer).GetRentalStatus(ctx, req.(*GetRentalStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalManagement_SetAutoRelock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAutoRelockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalManagementServer).SetAutoRelock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalManagement_SetAutoRelock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalManagementServer).SetAutoRelock(ctx, req.(*SetAutoRelockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalManagement_CancelRental_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRentalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalManagementServer).CancelRental(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalManagement_CancelRental_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalManagementServer).CancelRental(ctx, req.(*CancelRentalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalManagement_GrantTrunkAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantTrunkAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalManagementServer).GrantTrunkAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalManagement_GrantTrunkAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalManagementServer).GrantTrunkAccess(ctx, req.(*GrantTrunkAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalManagement_GetLockState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLockStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalManagementServer).GetLockState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalManagement_GetLockState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalManagementServer).GetLockState(ctx, req.(*GetLockStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalManagement_SetLockState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLockStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalManagementServer).SetLockState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalManagement_SetLockState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalManagementServer).SetLockState(ctx, req.(*SetLockStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RentalManagement_ServiceDesc is the grpc.ServiceDesc for RentalManagement service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RentalManagement_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rentalmanagement.v1.RentalManagement",
+	HandlerType: (*RentalManagementServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAvailableCars",
+			Handler:    _RentalManagement_GetAvailableCars_Handler,
+		},
+		{
+			MethodName: "GetCar",
+			Handler:    _RentalManagement_GetCar_Handler,
+		},
+		{
+			MethodName: "CreateRental",
+			Handler:    _RentalManagement_CreateRental_Handler,
+		},
+		{
+			MethodName: "GetNextRental",
+			Handler:    _RentalManagement_GetNextRental_Handler,
+		},
+		{
+			MethodName: "GetOverview",
+			Handler:    _RentalManagement_GetOverview_Handler,
+		},
+		{
+			MethodName: "GetRentalStatus",
+			Handler:    _RentalManagement_GetRentalStatus_Handler,
+		},
+		{
+			MethodName: "SetAutoRelock",
+			Handler:    _RentalManagement_SetAutoRelock_Handler,
+		},
+		{
+			MethodName: "CancelRental",
+			Handler:    _RentalManagement_CancelRental_Handler,
+		},
+		{
+			MethodName: "GrantTrunkAccess",
+			Handler:    _RentalManagement_GrantTrunkAccess_Handler,
+		},
+		{
+			MethodName: "GetLockState",
+			Handler:    _RentalManagement_GetLockState_Handler,
+		},
+		{
+			MethodName: "SetLockState",
+			Handler:    _RentalManagement_SetLockState_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rental_management.proto",
+}
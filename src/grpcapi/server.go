@@ -0,0 +1,275 @@
+// Package grpcapi provides the gRPC API of RentalManagement, which offers the core operations of the REST API to
+// internal services. The code in the pb package is generated from rental_management.proto.
+package grpcapi
+
+import (
+	"RentalManagement/api"
+	"RentalManagement/grpcapi/pb"
+	"RentalManagement/logic/model"
+	"RentalManagement/logic/operations"
+	"RentalManagement/util"
+	"context"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type server struct {
+	pb.UnimplementedRentalManagementServer
+	operations   operations.IOperations
+	timeProvider util.ITimeProvider
+}
+
+// NewServer creates a gRPC server that performs its calls with the given operations. Bearer tokens are verified with
+// the given verifier, the methods are restricted to the roles of the corresponding operations of the REST API. Every
+// call is traced, logged with a request ID and recorded in the metrics like a request to the REST API, continuing the
+// trace context of the caller.
+func NewServer(operations operations.IOperations, timeProvider util.ITimeProvider,
+	verifier *api.TokenVerifier) (*grpc.Server, error) {
+
+	methodRoles, err := newMethodRoles()
+	if err != nil {
+		return nil, err
+	}
+
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		otelgrpc.UnaryServerInterceptor(),
+		logCalls(),
+		recordMetrics(),
+		authenticate(verifier, methodRoles),
+	))
+	pb.RegisterRentalManagementServer(grpcServer, &server{operations: operations, timeProvider: timeProvider})
+	return grpcServer, nil
+}
+
+func (s *server) GetAvailableCars(ctx context.Context, request *pb.GetAvailableCarsRequest) (
+	*pb.GetAvailableCarsResponse, error) {
+
+	timePeriod, err := validTimePeriod(request.GetTimePeriod())
+	if err != nil {
+		return nil, err
+	}
+
+	cars, err := s.operations.GetAvailableCars(ctx, timePeriod)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	response := &pb.GetAvailableCarsResponse{Cars: make([]*pb.CarAvailable, 0, len(*cars))}
+	for _, car := range *cars {
+		response.Cars = append(response.Cars, mapCarAvailableToPb(car))
+	}
+	return response, nil
+}
+
+func (s *server) GetCar(ctx context.Context, request *pb.GetCarRequest) (*pb.Car, error) {
+	if !isValidVin(request.GetVin()) {
+		return nil, status.Error(codes.InvalidArgument, "invalid vin")
+	}
+
+	car, err := s.operations.GetCar(ctx, request.GetVin())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return mapCarToPb(car), nil
+}
+
+func (s *server) CreateRental(ctx context.Context, request *pb.CreateRentalRequest) (*emptypb.Empty, error) {
+	if !isValidVin(request.GetVin()) {
+		return nil, status.Error(codes.InvalidArgument, "invalid vin")
+	}
+	if !isValidCustomerId(request.GetCustomerId()) {
+		return nil, status.Error(codes.InvalidArgument, "invalid customerId")
+	}
+	if err := requireCustomer(ctx, request.GetCustomerId()); err != nil {
+		return nil, err
+	}
+	timePeriod, err := validTimePeriod(request.GetRentalPeriod())
+	if err != nil {
+		return nil, err
+	}
+	if timePeriod.StartDate.Before(s.timeProvider.Now()) {
+		return nil, status.Error(codes.FailedPrecondition, "startDate must be in the future")
+	}
+
+	err = s.operations.CreateRental(ctx, request.GetVin(), request.GetCustomerId(), timePeriod)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *server) GetNextRental(ctx context.Context, request *pb.GetNextRentalRequest) (*pb.GetNextRentalResponse,
+	error) {
+
+	if !isValidVin(request.GetVin()) {
+		return nil, status.Error(codes.InvalidArgument, "invalid vin")
+	}
+
+	rental, err := s.operations.GetNextRental(ctx, request.GetVin())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &pb.GetNextRentalResponse{Rental: mapRentalToPb(rental)}, nil
+}
+
+func (s *server) GetOverview(ctx context.Context, request *pb.GetOverviewRequest) (*pb.GetOverviewResponse, error) {
+	if !isValidCustomerId(request.GetCustomerId()) {
+		return nil, status.Error(codes.InvalidArgument, "invalid customerId")
+	}
+	if err := requireCustomer(ctx, request.GetCustomerId()); err != nil {
+		return nil, err
+	}
+
+	rentals, err := s.operations.GetOverview(ctx, request.GetCustomerId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	response := &pb.GetOverviewResponse{Rentals: make([]*pb.Rental, 0, len(*rentals))}
+	for _, rental := range *rentals {
+		response.Rentals = append(response.Rentals, mapRentalToPb(&rental))
+	}
+	return response, nil
+}
+
+func (s *server) GetRentalStatus(ctx context.Context, request *pb.GetRentalStatusRequest) (*pb.Rental, error) {
+	if !isValidRentalId(request.GetRentalId()) {
+		return nil, status.Error(codes.InvalidArgument, "invalid rentalId")
+	}
+	if err := requireRentalCustomer(ctx, s.operations, request.GetRentalId()); err != nil {
+		return nil, err
+	}
+
+	rental, err := s.operations.GetRentalStatus(ctx, request.GetRentalId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return mapRentalToPb(rental), nil
+}
+
+func (s *server) SetAutoRelock(ctx context.Context, request *pb.SetAutoRelockRequest) (*emptypb.Empty, error) {
+	if !isValidRentalId(request.GetRentalId()) {
+		return nil, status.Error(codes.InvalidArgument, "invalid rentalId")
+	}
+	if request.GetAutoRelock() == nil || request.GetAutoRelock().GetTimeoutSeconds() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "timeoutSeconds must not be negative")
+	}
+	if err := requireRentalCustomer(ctx, s.operations, request.GetRentalId()); err != nil {
+		return nil, err
+	}
+
+	autoRelock := model.AutoRelock{TimeoutSeconds: int(request.GetAutoRelock().GetTimeoutSeconds())}
+	err := s.operations.SetAutoRelock(ctx, request.GetRentalId(), autoRelock)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *server) CancelRental(ctx context.Context, request *pb.CancelRentalRequest) (*emptypb.Empty, error) {
+	if !isValidRentalId(request.GetRentalId()) {
+		return nil, status.Error(codes.InvalidArgument, "invalid rentalId")
+	}
+	if err := requireRentalCustomer(ctx, s.operations, request.GetRentalId()); err != nil {
+		return nil, err
+	}
+
+	err := s.operations.CancelRental(ctx, request.GetRentalId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *server) GrantTrunkAccess(ctx context.Context, request *pb.GrantTrunkAccessRequest) (*pb.TrunkAccess, error) {
+	if !isValidRentalId(request.GetRentalId()) {
+		return nil, status.Error(codes.InvalidArgument, "invalid rentalId")
+	}
+	timePeriod, err := validTimePeriod(request.GetValidityPeriod())
+	if err != nil {
+		return nil, err
+	}
+	recurrence, err := mapRecurrenceFromPb(request.GetRecurrence())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid recurrence: "+err.Error())
+	}
+	if recurrence != nil {
+		if err := recurrence.Validate(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid recurrence: "+err.Error())
+		}
+	}
+	if err := requireRentalCustomer(ctx, s.operations, request.GetRentalId()); err != nil {
+		return nil, err
+	}
+
+	trunkAccess, err := s.operations.GrantTrunkAccess(ctx, request.GetRentalId(), timePeriod, recurrence)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return mapTrunkAccessToPb(trunkAccess), nil
+}
+
+func (s *server) GetLockState(ctx context.Context, request *pb.GetLockStateRequest) (*pb.GetLockStateResponse,
+	error) {
+
+	if !isValidVin(request.GetVin()) {
+		return nil, status.Error(codes.InvalidArgument, "invalid vin")
+	}
+	if !isValidTrunkAccessToken(request.GetTrunkAccessToken()) {
+		return nil, status.Error(codes.InvalidArgument, "invalid trunkAccessToken")
+	}
+
+	lockState, err := s.operations.GetLockState(ctx, request.GetVin(), request.GetTrunkAccessToken())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &pb.GetLockStateResponse{TrunkLockState: lockStates[*lockState]}, nil
+}
+
+func (s *server) SetLockState(ctx context.Context, request *pb.SetLockStateRequest) (*emptypb.Empty, error) {
+	if !isValidVin(request.GetVin()) {
+		return nil, status.Error(codes.InvalidArgument, "invalid vin")
+	}
+	lockState := mapLockStateFromPb(request.GetTrunkLockState())
+	if lockState == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid trunkLockState")
+	}
+
+	var err error
+	switch actor := request.GetActor().(type) {
+	case *pb.SetLockStateRequest_CustomerId:
+		if !isValidCustomerId(actor.CustomerId) {
+			return nil, status.Error(codes.InvalidArgument, "invalid customerId")
+		}
+		if err := requireCustomer(ctx, actor.CustomerId); err != nil {
+			return nil, err
+		}
+		err = s.operations.SetLockStateCustomerId(ctx, *lockState, request.GetVin(), actor.CustomerId)
+	case *pb.SetLockStateRequest_TrunkAccessToken:
+		if !isValidTrunkAccessToken(actor.TrunkAccessToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid trunkAccessToken")
+		}
+		err = s.operations.SetLockStateTrunkAccessToken(ctx, *lockState, request.GetVin(), actor.TrunkAccessToken)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "either customerId or trunkAccessToken must be specified")
+	}
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// validTimePeriod maps the time period and checks that it does not end before it starts
+func validTimePeriod(timePeriod *pb.TimePeriod) (model.TimePeriod, error) {
+	modelTimePeriod, err := mapTimePeriodFromPb(timePeriod)
+	if err != nil {
+		return model.TimePeriod{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if modelTimePeriod.EndDate.Before(modelTimePeriod.StartDate) {
+		return model.TimePeriod{}, status.Error(codes.InvalidArgument, "startDate must be before endDate")
+	}
+	return modelTimePeriod, nil
+}
@@ -0,0 +1,498 @@
+package grpcapi
+
+import (
+	"RentalManagement/api"
+	"RentalManagement/grpcapi/pb"
+	"RentalManagement/logic/model"
+	"RentalManagement/logic/rentalErrors"
+	"RentalManagement/mocks"
+	"RentalManagement/testhelpers"
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const customerId = "customer@example.com"
+const vin = "WVWAA71K08W201030"
+const rentalId = "kskgnvsl"
+const trunkAccessToken = "aBcDeFgHiJkLmNoPqRsTuVwX"
+
+var now = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var rentalPeriod = model.TimePeriod{
+	StartDate: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+	EndDate:   time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+}
+
+type testAuthConfig struct {
+	jwksFile string
+}
+
+func (c *testAuthConfig) GetAuthJwksUrl() string       { return "" }
+func (c *testAuthConfig) GetAuthJwksFile() string      { return c.jwksFile }
+func (c *testAuthConfig) GetAuthIssuer() string        { return "" }
+func (c *testAuthConfig) GetAuthAudience() string      { return "" }
+func (c *testAuthConfig) GetAuthCustomerClaim() string { return "sub" }
+func (c *testAuthConfig) GetAuthRolesClaim() string    { return "roles" }
+
+// newTestClient serves the operations over an in-memory connection and returns a client for it together with the
+// issuer of the accepted bearer tokens
+func newTestClient(t *testing.T, operations *mocks.MockIOperations) (pb.RentalManagementClient,
+	*testhelpers.TokenIssuer) {
+
+	tokenIssuer, err := testhelpers.NewTokenIssuer(t.TempDir())
+	assert.Nil(t, err)
+	verifier, err := api.NewTokenVerifier(&testAuthConfig{jwksFile: tokenIssuer.JwksFile})
+	assert.Nil(t, err)
+
+	ctrl := gomock.NewController(t)
+	timeProvider := mocks.NewMockITimeProvider(ctrl)
+	timeProvider.EXPECT().Now().Return(now).AnyTimes()
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer, err := NewServer(operations, timeProvider, verifier)
+	assert.Nil(t, err)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	connection, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.Nil(t, err)
+	t.Cleanup(func() { _ = connection.Close() })
+
+	return pb.NewRentalManagementClient(connection), tokenIssuer
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), authorizationMetadataKey, token)
+}
+
+func assertCode(t *testing.T, code codes.Code, err error) {
+	assert.Equal(t, code, status.Code(err), err)
+}
+
+func TestServer_GetAvailableCars_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	operations := mocks.NewMockIOperations(ctrl)
+	client, tokenIssuer := newTestClient(t, operations)
+
+	operations.EXPECT().GetAvailableCars(gomock.Any(), rentalPeriod).Return(&[]model.CarAvailable{{
+		Vin:           vin,
+		Brand:         "Audi",
+		Model:         "A3",
+		NumberOfSeats: 5,
+	}}, nil)
+
+	response, err := client.GetAvailableCars(withToken(tokenIssuer.BearerToken(customerId)),
+		&pb.GetAvailableCarsRequest{TimePeriod: mapTimePeriodToPb(rentalPeriod)})
+
+	assert.Nil(t, err)
+	assert.Len(t, response.GetCars(), 1)
+	assert.Equal(t, vin, response.GetCars()[0].GetVin())
+	assert.Equal(t, int32(5), response.GetCars()[0].GetNumberOfSeats())
+}
+
+func TestServer_GetAvailableCars_unauthenticated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client, _ := newTestClient(t, mocks.NewMockIOperations(ctrl))
+
+	_, err := client.GetAvailableCars(context.Background(),
+		&pb.GetAvailableCarsRequest{TimePeriod: mapTimePeriodToPb(rentalPeriod)})
+
+	assertCode(t, codes.Unauthenticated, err)
+}
+
+func TestServer_GetAvailableCars_invalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client, _ := newTestClient(t, mocks.NewMockIOperations(ctrl))
+
+	_, err := client.GetAvailableCars(withToken("Bearer invalid"),
+		&pb.GetAvailableCarsRequest{TimePeriod: mapTimePeriodToPb(rentalPeriod)})
+
+	assertCode(t, codes.Unauthenticated, err)
+}
+
+func TestServer_GetAvailableCars_missingRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client, tokenIssuer := newTestClient(t, mocks.NewMockIOperations(ctrl))
+
+	_, err := client.GetAvailableCars(withToken(tokenIssuer.StaffBearerToken("service")),
+		&pb.GetAvailableCarsRequest{TimePeriod: mapTimePeriodToPb(rentalPeriod)})
+
+	assertCode(t, codes.PermissionDenied, err)
+}
+
+func TestServer_GetAvailableCars_invalidTimePeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client, tokenIssuer := newTestClient(t, mocks.NewMockIOperations(ctrl))
+
+	_, err := client.GetAvailableCars(withToken(tokenIssuer.BearerToken(customerId)),
+		&pb.GetAvailableCarsRequest{TimePeriod: &pb.TimePeriod{
+			StartDate: timestamppb.New(rentalPeriod.EndDate),
+			EndDate:   timestamppb.New(rentalPeriod.StartDate),
+		}})
+
+	assertCode(t, codes.InvalidArgument, err)
+}
+
+func TestServer_GetCar_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	operations := mocks.NewMockIOperations(ctrl)
+	client, tokenIssuer := newTestClient(t, operations)
+
+	operations.EXPECT().GetCar(gomock.Any(), vin).Return(&model.Car{Vin: vin, Brand: "Audi", Model: "A3"}, nil)
+
+	car, err := client.GetCar(withToken(tokenIssuer.StaffBearerToken("service")), &pb.GetCarRequest{Vin: vin})
+
+	assert.Nil(t, err)
+	assert.Equal(t, vin, car.GetVin())
+	assert.Equal(t, "Audi", car.GetBrand())
+	assert.Equal(t, "A3", car.GetModel())
+	assert.Nil(t, car.GetDynamicData())
+}
+
+func TestServer_GetCar_notFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	operations := mocks.NewMockIOperations(ctrl)
+	client, tokenIssuer := newTestClient(t, operations)
+
+	operations.EXPECT().GetCar(gomock.Any(), vin).Return(nil, rentalErrors.ErrCarNotFound)
+
+	_, err := client.GetCar(withToken(tokenIssuer.BearerToken(customerId)), &pb.GetCarRequest{Vin: vin})
+
+	assertCode(t, codes.NotFound, err)
+}
+
+func TestServer_CreateRental_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	operations := mocks.NewMockIOperations(ctrl)
+	client, tokenIssuer := newTestClient(t, operations)
+
+	operations.EXPECT().CreateRental(gomock.Any(), vin, customerId, rentalPeriod).Return(nil)
+
+	_, err := client.CreateRental(withToken(tokenIssuer.BearerToken(customerId)), &pb.CreateRentalRequest{
+		Vin:          vin,
+		CustomerId:   customerId,
+		RentalPeriod: mapTimePeriodToPb(rentalPeriod),
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestServer_CreateRental_otherCustomer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client, tokenIssuer := newTestClient(t, mocks.NewMockIOperations(ctrl))
+
+	_, err := client.CreateRental(withToken(tokenIssuer.BearerToken("other@example.com")), &pb.CreateRentalRequest{
+		Vin:          vin,
+		CustomerId:   customerId,
+		RentalPeriod: mapTimePeriodToPb(rentalPeriod),
+	})
+
+	assertCode(t, codes.PermissionDenied, err)
+}
+
+func TestServer_CreateRental_admin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	operations := mocks.NewMockIOperations(ctrl)
+	client, tokenIssuer := newTestClient(t, operations)
+
+	operations.EXPECT().CreateRental(gomock.Any(), vin, customerId, rentalPeriod).Return(nil)
+
+	_, err := client.CreateRental(withToken(tokenIssuer.StaffBearerToken("admin")), &pb.CreateRentalRequest{
+		Vin:          vin,
+		CustomerId:   customerId,
+		RentalPeriod: mapTimePeriodToPb(rentalPeriod),
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestServer_CreateRental_past(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client, tokenIssuer := newTestClient(t, mocks.NewMockIOperations(ctrl))
+
+	_, err := client.CreateRental(withToken(tokenIssuer.BearerToken(customerId)), &pb.CreateRentalRequest{
+		Vin:        vin,
+		CustomerId: customerId,
+		RentalPeriod: mapTimePeriodToPb(model.TimePeriod{
+			StartDate: now.Add(-time.Hour),
+			EndDate:   now.Add(time.Hour),
+		}),
+	})
+
+	assertCode(t, codes.FailedPrecondition, err)
+}
+
+func TestServer_CreateRental_conflictingRental(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	operations := mocks.NewMockIOperations(ctrl)
+	client, tokenIssuer := newTestClient(t, operations)
+
+	operations.EXPECT().CreateRental(gomock.Any(), vin, customerId, rentalPeriod).
+		Return(rentalErrors.ErrConflictingRentalExists)
+
+	_, err := client.CreateRental(withToken(tokenIssuer.BearerToken(customerId)), &pb.CreateRentalRequest{
+		Vin:          vin,
+		CustomerId:   customerId,
+		RentalPeriod: mapTimePeriodToPb(rentalPeriod),
+	})
+
+	assertCode(t, codes.AlreadyExists, err)
+}
+
+func TestServer_GetRentalStatus_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	operations := mocks.NewMockIOperations(ctrl)
+	client, tokenIssuer := newTestClient(t, operations)
+
+	operations.EXPECT().GetRentalStatus(gomock.Any(), rentalId).Return(&model.Rental{
+		Id:           rentalId,
+		State:        model.ACTIVE,
+		Customer:     &model.Customer{CustomerId: customerId},
+		RentalPeriod: rentalPeriod,
+		AutoRelock:   &model.AutoRelock{TimeoutSeconds: 60},
+	}, nil)
+
+	rental, err := client.GetRentalStatus(withToken(tokenIssuer.StaffBearerToken("fleetManager")),
+		&pb.GetRentalStatusRequest{RentalId: rentalId})
+
+	assert.Nil(t, err)
+	assert.Equal(t, rentalId, rental.GetId())
+	assert.Equal(t, pb.RentalState_RENTAL_STATE_ACTIVE, rental.GetState())
+	assert.Equal(t, customerId, rental.GetCustomerId())
+	assert.Equal(t, rentalPeriod.StartDate, rental.GetRentalPeriod().GetStartDate().AsTime())
+	assert.Equal(t, int32(60), rental.GetAutoRelock().GetTimeoutSeconds())
+}
+
+func TestServer_GetRentalStatus_notFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	operations := mocks.NewMockIOperations(ctrl)
+	client, tokenIssuer := newTestClient(t, operations)
+
+	operations.EXPECT().GetRentalCustomer(gomock.Any(), rentalId).Return("", rentalErrors.ErrRentalNotFound)
+
+	_, err := client.GetRentalStatus(withToken(tokenIssuer.BearerToken(customerId)),
+		&pb.GetRentalStatusRequest{RentalId: rentalId})
+
+	assertCode(t, codes.NotFound, err)
+}
+
+func TestServer_GetRentalStatus_operationsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	operations := mocks.NewMockIOperations(ctrl)
+	client, tokenIssuer := newTestClient(t, operations)
+
+	operations.EXPECT().GetRentalCustomer(gomock.Any(), rentalId).Return(customerId, nil)
+	operations.EXPECT().GetRentalStatus(gomock.Any(), rentalId).Return(nil, errors.New("database unavailable"))
+
+	_, err := client.GetRentalStatus(withToken(tokenIssuer.BearerToken(customerId)),
+		&pb.GetRentalStatusRequest{RentalId: rentalId})
+
+	assertCode(t, codes.Internal, err)
+	assert.NotContains(t, err.Error(), "database unavailable")
+}
+
+func TestServer_CancelRental_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	operations := mocks.NewMockIOperations(ctrl)
+	client, tokenIssuer := newTestClient(t, operations)
+
+	operations.EXPECT().GetRentalCustomer(gomock.Any(), rentalId).Return(customerId, nil)
+	operations.EXPECT().CancelRental(gomock.Any(), rentalId).Return(nil)
+
+	_, err := client.CancelRental(withToken(tokenIssuer.BearerToken(customerId)),
+		&pb.CancelRentalRequest{RentalId: rentalId})
+
+	assert.Nil(t, err)
+}
+
+func TestServer_CancelRental_notUpcoming(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	operations := mocks.NewMockIOperations(ctrl)
+	client, tokenIssuer := newTestClient(t, operations)
+
+	operations.EXPECT().GetRentalCustomer(gomock.Any(), rentalId).Return(customerId, nil)
+	operations.EXPECT().CancelRental(gomock.Any(), rentalId).Return(rentalErrors.ErrRentalNotUpcoming)
+
+	_, err := client.CancelRental(withToken(tokenIssuer.BearerToken(customerId)),
+		&pb.CancelRentalRequest{RentalId: rentalId})
+
+	assertCode(t, codes.FailedPrecondition, err)
+}
+
+func TestServer_GrantTrunkAccess_notActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	operations := mocks.NewMockIOperations(ctrl)
+	client, tokenIssuer := newTestClient(t, operations)
+
+	operations.EXPECT().GetRentalCustomer(gomock.Any(), rentalId).Return(customerId, nil)
+	operations.EXPECT().GrantTrunkAccess(gomock.Any(), rentalId, rentalPeriod, nil).
+		Return(nil, rentalErrors.ErrRentalNotActive)
+
+	_, err := client.GrantTrunkAccess(withToken(tokenIssuer.BearerToken(customerId)),
+		&pb.GrantTrunkAccessRequest{RentalId: rentalId, ValidityPeriod: mapTimePeriodToPb(rentalPeriod)})
+
+	assertCode(t, codes.FailedPrecondition, err)
+}
+
+func TestServer_rentalOfOtherCustomer(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(client pb.RentalManagementClient, ctx context.Context) error
+	}{
+		{"GetRentalStatus", func(client pb.RentalManagementClient, ctx context.Context) error {
+			_, err := client.GetRentalStatus(ctx, &pb.GetRentalStatusRequest{RentalId: rentalId})
+			return err
+		}},
+		{"SetAutoRelock", func(client pb.RentalManagementClient, ctx context.Context) error {
+			_, err := client.SetAutoRelock(ctx, &pb.SetAutoRelockRequest{
+				RentalId:   rentalId,
+				AutoRelock: &pb.AutoRelock{TimeoutSeconds: 60},
+			})
+			return err
+		}},
+		{"GrantTrunkAccess", func(client pb.RentalManagementClient, ctx context.Context) error {
+			_, err := client.GrantTrunkAccess(ctx, &pb.GrantTrunkAccessRequest{
+				RentalId:       rentalId,
+				ValidityPeriod: mapTimePeriodToPb(rentalPeriod),
+			})
+			return err
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			operations := mocks.NewMockIOperations(ctrl)
+			client, tokenIssuer := newTestClient(t, operations)
+
+			operations.EXPECT().GetRentalCustomer(gomock.Any(), rentalId).Return(customerId, nil)
+
+			err := test.call(client, withToken(tokenIssuer.BearerToken("other@example.com")))
+
+			assertCode(t, codes.PermissionDenied, err)
+		})
+	}
+}
+
+func TestServer_rentalOfOtherCustomer_admin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	operations := mocks.NewMockIOperations(ctrl)
+	client, tokenIssuer := newTestClient(t, operations)
+
+	operations.EXPECT().SetAutoRelock(gomock.Any(), rentalId, model.AutoRelock{TimeoutSeconds: 60}).Return(nil)
+
+	_, err := client.SetAutoRelock(withToken(tokenIssuer.StaffBearerToken("admin")), &pb.SetAutoRelockRequest{
+		RentalId:   rentalId,
+		AutoRelock: &pb.AutoRelock{TimeoutSeconds: 60},
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestServer_GetLockState_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	operations := mocks.NewMockIOperations(ctrl)
+	client, _ := newTestClient(t, operations)
+
+	lockState := model.UNLOCKED
+	operations.EXPECT().GetLockState(gomock.Any(), vin, trunkAccessToken).Return(&lockState, nil)
+
+	response, err := client.GetLockState(context.Background(),
+		&pb.GetLockStateRequest{Vin: vin, TrunkAccessToken: trunkAccessToken})
+
+	assert.Nil(t, err)
+	assert.Equal(t, pb.LockState_LOCK_STATE_UNLOCKED, response.GetTrunkLockState())
+}
+
+func TestServer_SetLockState_trunkAccessDenied(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	operations := mocks.NewMockIOperations(ctrl)
+	client, _ := newTestClient(t, operations)
+
+	operations.EXPECT().SetLockStateTrunkAccessToken(gomock.Any(), model.LOCKED, vin, trunkAccessToken).
+		Return(rentalErrors.ErrTrunkAccessDenied)
+
+	_, err := client.SetLockState(context.Background(), &pb.SetLockStateRequest{
+		Vin:            vin,
+		TrunkLockState: pb.LockState_LOCK_STATE_LOCKED,
+		Actor:          &pb.SetLockStateRequest_TrunkAccessToken{TrunkAccessToken: trunkAccessToken},
+	})
+
+	assertCode(t, codes.PermissionDenied, err)
+}
+
+func TestServer_SetLockState_customerUnauthenticated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client, _ := newTestClient(t, mocks.NewMockIOperations(ctrl))
+
+	_, err := client.SetLockState(context.Background(), &pb.SetLockStateRequest{
+		Vin:            vin,
+		TrunkLockState: pb.LockState_LOCK_STATE_LOCKED,
+		Actor:          &pb.SetLockStateRequest_CustomerId{CustomerId: customerId},
+	})
+
+	assertCode(t, codes.Unauthenticated, err)
+}
+
+func TestServer_SetLockState_unspecifiedLockState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client, _ := newTestClient(t, mocks.NewMockIOperations(ctrl))
+
+	_, err := client.SetLockState(context.Background(), &pb.SetLockStateRequest{
+		Vin:   vin,
+		Actor: &pb.SetLockStateRequest_TrunkAccessToken{TrunkAccessToken: trunkAccessToken},
+	})
+
+	assertCode(t, codes.InvalidArgument, err)
+}
+
+func TestServer_tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	ctrl := gomock.NewController(t)
+	operations := mocks.NewMockIOperations(ctrl)
+	client, tokenIssuer := newTestClient(t, operations)
+
+	operations.EXPECT().GetAvailableCars(gomock.Any(), rentalPeriod).Return(&[]model.CarAvailable{}, nil)
+
+	ctx := metadata.AppendToOutgoingContext(withToken(tokenIssuer.BearerToken(customerId)),
+		"traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	_, err := client.GetAvailableCars(ctx, &pb.GetAvailableCarsRequest{TimePeriod: mapTimePeriodToPb(rentalPeriod)})
+
+	assert.Nil(t, err)
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "rentalmanagement.v1.RentalManagement/GetAvailableCars", spans[0].Name())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+}
@@ -0,0 +1,31 @@
+package grpcapi
+
+import (
+	"net/mail"
+	"regexp"
+)
+
+// the patterns of the schemas of the OpenAPI specification, the REST API validates its requests against
+var (
+	vinPattern              = regexp.MustCompile(`^[A-HJ-NPR-Z0-9]{13}[0-9]{4}$`)
+	rentalIdPattern         = regexp.MustCompile(`^[a-zA-Z0-9]{8}$`)
+	trunkAccessTokenPattern = regexp.MustCompile(`^[a-zA-Z0-9]{24}$`)
+)
+
+func isValidVin(vin string) bool {
+	return vinPattern.MatchString(vin)
+}
+
+func isValidRentalId(rentalId string) bool {
+	return rentalIdPattern.MatchString(rentalId)
+}
+
+func isValidTrunkAccessToken(token string) bool {
+	return trunkAccessTokenPattern.MatchString(token)
+}
+
+// isValidCustomerId reports whether the customer ID is an email address as required by the OpenAPI specification
+func isValidCustomerId(customerId string) bool {
+	address, err := mail.ParseAddress(customerId)
+	return err == nil && address.Address == customerId
+}
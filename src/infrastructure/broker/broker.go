@@ -0,0 +1,55 @@
+// Package broker publishes rental events to the message broker other services consume them from.
+package broker
+
+//go:generate mockgen -source=./broker.go -package=mocks -destination=../../mocks/mock_broker.go
+
+import (
+	"RentalManagement/logic/model"
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Names of the broker implementations that can be configured
+const (
+	NameStdout = "stdout"
+	NameFile   = "file"
+	NameMemory = "memory"
+)
+
+type BrokerConfig interface {
+	GetEventBroker() string
+	GetEventBrokerFile() string
+}
+
+// Broker is the interface to the message broker. Publish returns nil once the broker has accepted the event,
+// any error means that the event may not have been published.
+type Broker interface {
+	Publish(ctx context.Context, event model.RentalEvent) error
+}
+
+// NewBroker creates the broker implementation selected by the configuration
+func NewBroker(config BrokerConfig) (Broker, error) {
+	switch config.GetEventBroker() {
+	case NameStdout:
+		return NewWriterBroker(os.Stdout), nil
+	case NameFile:
+		if config.GetEventBrokerFile() == "" {
+			return nil, fmt.Errorf("the %s broker requires a file", NameFile)
+		}
+		return NewFileBroker(config.GetEventBrokerFile())
+	case NameMemory:
+		return NewInMemoryBroker(), nil
+	}
+	return nil, fmt.Errorf("unknown broker %q", config.GetEventBroker())
+}
+
+// Close releases the resources the broker holds, e.g. the file of the file broker. No event may be published to the
+// broker afterwards.
+func Close(broker Broker) error {
+	if closer, ok := broker.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
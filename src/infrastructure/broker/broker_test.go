@@ -0,0 +1,142 @@
+package broker
+
+import (
+	"RentalManagement/logic/model"
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type TestBrokerConfig struct {
+	broker string
+	file   string
+}
+
+func (c *TestBrokerConfig) GetEventBroker() string {
+	return c.broker
+}
+
+func (c *TestBrokerConfig) GetEventBrokerFile() string {
+	return c.file
+}
+
+var event = model.RentalEvent{
+	EventId:    "Lw7yPz3RkA0sXe5D",
+	Type:       model.TrunkAccessGranted,
+	OccurredAt: time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC),
+	RentalId:   "rZ6IIwcD",
+	Vin:        "WVWAA71K08W201030",
+	CustomerId: "d9COw9vI",
+	TrunkAccessValidityPeriod: &model.TimePeriod{
+		StartDate: time.Date(2023, 3, 2, 6, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 3, 2, 7, 0, 0, 0, time.UTC),
+	},
+}
+
+const eventJson = `{"eventId":"Lw7yPz3RkA0sXe5D","type":"trunkAccessGranted","occurredAt":"2023-03-02T05:00:00Z",` +
+	`"rentalId":"rZ6IIwcD","vin":"WVWAA71K08W201030","customerId":"d9COw9vI",` +
+	`"trunkAccessValidityPeriod":{"startDate":"2023-03-02T06:00:00Z","endDate":"2023-03-02T07:00:00Z"}}`
+
+func TestInMemoryBroker(t *testing.T) {
+	broker := NewInMemoryBroker()
+
+	assert.Nil(t, broker.Publish(context.Background(), event))
+	assert.Nil(t, broker.Publish(context.Background(), event))
+
+	assert.Equal(t, []model.RentalEvent{event, event}, broker.Events())
+}
+
+func TestWriterBroker(t *testing.T) {
+	var buffer bytes.Buffer
+	broker := NewWriterBroker(&buffer)
+
+	assert.Nil(t, broker.Publish(context.Background(), event))
+	assert.Nil(t, broker.Publish(context.Background(), event))
+
+	assert.Equal(t, eventJson+"\n"+eventJson+"\n", buffer.String())
+}
+
+func TestFileBroker_appends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	assert.Nil(t, os.WriteFile(path, []byte(eventJson+"\n"), 0o644))
+
+	broker, err := NewFileBroker(path)
+	assert.Nil(t, err)
+	assert.Nil(t, broker.Publish(context.Background(), event))
+
+	content, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, eventJson+"\n"+eventJson+"\n", string(content))
+}
+
+func TestClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	fileBroker, err := NewFileBroker(path)
+	assert.Nil(t, err)
+
+	assert.Nil(t, Close(fileBroker))
+	assert.ErrorIs(t, fileBroker.Publish(context.Background(), event), os.ErrClosed)
+
+	// a writer passed to NewWriterBroker is left open
+	var buffer bytes.Buffer
+	writerBroker := NewWriterBroker(&buffer)
+	assert.Nil(t, Close(writerBroker))
+	assert.Nil(t, writerBroker.Publish(context.Background(), event))
+	assert.Nil(t, Close(NewInMemoryBroker()))
+}
+
+func TestNewBroker(t *testing.T) {
+	stdoutBroker, err := NewBroker(&TestBrokerConfig{broker: NameStdout})
+	assert.Nil(t, err)
+	assert.IsType(t, &WriterBroker{}, stdoutBroker)
+
+	fileBroker, err := NewBroker(&TestBrokerConfig{broker: NameFile, file: filepath.Join(t.TempDir(), "events")})
+	assert.Nil(t, err)
+	assert.IsType(t, &WriterBroker{}, fileBroker)
+
+	memoryBroker, err := NewBroker(&TestBrokerConfig{broker: NameMemory})
+	assert.Nil(t, err)
+	assert.IsType(t, &InMemoryBroker{}, memoryBroker)
+}
+
+func TestNewBroker_invalidConfig(t *testing.T) {
+	_, err := NewBroker(&TestBrokerConfig{broker: NameFile})
+	assert.NotNil(t, err)
+
+	_, err = NewBroker(&TestBrokerConfig{broker: "kafka"})
+	assert.NotNil(t, err)
+}
+
+type failingBroker struct {
+	err error
+}
+
+func (b *failingBroker) Publish(context.Context, model.RentalEvent) error {
+	return b.err
+}
+
+func TestFanOutBroker(t *testing.T) {
+	first := NewInMemoryBroker()
+	second := NewInMemoryBroker()
+	broker := NewFanOutBroker(first, second)
+
+	assert.Nil(t, broker.Publish(context.Background(), event))
+
+	assert.Equal(t, []model.RentalEvent{event}, first.Events())
+	assert.Equal(t, []model.RentalEvent{event}, second.Events())
+}
+
+func TestFanOutBroker_stopsAtFailure(t *testing.T) {
+	publishError := errors.New("publish failed")
+	last := NewInMemoryBroker()
+	broker := NewFanOutBroker(&failingBroker{err: publishError}, last)
+
+	assert.ErrorIs(t, broker.Publish(context.Background(), event), publishError)
+
+	assert.Empty(t, last.Events())
+}
@@ -0,0 +1,26 @@
+package broker
+
+import (
+	"RentalManagement/logic/model"
+	"context"
+)
+
+// FanOutBroker publishes every event to several brokers in the given order. It stops at the first broker that
+// fails, so the event is published again to all brokers in the next round. Hence, all but the last broker should
+// tolerate publishing an event twice.
+type FanOutBroker struct {
+	brokers []Broker
+}
+
+func NewFanOutBroker(brokers ...Broker) *FanOutBroker {
+	return &FanOutBroker{brokers: brokers}
+}
+
+func (b *FanOutBroker) Publish(ctx context.Context, event model.RentalEvent) error {
+	for _, broker := range b.brokers {
+		if err := broker.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package broker
+
+import (
+	"RentalManagement/logic/model"
+	"context"
+	"sync"
+)
+
+// InMemoryBroker keeps the published events in memory. It is meant for local use and testing, the events are not
+// delivered to other services.
+type InMemoryBroker struct {
+	mutex  sync.Mutex
+	events []model.RentalEvent
+}
+
+func NewInMemoryBroker() *InMemoryBroker {
+	return &InMemoryBroker{}
+}
+
+func (b *InMemoryBroker) Publish(_ context.Context, event model.RentalEvent) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.events = append(b.events, event)
+	return nil
+}
+
+// Events returns the events published so far in the order they have been published
+func (b *InMemoryBroker) Events() []model.RentalEvent {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	events := make([]model.RentalEvent, len(b.events))
+	copy(events, b.events)
+	return events
+}
@@ -0,0 +1,54 @@
+package broker
+
+import (
+	"RentalManagement/logic/model"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+)
+
+// WriterBroker writes each published event as a line of JSON to a writer, e.g. stdout or a file. It is meant for
+// local use and for setups in which another process forwards the lines to the actual message broker.
+type WriterBroker struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+	// file is the file the broker has opened itself and closes, nil if the caller owns the writer
+	file *os.File
+}
+
+func NewWriterBroker(writer io.Writer) *WriterBroker {
+	return &WriterBroker{encoder: json.NewEncoder(writer)}
+}
+
+// NewFileBroker creates a WriterBroker that appends the events to the file at the given path. The file is created if
+// it does not exist.
+func NewFileBroker(path string) (*WriterBroker, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	broker := NewWriterBroker(file)
+	broker.file = file
+	return broker, nil
+}
+
+func (b *WriterBroker) Publish(_ context.Context, event model.RentalEvent) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	// the encoder terminates each event with a newline
+	return b.encoder.Encode(event)
+}
+
+// Close closes the file of a broker created by NewFileBroker. A writer passed to NewWriterBroker is left open.
+func (b *WriterBroker) Close() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.file == nil {
+		return nil
+	}
+	return b.file.Close()
+}
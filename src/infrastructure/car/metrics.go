@@ -0,0 +1,51 @@
+package car
+
+import (
+	"RentalManagement/metrics"
+	"context"
+	carTypes "github.com/ccsapp/cargotypes"
+	"net/http"
+	"time"
+)
+
+// instrumentedClient records the latency and the errors of the calls of a client per endpoint of the Car server
+type instrumentedClient struct {
+	client ClientWithResponsesInterface
+}
+
+// NewInstrumentedClient returns a client that performs its calls with the given client and records their latency
+// and errors as metrics
+func NewInstrumentedClient(client ClientWithResponsesInterface) ClientWithResponsesInterface {
+	return &instrumentedClient{client: client}
+}
+
+func (c *instrumentedClient) GetCarsWithResponse(ctx context.Context) (*GetCarsResponse, error) {
+	start := time.Now()
+	response, err := c.client.GetCarsWithResponse(ctx)
+	observe("getCars", start, err, func() int { return response.StatusCode() })
+	return response, err
+}
+
+func (c *instrumentedClient) GetCarWithResponse(ctx context.Context, vin carTypes.VinParam) (*GetCarResponse, error) {
+	start := time.Now()
+	response, err := c.client.GetCarWithResponse(ctx, vin)
+	observe("getCar", start, err, func() int { return response.StatusCode() })
+	return response, err
+}
+
+func (c *instrumentedClient) ChangeTrunkLockStateWithResponse(ctx context.Context, vin carTypes.VinParam,
+	body carTypes.DynamicDataLockState) (*ChangeTrunkLockStateResponse, error) {
+	start := time.Now()
+	response, err := c.client.ChangeTrunkLockStateWithResponse(ctx, vin, body)
+	observe("changeTrunkLockState", start, err, func() int { return response.StatusCode() })
+	return response, err
+}
+
+// observe records a call to an endpoint that started at the given time. The status code is only requested if the
+// call did not fail.
+func observe(endpoint string, start time.Time, err error, statusCode func() int) {
+	metrics.CarRequestDuration.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
+	if err != nil || statusCode() >= http.StatusInternalServerError {
+		metrics.CarRequestErrors.WithLabelValues(endpoint).Inc()
+	}
+}
@@ -0,0 +1,70 @@
+package car_test
+
+import (
+	"RentalManagement/infrastructure/car"
+	"RentalManagement/metrics"
+	"RentalManagement/mocks"
+	"context"
+	"errors"
+	carTypes "github.com/ccsapp/cargotypes"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+const vin = "WVWAA71K08W201030"
+
+func TestInstrumentedClient_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	response := &car.GetCarResponse{HTTPResponse: &http.Response{StatusCode: http.StatusOK}}
+
+	mockClient := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockClient.EXPECT().GetCarWithResponse(ctx, vin).Return(response, nil)
+	errorCount := testutil.ToFloat64(metrics.CarRequestErrors.WithLabelValues("getCar"))
+
+	returnedResponse, err := car.NewInstrumentedClient(mockClient).GetCarWithResponse(ctx, vin)
+
+	assert.Nil(t, err)
+	assert.Equal(t, response, returnedResponse)
+	assert.Equal(t, errorCount, testutil.ToFloat64(metrics.CarRequestErrors.WithLabelValues("getCar")))
+}
+
+func TestInstrumentedClient_serverError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	response := &car.ChangeTrunkLockStateResponse{HTTPResponse: &http.Response{StatusCode: http.StatusBadGateway}}
+
+	mockClient := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockClient.EXPECT().ChangeTrunkLockStateWithResponse(ctx, vin, carTypes.LOCKED).Return(response, nil)
+	errorCount := testutil.ToFloat64(metrics.CarRequestErrors.WithLabelValues("changeTrunkLockState"))
+
+	_, err := car.NewInstrumentedClient(mockClient).ChangeTrunkLockStateWithResponse(ctx, vin, carTypes.LOCKED)
+
+	assert.Nil(t, err)
+	assert.Equal(t, errorCount+1,
+		testutil.ToFloat64(metrics.CarRequestErrors.WithLabelValues("changeTrunkLockState")))
+}
+
+func TestInstrumentedClient_error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	expectedErr := errors.New("connection refused")
+
+	mockClient := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockClient.EXPECT().GetCarsWithResponse(ctx).Return(nil, expectedErr)
+	errorCount := testutil.ToFloat64(metrics.CarRequestErrors.WithLabelValues("getCars"))
+
+	_, err := car.NewInstrumentedClient(mockClient).GetCarsWithResponse(ctx)
+
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, errorCount+1, testutil.ToFloat64(metrics.CarRequestErrors.WithLabelValues("getCars")))
+}
@@ -0,0 +1,20 @@
+package car
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Ping checks that the Car server is reachable and responds without a server error. It requests the VINs of the cars,
+// which is the cheapest call of the Car API.
+func Ping(ctx context.Context, client ClientWithResponsesInterface) error {
+	response, err := client.GetCarsWithResponse(ctx)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode() >= http.StatusInternalServerError {
+		return fmt.Errorf("the Car server responded with %d", response.StatusCode())
+	}
+	return nil
+}
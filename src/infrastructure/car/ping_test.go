@@ -0,0 +1,42 @@
+package car_test
+
+import (
+	"RentalManagement/infrastructure/car"
+	"RentalManagement/mocks"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestPing(t *testing.T) {
+	connectionRefused := errors.New("connection refused")
+
+	for _, testCase := range []struct {
+		name     string
+		response *car.GetCarsResponse
+		err      error
+		healthy  bool
+	}{
+		{"ok", &car.GetCarsResponse{HTTPResponse: &http.Response{StatusCode: http.StatusOK}}, nil, true},
+		{"clientError", &car.GetCarsResponse{HTTPResponse: &http.Response{StatusCode: http.StatusNotFound}}, nil, true},
+		{"serverError", &car.GetCarsResponse{HTTPResponse: &http.Response{StatusCode: http.StatusBadGateway}}, nil,
+			false},
+		{"unreachable", nil, connectionRefused, false},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockClient := mocks.NewMockClientWithResponsesInterface(ctrl)
+			mockClient.EXPECT().GetCarsWithResponse(ctx).Return(testCase.response, testCase.err)
+
+			err := car.Ping(ctx, mockClient)
+
+			assert.Equal(t, testCase.healthy, err == nil)
+		})
+	}
+}
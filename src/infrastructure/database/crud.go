@@ -8,13 +8,40 @@ import (
 	"RentalManagement/infrastructure/database/mappers"
 	"RentalManagement/logic/model"
 	"RentalManagement/logic/rentalErrors"
+	"RentalManagement/metrics"
 	"RentalManagement/util"
 	"context"
 	"errors"
+	"time"
 )
 
 const CollectionBaseName = "rentals"
 
+// TrunkAccessLogCollectionBaseName is the base name of the append-only trunk access audit log collection
+const TrunkAccessLogCollectionBaseName = "trunkAccessLog"
+
+// RelockJobCollectionBaseName is the base name of the collection of pending trunk relock jobs
+const RelockJobCollectionBaseName = "relockJobs"
+
+// OutboxCollectionBaseName is the base name of the outbox collection of rental events that have not been published
+const OutboxCollectionBaseName = "outbox"
+
+// pendingEventsField is the array of a car that holds the events of changes of its rentals until they are moved to
+// the outbox
+const pendingEventsField = "pendingEvents"
+
+// WebhookCollectionBaseName is the base name of the collection of webhook subscriptions
+const WebhookCollectionBaseName = "webhooks"
+
+// WebhookDeliveryCollectionBaseName is the base name of the collection of pending and completed webhook deliveries
+const WebhookDeliveryCollectionBaseName = "webhookDeliveries"
+
+// ReminderCollectionBaseName is the base name of the collection of reminders that have been sent to customers
+const ReminderCollectionBaseName = "reminders"
+
+// CalendarSubscriptionCollectionBaseName is the base name of the collection of calendar subscriptions of customers
+const CalendarSubscriptionCollectionBaseName = "calendarSubscriptions"
+
 var OptimisticLockingError = errors.New("optimistic locking failed")
 
 type CrudConfig interface {
@@ -31,11 +58,13 @@ type ICRUD interface {
 	// SetTrunkToken sets the trunk token of a rental.
 	// Any old trunk token is overwritten.
 	// The validity period of the trunk token is restricted to the validity period of the rental.
+	// If the trunk token is recurring, the validity period is further restricted to the span from the first to the last
+	// occurrence within it.
 	// The resulting trunk token written to the database is returned (nil if any error occurred).
 	// If the rental does not exist, rentalErrors.ErrRentalNotFound is returned.
 	// If the rental is not active, rentalErrors.ErrRentalNotActive is returned.
-	// If the rental is not active at any time during the validity period,
-	// rentalErrors.ErrRentalNotOverlapping is returned.
+	// If the rental is not active at any time during the validity period (or, for recurring trunk tokens, during any
+	// occurrence), rentalErrors.ErrRentalNotOverlapping is returned.
 	// This method uses optimistic locking for race condition safety.
 	// If an optimistic locking error occurs, the method is retried up to 2 times.
 	// If the optimistic locking error persists, OptimisticLockingError is returned.
@@ -44,22 +73,109 @@ type ICRUD interface {
 	GetRental(ctx context.Context, rentalId model.RentalId) (*model.Rental, error)
 	// GetNextRental returns the active or next upcoming rental of a car. If there is no next rental, nil is returned.
 	GetNextRental(ctx context.Context, vin model.Vin) (*model.Rental, error)
-	// GetTrunkAccess returns the trunk access token of a rental.
+	// GetTrunkAccessRental returns the rental the trunk access token belongs to. The token of the rental is set.
 	// If token is not registered with the car with the provided vin, rentalErrors.ErrTrunkAccessDenied is returned.
-	GetTrunkAccess(ctx context.Context, vin model.Vin, token model.TrunkAccessToken) (*model.TrunkAccess, error)
+	GetTrunkAccessRental(ctx context.Context, vin model.Vin, token model.TrunkAccessToken) (*model.Rental, error)
+
+	// AddTrunkAccessLogEntry appends an entry to the trunk access audit log. Entries are never changed or removed.
+	AddTrunkAccessLogEntry(ctx context.Context, entry model.TrunkAccessLogEntry) error
+	// GetTrunkAccessLogOfRental returns all trunk access log entries of a rental, the oldest entry first.
+	GetTrunkAccessLogOfRental(ctx context.Context, rentalId model.RentalId) (*[]model.TrunkAccessLogEntry, error)
+	// GetTrunkAccessLogOfCar returns all trunk access log entries of a car, the oldest entry first.
+	GetTrunkAccessLogOfCar(ctx context.Context, vin model.Vin) (*[]model.TrunkAccessLogEntry, error)
+	// SetAutoRelockTimeout sets the number of seconds after which an unlocked trunk is locked again during a rental.
+	// If the rental does not exist, rentalErrors.ErrRentalNotFound is returned.
+	SetAutoRelockTimeout(ctx context.Context, rentalId model.RentalId, timeoutSeconds int) error
+	// CancelRental marks an upcoming rental as cancelled, so it does not occupy its car anymore.
+	// If the rental does not exist, rentalErrors.ErrRentalNotFound is returned. If it has started or has been
+	// cancelled already, rentalErrors.ErrRentalNotUpcoming is returned.
+	CancelRental(ctx context.Context, rentalId model.RentalId) error
+	// ScheduleRelock stores a relock job. Any pending relock job of the same car is replaced.
+	ScheduleRelock(ctx context.Context, job model.RelockJob) error
+	// CancelRelock removes the pending relock job of a car. It is no error if there is none.
+	CancelRelock(ctx context.Context, vin model.Vin) error
+	// GetDueRelockJobs returns all relock jobs that are due at the given time, the earliest job first.
+	GetDueRelockJobs(ctx context.Context, now time.Time) (*[]model.RelockJob, error)
+	// CompleteRelock removes the given relock job. It is no error if the job has been replaced or cancelled in the
+	// meantime, in which case the database is not changed.
+	CompleteRelock(ctx context.Context, job model.RelockJob) error
+	// MoveRentalEventsToOutbox moves the pending rental events from the cars to the outbox. CreateRental,
+	// SetTrunkToken, SetAutoRelockTimeout and CancelRental add their events to the car of the rental with the same
+	// write as their change, so an event is pending if and only if its change has been made. An event that has been
+	// moved already is not added to the outbox again.
+	MoveRentalEventsToOutbox(ctx context.Context) error
+	// GetPendingRentalEvents returns all rental events in the outbox, the oldest event first.
+	GetPendingRentalEvents(ctx context.Context) (*[]model.RentalEvent, error)
+	// RemoveRentalEvent removes a published event from the outbox. It is no error if the event has been removed
+	// already.
+	RemoveRentalEvent(ctx context.Context, eventId string) error
+
+	// CreateWebhook stores a new webhook subscription.
+	CreateWebhook(ctx context.Context, webhook model.Webhook) error
+	// GetWebhooks returns all webhook subscriptions, the oldest first.
+	GetWebhooks(ctx context.Context) (*[]model.Webhook, error)
+	// GetWebhook returns the webhook subscription with the given ID.
+	// If the webhook does not exist, rentalErrors.ErrWebhookNotFound is returned.
+	GetWebhook(ctx context.Context, webhookId model.WebhookId) (*model.Webhook, error)
+	// DeleteWebhook removes the webhook subscription with the given ID. Its deliveries are kept.
+	// If the webhook does not exist, rentalErrors.ErrWebhookNotFound is returned.
+	DeleteWebhook(ctx context.Context, webhookId model.WebhookId) error
+	// AddWebhookDelivery stores a new webhook delivery. It is no error if a delivery with the same ID exists already,
+	// in which case the database is not changed.
+	AddWebhookDelivery(ctx context.Context, delivery model.WebhookDelivery) error
+	// UpdateWebhookDelivery overwrites the stored webhook delivery with the same ID.
+	UpdateWebhookDelivery(ctx context.Context, delivery model.WebhookDelivery) error
+	// GetDueWebhookDeliveries returns all pending webhook deliveries whose next attempt is due at the given time,
+	// the earliest first.
+	GetDueWebhookDeliveries(ctx context.Context, now time.Time) (*[]model.WebhookDelivery, error)
+	// GetWebhookDeliveries returns all deliveries of a webhook, the newest first.
+	GetWebhookDeliveries(ctx context.Context, webhookId model.WebhookId) (*[]model.WebhookDelivery, error)
+
+	// GetRentalsStartingIn returns all rentals that start after the start and no later than the end of the period.
+	GetRentalsStartingIn(ctx context.Context, period model.TimePeriod) (*[]model.Rental, error)
+	// GetRentalsEndingIn returns all rentals that end after the start and no later than the end of the period.
+	GetRentalsEndingIn(ctx context.Context, period model.TimePeriod) (*[]model.Rental, error)
+	// GetRentalsInPeriod returns all rentals that overlap with the period.
+	GetRentalsInPeriod(ctx context.Context, period model.TimePeriod) (*[]model.Rental, error)
+	// IsReminderSent returns whether the given reminder has been recorded as sent.
+	IsReminderSent(ctx context.Context, reminder model.Reminder) (bool, error)
+	// AddSentReminder records that the given reminder has been sent at the given time. It is no error if the reminder
+	// has been recorded already, in which case the database is not changed.
+	AddSentReminder(ctx context.Context, reminder model.Reminder, sentAt time.Time) error
+
+	// SetCalendarToken stores the token of the calendar subscription of a customer. Any previous token of the
+	// customer is replaced.
+	SetCalendarToken(ctx context.Context, customerId model.CustomerId, token model.CalendarToken) error
+	// GetCalendarCustomer returns the customer the calendar subscription token belongs to.
+	// If the token is unknown, rentalErrors.ErrCalendarNotFound is returned.
+	GetCalendarCustomer(ctx context.Context, token model.CalendarToken) (model.CustomerId, error)
 }
 
 type crud struct {
-	db           db.IConnection
-	collection   string
-	timeProvider util.ITimeProvider
+	db                       db.IConnection
+	collection               string
+	trunkAccessLogCollection string
+	relockJobCollection      string
+	outboxCollection         string
+	webhookCollection        string
+	deliveryCollection       string
+	reminderCollection       string
+	calendarCollection       string
+	timeProvider             util.ITimeProvider
 }
 
 func NewICRUD(db db.IConnection, config CrudConfig, provider util.ITimeProvider) ICRUD {
 	return &crud{
-		db:           db,
-		collection:   config.GetAppCollectionPrefix() + CollectionBaseName,
-		timeProvider: provider,
+		db:                       db,
+		collection:               config.GetAppCollectionPrefix() + CollectionBaseName,
+		trunkAccessLogCollection: config.GetAppCollectionPrefix() + TrunkAccessLogCollectionBaseName,
+		relockJobCollection:      config.GetAppCollectionPrefix() + RelockJobCollectionBaseName,
+		outboxCollection:         config.GetAppCollectionPrefix() + OutboxCollectionBaseName,
+		webhookCollection:        config.GetAppCollectionPrefix() + WebhookCollectionBaseName,
+		deliveryCollection:       config.GetAppCollectionPrefix() + WebhookDeliveryCollectionBaseName,
+		reminderCollection:       config.GetAppCollectionPrefix() + ReminderCollectionBaseName,
+		calendarCollection:       config.GetAppCollectionPrefix() + CalendarSubscriptionCollectionBaseName,
+		timeProvider:             provider,
 	}
 }
 
@@ -68,17 +184,17 @@ func (c *crud) GetUnavailableCars(ctx context.Context, timePeriod model.TimePeri
 
 	factory := c.db.GetFactory()
 
-	// startDate <= timePeriod.EndDate AND endDate >= timePeriod.StartDate
+	// startDate <= timePeriod.EndDate AND endDate >= timePeriod.StartDate, cancelled rentals do not occupy the car
 	// this call creates cars that have only their VIN set
 	err := c.db.FindMany(
 		ctx,
 		c.collection,
 		factory.FilterElementMatch(
 			"rentals",
-			factory.FilterAnd(
+			notCancelled(factory, "", factory.FilterAnd(
 				factory.FilterLess("rentalPeriod.startDate", timePeriod.EndDate),
 				factory.FilterGreater("rentalPeriod.endDate", timePeriod.StartDate),
-			),
+			)),
 		),
 		&db.Options{Projection: factory.ProjectionID()},
 		&cars,
@@ -105,6 +221,14 @@ func (c *crud) CreateRental(ctx context.Context, vin model.Vin, customerId model
 		RentalPeriod: mappers.MapTimePeriodToDb(&timePeriod),
 	}
 
+	event := c.newRentalEvent(model.RentalEvent{
+		Type:         model.RentalCreated,
+		RentalId:     rental.RentalId,
+		Vin:          vin,
+		CustomerId:   customerId,
+		RentalPeriod: &timePeriod,
+	})
+
 	err := c.db.UpdateOne(
 		ctx,
 		c.collection,
@@ -113,23 +237,25 @@ func (c *crud) CreateRental(ctx context.Context, vin model.Vin, customerId model
 			factory.FilterNot(
 				factory.FilterElementMatch(
 					"rentals",
-					factory.FilterAnd(
+					notCancelled(factory, "", factory.FilterAnd(
 						factory.FilterLess("rentalPeriod.startDate", timePeriod.EndDate),
 						factory.FilterGreater("rentalPeriod.endDate", timePeriod.StartDate),
-					),
+					)),
 				),
 			),
 		),
-		factory.UpdatePush("rentals", rental),
+		factory.UpdateCombine(
+			factory.UpdatePush("rentals", rental),
+			factory.UpdatePush(pendingEventsField, event),
+		),
 		true,
 	)
 
-	// If the update failed because of a duplicate key error, there exists a car (because of the duplicate key error)
-	// that has a conflicting rental (because upsert chose insert)
+	// If the update failed because of a duplicate key error, there exists a car (because of the duplicate key
+	// error) that has a conflicting rental (because upsert chose insert)
 	if errors.Is(err, db.DuplicateKeyError) {
 		return rentalErrors.ErrConflictingRentalExists
 	}
-
 	return err
 }
 
@@ -163,6 +289,9 @@ func (c *crud) SetTrunkToken(ctx context.Context, rentalId model.RentalId,
 
 	// if an optimistic locking error occurs, try again (but only twice)
 	for i := 0; i < 3; i++ {
+		if i > 0 {
+			metrics.TrunkTokenRetries.Inc()
+		}
 		returnedAccess, err = c.trySetTrunkToken(ctx, rentalId, trunkAccess)
 
 		if !errors.Is(err, OptimisticLockingError) {
@@ -170,7 +299,10 @@ func (c *crud) SetTrunkToken(ctx context.Context, rentalId model.RentalId,
 		}
 	}
 
-	return returnedAccess, err
+	if err != nil {
+		return nil, err
+	}
+	return returnedAccess, nil
 }
 
 func (c *crud) trySetTrunkToken(ctx context.Context, rentalId model.RentalId,
@@ -221,23 +353,48 @@ func (c *crud) trySetTrunkToken(ctx context.Context, rentalId model.RentalId,
 		return nil, rentalErrors.ErrRentalNotOverlapping
 	}
 
+	if trunkAccess.Recurrence != nil {
+		// the token is only usable during occurrences, so cut the validity period to the first and last one
+		restrictedValidityPeriod = trunkAccess.Recurrence.RestrictTo(restrictedValidityPeriod)
+		if restrictedValidityPeriod == nil {
+			return nil, rentalErrors.ErrRentalNotOverlapping
+		}
+	}
+
 	trunkAccess.ValidityPeriod = *restrictedValidityPeriod
 
 	trunkTokenEntity := mappers.MapTokenToDb(&trunkAccess)
 
+	// the token itself is a secret, so only its validity period is published
+	event := c.newRentalEvent(model.RentalEvent{
+		Type:                      model.TrunkAccessGranted,
+		RentalId:                  rentalId,
+		Vin:                       cars[0].Vin,
+		CustomerId:                rentalEntity.CustomerId,
+		TrunkAccessValidityPeriod: &trunkAccess.ValidityPeriod,
+	})
+
 	// Optimistic Locking: If the rental changed in the meantime, the update will not do anything
 	// (i.e. return NoDocumentsError)
+	unchanged := factory.FilterMatch(rentalEntity)
+	if rentalEntity.TrunkToken == nil {
+		// a missing token is omitted from the match, so a token set in the meantime has to be excluded explicitly
+		unchanged = factory.FilterAnd(unchanged, factory.FilterEqual("trunkToken", nil))
+	}
 	err = c.db.UpdateOne(
 		ctx,
 		c.collection,
 		factory.FilterElementMatch(
 			"rentals",
-			factory.FilterMatch(rentalEntity),
+			unchanged,
 		),
-		factory.UpdateMatchingArrayElement(
-			"rentals",
-			"trunkToken",
-			*trunkTokenEntity,
+		factory.UpdateCombine(
+			factory.UpdateMatchingArrayElement(
+				"rentals",
+				"trunkToken",
+				*trunkTokenEntity,
+			),
+			factory.UpdatePush(pendingEventsField, event),
 		),
 		false, // no upsert
 	)
@@ -286,7 +443,8 @@ func (c *crud) GetNextRental(ctx context.Context, vin model.Vin) (*model.Rental,
 		"rentals",
 		factory.FilterAnd(
 			factory.FilterEqual("_id", vin),
-			factory.FilterGreater("rentals.rentalPeriod.endDate", c.timeProvider.Now()),
+			notCancelled(factory, "rentals.", factory.FilterGreater("rentals.rentalPeriod.endDate",
+				c.timeProvider.Now())),
 		),
 		1, //limit to 1
 		factory.SortAsc("rentals.rentalPeriod.startDate"),
@@ -303,7 +461,7 @@ func (c *crud) GetNextRental(ctx context.Context, vin model.Vin) (*model.Rental,
 	return &rental[0], nil
 }
 
-func (c *crud) GetTrunkAccess(ctx context.Context, vin model.Vin, token model.TrunkAccessToken) (*model.TrunkAccess,
+func (c *crud) GetTrunkAccessRental(ctx context.Context, vin model.Vin, token model.TrunkAccessToken) (*model.Rental,
 	error) {
 
 	var cars []entities.Car
@@ -332,5 +490,496 @@ func (c *crud) GetTrunkAccess(ctx context.Context, vin model.Vin, token model.Tr
 	}
 
 	rentals := mappers.MapCarFromDbToRentals(&cars[0], c.timeProvider)
-	return rentals[0].Token, nil
+	return &rentals[0], nil
+}
+
+func (c *crud) AddTrunkAccessLogEntry(ctx context.Context, entry model.TrunkAccessLogEntry) error {
+	_, err := c.db.Insert(
+		ctx,
+		c.trunkAccessLogCollection,
+		mappers.MapTrunkAccessLogEntryToDb(&entry, util.GenerateRandomString(16)),
+	)
+	return err
+}
+
+func (c *crud) GetTrunkAccessLogOfRental(ctx context.Context, rentalId model.RentalId) (
+	*[]model.TrunkAccessLogEntry, error) {
+
+	factory := c.db.GetFactory()
+	return c.getTrunkAccessLog(ctx, factory.FilterEqual("rentalId", rentalId))
+}
+
+func (c *crud) GetTrunkAccessLogOfCar(ctx context.Context, vin model.Vin) (*[]model.TrunkAccessLogEntry, error) {
+	factory := c.db.GetFactory()
+	return c.getTrunkAccessLog(ctx, factory.FilterEqual("vin", vin))
+}
+
+func (c *crud) getTrunkAccessLog(ctx context.Context, filter db.Filter) (*[]model.TrunkAccessLogEntry, error) {
+	var entries []entities.TrunkAccessLogEntry
+
+	factory := c.db.GetFactory()
+
+	err := c.db.FindMany(
+		ctx,
+		c.trunkAccessLogCollection,
+		filter,
+		&db.Options{Sort: factory.SortAsc("timestamp")},
+		&entries,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	logEntries := mappers.MapTrunkAccessLogFromDb(&entries)
+	return &logEntries, nil
+}
+
+func (c *crud) SetAutoRelockTimeout(ctx context.Context, rentalId model.RentalId, timeoutSeconds int) error {
+	// the event names the car and the customer of the rental, which never change, so they are read beforehand
+	rental, err := c.GetRental(ctx, rentalId)
+	if err != nil {
+		return err
+	}
+
+	event := c.newRentalEvent(model.RentalEvent{
+		Type:       model.RentalChanged,
+		RentalId:   rentalId,
+		Vin:        rental.Car.Vin,
+		CustomerId: rental.Customer.CustomerId,
+		AutoRelock: &model.AutoRelock{TimeoutSeconds: timeoutSeconds},
+	})
+
+	factory := c.db.GetFactory()
+
+	err = c.db.UpdateOne(
+		ctx,
+		c.collection,
+		factory.FilterElementMatch(
+			"rentals",
+			factory.FilterEqual("rentalId", rentalId),
+		),
+		factory.UpdateCombine(
+			factory.UpdateMatchingArrayElement(
+				"rentals",
+				"autoRelockTimeout",
+				timeoutSeconds,
+			),
+			factory.UpdatePush(pendingEventsField, event),
+		),
+		false, // no upsert
+	)
+
+	if errors.Is(err, db.NoDocumentsError) {
+		return rentalErrors.ErrRentalNotFound
+	}
+	return err
+}
+
+func (c *crud) CancelRental(ctx context.Context, rentalId model.RentalId) error {
+	// the event names the car and the customer of the rental, which never change, so they are read beforehand
+	rental, err := c.GetRental(ctx, rentalId)
+	if err != nil {
+		return err
+	}
+	if rental.State != model.UPCOMING {
+		return rentalErrors.ErrRentalNotUpcoming
+	}
+
+	now := c.timeProvider.Now()
+	event := c.newRentalEvent(model.RentalEvent{
+		Type:       model.RentalCancelled,
+		RentalId:   rentalId,
+		Vin:        rental.Car.Vin,
+		CustomerId: rental.Customer.CustomerId,
+	})
+
+	factory := c.db.GetFactory()
+
+	// the rental must still be upcoming when it is updated, i.e. it must not have started or been cancelled in the
+	// meantime
+	err = c.db.UpdateOne(
+		ctx,
+		c.collection,
+		factory.FilterElementMatch(
+			"rentals",
+			notCancelled(factory, "", factory.FilterAnd(
+				factory.FilterEqual("rentalId", rentalId),
+				factory.FilterGreaterEqual("rentalPeriod.startDate", now),
+			)),
+		),
+		factory.UpdateCombine(
+			factory.UpdateMatchingArrayElement(
+				"rentals",
+				"cancelledAt",
+				now,
+			),
+			factory.UpdatePush(pendingEventsField, event),
+		),
+		false, // no upsert
+	)
+
+	// rentals are never removed, so the rental is not upcoming anymore
+	if errors.Is(err, db.NoDocumentsError) {
+		return rentalErrors.ErrRentalNotUpcoming
+	}
+	return err
+}
+
+func (c *crud) ScheduleRelock(ctx context.Context, job model.RelockJob) error {
+	factory := c.db.GetFactory()
+
+	// there is at most one job per car (the VIN is the ID), so a newer job replaces the pending one
+	return c.db.UpdateOne(
+		ctx,
+		c.relockJobCollection,
+		factory.FilterEqual("_id", job.Vin),
+		factory.UpdateMultiple(mappers.MapRelockJobToDb(&job)),
+		true,
+	)
+}
+
+func (c *crud) CancelRelock(ctx context.Context, vin model.Vin) error {
+	factory := c.db.GetFactory()
+
+	err := c.db.DeleteOne(ctx, c.relockJobCollection, factory.FilterEqual("_id", vin))
+	if errors.Is(err, db.NoDocumentsError) {
+		return nil
+	}
+	return err
+}
+
+func (c *crud) GetDueRelockJobs(ctx context.Context, now time.Time) (*[]model.RelockJob, error) {
+	var jobs []entities.RelockJob
+
+	factory := c.db.GetFactory()
+
+	err := c.db.FindMany(
+		ctx,
+		c.relockJobCollection,
+		factory.FilterLessEqual("dueAt", now),
+		&db.Options{Sort: factory.SortAsc("dueAt")},
+		&jobs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	relockJobs := mappers.MapRelockJobsFromDb(&jobs)
+	return &relockJobs, nil
+}
+
+func (c *crud) CompleteRelock(ctx context.Context, job model.RelockJob) error {
+	factory := c.db.GetFactory()
+
+	// only delete the job if it is unchanged, a replacing job must not get lost
+	err := c.db.DeleteOne(ctx, c.relockJobCollection, factory.FilterMatch(mappers.MapRelockJobToDb(&job)))
+	if errors.Is(err, db.NoDocumentsError) {
+		return nil
+	}
+	return err
+}
+
+// newRentalEvent returns the entity of an event with its ID and time set. It must be pushed to the pending events of
+// the car by the same update as the change it reports.
+func (c *crud) newRentalEvent(event model.RentalEvent) entities.RentalEvent {
+	event.EventId = util.GenerateRandomString(16)
+	event.OccurredAt = c.timeProvider.Now()
+
+	return mappers.MapRentalEventToDb(&event)
+}
+
+// notCancelled adds the condition that the cancellation time of the rental is null to the filter, so that it only
+// matches rentals that are not cancelled. The prefix is prepended to the field name of the cancellation time.
+func notCancelled(factory db.QueryFactory, prefix string, filter db.Filter) db.Filter {
+	return factory.FilterAnd(factory.FilterEqual(prefix+"cancelledAt", nil), filter)
+}
+
+func (c *crud) MoveRentalEventsToOutbox(ctx context.Context) error {
+	var cars []entities.Car
+
+	factory := c.db.GetFactory()
+
+	// cars without pending events have no event IDs to compare, which counts as null
+	err := c.db.FindMany(
+		ctx,
+		c.collection,
+		factory.FilterNot(factory.FilterEqual(pendingEventsField+"._id", nil)),
+		nil,
+		&cars,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, car := range cars {
+		for _, event := range car.PendingEvents {
+			// an event whose removal from the car failed before is in the outbox already
+			_, err = c.db.Insert(ctx, c.outboxCollection, event)
+			if err != nil && !errors.Is(err, db.DuplicateKeyError) {
+				return err
+			}
+
+			err = c.db.UpdateOne(
+				ctx,
+				c.collection,
+				factory.FilterEqual("_id", car.Vin),
+				factory.UpdatePull(pendingEventsField, factory.FilterEqual("_id", event.EventId)),
+				false, // no upsert
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *crud) GetPendingRentalEvents(ctx context.Context) (*[]model.RentalEvent, error) {
+	var events []entities.RentalEvent
+
+	factory := c.db.GetFactory()
+
+	err := c.db.FindMany(
+		ctx,
+		c.outboxCollection,
+		factory.FilterEverything(),
+		&db.Options{Sort: factory.SortAsc("occurredAt")},
+		&events,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rentalEvents := mappers.MapRentalEventsFromDb(&events)
+	return &rentalEvents, nil
+}
+
+func (c *crud) RemoveRentalEvent(ctx context.Context, eventId string) error {
+	factory := c.db.GetFactory()
+
+	err := c.db.DeleteOne(ctx, c.outboxCollection, factory.FilterEqual("_id", eventId))
+	if errors.Is(err, db.NoDocumentsError) {
+		return nil
+	}
+	return err
+}
+
+func (c *crud) CreateWebhook(ctx context.Context, webhook model.Webhook) error {
+	_, err := c.db.Insert(ctx, c.webhookCollection, mappers.MapWebhookToDb(&webhook))
+	return err
+}
+
+func (c *crud) GetWebhooks(ctx context.Context) (*[]model.Webhook, error) {
+	var webhooks []entities.Webhook
+
+	factory := c.db.GetFactory()
+
+	err := c.db.FindMany(
+		ctx,
+		c.webhookCollection,
+		factory.FilterEverything(),
+		&db.Options{Sort: factory.SortAsc("createdAt")},
+		&webhooks,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookModels := mappers.MapWebhooksFromDb(&webhooks)
+	return &webhookModels, nil
+}
+
+func (c *crud) GetWebhook(ctx context.Context, webhookId model.WebhookId) (*model.Webhook, error) {
+	var webhook entities.Webhook
+
+	factory := c.db.GetFactory()
+
+	err := c.db.FindOne(ctx, c.webhookCollection, factory.FilterEqual("_id", webhookId), nil, &webhook)
+	if errors.Is(err, db.NoDocumentsError) {
+		return nil, rentalErrors.ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	webhookModel := mappers.MapWebhookFromDb(&webhook)
+	return &webhookModel, nil
+}
+
+func (c *crud) DeleteWebhook(ctx context.Context, webhookId model.WebhookId) error {
+	factory := c.db.GetFactory()
+
+	err := c.db.DeleteOne(ctx, c.webhookCollection, factory.FilterEqual("_id", webhookId))
+	if errors.Is(err, db.NoDocumentsError) {
+		return rentalErrors.ErrWebhookNotFound
+	}
+	return err
+}
+
+func (c *crud) AddWebhookDelivery(ctx context.Context, delivery model.WebhookDelivery) error {
+	// the relay publishes events at least once, so the delivery may have been added in an earlier round
+	_, err := c.db.Insert(ctx, c.deliveryCollection, mappers.MapWebhookDeliveryToDb(&delivery))
+	if errors.Is(err, db.DuplicateKeyError) {
+		return nil
+	}
+	return err
+}
+
+func (c *crud) UpdateWebhookDelivery(ctx context.Context, delivery model.WebhookDelivery) error {
+	factory := c.db.GetFactory()
+
+	return c.db.UpdateOne(
+		ctx,
+		c.deliveryCollection,
+		factory.FilterEqual("_id", delivery.Id),
+		factory.UpdateMultiple(mappers.MapWebhookDeliveryToDb(&delivery)),
+		false, // no upsert
+	)
+}
+
+func (c *crud) GetDueWebhookDeliveries(ctx context.Context, now time.Time) (*[]model.WebhookDelivery, error) {
+	factory := c.db.GetFactory()
+
+	return c.getWebhookDeliveries(
+		ctx,
+		factory.FilterAnd(
+			factory.FilterEqual("state", model.PENDING),
+			factory.FilterLessEqual("nextAttemptAt", now),
+		),
+		factory.SortAsc("nextAttemptAt"),
+	)
+}
+
+func (c *crud) GetWebhookDeliveries(ctx context.Context, webhookId model.WebhookId) (
+	*[]model.WebhookDelivery, error) {
+
+	factory := c.db.GetFactory()
+	return c.getWebhookDeliveries(ctx, factory.FilterEqual("webhookId", webhookId), factory.SortDesc("createdAt"))
+}
+
+func (c *crud) getWebhookDeliveries(ctx context.Context, filter db.Filter, sort db.Sort) (
+	*[]model.WebhookDelivery, error) {
+
+	var deliveries []entities.WebhookDelivery
+
+	err := c.db.FindMany(ctx, c.deliveryCollection, filter, &db.Options{Sort: sort}, &deliveries)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryModels := mappers.MapWebhookDeliveriesFromDb(&deliveries)
+	return &deliveryModels, nil
+}
+
+func (c *crud) GetRentalsStartingIn(ctx context.Context, period model.TimePeriod) (*[]model.Rental, error) {
+	return c.getRentalsWithDateIn(ctx, "rentals.rentalPeriod.startDate", period)
+}
+
+func (c *crud) GetRentalsEndingIn(ctx context.Context, period model.TimePeriod) (*[]model.Rental, error) {
+	return c.getRentalsWithDateIn(ctx, "rentals.rentalPeriod.endDate", period)
+}
+
+func (c *crud) getRentalsWithDateIn(ctx context.Context, dateField string, period model.TimePeriod) (
+	*[]model.Rental, error) {
+
+	var cars []entities.Car
+
+	factory := c.db.GetFactory()
+
+	err := c.db.Aggregate(
+		ctx, c.collection, factory.ArrayFilterAggregation(
+			"rentals",
+			notCancelled(factory, "rentals.", factory.FilterAnd(
+				factory.FilterGreater(dateField, period.StartDate),
+				factory.FilterLessEqual(dateField, period.EndDate),
+			)),
+			-1, //no limit
+			nil,
+		), &cars,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rentals := mappers.MapCarsFromDbToRentals(&cars, c.timeProvider)
+
+	return &rentals, nil
+}
+
+func (c *crud) GetRentalsInPeriod(ctx context.Context, period model.TimePeriod) (*[]model.Rental, error) {
+	var cars []entities.Car
+
+	factory := c.db.GetFactory()
+
+	// same overlap condition as in GetUnavailableCars
+	err := c.db.Aggregate(
+		ctx, c.collection, factory.ArrayFilterAggregation(
+			"rentals",
+			factory.FilterAnd(
+				factory.FilterLess("rentals.rentalPeriod.startDate", period.EndDate),
+				factory.FilterGreater("rentals.rentalPeriod.endDate", period.StartDate),
+			),
+			-1, //no limit
+			nil,
+		), &cars,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rentals := mappers.MapCarsFromDbToRentals(&cars, c.timeProvider)
+
+	return &rentals, nil
+}
+
+func (c *crud) IsReminderSent(ctx context.Context, reminder model.Reminder) (bool, error) {
+	var sentReminder entities.SentReminder
+
+	factory := c.db.GetFactory()
+
+	err := c.db.FindOne(ctx, c.reminderCollection, factory.FilterEqual("_id", reminder.Id()), nil, &sentReminder)
+	if errors.Is(err, db.NoDocumentsError) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *crud) AddSentReminder(ctx context.Context, reminder model.Reminder, sentAt time.Time) error {
+	_, err := c.db.Insert(ctx, c.reminderCollection, mappers.MapSentReminderToDb(&reminder, sentAt))
+	if errors.Is(err, db.DuplicateKeyError) {
+		return nil
+	}
+	return err
+}
+
+func (c *crud) SetCalendarToken(ctx context.Context, customerId model.CustomerId, token model.CalendarToken) error {
+	factory := c.db.GetFactory()
+
+	// there is at most one subscription per customer (the customer ID is the ID), so the previous URL stops working
+	return c.db.UpdateOne(
+		ctx,
+		c.calendarCollection,
+		factory.FilterEqual("_id", customerId),
+		factory.UpdateMultiple(mappers.MapCalendarSubscriptionToDb(customerId, token)),
+		true,
+	)
+}
+
+func (c *crud) GetCalendarCustomer(ctx context.Context, token model.CalendarToken) (model.CustomerId, error) {
+	var subscription entities.CalendarSubscription
+
+	factory := c.db.GetFactory()
+
+	err := c.db.FindOne(ctx, c.calendarCollection, factory.FilterEqual("token", token), nil, &subscription)
+	if errors.Is(err, db.NoDocumentsError) {
+		return "", rentalErrors.ErrCalendarNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return subscription.CustomerId, nil
 }
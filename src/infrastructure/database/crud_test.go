@@ -3,1802 +3,1663 @@ package database
 import (
 	"RentalManagement/infrastructure/database/db"
 	"RentalManagement/infrastructure/database/entities"
-	"RentalManagement/infrastructure/database/mappers"
 	"RentalManagement/logic/model"
 	"RentalManagement/logic/rentalErrors"
-	"RentalManagement/mocks"
+	"RentalManagement/metrics"
 	"context"
 	"errors"
-	"github.com/golang/mock/gomock"
-	"github.com/stretchr/testify/assert"
+	"fmt"
+	"os"
 	"testing"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
 )
 
-var collectionPrefix = "collectionPrefix"
+// The scenarios run against real backends: always against the in-memory database and against MongoDB if
+// MONGODB_CONNECTION_STRING and MONGODB_DATABASE_NAME are set. Database errors and concurrent writes are injected by
+// wrapping the connection.
 
-type TestCrudConfig struct{}
+type testDatabaseConfig struct{}
 
-func (c *TestCrudConfig) GetAppCollectionPrefix() string {
-	return collectionPrefix
+func (c *testDatabaseConfig) GetMongoDbConnectionString() string {
+	return os.Getenv("MONGODB_CONNECTION_STRING")
 }
 
-var config = &TestCrudConfig{}
+func (c *testDatabaseConfig) GetMongoDbDatabase() string {
+	return os.Getenv("MONGODB_DATABASE_NAME")
+}
 
-var timePeriod2023 = model.TimePeriod{
-	StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-	EndDate:   time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+type testCrudConfig struct {
+	collectionPrefix string
 }
 
-func TestCrud_GetUnavailableCars_success(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+func (c *testCrudConfig) GetAppCollectionPrefix() string {
+	return c.collectionPrefix
+}
 
-	ctx := context.Background()
+// testClock is the time provider of the scenarios, whose time the scenarios set
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+var eventTime = time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC)
 
-	factory := &db.PseudoFactory{}
+var timePeriod2023 = model.TimePeriod{
+	StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	EndDate:   time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+}
 
-	mockTime := mocks.NewMockITimeProvider(ctrl)
+var backends = []struct {
+	name    string
+	connect func(t *testing.T) db.IConnection
+}{
+	{"memory", func(t *testing.T) db.IConnection {
+		return db.NewMemoryConnection()
+	}},
+	{"mongo", func(t *testing.T) db.IConnection {
+		config := &testDatabaseConfig{}
+		if config.GetMongoDbConnectionString() == "" || config.GetMongoDbDatabase() == "" {
+			t.Skip("MONGODB_CONNECTION_STRING and MONGODB_DATABASE_NAME are not set")
+		}
+		connection, err := db.NewDbConnection(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return connection
+	}},
+}
 
-	timePeriod := model.TimePeriod{
-		StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-		EndDate:   time.Date(2052, 12, 31, 0, 0, 0, 0, time.UTC),
+// runOnBackends runs the scenario against each backend with empty collections. The clock is set to eventTime.
+func runOnBackends(t *testing.T, scenario func(t *testing.T, crud ICRUD, clock *testClock)) {
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			connection := backend.connect(t)
+			// generate a collection prefix so that concurrent executions do not interfere
+			prefix := fmt.Sprintf("test-%d-", time.Now().UnixNano())
+			t.Cleanup(func() {
+				for _, baseName := range []string{CollectionBaseName, TrunkAccessLogCollectionBaseName,
+					RelockJobCollectionBaseName, OutboxCollectionBaseName, WebhookCollectionBaseName,
+					WebhookDeliveryCollectionBaseName, ReminderCollectionBaseName,
+					CalendarSubscriptionCollectionBaseName} {
+					assert.Nil(t, connection.DropCollection(context.Background(), prefix+baseName))
+				}
+				assert.Nil(t, connection.CleanUpDatabase())
+			})
+
+			clock := &testClock{now: eventTime}
+			scenario(t, NewICRUD(connection, &testCrudConfig{collectionPrefix: prefix}, clock), clock)
+		})
 	}
+}
 
-	var cars = []entities.Car{
-		{
-			Vin: "SAJWA0ES6DPS56028",
-		},
-		{
-			Vin: "1G1ZB5ST5GF123456",
-		},
+// months returns the period from the first day of the start month to the first day of the end month in 2023
+func months(startMonth, endMonth time.Month) model.TimePeriod {
+	return model.TimePeriod{
+		StartDate: time.Date(2023, startMonth, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, endMonth, 1, 0, 0, 0, 0, time.UTC),
 	}
+}
 
-	var expectedVins = []model.Vin{"SAJWA0ES6DPS56028", "1G1ZB5ST5GF123456"}
+func rentalIds(rentals *[]model.Rental) []model.RentalId {
+	ids := []model.RentalId{}
+	for _, rental := range *rentals {
+		ids = append(ids, rental.Id)
+	}
+	return ids
+}
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
-	mockConnection.EXPECT().GetFactory().Return(factory)
-	mockConnection.EXPECT().FindMany(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.FilterElementMatch(
-			"rentals",
-			factory.FilterAnd(
-				factory.FilterLess("rentalPeriod.startDate", timePeriod.EndDate),
-				factory.FilterGreater("rentalPeriod.endDate", timePeriod.StartDate),
-			),
-		),
-		&db.Options{Projection: factory.ProjectionID()},
-		gomock.Any(),
-	).SetArg(4, cars).Return(nil)
+// rentalOf returns the only rental of the customer
+func rentalOf(t *testing.T, crud ICRUD, customerId model.CustomerId) model.Rental {
+	rentals, err := crud.GetRentalsOfCustomer(context.Background(), customerId)
+	if err != nil || len(*rentals) != 1 {
+		t.Fatalf("the customer %s has no single rental: %v", customerId, err)
+	}
+	return (*rentals)[0]
+}
 
-	crud := NewICRUD(mockConnection, config, mockTime)
-	vins, err := crud.GetUnavailableCars(ctx, timePeriod)
+// createRental creates a rental and returns it. The customer must not have other rentals.
+func createRental(t *testing.T, crud ICRUD, vin model.Vin, customerId model.CustomerId,
+	period model.TimePeriod) model.Rental {
 
-	assert.Nil(t, err)
-	assert.Equal(t, expectedVins, *vins)
+	if err := crud.CreateRental(context.Background(), vin, customerId, period); err != nil {
+		t.Fatal(err)
+	}
+	return rentalOf(t, crud, customerId)
 }
 
-func TestCrud_GetUnavailableCars_successEmpty(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
+// publishedEvents moves the pending rental events to the outbox and returns them, the oldest event first
+func publishedEvents(t *testing.T, crud ICRUD) []model.RentalEvent {
 	ctx := context.Background()
-
-	factory := &db.PseudoFactory{}
-
-	mockTime := mocks.NewMockITimeProvider(ctrl)
-
-	timePeriod := model.TimePeriod{
-		StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-		EndDate:   time.Date(2052, 12, 31, 0, 0, 0, 0, time.UTC),
+	if err := crud.MoveRentalEventsToOutbox(ctx); err != nil {
+		t.Fatal(err)
+	}
+	events, err := crud.GetPendingRentalEvents(ctx)
+	if err != nil {
+		t.Fatal(err)
 	}
+	return *events
+}
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
-	mockConnection.EXPECT().GetFactory().Return(factory)
-	mockConnection.EXPECT().FindMany(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.FilterElementMatch(
-			"rentals",
-			factory.FilterAnd(
-				factory.FilterLess("rentalPeriod.startDate", timePeriod.EndDate),
-				factory.FilterGreater("rentalPeriod.endDate", timePeriod.StartDate),
-			),
-		),
-		&db.Options{Projection: factory.ProjectionID()},
-		gomock.Any(),
-	).SetArg(4, []entities.Car{}).Return(nil)
+// assertEvent asserts that the event equals the expected one apart from its randomly generated ID
+func assertEvent(t *testing.T, expected model.RentalEvent, actual model.RentalEvent) {
+	assert.Len(t, actual.EventId, 16)
+	expected.EventId = actual.EventId
+	assert.Equal(t, expected, actual)
+}
 
-	crud := NewICRUD(mockConnection, config, mockTime)
-	vins, err := crud.GetUnavailableCars(ctx, timePeriod)
+var errDatabase = errors.New("database error")
 
-	assert.Nil(t, err)
+// failingConnection fails the operations of the given methods with errDatabase
+type failingConnection struct {
+	db.IConnection
+	methods []string
+}
 
-	// nil means empty
-	assert.Equal(t, []string{}, *vins)
+func (c *failingConnection) fails(method string) bool {
+	for _, failingMethod := range c.methods {
+		if failingMethod == method {
+			return true
+		}
+	}
+	return false
 }
 
-func TestCrud_GetUnavailableCars_databaseError(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+func (c *failingConnection) Insert(ctx context.Context, collection string, document any) (db.ID, error) {
+	if c.fails("Insert") {
+		return "", errDatabase
+	}
+	return c.IConnection.Insert(ctx, collection, document)
+}
 
-	databaseError := errors.New("database error")
+func (c *failingConnection) FindOne(ctx context.Context, collection string, filter db.Filter, options *db.Options,
+	result any) error {
+	if c.fails("FindOne") {
+		return errDatabase
+	}
+	return c.IConnection.FindOne(ctx, collection, filter, options, result)
+}
 
-	ctx := context.Background()
+func (c *failingConnection) FindMany(ctx context.Context, collection string, filter db.Filter, options *db.Options,
+	results any) error {
+	if c.fails("FindMany") {
+		return errDatabase
+	}
+	return c.IConnection.FindMany(ctx, collection, filter, options, results)
+}
 
-	factory := &db.PseudoFactory{}
+func (c *failingConnection) UpdateOne(ctx context.Context, collection string, filter db.Filter, update db.Update,
+	upsert bool) error {
+	if c.fails("UpdateOne") {
+		return errDatabase
+	}
+	return c.IConnection.UpdateOne(ctx, collection, filter, update, upsert)
+}
 
-	mockTime := mocks.NewMockITimeProvider(ctrl)
+func (c *failingConnection) DeleteOne(ctx context.Context, collection string, filter db.Filter) error {
+	if c.fails("DeleteOne") {
+		return errDatabase
+	}
+	return c.IConnection.DeleteOne(ctx, collection, filter)
+}
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
-	mockConnection.EXPECT().GetFactory().Return(factory)
-	mockConnection.EXPECT().FindMany(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.FilterElementMatch(
-			"rentals",
-			factory.FilterAnd(
-				factory.FilterLess("rentalPeriod.startDate", timePeriod2023.EndDate),
-				factory.FilterGreater("rentalPeriod.endDate", timePeriod2023.StartDate),
-			),
-		),
-		&db.Options{Projection: factory.ProjectionID()},
-		gomock.Any(),
-	).Return(databaseError)
+func (c *failingConnection) Aggregate(ctx context.Context, collection string, pipeline db.Pipeline,
+	results any) error {
+	if c.fails("Aggregate") {
+		return errDatabase
+	}
+	return c.IConnection.Aggregate(ctx, collection, pipeline, results)
+}
 
-	crud := NewICRUD(mockConnection, config, mockTime)
-	vins, err := crud.GetUnavailableCars(ctx, timePeriod2023)
+// failing returns a copy of crud whose operations of the given methods fail with errDatabase
+func failing(original ICRUD, methods ...string) ICRUD {
+	copied := *original.(*crud)
+	copied.db = &failingConnection{IConnection: copied.db, methods: methods}
+	return &copied
+}
 
-	assert.ErrorIs(t, err, databaseError)
-	assert.Nil(t, vins)
+// interleavingConnection runs a concurrent write before each of the first writes of UpdateOne, so that the rental
+// changes between reading and updating it
+type interleavingConnection struct {
+	db.IConnection
+	writes []func()
 }
 
-func TestCrud_CreateRental_success(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+func (c *interleavingConnection) UpdateOne(ctx context.Context, collection string, filter db.Filter,
+	update db.Update, upsert bool) error {
+	if len(c.writes) > 0 {
+		write := c.writes[0]
+		c.writes = c.writes[1:]
+		write()
+	}
+	return c.IConnection.UpdateOne(ctx, collection, filter, update, upsert)
+}
 
-	ctx := context.Background()
+// interleaved returns a copy of crud whose updates are preceded by the given concurrent writes
+func interleaved(original ICRUD, writes ...func()) ICRUD {
+	copied := *original.(*crud)
+	copied.db = &interleavingConnection{IConnection: copied.db, writes: writes}
+	return &copied
+}
 
-	factory := &db.PseudoFactory{}
-
-	mockTime := mocks.NewMockITimeProvider(ctrl)
-
-	vin := "SAJWA0ES6DPS56028"
-	customerId := "jJ8mNg6Z"
-
-	mockConnection := mocks.NewMockIConnection(ctrl)
-	mockConnection.EXPECT().GetFactory().Return(factory)
-	mockConnection.EXPECT().UpdateOne(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.FilterAnd(
-			factory.FilterEqual("_id", vin),
-			factory.FilterNot(
-				factory.FilterElementMatch(
-					"rentals",
-					factory.FilterAnd(
-						factory.FilterLess("rentalPeriod.startDate", timePeriod2023.EndDate),
-						factory.FilterGreater("rentalPeriod.endDate", timePeriod2023.StartDate),
-					),
-				),
-			),
-		),
-		gomock.Any(),
-		true,
-	).Do(func(_ context.Context, _ string, _ interface{}, update db.Update, _ bool) {
-		// since the rental id is random, we can't check it
-		// and need to check everything else manually
-		fieldName, value, err := factory.UnpackPushUpdate(update)
-		assert.Nil(t, err)
-		assert.Equal(t, "rentals", fieldName)
-
-		rental, ok := value.(entities.Rental)
-		assert.True(t, ok)
-		assert.Equal(t, customerId, rental.CustomerId)
-		assert.Equal(t, timePeriod2023.StartDate, rental.RentalPeriod.StartDate)
-		assert.Equal(t, timePeriod2023.EndDate, rental.RentalPeriod.EndDate)
-		assert.Equal(t, 8, len(rental.RentalId))
-	}).Return(nil)
-
-	crud := NewICRUD(mockConnection, config, mockTime)
-
-	err := crud.CreateRental(ctx, vin, customerId, timePeriod2023)
-	assert.Nil(t, err)
+// deleteCarOf deletes the car of the rental together with all its rentals
+func deleteCarOf(ctx context.Context, original ICRUD, rentalId model.RentalId) error {
+	backend := original.(*crud)
+	return backend.db.DeleteOne(ctx, backend.collection,
+		backend.db.GetFactory().FilterEqual("rentals.rentalId", rentalId))
 }
 
-func TestCrud_CreateRental_databaseError(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+// setRentalPeriod changes the period of the rental without adding an event
+func setRentalPeriod(ctx context.Context, original ICRUD, rentalId model.RentalId, period model.TimePeriod) error {
+	backend := original.(*crud)
+	factory := backend.db.GetFactory()
+	return backend.db.UpdateOne(ctx, backend.collection,
+		factory.FilterElementMatch("rentals", factory.FilterEqual("rentalId", rentalId)),
+		factory.UpdateMatchingArrayElement("rentals", "rentalPeriod",
+			entities.TimePeriod{StartDate: period.StartDate, EndDate: period.EndDate}),
+		false)
+}
 
-	ctx := context.Background()
+func TestCrud_GetUnavailableCars_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		createRental(t, crud, "car1", "customer1", months(5, 7))
+		createRental(t, crud, "car1", "customer2", months(7, 8))
+		createRental(t, crud, "car2", "customer3", months(8, 9))
+		cancelled := createRental(t, crud, "car3", "customer4", months(7, 9))
+		assert.Nil(t, crud.CancelRental(ctx, cancelled.Id))
+
+		vins, err := crud.GetUnavailableCars(ctx, months(6, 8))
+		assert.Nil(t, err)
+		assert.Equal(t, []model.Vin{"car1"}, *vins)
 
-	factory := &db.PseudoFactory{}
+		vins, err = crud.GetUnavailableCars(ctx, months(7, 9))
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []model.Vin{"car1", "car2"}, *vins)
+	})
+}
 
-	mockTime := mocks.NewMockITimeProvider(ctrl)
+func TestCrud_GetUnavailableCars_successEmpty(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		createRental(t, crud, "car1", "customer1", months(5, 7))
 
-	vin := "SAJWA0ES6DPS56028"
-	customerId := "jJ8mNg6Z"
+		// the periods touch, but do not overlap
+		vins, err := crud.GetUnavailableCars(ctx, months(7, 9))
+		assert.Nil(t, err)
+		assert.Empty(t, *vins)
+	})
+}
 
-	databaseError := errors.New("database error")
+func TestCrud_GetUnavailableCars_databaseError(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		vins, err := failing(crud, "FindMany").GetUnavailableCars(context.Background(), months(7, 9))
+		assert.ErrorIs(t, err, errDatabase)
+		assert.Nil(t, vins)
+	})
+}
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
-	mockConnection.EXPECT().GetFactory().Return(factory)
-	mockConnection.EXPECT().UpdateOne(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		gomock.Any(),
-		gomock.Any(),
-		true,
-	).Return(databaseError)
+func TestCrud_CreateRental_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		assert.Nil(t, crud.CreateRental(ctx, "car1", "customer1", months(7, 8)))
+		// a rental of another car may overlap
+		assert.Nil(t, crud.CreateRental(ctx, "car2", "customer2", months(7, 8)))
+		// a rental of the same car may follow
+		assert.Nil(t, crud.CreateRental(ctx, "car1", "customer3", months(8, 9)))
+
+		rental := rentalOf(t, crud, "customer1")
+		assert.Len(t, rental.Id, 8)
+		assert.Equal(t, model.Rental{
+			State:        model.UPCOMING,
+			Car:          &model.Car{Vin: "car1"},
+			Id:           rental.Id,
+			Customer:     &model.Customer{CustomerId: "customer1"},
+			RentalPeriod: months(7, 8),
+		}, rental)
+
+		events := publishedEvents(t, crud)
+		if assert.Len(t, events, 3) {
+			period := months(7, 8)
+			assertEvent(t, model.RentalEvent{
+				Type:         model.RentalCreated,
+				OccurredAt:   eventTime,
+				RentalId:     rental.Id,
+				Vin:          "car1",
+				CustomerId:   "customer1",
+				RentalPeriod: &period,
+			}, events[0])
+		}
+	})
+}
 
-	crud := NewICRUD(mockConnection, config, mockTime)
+func TestCrud_CreateRental_databaseError(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		err := failing(crud, "UpdateOne").CreateRental(ctx, "car1", "customer1", months(7, 8))
+		assert.ErrorIs(t, err, errDatabase)
 
-	err := crud.CreateRental(ctx, vin, customerId, timePeriod2023)
-	assert.ErrorIs(t, err, databaseError)
+		rentals, err := crud.GetRentalsOfCustomer(ctx, "customer1")
+		assert.Nil(t, err)
+		assert.Empty(t, *rentals)
+	})
 }
 
 func TestCrud_CreateRental_conflict(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		createRental(t, crud, "car1", "customer1", months(5, 7))
 
-	ctx := context.Background()
-
-	factory := &db.PseudoFactory{}
-
-	mockTime := mocks.NewMockITimeProvider(ctrl)
-
-	vin := "SAJWA0ES6DPS56028"
-	customerId := "jJ8mNg6Z"
-
-	mockConnection := mocks.NewMockIConnection(ctrl)
-	mockConnection.EXPECT().GetFactory().Return(factory)
-	mockConnection.EXPECT().UpdateOne(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		gomock.Any(),
-		gomock.Any(),
-		true,
-	).Return(db.DuplicateKeyError)
-
-	crud := NewICRUD(mockConnection, config, mockTime)
+		err := crud.CreateRental(ctx, "car1", "customer2", months(6, 8))
+		assert.ErrorIs(t, err, rentalErrors.ErrConflictingRentalExists)
 
-	err := crud.CreateRental(ctx, vin, customerId, timePeriod2023)
-	assert.ErrorIs(t, err, rentalErrors.ErrConflictingRentalExists)
+		// the conflicting rental has been neither stored nor published
+		rentals, err := crud.GetRentalsOfCustomer(ctx, "customer2")
+		assert.Nil(t, err)
+		assert.Empty(t, *rentals)
+		assert.Len(t, publishedEvents(t, crud), 1)
+	})
 }
 
 func TestCrud_GetRentalsOfCustomer_success_NoRentals(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
-	customerId := "jJ8mNg6Z"
-
-	mockConnection := mocks.NewMockIConnection(ctrl)
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
-
-	var car1 = entities.Car{
-		Vin:     "WVWAA71K08W201030",
-		Rentals: []entities.Rental{},
-	}
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		createRental(t, crud, "car1", "customer1", months(5, 7))
 
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterEqual("rentals.customer", customerId),
-			-1,
-			nil,
-		),
-		gomock.Any(),
-	).SetArg(3, []entities.Car{car1}).Return(nil)
-
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	returnedRentals, err := crud.GetRentalsOfCustomer(ctx, customerId)
-
-	assert.Nil(t, err)
-	assert.Equal(t, &[]model.Rental{}, returnedRentals)
+		rentals, err := crud.GetRentalsOfCustomer(context.Background(), "customer2")
+		assert.Nil(t, err)
+		assert.Empty(t, *rentals)
+	})
 }
 
 func TestCrud_GetRentalsOfCustomer_success(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	ctx := context.Background()
-
-	mockConnection := mocks.NewMockIConnection(ctrl)
-
-	factory := db.PseudoFactory{}
-
-	customerId := "jJ8mNg6Z"
-
-	var car1 = entities.Car{
-		Vin: "WVWAA71K08W201030",
-		Rentals: []entities.Rental{
-			{
-				RentalId:   "rZ6IIwcD",
-				CustomerId: customerId,
-				RentalPeriod: entities.TimePeriod{
-					EndDate:   time.Date(2023, 4, 3, 1, 0, 0, 0, time.UTC),
-					StartDate: time.Date(2023, 3, 2, 3, 0, 0, 0, time.UTC),
-				},
-				TrunkToken: nil,
-			},
-		},
-	}
-
-	var car2 = entities.Car{
-		Vin: "AVWAA71K08W201031",
-		Rentals: []entities.Rental{
-			{
-				RentalId:   "rZ6I8waD",
-				CustomerId: customerId,
-				RentalPeriod: entities.TimePeriod{
-					EndDate:   time.Date(2023, 3, 3, 1, 0, 0, 0, time.UTC),
-					StartDate: time.Date(2023, 1, 2, 3, 0, 0, 0, time.UTC),
-				},
-				TrunkToken: &entities.TrunkAccessToken{
-					Token: "bumrLuCMbumrLuCMbumrLuCM",
-					ValidityPeriod: entities.TimePeriod{
-						EndDate:   time.Date(2023, 2, 3, 1, 0, 0, 0, time.UTC),
-						StartDate: time.Date(2023, 2, 2, 3, 0, 0, 0, time.UTC),
-					},
-				},
-			},
-		},
-	}
-
-	var rentalModel1 = model.Rental{
-		State:    model.ACTIVE,
-		Car:      &model.Car{Vin: "WVWAA71K08W201030"},
-		Id:       "rZ6IIwcD",
-		Customer: &model.Customer{CustomerId: customerId},
-		RentalPeriod: model.TimePeriod{
-			EndDate:   time.Date(2023, 4, 3, 1, 0, 0, 0, time.UTC),
-			StartDate: time.Date(2023, 3, 2, 3, 0, 0, 0, time.UTC),
-		},
-		Token: nil,
-	}
-
-	var rentalModel2 = model.Rental{
-		State:    model.EXPIRED,
-		Car:      &model.Car{Vin: "AVWAA71K08W201031"},
-		Id:       "rZ6I8waD",
-		Customer: &model.Customer{CustomerId: customerId},
-		RentalPeriod: model.TimePeriod{
-			EndDate:   time.Date(2023, 3, 3, 1, 0, 0, 0, time.UTC),
-			StartDate: time.Date(2023, 1, 2, 3, 0, 0, 0, time.UTC),
-		},
-		Token: &model.TrunkAccess{
-			Token: "bumrLuCMbumrLuCMbumrLuCM",
-			ValidityPeriod: model.TimePeriod{
-				EndDate:   time.Date(2023, 2, 3, 1, 0, 0, 0, time.UTC),
-				StartDate: time.Date(2023, 2, 2, 3, 0, 0, 0, time.UTC),
-			},
-		},
-	}
-
-	var cars = []entities.Car{car1, car2}
-	var rentals = []model.Rental{rentalModel1, rentalModel2}
-	var currentDate = time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
-	mockTimeProvider.EXPECT().Now().Return(currentDate)
-	mockTimeProvider.EXPECT().Now().Return(currentDate)
-
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterEqual("rentals.customer", customerId),
-			-1,
-			nil,
-		),
-		gomock.Any(),
-	).SetArg(3, cars).Return(nil)
-
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	returnedRentals, err := crud.GetRentalsOfCustomer(ctx, customerId)
-
-	assert.Nil(t, err)
-	assert.Equal(t, &rentals, returnedRentals)
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		assert.Nil(t, crud.CreateRental(ctx, "car1", "customer1", months(1, 2)))
+		assert.Nil(t, crud.CreateRental(ctx, "car1", "customer2", months(5, 7)))
+		assert.Nil(t, crud.CreateRental(ctx, "car1", "customer1", months(7, 8)))
+		assert.Nil(t, crud.CreateRental(ctx, "car2", "customer1", months(5, 7)))
+
+		rentals, err := crud.GetRentalsOfCustomer(ctx, "customer1")
+		assert.Nil(t, err)
+		if !assert.Len(t, *rentals, 3) {
+			return
+		}
+		states := map[model.TimePeriod][]model.State{}
+		for _, rental := range *rentals {
+			assert.Equal(t, "customer1", rental.Customer.CustomerId)
+			states[rental.RentalPeriod] = append(states[rental.RentalPeriod], rental.State)
+		}
+		// the state is derived from the current time
+		assert.Equal(t, map[model.TimePeriod][]model.State{
+			months(1, 2): {model.EXPIRED},
+			months(5, 7): {model.ACTIVE},
+			months(7, 8): {model.UPCOMING},
+		}, states)
+	})
 }
 
 func TestCrud_GetRentalsOfCustomer_databaseError(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
-	dbError := errors.New("db error")
-	customerId := "jJ8mNg6Z"
-
-	mockConnection := mocks.NewMockIConnection(ctrl)
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
-
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		gomock.Any(),
-		gomock.Any(),
-	).Return(dbError)
-
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	returnedRentals, err := crud.GetRentalsOfCustomer(ctx, customerId)
-
-	assert.ErrorIs(t, err, dbError)
-	assert.Nil(t, returnedRentals)
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		rentals, err := failing(crud, "Aggregate").GetRentalsOfCustomer(context.Background(), "customer1")
+		assert.ErrorIs(t, err, errDatabase)
+		assert.Nil(t, rentals)
+	})
 }
 
 func TestCrud_SetTrunkToken_success_noRestriction_existingToken(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
-
-	mockConnection := mocks.NewMockIConnection(ctrl)
-
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
-	mockTimeProvider.EXPECT().Now().Return(
-		time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC),
-	)
-
-	existingRental := entities.Rental{
-		RentalId:   "rentalId",
-		CustomerId: "customer",
-		RentalPeriod: entities.TimePeriod{
-			StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-		},
-		TrunkToken: &entities.TrunkAccessToken{
-			Token: "bumrLuCMbumrLuCMbumrLuCM",
-			ValidityPeriod: entities.TimePeriod{
-				StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-				EndDate:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-			},
-		},
-	}
-
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterEqual("rentals.rentalId", "rentalId"),
-			1, // limit to 1
-			nil,
-		),
-		gomock.Any(),
-	).SetArg(3, []entities.Car{
-		{
-			Vin:     "AVWAA71K08W201031",
-			Rentals: []entities.Rental{existingRental},
-		},
-	}).Return(nil)
-
-	newToken := model.TrunkAccess{
-		Token: "thisIsTheNewToken1234567",
-		ValidityPeriod: model.TimePeriod{
-			StartDate: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
-		},
-	}
-
-	newTokenEntity := *mappers.MapTokenToDb(&newToken)
-
-	mockConnection.EXPECT().UpdateOne(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.FilterElementMatch(
-			"rentals",
-			factory.FilterMatch(existingRental),
-		),
-		factory.UpdateMatchingArrayElement(
-			"rentals",
-			"trunkToken",
-			newTokenEntity,
-		),
-		false, // no upsert
-	).Return(nil)
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		rental := createRental(t, crud, "car1", "customer1", months(1, 12))
+		_, err := crud.SetTrunkToken(ctx, rental.Id, model.TrunkAccess{Token: "oldToken", ValidityPeriod: months(5, 7)})
+		assert.Nil(t, err)
 
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	retToken, err := crud.SetTrunkToken(ctx, "rentalId", newToken)
+		newToken := model.TrunkAccess{Token: "newToken", ValidityPeriod: months(6, 8)}
+		access, err := crud.SetTrunkToken(ctx, rental.Id, newToken)
+		assert.Nil(t, err)
+		assert.Equal(t, &newToken, access)
 
-	assert.Nil(t, err)
-	assert.Equal(t, &newToken, retToken)
+		stored, err := crud.GetRental(ctx, rental.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, &newToken, stored.Token)
+
+		events := publishedEvents(t, crud)
+		if assert.Len(t, events, 3) {
+			assertEvent(t, model.RentalEvent{
+				Type:                      model.TrunkAccessGranted,
+				OccurredAt:                eventTime,
+				RentalId:                  rental.Id,
+				Vin:                       "car1",
+				CustomerId:                "customer1",
+				TrunkAccessValidityPeriod: &newToken.ValidityPeriod,
+			}, events[2])
+		}
+	})
 }
 
 func TestCrud_SetTrunkToken_success_noRestriction_newToken(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
-
-	mockConnection := mocks.NewMockIConnection(ctrl)
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		rental := createRental(t, crud, "car1", "customer1", months(1, 12))
 
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
-	mockTimeProvider.EXPECT().Now().Return(
-		time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC),
-	)
-
-	existingRental := entities.Rental{
-		RentalId:   "rentalId",
-		CustomerId: "customer",
-		RentalPeriod: entities.TimePeriod{
-			StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-		},
-		TrunkToken: nil,
-	}
-
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterEqual("rentals.rentalId", "rentalId"),
-			1, // limit to 1
-			nil,
-		),
-		gomock.Any(),
-	).SetArg(3, []entities.Car{
-		{
-			Vin:     "AVWAA71K08W201031",
-			Rentals: []entities.Rental{existingRental},
-		},
-	}).Return(nil)
-
-	newToken := model.TrunkAccess{
-		Token: "thisIsTheNewToken1234567",
-		ValidityPeriod: model.TimePeriod{
-			StartDate: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
-		},
-	}
-
-	newTokenEntity := *mappers.MapTokenToDb(&newToken)
-
-	mockConnection.EXPECT().UpdateOne(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.FilterElementMatch(
-			"rentals",
-			factory.FilterMatch(existingRental),
-		),
-		factory.UpdateMatchingArrayElement(
-			"rentals",
-			"trunkToken",
-			newTokenEntity,
-		),
-		false, // no upsert
-	).Return(nil)
-
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	retToken, err := crud.SetTrunkToken(ctx, "rentalId", newToken)
+		newToken := model.TrunkAccess{Token: "newToken", ValidityPeriod: months(6, 8)}
+		access, err := crud.SetTrunkToken(ctx, rental.Id, newToken)
+		assert.Nil(t, err)
+		assert.Equal(t, &newToken, access)
 
-	assert.Nil(t, err)
-	assert.Equal(t, &newToken, retToken)
+		stored, err := crud.GetRental(ctx, rental.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, &newToken, stored.Token)
+		assert.Len(t, publishedEvents(t, crud), 2)
+	})
 }
 
 func TestCrud_SetTrunkToken_success_restriction_newToken(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		rental := createRental(t, crud, "car1", "customer1", months(5, 7))
 
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
+		// the validity period is restricted to the rental
+		access, err := crud.SetTrunkToken(ctx, rental.Id, model.TrunkAccess{Token: "newToken",
+			ValidityPeriod: months(4, 6)})
+		assert.Nil(t, err)
+		restricted := model.TrunkAccess{Token: "newToken", ValidityPeriod: months(5, 6)}
+		assert.Equal(t, &restricted, access)
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
+		stored, err := crud.GetRental(ctx, rental.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, &restricted, stored.Token)
 
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
-	mockTimeProvider.EXPECT().Now().Return(
-		time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC),
-	)
+		events := publishedEvents(t, crud)
+		if assert.Len(t, events, 2) {
+			assert.Equal(t, &restricted.ValidityPeriod, events[1].TrunkAccessValidityPeriod)
+		}
+	})
+}
 
-	existingRental := entities.Rental{
-		RentalId:   "rentalId",
-		CustomerId: "customer",
-		RentalPeriod: entities.TimePeriod{
+func TestCrud_SetTrunkToken_success_recurring(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		rental := createRental(t, crud, "car1", "customer1", model.TimePeriod{
 			StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
 			EndDate:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-		},
-		TrunkToken: nil,
-	}
-
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterEqual("rentals.rentalId", "rentalId"),
-			1, // limit to 1
-			nil,
-		),
-		gomock.Any(),
-	).SetArg(3, []entities.Car{
-		{
-			Vin:     "AVWAA71K08W201031",
-			Rentals: []entities.Rental{existingRental},
-		},
-	}).Return(nil)
-
-	newToken := model.TrunkAccess{
-		Token: "thisIsTheNewToken1234567",
-		ValidityPeriod: model.TimePeriod{
-			StartDate: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
-		},
-	}
-
-	newTokenRestricted := model.TrunkAccess{
-		Token: "thisIsTheNewToken1234567",
-		ValidityPeriod: model.TimePeriod{
-			StartDate: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-		},
-	}
-
-	newTokenEntity := *mappers.MapTokenToDb(&newTokenRestricted)
-
-	mockConnection.EXPECT().UpdateOne(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.FilterElementMatch(
-			"rentals",
-			factory.FilterMatch(existingRental),
-		),
-		factory.UpdateMatchingArrayElement(
-			"rentals",
-			"trunkToken",
-			newTokenEntity,
-		),
-		false, // no upsert
-	).Return(nil)
+		})
+
+		// 2023-06-01 is a Thursday, 2023-12-31 is a Sunday
+		recurrence := model.Recurrence{
+			DaysOfWeek: []model.Weekday{model.MONDAY, model.FRIDAY},
+			StartTime:  "08:00",
+			EndTime:    "12:00",
+			TimeZone:   "UTC",
+		}
+		access, err := crud.SetTrunkToken(ctx, rental.Id, model.TrunkAccess{
+			Token: "newToken",
+			ValidityPeriod: model.TimePeriod{
+				StartDate: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			Recurrence: &recurrence,
+		})
+		assert.Nil(t, err)
 
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	retToken, err := crud.SetTrunkToken(ctx, "rentalId", newToken)
+		// the validity period is restricted to the first and the last occurrence within the rental
+		restricted := model.TrunkAccess{
+			Token: "newToken",
+			ValidityPeriod: model.TimePeriod{
+				StartDate: time.Date(2023, 6, 2, 8, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2023, 12, 29, 12, 0, 0, 0, time.UTC),
+			},
+			Recurrence: &recurrence,
+		}
+		assert.Equal(t, &restricted, access)
 
-	assert.Nil(t, err)
-	assert.Equal(t, &newTokenRestricted, retToken)
+		stored, err := crud.GetRental(ctx, rental.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, &restricted, stored.Token)
+	})
 }
 
 func TestCrud_SetTrunkToken_optimisticLockingError_recoverAfter1_restrict(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		rental := createRental(t, crud, "car1", "customer1", months(1, 12))
+		trunkTokenRetries := testutil.ToFloat64(metrics.TrunkTokenRetries)
 
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
+		// the rental is shortened before the first update
+		access, err := interleaved(crud, func() {
+			assert.Nil(t, setRentalPeriod(ctx, crud, rental.Id, months(1, 7)))
+		}).SetTrunkToken(ctx, rental.Id, model.TrunkAccess{Token: "newToken", ValidityPeriod: months(1, 10)})
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
+		// the token is restricted to the new rental period after the optimistic locking error
+		assert.Nil(t, err)
+		restricted := model.TrunkAccess{Token: "newToken", ValidityPeriod: months(1, 7)}
+		assert.Equal(t, &restricted, access)
+		assert.Equal(t, trunkTokenRetries+1, testutil.ToFloat64(metrics.TrunkTokenRetries))
 
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
-	currentTime := time.Date(2023, 4, 1, 1, 0, 0, 0, time.UTC)
-	mockTimeProvider.EXPECT().Now().Return(currentTime).Times(2)
+		stored, err := crud.GetRental(ctx, rental.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, &restricted, stored.Token)
+
+		// only the successful update has added its event
+		events := publishedEvents(t, crud)
+		if assert.Len(t, events, 2) {
+			assert.Equal(t, &restricted.ValidityPeriod, events[1].TrunkAccessValidityPeriod)
+		}
+	})
+}
 
-	existingRental := entities.Rental{
-		RentalId:   "rentalId",
-		CustomerId: "customer",
-		RentalPeriod: entities.TimePeriod{
-			StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-		},
-		TrunkToken: nil,
-	}
+func TestCrud_SetTrunkToken_optimisticLockingError_recoverAfter1_rentalDisappears(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		rental := createRental(t, crud, "car1", "customer1", months(5, 7))
+
+		// the car of the rental is deleted before the rental is updated
+		access, err := interleaved(crud, func() {
+			assert.Nil(t, deleteCarOf(ctx, crud, rental.Id))
+		}).SetTrunkToken(ctx, rental.Id, model.TrunkAccess{Token: "newToken", ValidityPeriod: months(5, 7)})
+		assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
+		assert.Nil(t, access)
+
+		_, err = crud.GetRental(ctx, rental.Id)
+		assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
+	})
+}
 
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterEqual("rentals.rentalId", "rentalId"),
-			1, // limit to 1
-			nil,
-		),
-		gomock.Any(),
-	).SetArg(3, []entities.Car{
-		{
-			Vin:     "AVWAA71K08W201031",
-			Rentals: []entities.Rental{existingRental},
-		},
-	}).Return(nil)
-
-	newToken := model.TrunkAccess{
-		Token: "thisIsTheNewToken1234567",
-		ValidityPeriod: model.TimePeriod{
-			StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
-		},
-	}
+func TestCrud_SetTrunkToken_optimisticLockingError_recoverAfter1_rentalExpired(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		rental := createRental(t, crud, "car1", "customer1", months(5, 7))
+
+		// the rental is changed and ends before the retry reads it again
+		access, err := interleaved(crud, func() {
+			_, err := crud.SetTrunkToken(ctx, rental.Id, model.TrunkAccess{
+				Token:          "concurrentToken",
+				ValidityPeriod: months(5, 7),
+			})
+			assert.Nil(t, err)
+			clock.now = months(7, 8).EndDate
+		}).SetTrunkToken(ctx, rental.Id, model.TrunkAccess{Token: "newToken", ValidityPeriod: months(5, 7)})
+		assert.ErrorIs(t, err, rentalErrors.ErrRentalNotActive)
+		assert.Nil(t, access)
+
+		stored, err := crud.GetRental(ctx, rental.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, "concurrentToken", stored.Token.Token)
+	})
+}
 
-	newTokenEntity := *mappers.MapTokenToDb(&newToken)
-
-	mockConnection.EXPECT().UpdateOne(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.FilterElementMatch(
-			"rentals",
-			factory.FilterMatch(existingRental),
-		),
-		factory.UpdateMatchingArrayElement(
-			"rentals",
-			"trunkToken",
-			newTokenEntity,
-		),
-		false, // no upsert
-	).Return(db.NoDocumentsError)
-
-	existingRentalAfterError := entities.Rental{
-		RentalId:   "rentalId",
-		CustomerId: "remotsuc",
-		RentalPeriod: entities.TimePeriod{
-			StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
-		},
-		TrunkToken: nil,
+// concurrentToken returns a concurrent write that sets the trunk token of the rental
+func concurrentToken(t *testing.T, crud ICRUD, rentalId model.RentalId, token string) func() {
+	return func() {
+		_, err := crud.SetTrunkToken(context.Background(), rentalId, model.TrunkAccess{
+			Token:          token,
+			ValidityPeriod: months(1, 12),
+		})
+		assert.Nil(t, err)
 	}
+}
 
-	// CRUD should retry by first retrieving the rental again
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterEqual("rentals.rentalId", "rentalId"),
-			1, // limit to 1
-			nil,
-		),
-		gomock.Any(),
-	).SetArg(3, []entities.Car{
-		{
-			Vin:     "AVWAA71K08W201031",
-			Rentals: []entities.Rental{existingRentalAfterError},
-		},
-	}).Return(nil)
-
-	// the token should be restricted to the new rental period after the optimistic locking error
-	newTokenRestricted := model.TrunkAccess{
-		Token: "thisIsTheNewToken1234567",
-		ValidityPeriod: model.TimePeriod{
-			StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
-		},
-	}
+func TestCrud_SetTrunkToken_optimisticLockingError_recoverAfter2(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		rental := createRental(t, crud, "car1", "customer1", months(1, 12))
+		trunkTokenRetries := testutil.ToFloat64(metrics.TrunkTokenRetries)
+
+		access, err := interleaved(crud, concurrentToken(t, crud, rental.Id, "concurrentToken1"),
+			concurrentToken(t, crud, rental.Id, "concurrentToken2")).SetTrunkToken(ctx, rental.Id,
+			model.TrunkAccess{Token: "newToken", ValidityPeriod: months(1, 12)})
+		assert.Nil(t, err)
+		assert.Equal(t, "newToken", access.Token)
+		assert.Equal(t, trunkTokenRetries+2, testutil.ToFloat64(metrics.TrunkTokenRetries))
 
-	newTokenEntity = *mappers.MapTokenToDb(&newTokenRestricted)
+		stored, err := crud.GetRental(ctx, rental.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, access, stored.Token)
+		assert.Len(t, publishedEvents(t, crud), 4)
+	})
+}
 
-	mockConnection.EXPECT().UpdateOne(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.FilterElementMatch(
-			"rentals",
-			factory.FilterMatch(existingRentalAfterError),
-		),
-		factory.UpdateMatchingArrayElement(
-			"rentals",
-			"trunkToken",
-			newTokenEntity,
-		),
-		false, // no upsert
-	).Return(nil)
+func TestCrud_SetTrunkToken_optimisticLockingError_failAfter3(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		rental := createRental(t, crud, "car1", "customer1", months(1, 12))
+		trunkTokenRetries := testutil.ToFloat64(metrics.TrunkTokenRetries)
+
+		// after three failed updates, the optimistic locking error is returned and the concurrent write persists
+		access, err := interleaved(crud, concurrentToken(t, crud, rental.Id, "concurrentToken1"),
+			concurrentToken(t, crud, rental.Id, "concurrentToken2"),
+			concurrentToken(t, crud, rental.Id, "concurrentToken3")).SetTrunkToken(ctx, rental.Id,
+			model.TrunkAccess{Token: "newToken", ValidityPeriod: months(1, 12)})
+		assert.ErrorIs(t, err, OptimisticLockingError)
+		assert.Nil(t, access)
+		assert.Equal(t, trunkTokenRetries+2, testutil.ToFloat64(metrics.TrunkTokenRetries))
+
+		stored, err := crud.GetRental(ctx, rental.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, "concurrentToken3", stored.Token.Token)
+		assert.Len(t, publishedEvents(t, crud), 4)
+	})
+}
 
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	retToken, err := crud.SetTrunkToken(ctx, "rentalId", newToken)
+func TestCrud_SetTrunkToken_rentalNotFound(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		createRental(t, crud, "car1", "customer1", months(5, 7))
+
+		access, err := crud.SetTrunkToken(context.Background(), "unknown",
+			model.TrunkAccess{Token: "newToken", ValidityPeriod: months(5, 7)})
+		assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
+		assert.Nil(t, access)
+	})
+}
 
-	assert.Nil(t, err)
-	assert.Equal(t, &newTokenRestricted, retToken)
+func TestCrud_SetTrunkToken_dbError_aggregate(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		rental := createRental(t, crud, "car1", "customer1", months(5, 7))
+
+		access, err := failing(crud, "Aggregate").SetTrunkToken(context.Background(), rental.Id,
+			model.TrunkAccess{Token: "newToken", ValidityPeriod: months(5, 7)})
+		assert.ErrorIs(t, err, errDatabase)
+		assert.Nil(t, access)
+	})
 }
 
-func TestCrud_SetTrunkToken_optimisticLockingError_recoverAfter1_rentalDisappears(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+func TestCrud_SetTrunkToken_dbError_update(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		rental := createRental(t, crud, "car1", "customer1", months(5, 7))
+		trunkTokenRetries := testutil.ToFloat64(metrics.TrunkTokenRetries)
+
+		// a database error is not retried
+		access, err := failing(crud, "UpdateOne").SetTrunkToken(ctx, rental.Id,
+			model.TrunkAccess{Token: "newToken", ValidityPeriod: months(5, 7)})
+		assert.ErrorIs(t, err, errDatabase)
+		assert.Nil(t, access)
+		assert.Equal(t, trunkTokenRetries, testutil.ToFloat64(metrics.TrunkTokenRetries))
+
+		stored, err := crud.GetRental(ctx, rental.Id)
+		assert.Nil(t, err)
+		assert.Nil(t, stored.Token)
+	})
+}
 
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
+func TestCrud_SetTrunkToken_periodNotOverlapping(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		rental := createRental(t, crud, "car1", "customer1", months(5, 7))
+
+		access, err := crud.SetTrunkToken(context.Background(), rental.Id,
+			model.TrunkAccess{Token: "newToken", ValidityPeriod: months(8, 9)})
+		assert.ErrorIs(t, err, rentalErrors.ErrRentalNotOverlapping)
+		assert.Nil(t, access)
+		assert.Len(t, publishedEvents(t, crud), 1)
+	})
+}
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
+func TestCrud_SetTrunkToken_recurrenceNotOverlapping(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		rental := createRental(t, crud, "car1", "customer1", months(1, 12))
 
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
-	currentTime := time.Date(2023, 4, 1, 1, 0, 0, 0, time.UTC)
-	mockTimeProvider.EXPECT().Now().Return(currentTime).Times(1)
+		// 2023-06-03 and 2023-06-04 are a Saturday and a Sunday
+		access, err := crud.SetTrunkToken(context.Background(), rental.Id, model.TrunkAccess{
+			Token: "newToken",
+			ValidityPeriod: model.TimePeriod{
+				StartDate: time.Date(2023, 6, 3, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2023, 6, 5, 0, 0, 0, 0, time.UTC),
+			},
+			Recurrence: &model.Recurrence{
+				DaysOfWeek: []model.Weekday{model.MONDAY},
+				StartTime:  "08:00",
+				EndTime:    "12:00",
+				TimeZone:   "UTC",
+			},
+		})
+		assert.ErrorIs(t, err, rentalErrors.ErrRentalNotOverlapping)
+		assert.Nil(t, access)
+	})
+}
 
-	existingRental := entities.Rental{
-		RentalId:   "rentalId",
-		CustomerId: "customer",
-		RentalPeriod: entities.TimePeriod{
-			StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-		},
-		TrunkToken: nil,
-	}
+func TestCrud_SetTrunkToken_rentalNotActive(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		upcoming := createRental(t, crud, "car1", "customer1", months(7, 8))
+		expired := createRental(t, crud, "car1", "customer2", months(1, 2))
+
+		for _, rental := range []model.Rental{upcoming, expired} {
+			access, err := crud.SetTrunkToken(ctx, rental.Id,
+				model.TrunkAccess{Token: "newToken", ValidityPeriod: months(1, 12)})
+			assert.ErrorIs(t, err, rentalErrors.ErrRentalNotActive)
+			assert.Nil(t, access)
+		}
+	})
+}
 
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterEqual("rentals.rentalId", "rentalId"),
-			1, // limit to 1
-			nil,
-		),
-		gomock.Any(),
-	).SetArg(3, []entities.Car{
-		{
-			Vin:     "AVWAA71K08W201031",
-			Rentals: []entities.Rental{existingRental},
-		},
-	}).Return(nil)
-
-	newToken := model.TrunkAccess{
-		Token: "thisIsTheNewToken1234567",
-		ValidityPeriod: model.TimePeriod{
-			StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
-		},
-	}
+func TestCrud_GetRental_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		createRental(t, crud, "car1", "customer1", months(5, 7))
+		created := createRental(t, crud, "car1", "customer2", months(7, 8))
 
-	newTokenEntity := *mappers.MapTokenToDb(&newToken)
-
-	mockConnection.EXPECT().UpdateOne(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.FilterElementMatch(
-			"rentals",
-			factory.FilterMatch(existingRental),
-		),
-		factory.UpdateMatchingArrayElement(
-			"rentals",
-			"trunkToken",
-			newTokenEntity,
-		),
-		false, // no upsert
-	).Return(db.NoDocumentsError)
-
-	// CRUD should retry by first retrieving the rental again
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterEqual("rentals.rentalId", "rentalId"),
-			1, // limit to 1
-			nil,
-		),
-		gomock.Any(),
-	).Return(nil)
-
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	retToken, err := crud.SetTrunkToken(ctx, "rentalId", newToken)
-
-	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
-	assert.Nil(t, retToken)
+		rental, err := crud.GetRental(ctx, created.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, created, *rental)
+		assert.Equal(t, model.UPCOMING, rental.State)
+		assert.Equal(t, "car1", rental.Car.Vin)
+	})
 }
 
-func TestCrud_SetTrunkToken_optimisticLockingError_recoverAfter1_rentalExpired(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+func TestCrud_GetRental_rentalIdNotFoundError(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		createRental(t, crud, "car1", "customer1", months(5, 7))
 
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
+		rental, err := crud.GetRental(context.Background(), "unknown")
+		assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
+		assert.Nil(t, rental)
+	})
+}
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
+func TestCrud_GetRental_databaseError(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		rental := createRental(t, crud, "car1", "customer1", months(5, 7))
 
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
-	currentTime := time.Date(2023, 4, 1, 1, 0, 0, 0, time.UTC)
-	mockTimeProvider.EXPECT().Now().Return(currentTime).Times(2)
+		stored, err := failing(crud, "Aggregate").GetRental(context.Background(), rental.Id)
+		assert.ErrorIs(t, err, errDatabase)
+		assert.Nil(t, stored)
+	})
+}
 
-	existingRental := entities.Rental{
-		RentalId:   "rentalId",
-		CustomerId: "customer",
-		RentalPeriod: entities.TimePeriod{
-			StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-		},
-		TrunkToken: nil,
-	}
+func TestCrud_GetNextRental_success_exists(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		createRental(t, crud, "car1", "customer1", months(9, 10))
+		next := createRental(t, crud, "car1", "customer2", months(8, 9))
+		cancelled := createRental(t, crud, "car1", "customer3", months(7, 8))
+		assert.Nil(t, crud.CancelRental(ctx, cancelled.Id))
+		createRental(t, crud, "car1", "customer4", months(1, 2))
+		createRental(t, crud, "car2", "customer5", months(6, 7))
+
+		rental, err := crud.GetNextRental(ctx, "car1")
+		assert.Nil(t, err)
+		assert.Equal(t, &next, rental)
 
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterEqual("rentals.rentalId", "rentalId"),
-			1, // limit to 1
-			nil,
-		),
-		gomock.Any(),
-	).SetArg(3, []entities.Car{
-		{
-			Vin:     "AVWAA71K08W201031",
-			Rentals: []entities.Rental{existingRental},
-		},
-	}).Return(nil)
-
-	newToken := model.TrunkAccess{
-		Token: "thisIsTheNewToken1234567",
-		ValidityPeriod: model.TimePeriod{
-			StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
-		},
-	}
+		// an active rental is the next rental
+		active := createRental(t, crud, "car1", "customer6", months(5, 7))
+		rental, err = crud.GetNextRental(ctx, "car1")
+		assert.Nil(t, err)
+		assert.Equal(t, &active, rental)
+	})
+}
 
-	newTokenEntity := *mappers.MapTokenToDb(&newToken)
-
-	mockConnection.EXPECT().UpdateOne(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.FilterElementMatch(
-			"rentals",
-			factory.FilterMatch(existingRental),
-		),
-		factory.UpdateMatchingArrayElement(
-			"rentals",
-			"trunkToken",
-			newTokenEntity,
-		),
-		false, // no upsert
-	).Return(db.NoDocumentsError)
-
-	// the rental is now expired
-	existingRentalAfterError := entities.Rental{
-		RentalId:   "rentalId",
-		CustomerId: "remotsuc",
-		RentalPeriod: entities.TimePeriod{
-			StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
-		},
-		TrunkToken: nil,
-	}
+func TestCrud_GetNextRental_success_notExists(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		createRental(t, crud, "car1", "customer1", months(1, 2))
+		createRental(t, crud, "car2", "customer2", months(6, 7))
 
-	// CRUD should retry by first retrieving the rental again
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterEqual("rentals.rentalId", "rentalId"),
-			1, // limit to 1
-			nil,
-		),
-		gomock.Any(),
-	).SetArg(3, []entities.Car{
-		{
-			Vin:     "AVWAA71K08W201031",
-			Rentals: []entities.Rental{existingRentalAfterError},
-		},
-	}).Return(nil)
-
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	retToken, err := crud.SetTrunkToken(ctx, "rentalId", newToken)
-
-	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotActive)
-	assert.Nil(t, retToken)
+		rental, err := crud.GetNextRental(context.Background(), "car1")
+		assert.Nil(t, err)
+		assert.Nil(t, rental)
+	})
 }
 
-func TestCrud_SetTrunkToken_optimisticLockingError_recoverAfter2(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
+func TestCrud_GetNextRental_databaseError(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		rental, err := failing(crud, "Aggregate").GetNextRental(context.Background(), "car1")
+		assert.ErrorIs(t, err, errDatabase)
+		assert.Nil(t, rental)
+	})
+}
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
+func TestCrud_GetTrunkAccessRental_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		created := createRental(t, crud, "car1", "customer1", months(5, 7))
+		createRental(t, crud, "car1", "customer2", months(7, 8))
+		access, err := crud.SetTrunkToken(ctx, created.Id, model.TrunkAccess{Token: "token",
+			ValidityPeriod: months(5, 7)})
+		assert.Nil(t, err)
 
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
-	currentTime := time.Date(2023, 4, 1, 1, 0, 0, 0, time.UTC)
-	mockTimeProvider.EXPECT().Now().Return(currentTime).Times(3)
+		rental, err := crud.GetTrunkAccessRental(ctx, "car1", "token")
+		assert.Nil(t, err)
+		created.Token = access
+		assert.Equal(t, &created, rental)
+	})
+}
 
-	existingRental := entities.Rental{
-		RentalId:   "rentalId",
-		CustomerId: "customer",
-		RentalPeriod: entities.TimePeriod{
-			StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-		},
-		TrunkToken: nil,
-	}
+func TestCrud_GetTrunkAccessRental_trunkAccessDenied(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		created := createRental(t, crud, "car1", "customer1", months(5, 7))
+		_, err := crud.SetTrunkToken(ctx, created.Id, model.TrunkAccess{Token: "token", ValidityPeriod: months(5, 7)})
+		assert.Nil(t, err)
 
-	mockConnection.EXPECT().GetFactory().Return(&factory).Times(3)
-
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterEqual("rentals.rentalId", "rentalId"),
-			1, // limit to 1
-			nil,
-		),
-		gomock.Any(),
-	).SetArg(3, []entities.Car{
-		{
-			Vin:     "AVWAA71K08W201031",
-			Rentals: []entities.Rental{existingRental},
-		},
-	}).Return(nil).Times(3)
-
-	newToken := model.TrunkAccess{
-		Token: "thisIsTheNewToken1234567",
-		ValidityPeriod: model.TimePeriod{
-			StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
-		},
-	}
+		// the token is only registered with the car of its rental
+		rental, err := crud.GetTrunkAccessRental(ctx, "car2", "token")
+		assert.ErrorIs(t, err, rentalErrors.ErrTrunkAccessDenied)
+		assert.Nil(t, rental)
+		rental, err = crud.GetTrunkAccessRental(ctx, "car1", "unknown")
+		assert.ErrorIs(t, err, rentalErrors.ErrTrunkAccessDenied)
+		assert.Nil(t, rental)
+	})
+}
 
-	newTokenEntity := *mappers.MapTokenToDb(&newToken)
-
-	mockConnection.EXPECT().UpdateOne(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.FilterElementMatch(
-			"rentals",
-			factory.FilterMatch(existingRental),
-		),
-		factory.UpdateMatchingArrayElement(
-			"rentals",
-			"trunkToken",
-			newTokenEntity,
-		),
-		false, // no upsert
-	).Return(db.NoDocumentsError).Times(2)
-
-	mockConnection.EXPECT().UpdateOne(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.FilterElementMatch(
-			"rentals",
-			factory.FilterMatch(existingRental),
-		),
-		factory.UpdateMatchingArrayElement(
-			"rentals",
-			"trunkToken",
-			newTokenEntity,
-		),
-		false, // no upsert
-	).Return(nil)
-
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	retToken, err := crud.SetTrunkToken(ctx, "rentalId", newToken)
-
-	assert.Nil(t, err)
-	assert.Equal(t, &newToken, retToken)
+func TestCrud_GetTrunkAccessRental_databaseError(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		rental, err := failing(crud, "Aggregate").GetTrunkAccessRental(context.Background(), "car1", "token")
+		assert.ErrorIs(t, err, errDatabase)
+		assert.Nil(t, rental)
+	})
 }
 
-func TestCrud_SetTrunkToken_optimisticLockingError_failAfter3(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+var trunkAccessLogRentalId = "rZ6IIwcD"
+var trunkAccessLogLockState = model.LOCKED
+
+var trunkAccessLogEntry = model.TrunkAccessLogEntry{
+	Vin:       "WVWAA71K08W201030",
+	RentalId:  &trunkAccessLogRentalId,
+	ActorType: model.CUSTOMER,
+	Actor:     "customer@example.com",
+	Action:    model.SETLOCKSTATE,
+	LockState: &trunkAccessLogLockState,
+	Outcome:   model.GRANTED,
+	Timestamp: time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+}
 
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
+func TestCrud_AddTrunkAccessLogEntry_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		assert.Nil(t, crud.AddTrunkAccessLogEntry(ctx, trunkAccessLogEntry))
+		// entries are never merged, even if they are equal
+		assert.Nil(t, crud.AddTrunkAccessLogEntry(ctx, trunkAccessLogEntry))
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
+		log, err := crud.GetTrunkAccessLogOfCar(ctx, trunkAccessLogEntry.Vin)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.TrunkAccessLogEntry{trunkAccessLogEntry, trunkAccessLogEntry}, *log)
+	})
+}
 
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
-	currentTime := time.Date(2023, 4, 1, 1, 0, 0, 0, time.UTC)
-	mockTimeProvider.EXPECT().Now().Return(currentTime).Times(3)
+func TestCrud_AddTrunkAccessLogEntry_databaseError(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		err := failing(crud, "Insert").AddTrunkAccessLogEntry(context.Background(), trunkAccessLogEntry)
+		assert.ErrorIs(t, err, errDatabase)
+	})
+}
 
-	existingRental := entities.Rental{
-		RentalId:   "rentalId",
-		CustomerId: "customer",
-		RentalPeriod: entities.TimePeriod{
-			StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-		},
-		TrunkToken: nil,
-	}
+func TestCrud_GetTrunkAccessLogOfRental_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		later := trunkAccessLogEntry
+		later.Action = model.GETLOCKSTATE
+		later.LockState = nil
+		later.Timestamp = trunkAccessLogEntry.Timestamp.Add(time.Minute)
+		otherRentalId := "otherRental"
+		otherRental := trunkAccessLogEntry
+		otherRental.RentalId = &otherRentalId
+		anonymous := trunkAccessLogEntry
+		anonymous.RentalId = nil
+		anonymous.ActorType = model.TOKEN
+		anonymous.Outcome = model.DENIED
+		for _, entry := range []model.TrunkAccessLogEntry{later, otherRental, trunkAccessLogEntry, anonymous} {
+			assert.Nil(t, crud.AddTrunkAccessLogEntry(ctx, entry))
+		}
+
+		// the oldest entry comes first
+		log, err := crud.GetTrunkAccessLogOfRental(ctx, trunkAccessLogRentalId)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.TrunkAccessLogEntry{trunkAccessLogEntry, later}, *log)
 
-	mockConnection.EXPECT().GetFactory().Return(&factory).Times(3)
-
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterEqual("rentals.rentalId", "rentalId"),
-			1, // limit to 1
-			nil,
-		),
-		gomock.Any(),
-	).SetArg(3, []entities.Car{
-		{
-			Vin:     "AVWAA71K08W201031",
-			Rentals: []entities.Rental{existingRental},
-		},
-	}).Return(nil).Times(3)
-
-	newToken := model.TrunkAccess{
-		Token: "thisIsTheNewToken1234567",
-		ValidityPeriod: model.TimePeriod{
-			StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
-		},
-	}
+		log, err = crud.GetTrunkAccessLogOfRental(ctx, "unknown")
+		assert.Nil(t, err)
+		assert.Empty(t, *log)
+	})
+}
 
-	newTokenEntity := *mappers.MapTokenToDb(&newToken)
+func TestCrud_GetTrunkAccessLogOfRental_databaseError(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		log, err := failing(crud, "FindMany").GetTrunkAccessLogOfRental(context.Background(), trunkAccessLogRentalId)
+		assert.ErrorIs(t, err, errDatabase)
+		assert.Nil(t, log)
+	})
+}
 
-	mockConnection.EXPECT().UpdateOne(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.FilterElementMatch(
-			"rentals",
-			factory.FilterMatch(existingRental),
-		),
-		factory.UpdateMatchingArrayElement(
-			"rentals",
-			"trunkToken",
-			newTokenEntity,
-		),
-		false, // no upsert
-	).Return(db.NoDocumentsError).Times(3)
+func TestCrud_GetTrunkAccessLogOfCar_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		anonymous := trunkAccessLogEntry
+		anonymous.RentalId = nil
+		anonymous.ActorType = model.TOKEN
+		anonymous.Actor = "abcd"
+		anonymous.Outcome = model.DENIED
+		anonymous.Timestamp = trunkAccessLogEntry.Timestamp.Add(-time.Minute)
+		otherCar := trunkAccessLogEntry
+		otherCar.Vin = "WVWAA71K08W201031"
+		for _, entry := range []model.TrunkAccessLogEntry{trunkAccessLogEntry, otherCar, anonymous} {
+			assert.Nil(t, crud.AddTrunkAccessLogEntry(ctx, entry))
+		}
+
+		// entries without a rental are included
+		log, err := crud.GetTrunkAccessLogOfCar(ctx, trunkAccessLogEntry.Vin)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.TrunkAccessLogEntry{anonymous, trunkAccessLogEntry}, *log)
+	})
+}
 
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	retToken, err := crud.SetTrunkToken(ctx, "rentalId", newToken)
+var relockJob = model.RelockJob{
+	Vin:      "WVWAA71K08W201030",
+	RentalId: "rZ6IIwcD",
+	DueAt:    time.Date(2023, 4, 1, 0, 5, 0, 0, time.UTC),
+}
 
-	assert.ErrorIs(t, err, OptimisticLockingError)
-	assert.Nil(t, retToken)
+func TestCrud_SetAutoRelockTimeout_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		createRental(t, crud, "car1", "customer1", months(5, 7))
+		rental := createRental(t, crud, "car1", "customer2", months(7, 8))
+
+		assert.Nil(t, crud.SetAutoRelockTimeout(ctx, rental.Id, 30))
+
+		assert.Equal(t, &model.AutoRelock{TimeoutSeconds: 30}, rentalOf(t, crud, "customer2").AutoRelock)
+		assert.Nil(t, rentalOf(t, crud, "customer1").AutoRelock)
+
+		events := publishedEvents(t, crud)
+		if assert.Len(t, events, 3) {
+			assertEvent(t, model.RentalEvent{
+				Type:       model.RentalChanged,
+				OccurredAt: eventTime,
+				RentalId:   rental.Id,
+				Vin:        "car1",
+				CustomerId: "customer2",
+				AutoRelock: &model.AutoRelock{TimeoutSeconds: 30},
+			}, events[2])
+		}
+	})
 }
 
-func TestCrud_SetTrunkToken_rentalNotFound(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+func TestCrud_SetAutoRelockTimeout_rentalNotFound(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		createRental(t, crud, "car1", "customer1", months(5, 7))
 
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
+		err := crud.SetAutoRelockTimeout(context.Background(), "unknown", 30)
+		assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
+		assert.Len(t, publishedEvents(t, crud), 1)
+	})
+}
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
+func TestCrud_SetAutoRelockTimeout_removedRental(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		rental := createRental(t, crud, "car1", "customer1", months(5, 7))
+
+		// the rental is removed between reading and updating it
+		err := interleaved(crud, func() {
+			assert.Nil(t, deleteCarOf(ctx, crud, rental.Id))
+		}).SetAutoRelockTimeout(ctx, rental.Id, 30)
+		assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
+	})
+}
 
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
+func TestCrud_CancelRental_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		createRental(t, crud, "car1", "customer1", months(5, 7))
+		upcoming := createRental(t, crud, "car1", "customer2", months(7, 8))
 
-	mockConnection.EXPECT().GetFactory().Return(&factory)
+		assert.Nil(t, crud.CancelRental(ctx, upcoming.Id))
+		assert.Equal(t, model.CANCELLED, rentalOf(t, crud, "customer2").State)
+		assert.Equal(t, model.ACTIVE, rentalOf(t, crud, "customer1").State)
 
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterEqual("rentals.rentalId", "rentalId"),
-			1, // limit to 1
-			nil,
-		),
-		gomock.Any(),
-	).SetArg(3, []entities.Car{}).Return(nil)
+		// the cancelled rental does not occupy the car anymore
+		vins, err := crud.GetUnavailableCars(ctx, months(7, 8))
+		assert.Nil(t, err)
+		assert.Empty(t, *vins)
+		assert.Nil(t, crud.CreateRental(ctx, "car1", "customer3", months(7, 8)))
+
+		events := publishedEvents(t, crud)
+		if assert.Len(t, events, 4) {
+			assertEvent(t, model.RentalEvent{
+				Type:       model.RentalCancelled,
+				OccurredAt: eventTime,
+				RentalId:   upcoming.Id,
+				Vin:        "car1",
+				CustomerId: "customer2",
+			}, events[2])
+		}
+	})
+}
 
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	retToken, err := crud.SetTrunkToken(ctx, "rentalId", model.TrunkAccess{})
+func TestCrud_CancelRental_rentalNotFound(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		createRental(t, crud, "car1", "customer1", months(7, 8))
 
-	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
-	assert.Nil(t, retToken)
+		err := crud.CancelRental(context.Background(), "unknown")
+		assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
+	})
 }
 
-func TestCrud_SetTrunkToken_dbError_aggregate(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+func TestCrud_CancelRental_notUpcoming(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		active := createRental(t, crud, "car1", "customer1", months(5, 7))
+		expired := createRental(t, crud, "car1", "customer2", months(1, 2))
+		cancelled := createRental(t, crud, "car1", "customer3", months(7, 8))
+		assert.Nil(t, crud.CancelRental(ctx, cancelled.Id))
+
+		for _, rental := range []model.Rental{active, expired, cancelled} {
+			assert.ErrorIs(t, crud.CancelRental(ctx, rental.Id), rentalErrors.ErrRentalNotUpcoming)
+		}
+		assert.Len(t, publishedEvents(t, crud), 4)
+	})
+}
 
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
+func TestCrud_CancelRental_cancelledInMeantime(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		rental := createRental(t, crud, "car1", "customer1", months(7, 8))
+
+		// the rental is cancelled concurrently between reading and updating it
+		err := interleaved(crud, func() {
+			assert.Nil(t, crud.CancelRental(ctx, rental.Id))
+		}).CancelRental(ctx, rental.Id)
+		assert.ErrorIs(t, err, rentalErrors.ErrRentalNotUpcoming)
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
+		// only the concurrent cancellation has added its event
+		assert.Len(t, publishedEvents(t, crud), 2)
+	})
+}
 
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
+func TestCrud_ScheduleRelock_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		assert.Nil(t, crud.ScheduleRelock(ctx, relockJob))
 
-	mockConnection.EXPECT().GetFactory().Return(&factory)
+		jobs, err := crud.GetDueRelockJobs(ctx, relockJob.DueAt)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.RelockJob{relockJob}, *jobs)
 
-	dbError := errors.New("db error")
+		// a newer job of the same car replaces the pending one
+		replacingJob := relockJob
+		replacingJob.DueAt = relockJob.DueAt.Add(time.Minute)
+		assert.Nil(t, crud.ScheduleRelock(ctx, replacingJob))
+		jobs, err = crud.GetDueRelockJobs(ctx, replacingJob.DueAt)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.RelockJob{replacingJob}, *jobs)
+	})
+}
 
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterEqual("rentals.rentalId", "rentalId"),
-			1, // limit to 1
-			nil,
-		),
-		gomock.Any(),
-	).Return(dbError)
+func TestCrud_CancelRelock_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		otherJob := model.RelockJob{Vin: "WVWAA71K08W201031", RentalId: "rental2", DueAt: relockJob.DueAt}
+		assert.Nil(t, crud.ScheduleRelock(ctx, relockJob))
+		assert.Nil(t, crud.ScheduleRelock(ctx, otherJob))
 
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	retToken, err := crud.SetTrunkToken(ctx, "rentalId", model.TrunkAccess{})
+		assert.Nil(t, crud.CancelRelock(ctx, relockJob.Vin))
 
-	assert.ErrorIs(t, err, dbError)
-	assert.Nil(t, retToken)
+		jobs, err := crud.GetDueRelockJobs(ctx, relockJob.DueAt)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.RelockJob{otherJob}, *jobs)
+	})
 }
 
-func TestCrud_SetTrunkToken_dbError_update(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+func TestCrud_CancelRelock_noPendingJob(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		assert.Nil(t, crud.CancelRelock(context.Background(), relockJob.Vin))
+	})
+}
 
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
+func TestCrud_GetDueRelockJobs_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		earlierJob := model.RelockJob{Vin: "WVWAA71K08W201031", RentalId: "rental2",
+			DueAt: relockJob.DueAt.Add(-time.Minute)}
+		laterJob := model.RelockJob{Vin: "WVWAA71K08W201032", RentalId: "rental3",
+			DueAt: relockJob.DueAt.Add(time.Minute)}
+		assert.Nil(t, crud.ScheduleRelock(ctx, relockJob))
+		assert.Nil(t, crud.ScheduleRelock(ctx, laterJob))
+		assert.Nil(t, crud.ScheduleRelock(ctx, earlierJob))
+
+		// the earliest job comes first, a job due at the given time is included
+		jobs, err := crud.GetDueRelockJobs(ctx, relockJob.DueAt)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.RelockJob{earlierJob, relockJob}, *jobs)
+	})
+}
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
+func TestCrud_GetDueRelockJobs_databaseError(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		jobs, err := failing(crud, "FindMany").GetDueRelockJobs(context.Background(), relockJob.DueAt)
+		assert.ErrorIs(t, err, errDatabase)
+		assert.Nil(t, jobs)
+	})
+}
 
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
-	mockTimeProvider.EXPECT().Now().Return(
-		time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC),
-	)
+func TestCrud_CompleteRelock_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		assert.Nil(t, crud.ScheduleRelock(ctx, relockJob))
 
-	existingRental := entities.Rental{
-		RentalId:   "rentalId",
-		CustomerId: "customer",
-		RentalPeriod: entities.TimePeriod{
-			StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-		},
-		TrunkToken: nil,
-	}
+		assert.Nil(t, crud.CompleteRelock(ctx, relockJob))
+		// the job has been completed already
+		assert.Nil(t, crud.CompleteRelock(ctx, relockJob))
 
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterEqual("rentals.rentalId", "rentalId"),
-			1, // limit to 1
-			nil,
-		),
-		gomock.Any(),
-	).SetArg(3, []entities.Car{
-		{
-			Vin:     "AVWAA71K08W201031",
-			Rentals: []entities.Rental{existingRental},
-		},
-	}).Return(nil)
-
-	newToken := model.TrunkAccess{
-		Token: "thisIsTheNewToken1234567",
-		ValidityPeriod: model.TimePeriod{
-			StartDate: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
-		},
-	}
+		jobs, err := crud.GetDueRelockJobs(ctx, relockJob.DueAt)
+		assert.Nil(t, err)
+		assert.Empty(t, *jobs)
+	})
+}
 
-	newTokenEntity := *mappers.MapTokenToDb(&newToken)
+func TestCrud_CompleteRelock_replacedJob(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		replacingJob := relockJob
+		replacingJob.DueAt = relockJob.DueAt.Add(time.Minute)
+		assert.Nil(t, crud.ScheduleRelock(ctx, relockJob))
+		assert.Nil(t, crud.ScheduleRelock(ctx, replacingJob))
 
-	dbError := errors.New("db error")
+		// the replaced job is completed late, which must not remove the replacing job
+		assert.Nil(t, crud.CompleteRelock(ctx, relockJob))
 
-	mockConnection.EXPECT().UpdateOne(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.FilterElementMatch(
-			"rentals",
-			factory.FilterMatch(existingRental),
-		),
-		factory.UpdateMatchingArrayElement(
-			"rentals",
-			"trunkToken",
-			newTokenEntity,
-		),
-		false, // no upsert
-	).Return(dbError)
+		jobs, err := crud.GetDueRelockJobs(ctx, replacingJob.DueAt)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.RelockJob{replacingJob}, *jobs)
+	})
+}
 
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	retToken, err := crud.SetTrunkToken(ctx, "rentalId", newToken)
+func TestCrud_MoveRentalEventsToOutbox_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		createRental(t, crud, "car1", "customer1", months(5, 7))
+		createRental(t, crud, "car2", "customer2", months(5, 7))
 
-	assert.ErrorIs(t, err, dbError)
-	assert.Nil(t, retToken)
-}
+		// the events are pending on the cars until they are moved to the outbox
+		events, err := crud.GetPendingRentalEvents(ctx)
+		assert.Nil(t, err)
+		assert.Empty(t, *events)
 
-func TestCrud_SetTrunkToken_periodNotOverlapping(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+		assert.Nil(t, crud.MoveRentalEventsToOutbox(ctx))
+		// the events have been removed from the cars, so they are not moved again
+		assert.Nil(t, crud.MoveRentalEventsToOutbox(ctx))
 
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
+		events, err = crud.GetPendingRentalEvents(ctx)
+		assert.Nil(t, err)
+		assert.Len(t, *events, 2)
+	})
+}
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
+func TestCrud_MoveRentalEventsToOutbox_alreadyMoved(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		createRental(t, crud, "car1", "customer1", months(5, 7))
 
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
-	mockTimeProvider.EXPECT().Now().Return(
-		time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC),
-	)
+		// the event is added to the outbox, but its removal from the car fails
+		assert.ErrorIs(t, failing(crud, "UpdateOne").MoveRentalEventsToOutbox(ctx), errDatabase)
 
-	existingRental := entities.Rental{
-		RentalId:   "rentalId",
-		CustomerId: "customer",
-		RentalPeriod: entities.TimePeriod{
-			StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-		},
-		TrunkToken: nil,
-	}
+		// the event is not added to the outbox again
+		assert.Nil(t, crud.MoveRentalEventsToOutbox(ctx))
+		events, err := crud.GetPendingRentalEvents(ctx)
+		assert.Nil(t, err)
+		assert.Len(t, *events, 1)
+	})
+}
 
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterEqual("rentals.rentalId", "rentalId"),
-			1, // limit to 1
-			nil,
-		),
-		gomock.Any(),
-	).SetArg(3, []entities.Car{
-		{
-			Vin:     "AVWAA71K08W201031",
-			Rentals: []entities.Rental{existingRental},
-		},
-	}).Return(nil)
-
-	newToken := model.TrunkAccess{
-		Token: "thisIsTheNewToken1234567",
-		ValidityPeriod: model.TimePeriod{
-			StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
-		},
-	}
+func TestCrud_MoveRentalEventsToOutbox_databaseError(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		createRental(t, crud, "car1", "customer1", months(5, 7))
 
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	retToken, err := crud.SetTrunkToken(ctx, "rentalId", newToken)
+		assert.ErrorIs(t, failing(crud, "FindMany").MoveRentalEventsToOutbox(ctx), errDatabase)
+		assert.ErrorIs(t, failing(crud, "Insert").MoveRentalEventsToOutbox(ctx), errDatabase)
 
-	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotOverlapping)
-	assert.Nil(t, retToken)
+		// the event is still pending on the car
+		assert.Len(t, publishedEvents(t, crud), 1)
+	})
 }
 
-func TestCrud_SetTrunkToken_rentalNotActive(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+func TestCrud_GetPendingRentalEvents_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		clock.now = eventTime.Add(time.Minute)
+		later := createRental(t, crud, "car1", "customer1", months(5, 7))
+		clock.now = eventTime
+		earlier := createRental(t, crud, "car2", "customer2", months(5, 7))
+
+		// the oldest event comes first, regardless of the order of the cars
+		events := publishedEvents(t, crud)
+		if assert.Len(t, events, 2) {
+			period := months(5, 7)
+			assertEvent(t, model.RentalEvent{
+				Type:         model.RentalCreated,
+				OccurredAt:   eventTime,
+				RentalId:     earlier.Id,
+				Vin:          "car2",
+				CustomerId:   "customer2",
+				RentalPeriod: &period,
+			}, events[0])
+			assert.Equal(t, later.Id, events[1].RentalId)
+		}
+	})
+}
 
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
+func TestCrud_GetPendingRentalEvents_databaseError(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		events, err := failing(crud, "FindMany").GetPendingRentalEvents(context.Background())
+		assert.ErrorIs(t, err, errDatabase)
+		assert.Nil(t, events)
+	})
+}
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
+func TestCrud_RemoveRentalEvent_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		createRental(t, crud, "car1", "customer1", months(5, 7))
+		createRental(t, crud, "car2", "customer2", months(5, 7))
+		events := publishedEvents(t, crud)
 
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
-	mockTimeProvider.EXPECT().Now().Return(
-		time.Date(2100, 1, 1, 1, 0, 0, 0, time.UTC),
-	)
+		assert.Nil(t, crud.RemoveRentalEvent(ctx, events[0].EventId))
 
-	existingRental := entities.Rental{
-		RentalId:   "rentalId",
-		CustomerId: "customer",
-		RentalPeriod: entities.TimePeriod{
-			StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-		},
-		TrunkToken: nil,
-	}
+		remaining, err := crud.GetPendingRentalEvents(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, events[1:], *remaining)
+	})
+}
 
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterEqual("rentals.rentalId", "rentalId"),
-			1, // limit to 1
-			nil,
-		),
-		gomock.Any(),
-	).SetArg(3, []entities.Car{
-		{
-			Vin:     "AVWAA71K08W201031",
-			Rentals: []entities.Rental{existingRental},
-		},
-	}).Return(nil)
-
-	newToken := model.TrunkAccess{
-		Token: "thisIsTheNewToken1234567",
-		ValidityPeriod: model.TimePeriod{
-			StartDate: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:   time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
-		},
-	}
+func TestCrud_RemoveRentalEvent_alreadyRemoved(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		createRental(t, crud, "car1", "customer1", months(5, 7))
+		events := publishedEvents(t, crud)
 
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	retToken, err := crud.SetTrunkToken(ctx, "rentalId", newToken)
+		assert.Nil(t, crud.RemoveRentalEvent(ctx, events[0].EventId))
+		assert.Nil(t, crud.RemoveRentalEvent(ctx, events[0].EventId))
+	})
+}
 
-	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotActive)
-	assert.Nil(t, retToken)
+var rentalEvent = model.RentalEvent{
+	EventId:      "Xo0kGm4GUtbN2r3h",
+	Type:         model.RentalCreated,
+	OccurredAt:   eventTime,
+	RentalId:     "rZ6IIwcD",
+	Vin:          "WVWAA71K08W201030",
+	CustomerId:   "d9COw9vI",
+	RentalPeriod: &timePeriod2023,
 }
 
-func TestCrud_GetRental_success(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+var webhook = model.Webhook{
+	Id:         "Ck3XlmXtlq0xGpH9",
+	Url:        "https://billing.example.com/hooks/rentals",
+	EventTypes: []model.RentalEventType{model.RentalCreated},
+	Secret:     "whsec-0123456789abcdef",
+	CreatedAt:  eventTime,
+}
 
-	ctx := context.Background()
+var webhookDelivery = model.WebhookDelivery{
+	Id:            rentalEvent.EventId + webhook.Id,
+	WebhookId:     webhook.Id,
+	Event:         rentalEvent,
+	State:         model.PENDING,
+	CreatedAt:     eventTime,
+	NextAttemptAt: &eventTime,
+}
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
-
-	factory := db.PseudoFactory{}
-
-	rentalId := "rZ6IIwcD"
-
-	var car = entities.Car{
-		Vin: "WVWAA71K08W201030",
-		Rentals: []entities.Rental{
-			{
-				RentalId:   rentalId,
-				CustomerId: "jJ8mNg6Z",
-				RentalPeriod: entities.TimePeriod{
-					EndDate:   time.Date(2023, 4, 3, 1, 0, 0, 0, time.UTC),
-					StartDate: time.Date(2023, 3, 2, 3, 0, 0, 0, time.UTC),
-				},
-				TrunkToken: &entities.TrunkAccessToken{
-					Token: "bumrLuCMbumrLuCMbumrLuCM",
-					ValidityPeriod: entities.TimePeriod{
-						EndDate:   time.Date(2023, 2, 3, 1, 0, 0, 0, time.UTC),
-						StartDate: time.Date(2023, 2, 2, 3, 0, 0, 0, time.UTC),
-					},
-				},
-			},
-		},
-	}
+func TestCrud_CreateWebhook_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		assert.Nil(t, crud.CreateWebhook(ctx, webhook))
 
-	var rental = model.Rental{
-		State:    model.ACTIVE,
-		Car:      &model.Car{Vin: "WVWAA71K08W201030"},
-		Id:       rentalId,
-		Customer: &model.Customer{CustomerId: "jJ8mNg6Z"},
-		RentalPeriod: model.TimePeriod{
-			EndDate:   time.Date(2023, 4, 3, 1, 0, 0, 0, time.UTC),
-			StartDate: time.Date(2023, 3, 2, 3, 0, 0, 0, time.UTC),
-		},
-		Token: &model.TrunkAccess{
-			Token: "bumrLuCMbumrLuCMbumrLuCM",
-			ValidityPeriod: model.TimePeriod{
-				EndDate:   time.Date(2023, 2, 3, 1, 0, 0, 0, time.UTC),
-				StartDate: time.Date(2023, 2, 2, 3, 0, 0, 0, time.UTC),
-			},
-		},
-	}
+		stored, err := crud.GetWebhook(ctx, webhook.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, &webhook, stored)
+	})
+}
 
-	var cars = []entities.Car{car}
-	var currentDate = time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
-	mockTimeProvider.EXPECT().Now().Return(currentDate)
+func TestCrud_GetWebhooks_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		newer := model.Webhook{Id: "webhook2", Url: "https://example.com/hooks",
+			EventTypes: []model.RentalEventType{model.RentalChanged, model.TrunkAccessGranted}, Secret: "secret",
+			CreatedAt: eventTime.Add(time.Minute)}
+		assert.Nil(t, crud.CreateWebhook(ctx, newer))
+		assert.Nil(t, crud.CreateWebhook(ctx, webhook))
+
+		// the oldest webhook comes first
+		webhooks, err := crud.GetWebhooks(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.Webhook{webhook, newer}, *webhooks)
+	})
+}
 
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterEqual("rentals.rentalId", rentalId),
-			1,
-			nil,
-		),
-		gomock.Any(),
-	).SetArg(3, cars).Return(nil)
+func TestCrud_GetWebhooks_databaseError(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		webhooks, err := failing(crud, "FindMany").GetWebhooks(context.Background())
+		assert.ErrorIs(t, err, errDatabase)
+		assert.Nil(t, webhooks)
+	})
+}
 
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	returnedRental, err := crud.GetRental(ctx, rentalId)
+func TestCrud_GetWebhook_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		other := webhook
+		other.Id = "webhook2"
+		assert.Nil(t, crud.CreateWebhook(ctx, other))
+		assert.Nil(t, crud.CreateWebhook(ctx, webhook))
 
-	assert.Nil(t, err)
-	assert.Equal(t, &rental, returnedRental)
+		stored, err := crud.GetWebhook(ctx, webhook.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, &webhook, stored)
+	})
 }
 
-func TestCrud_GetRental_rentalIdNotFoundError(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
-	rentalId := "norental"
+func TestCrud_GetWebhook_notFound(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		stored, err := crud.GetWebhook(context.Background(), webhook.Id)
+		assert.ErrorIs(t, err, rentalErrors.ErrWebhookNotFound)
+		assert.Nil(t, stored)
+	})
+}
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
+func TestCrud_DeleteWebhook_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		assert.Nil(t, crud.CreateWebhook(ctx, webhook))
+		assert.Nil(t, crud.AddWebhookDelivery(ctx, webhookDelivery))
 
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		gomock.Any(),
-		gomock.Any(),
-	).Return(nil)
+		assert.Nil(t, crud.DeleteWebhook(ctx, webhook.Id))
 
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	returnedRental, err := crud.GetRental(ctx, rentalId)
+		_, err := crud.GetWebhook(ctx, webhook.Id)
+		assert.ErrorIs(t, err, rentalErrors.ErrWebhookNotFound)
+		// the deliveries are kept
+		deliveries, err := crud.GetWebhookDeliveries(ctx, webhook.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.WebhookDelivery{webhookDelivery}, *deliveries)
+	})
+}
 
-	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
-	assert.Nil(t, returnedRental)
+func TestCrud_DeleteWebhook_notFound(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		err := crud.DeleteWebhook(context.Background(), webhook.Id)
+		assert.ErrorIs(t, err, rentalErrors.ErrWebhookNotFound)
+	})
 }
 
-func TestCrud_GetRental_databaseError(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+func TestCrud_AddWebhookDelivery_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		assert.Nil(t, crud.AddWebhookDelivery(ctx, webhookDelivery))
 
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
-	dbError := errors.New("db error")
-	rentalId := "rZ6IIwcD"
+		deliveries, err := crud.GetWebhookDeliveries(ctx, webhook.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.WebhookDelivery{webhookDelivery}, *deliveries)
+	})
+}
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
+func TestCrud_AddWebhookDelivery_alreadyAdded(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		assert.Nil(t, crud.AddWebhookDelivery(ctx, webhookDelivery))
 
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		gomock.Any(),
-		gomock.Any(),
-	).Return(dbError)
+		// the stored delivery is not changed
+		changed := webhookDelivery
+		changed.Attempts = 5
+		assert.Nil(t, crud.AddWebhookDelivery(ctx, changed))
 
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	returnedRental, err := crud.GetRental(ctx, rentalId)
+		deliveries, err := crud.GetWebhookDeliveries(ctx, webhook.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.WebhookDelivery{webhookDelivery}, *deliveries)
+	})
+}
 
-	assert.ErrorIs(t, err, dbError)
-	assert.Nil(t, returnedRental)
+func TestCrud_UpdateWebhookDelivery_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		assert.Nil(t, crud.AddWebhookDelivery(ctx, webhookDelivery))
+
+		statusCode := 200
+		delivered := webhookDelivery
+		delivered.State = model.DELIVERED
+		delivered.Attempts = 1
+		delivered.NextAttemptAt = nil
+		delivered.LastAttemptAt = &eventTime
+		delivered.LastStatusCode = &statusCode
+		assert.Nil(t, crud.UpdateWebhookDelivery(ctx, delivered))
+
+		deliveries, err := crud.GetWebhookDeliveries(ctx, webhook.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.WebhookDelivery{delivered}, *deliveries)
+	})
 }
 
-func TestCrud_GetNextRental_success_exists(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+func TestCrud_GetDueWebhookDeliveries_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		earlierAttemptAt := eventTime.Add(-time.Minute)
+		earlier := webhookDelivery
+		earlier.Id = "delivery2"
+		earlier.NextAttemptAt = &earlierAttemptAt
+		laterAttemptAt := eventTime.Add(time.Minute)
+		later := webhookDelivery
+		later.Id = "delivery3"
+		later.NextAttemptAt = &laterAttemptAt
+		deadLetter := webhookDelivery
+		deadLetter.Id = "delivery4"
+		deadLetter.State = model.DEADLETTER
+		for _, delivery := range []model.WebhookDelivery{webhookDelivery, later, deadLetter, earlier} {
+			assert.Nil(t, crud.AddWebhookDelivery(ctx, delivery))
+		}
+
+		// only pending deliveries are due, the earliest comes first
+		deliveries, err := crud.GetDueWebhookDeliveries(ctx, eventTime)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.WebhookDelivery{earlier, webhookDelivery}, *deliveries)
+	})
+}
 
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
-
-	exampleCar := entities.Car{
-		Vin: "WVWAA71K08W201030",
-		Rentals: []entities.Rental{
-			{
-				RentalId:   "rZ6IIwcD",
-				CustomerId: "jJ8mNg6Z",
-				RentalPeriod: entities.TimePeriod{
-					EndDate:   time.Date(2023, 4, 3, 1, 0, 0, 0, time.UTC),
-					StartDate: time.Date(2023, 3, 2, 3, 0, 0, 0, time.UTC),
-				},
-				TrunkToken: nil,
-			},
-		},
-	}
-	var cars = []entities.Car{exampleCar}
-	exampleRental := model.Rental{
-		State:    model.ACTIVE,
-		Car:      &model.Car{Vin: "WVWAA71K08W201030"},
-		Id:       "rZ6IIwcD",
-		Customer: &model.Customer{CustomerId: "jJ8mNg6Z"},
-		RentalPeriod: model.TimePeriod{
-			EndDate:   time.Date(2023, 4, 3, 1, 0, 0, 0, time.UTC),
-			StartDate: time.Date(2023, 3, 2, 3, 0, 0, 0, time.UTC),
-		},
-		Token: nil,
-	}
-	currentDate := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
-
-	mockConnection := mocks.NewMockIConnection(ctrl)
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
-
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockTimeProvider.EXPECT().Now().Return(currentDate)
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterAnd(
-				factory.FilterEqual("_id", "WVWAA71K08W201030"),
-				factory.FilterGreater("rentals.rentalPeriod.endDate", currentDate),
-			),
-			1,
-			factory.SortAsc("rentals.rentalPeriod.startDate"),
-		),
-		gomock.Any(),
-	).SetArg(3, cars).Return(nil)
-	mockTimeProvider.EXPECT().Now().Return(currentDate)
-
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	returnedRental, err := crud.GetNextRental(ctx, "WVWAA71K08W201030")
-
-	assert.Nil(t, err)
-	assert.Equal(t, &exampleRental, returnedRental)
+func TestCrud_GetDueWebhookDeliveries_databaseError(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		deliveries, err := failing(crud, "FindMany").GetDueWebhookDeliveries(context.Background(), eventTime)
+		assert.ErrorIs(t, err, errDatabase)
+		assert.Nil(t, deliveries)
+	})
 }
 
-func TestCrud_GetNextRental_success_notExists(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+func TestCrud_GetWebhookDeliveries_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		newer := webhookDelivery
+		newer.Id = "delivery2"
+		newer.CreatedAt = eventTime.Add(time.Minute)
+		otherWebhook := webhookDelivery
+		otherWebhook.Id = "delivery3"
+		otherWebhook.WebhookId = "webhook2"
+		for _, delivery := range []model.WebhookDelivery{webhookDelivery, otherWebhook, newer} {
+			assert.Nil(t, crud.AddWebhookDelivery(ctx, delivery))
+		}
+
+		// the newest delivery comes first
+		deliveries, err := crud.GetWebhookDeliveries(ctx, webhook.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.WebhookDelivery{newer, webhookDelivery}, *deliveries)
+	})
+}
 
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
+var reminderTime = time.Date(2023, 4, 1, 1, 0, 0, 0, time.UTC)
+
+var reminderPeriod = model.TimePeriod{StartDate: reminderTime, EndDate: reminderTime.Add(24 * time.Hour)}
+
+var reminder = model.Reminder{RentalId: "rZ6IIwcD", Kind: model.RentalStartReminder, Offset: time.Hour}
+
+// createReminderRentals creates a rental that starts and ends within the reminder period, one that starts at its
+// start, one that ends at its end and a cancelled one that starts and ends within it. The rentals are returned in
+// this order.
+func createReminderRentals(t *testing.T, crud ICRUD, clock *testClock) []model.Rental {
+	clock.now = reminderTime.Add(-time.Hour)
+	within := createRental(t, crud, "car1", "customer1", model.TimePeriod{
+		StartDate: reminderTime.Add(time.Hour),
+		EndDate:   reminderTime.Add(3 * time.Hour),
+	})
+	atStart := createRental(t, crud, "car2", "customer2", model.TimePeriod{
+		StartDate: reminderTime,
+		EndDate:   reminderTime.Add(2 * time.Hour),
+	})
+	atEnd := createRental(t, crud, "car3", "customer3", model.TimePeriod{
+		StartDate: reminderTime.Add(time.Hour),
+		EndDate:   reminderPeriod.EndDate,
+	})
+	cancelled := createRental(t, crud, "car4", "customer4", model.TimePeriod{
+		StartDate: reminderTime.Add(time.Hour),
+		EndDate:   reminderTime.Add(3 * time.Hour),
+	})
+	assert.Nil(t, crud.CancelRental(context.Background(), cancelled.Id))
+	return []model.Rental{within, atStart, atEnd, rentalOf(t, crud, "customer4")}
+}
 
-	currentDate := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+func TestCrud_GetRentalsStartingIn_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		rentals := createReminderRentals(t, crud, clock)
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
+		// a rental starting at the start of the period has been found in an earlier period
+		found, err := crud.GetRentalsStartingIn(context.Background(), reminderPeriod)
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []model.Rental{rentals[0], rentals[2]}, *found)
+	})
+}
 
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockTimeProvider.EXPECT().Now().Return(currentDate)
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterAnd(
-				factory.FilterEqual("_id", "WVWAA71K08W201030"),
-				factory.FilterGreater("rentals.rentalPeriod.endDate", currentDate),
-			),
-			1,
-			factory.SortAsc("rentals.rentalPeriod.startDate"),
-		),
-		gomock.Any(),
-	).SetArg(3, []entities.Car{}).Return(nil)
+func TestCrud_GetRentalsEndingIn_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		rentals := createReminderRentals(t, crud, clock)
 
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	returnedRental, err := crud.GetNextRental(ctx, "WVWAA71K08W201030")
+		found, err := crud.GetRentalsEndingIn(context.Background(), reminderPeriod)
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []model.Rental{rentals[0], rentals[1], rentals[2]}, *found)
+	})
+}
 
-	assert.Nil(t, err)
-	assert.Nil(t, returnedRental)
+func TestCrud_GetRentalsEndingIn_databaseError(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		rentals, err := failing(crud, "Aggregate").GetRentalsEndingIn(context.Background(), reminderPeriod)
+		assert.ErrorIs(t, err, errDatabase)
+		assert.Nil(t, rentals)
+	})
 }
 
-func TestCrud_GetNextRental_databaseError(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+func TestCrud_GetRentalsInPeriod_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		rental1 := createRental(t, crud, "car1", "customer1", months(5, 7))
+		createRental(t, crud, "car1", "customer2", months(7, 8))
+		rental3 := createRental(t, crud, "car2", "customer3", months(6, 9))
+		cancelled := createRental(t, crud, "car3", "customer4", months(7, 9))
+		assert.Nil(t, crud.CancelRental(ctx, cancelled.Id))
+
+		// cancelled rentals are included
+		rentals, err := crud.GetRentalsInPeriod(ctx, months(6, 7))
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []model.RentalId{rental1.Id, rental3.Id}, rentalIds(rentals))
+		rentals, err = crud.GetRentalsInPeriod(ctx, months(8, 9))
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []model.RentalId{rental3.Id, cancelled.Id}, rentalIds(rentals))
+		rentals, err = crud.GetRentalsInPeriod(ctx, months(10, 11))
+		assert.Nil(t, err)
+		assert.Empty(t, *rentals)
+	})
+}
 
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
-	dbError := errors.New("db error")
-
-	currentDate := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
-
-	mockConnection := mocks.NewMockIConnection(ctrl)
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
-
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockTimeProvider.EXPECT().Now().Return(currentDate)
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterAnd(
-				factory.FilterEqual("_id", "WVWAA71K08W201030"),
-				factory.FilterGreater("rentals.rentalPeriod.endDate", currentDate),
-			),
-			1,
-			factory.SortAsc("rentals.rentalPeriod.startDate"),
-		),
-		gomock.Any(),
-	).Return(dbError)
-
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	returnedRental, err := crud.GetNextRental(ctx, "WVWAA71K08W201030")
-
-	assert.ErrorIs(t, err, dbError)
-	assert.Nil(t, returnedRental)
-}
-
-func TestCrud_GetTrunkAccess_success(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+func TestCrud_GetRentalsInPeriod_databaseError(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		rentals, err := failing(crud, "Aggregate").GetRentalsInPeriod(context.Background(), reminderPeriod)
+		assert.ErrorIs(t, err, errDatabase)
+		assert.Nil(t, rentals)
+	})
+}
 
-	ctx := context.Background()
+func TestCrud_IsReminderSent_sent(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		assert.Nil(t, crud.AddSentReminder(ctx, reminder, reminderTime))
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
-
-	factory := db.PseudoFactory{}
-
-	token := "bumrLuCMbumrLuCMbumrLuCM"
-	vin := "WVWAA71K08W201030"
-
-	var car = entities.Car{
-		Vin: vin,
-		Rentals: []entities.Rental{
-			{
-				RentalId:   "rZ6IIwcD",
-				CustomerId: "jJ8mNg6Z",
-				RentalPeriod: entities.TimePeriod{
-					EndDate:   time.Date(2023, 4, 3, 1, 0, 0, 0, time.UTC),
-					StartDate: time.Date(2023, 3, 2, 3, 0, 0, 0, time.UTC),
-				},
-				TrunkToken: &entities.TrunkAccessToken{
-					Token: token,
-					ValidityPeriod: entities.TimePeriod{
-						EndDate:   time.Date(2023, 2, 3, 1, 0, 0, 0, time.UTC),
-						StartDate: time.Date(2023, 2, 2, 3, 0, 0, 0, time.UTC),
-					},
-				},
-			},
-		},
-	}
+		sent, err := crud.IsReminderSent(ctx, reminder)
+		assert.Nil(t, err)
+		assert.True(t, sent)
+	})
+}
 
-	var access = model.TrunkAccess{
-		Token: "bumrLuCMbumrLuCMbumrLuCM",
-		ValidityPeriod: model.TimePeriod{
-			EndDate:   time.Date(2023, 2, 3, 1, 0, 0, 0, time.UTC),
-			StartDate: time.Date(2023, 2, 2, 3, 0, 0, 0, time.UTC),
-		},
-	}
+func TestCrud_IsReminderSent_notSent(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		// a reminder of another kind has been sent
+		assert.Nil(t, crud.AddSentReminder(ctx, model.Reminder{RentalId: reminder.RentalId,
+			Kind: model.RentalEndReminder, Offset: reminder.Offset}, reminderTime))
 
-	var cars = []entities.Car{car}
+		sent, err := crud.IsReminderSent(ctx, reminder)
+		assert.Nil(t, err)
+		assert.False(t, sent)
+	})
+}
 
-	var currentDate = time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
-	mockTimeProvider.EXPECT().Now().Return(currentDate)
+func TestCrud_IsReminderSent_databaseError(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		sent, err := failing(crud, "FindOne").IsReminderSent(context.Background(), reminder)
+		assert.ErrorIs(t, err, errDatabase)
+		assert.False(t, sent)
+	})
+}
 
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		factory.ArrayFilterAggregation(
-			"rentals",
-			factory.FilterAnd(
-				factory.FilterEqual("rentals.trunkToken.token", token),
-				factory.FilterEqual("_id", vin),
-			),
-			1,
-			nil,
-		),
-		gomock.Any(),
-	).SetArg(3, cars).Return(nil)
+func TestCrud_AddSentReminder_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		assert.Nil(t, crud.AddSentReminder(ctx, reminder, reminderTime))
 
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	returnedAccess, err := crud.GetTrunkAccess(ctx, vin, token)
+		sent, err := crud.IsReminderSent(ctx, reminder)
+		assert.Nil(t, err)
+		assert.True(t, sent)
+	})
+}
 
-	assert.Nil(t, err)
-	assert.Equal(t, &access, returnedAccess)
+func TestCrud_AddSentReminder_alreadyAdded(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		assert.Nil(t, crud.AddSentReminder(ctx, reminder, reminderTime))
+		assert.Nil(t, crud.AddSentReminder(ctx, reminder, reminderTime.Add(time.Minute)))
+	})
 }
 
-func TestCrud_GetTrunkAccess_trunkAccessDenied(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+const calendarToken = "Qm7Ax0tWbLk2ZsPfR4uNcYe9JhVd3oGi"
 
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
-	token := "not token"
-	vin := "WVWAA71K08W201030"
+func TestCrud_SetCalendarToken_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		assert.Nil(t, crud.SetCalendarToken(ctx, "customer1", "previousToken"))
+		assert.Nil(t, crud.SetCalendarToken(ctx, "customer2", "otherToken"))
 
-	mockConnection := mocks.NewMockIConnection(ctrl)
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
+		// the previous token of the customer is replaced
+		assert.Nil(t, crud.SetCalendarToken(ctx, "customer1", calendarToken))
 
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		gomock.Any(),
-		gomock.Any(),
-	).SetArg(3, []entities.Car{}).Return(nil)
+		customerId, err := crud.GetCalendarCustomer(ctx, calendarToken)
+		assert.Nil(t, err)
+		assert.Equal(t, "customer1", customerId)
+		_, err = crud.GetCalendarCustomer(ctx, "previousToken")
+		assert.ErrorIs(t, err, rentalErrors.ErrCalendarNotFound)
+		customerId, err = crud.GetCalendarCustomer(ctx, "otherToken")
+		assert.Nil(t, err)
+		assert.Equal(t, "customer2", customerId)
+	})
+}
 
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	returnedAccess, err := crud.GetTrunkAccess(ctx, vin, token)
+func TestCrud_GetCalendarCustomer_success(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		ctx := context.Background()
+		assert.Nil(t, crud.SetCalendarToken(ctx, "customer1", calendarToken))
 
-	assert.ErrorIs(t, err, rentalErrors.ErrTrunkAccessDenied)
-	assert.Nil(t, returnedAccess)
+		customerId, err := crud.GetCalendarCustomer(ctx, calendarToken)
+		assert.Nil(t, err)
+		assert.Equal(t, "customer1", customerId)
+	})
 }
 
-func TestCrud_GetTrunkAccess_databaseError(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	ctx := context.Background()
-	factory := db.PseudoFactory{}
-	dbError := errors.New("db error")
-	token := "bumrLuCMbumrLuCMbumrLuCM"
-	vin := "WVWAA71K08W201030"
-
-	mockConnection := mocks.NewMockIConnection(ctrl)
-	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
-
-	mockConnection.EXPECT().GetFactory().Return(&factory)
-	mockConnection.EXPECT().Aggregate(
-		ctx,
-		collectionPrefix+CollectionBaseName,
-		gomock.Any(),
-		gomock.Any(),
-	).Return(dbError)
-
-	crud := NewICRUD(mockConnection, config, mockTimeProvider)
-	returnedAccess, err := crud.GetTrunkAccess(ctx, vin, token)
-
-	assert.ErrorIs(t, err, dbError)
-	assert.Nil(t, returnedAccess)
+func TestCrud_GetCalendarCustomer_notFound(t *testing.T) {
+	runOnBackends(t, func(t *testing.T, crud ICRUD, clock *testClock) {
+		customerId, err := crud.GetCalendarCustomer(context.Background(), calendarToken)
+		assert.ErrorIs(t, err, rentalErrors.ErrCalendarNotFound)
+		assert.Empty(t, customerId)
+	})
 }
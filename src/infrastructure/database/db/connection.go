@@ -43,12 +43,19 @@ type IConnection interface {
 	// duplicate ID.
 	UpdateOne(ctx context.Context, collection string, filter Filter, update Update, upsert bool) error
 
+	// DeleteOne deletes the first document with the given filter from the specified collection.
+	// Returns a NoDocumentsError if no document matched the filter.
+	DeleteOne(ctx context.Context, collection string, filter Filter) error
+
 	// DropCollection drops a given collection. This is a destructive relation and should only be used for testing.
 	DropCollection(ctx context.Context, collection string) error
 
 	// Aggregate retrieves a slice of documents from the specified collection generated by the given pipeline.
 	// results must be a pointer to a slice.
 	Aggregate(ctx context.Context, collection string, pipeline Pipeline, results any) error
+
+	// Ping checks that the database is reachable. Any rentalErrors are unexpected.
+	Ping(ctx context.Context) error
 }
 
 // Options defines optional parameters for some query methods. Each field may be nil to use default behaviour.
@@ -168,6 +175,17 @@ func (m *connection) UpdateOne(ctx context.Context, collection string, filter Fi
 	return nil
 }
 
+func (m *connection) DeleteOne(ctx context.Context, collection string, filter Filter) error {
+	res, err := m.database.Collection(collection).DeleteOne(ctx, filter.getFilter())
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return NoDocumentsError
+	}
+	return nil
+}
+
 func (m *connection) DropCollection(ctx context.Context, collection string) error {
 	return m.database.Collection(collection).Drop(ctx)
 }
@@ -179,3 +197,7 @@ func (m *connection) Aggregate(ctx context.Context, collection string, pipeline
 	}
 	return res.All(ctx, results)
 }
+
+func (m *connection) Ping(ctx context.Context) error {
+	return m.client.Ping(ctx, nil)
+}
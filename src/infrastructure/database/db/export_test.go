@@ -0,0 +1,11 @@
+package db
+
+import "context"
+
+// NewReconnectingConnectionWithConnect creates a ReconnectingConnection that creates its connections with connect
+var NewReconnectingConnectionWithConnect = newReconnectingConnection
+
+// ReconnectIfChanged exposes reconnectIfChanged for testing
+func (c *ReconnectingConnection) ReconnectIfChanged() error {
+	return c.reconnectIfChanged(context.Background())
+}
@@ -0,0 +1,127 @@
+package db
+
+import (
+	"RentalManagement/metrics"
+	"RentalManagement/tracing"
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+// instrumentedConnection traces the database operations of a connection as spans and records their latency per
+// method as metrics
+type instrumentedConnection struct {
+	connection IConnection
+}
+
+// NewInstrumentedConnection returns a connection that performs its operations with the given connection, traces
+// them as spans and records their latency as metrics
+func NewInstrumentedConnection(connection IConnection) IConnection {
+	return &instrumentedConnection{connection: connection}
+}
+
+// operation is a database operation in progress
+type operation struct {
+	method string
+	start  time.Time
+	span   trace.Span
+}
+
+// startOperation starts the span of an operation of the given method on the given collection. The returned context
+// carries the span.
+func startOperation(ctx context.Context, method string, collection string) (context.Context, *operation) {
+	attributes := []attribute.KeyValue{semconv.DBSystemMongoDB, semconv.DBOperation(method)}
+	if collection != "" {
+		attributes = append(attributes, semconv.DBMongoDBCollection(collection))
+	}
+	ctx, span := tracing.Tracer().Start(ctx, "mongo."+method,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+	return ctx, &operation{method: method, start: time.Now(), span: span}
+}
+
+// end ends the span of the operation and records its latency. Expected errors like NoDocumentsError do not mark the
+// span as failed, as they are part of the contract of the connection.
+func (o *operation) end(err error) {
+	metrics.DatabaseOperationDuration.WithLabelValues(o.method).Observe(time.Since(o.start).Seconds())
+	if err != nil && !isExpectedError(err) {
+		o.span.RecordError(err)
+		o.span.SetStatus(codes.Error, err.Error())
+	}
+	o.span.End()
+}
+
+func isExpectedError(err error) bool {
+	return errors.Is(err, NoDocumentsError) || errors.Is(err, DuplicateKeyError)
+}
+
+func (c *instrumentedConnection) CleanUpDatabase() error {
+	return c.connection.CleanUpDatabase()
+}
+
+func (c *instrumentedConnection) GetFactory() QueryFactory {
+	return c.connection.GetFactory()
+}
+
+func (c *instrumentedConnection) Insert(ctx context.Context, collection string, document any) (ID, error) {
+	ctx, operation := startOperation(ctx, "Insert", collection)
+	id, err := c.connection.Insert(ctx, collection, document)
+	operation.end(err)
+	return id, err
+}
+
+func (c *instrumentedConnection) FindOne(ctx context.Context, collection string, filter Filter, options *Options,
+	result any) error {
+	ctx, operation := startOperation(ctx, "FindOne", collection)
+	err := c.connection.FindOne(ctx, collection, filter, options, result)
+	operation.end(err)
+	return err
+}
+
+func (c *instrumentedConnection) FindMany(ctx context.Context, collection string, filter Filter, options *Options,
+	results any) error {
+	ctx, operation := startOperation(ctx, "FindMany", collection)
+	err := c.connection.FindMany(ctx, collection, filter, options, results)
+	operation.end(err)
+	return err
+}
+
+func (c *instrumentedConnection) UpdateOne(ctx context.Context, collection string, filter Filter, update Update,
+	upsert bool) error {
+	ctx, operation := startOperation(ctx, "UpdateOne", collection)
+	err := c.connection.UpdateOne(ctx, collection, filter, update, upsert)
+	operation.end(err)
+	return err
+}
+
+func (c *instrumentedConnection) DeleteOne(ctx context.Context, collection string, filter Filter) error {
+	ctx, operation := startOperation(ctx, "DeleteOne", collection)
+	err := c.connection.DeleteOne(ctx, collection, filter)
+	operation.end(err)
+	return err
+}
+
+func (c *instrumentedConnection) DropCollection(ctx context.Context, collection string) error {
+	ctx, operation := startOperation(ctx, "DropCollection", collection)
+	err := c.connection.DropCollection(ctx, collection)
+	operation.end(err)
+	return err
+}
+
+func (c *instrumentedConnection) Aggregate(ctx context.Context, collection string, pipeline Pipeline,
+	results any) error {
+	ctx, operation := startOperation(ctx, "Aggregate", collection)
+	err := c.connection.Aggregate(ctx, collection, pipeline, results)
+	operation.end(err)
+	return err
+}
+
+func (c *instrumentedConnection) Ping(ctx context.Context) error {
+	ctx, operation := startOperation(ctx, "Ping", "")
+	err := c.connection.Ping(ctx)
+	operation.end(err)
+	return err
+}
@@ -0,0 +1,61 @@
+package db_test
+
+import (
+	"RentalManagement/infrastructure/database/db"
+	"RentalManagement/mocks"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"testing"
+)
+
+// recordSpans lets the global tracer provider record the ended spans for the duration of the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previousProvider) })
+	return recorder
+}
+
+func TestInstrumentedConnection_expectedError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recorder := recordSpans(t)
+	ctx := context.Background()
+
+	mockConnection := mocks.NewMockIConnection(ctrl)
+	mockConnection.EXPECT().FindOne(gomock.Any(), "rentals", nil, nil, nil).Return(db.NoDocumentsError)
+
+	err := db.NewInstrumentedConnection(mockConnection).FindOne(ctx, "rentals", nil, nil, nil)
+
+	assert.Equal(t, db.NoDocumentsError, err)
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "mongo.FindOne", spans[0].Name())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+}
+
+func TestInstrumentedConnection_unexpectedError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recorder := recordSpans(t)
+	ctx := context.Background()
+
+	mockConnection := mocks.NewMockIConnection(ctrl)
+	mockConnection.EXPECT().DeleteOne(gomock.Any(), "rentals", nil).Return(errors.New("connection lost"))
+
+	err := db.NewInstrumentedConnection(mockConnection).DeleteOne(ctx, "rentals", nil)
+
+	assert.NotNil(t, err)
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
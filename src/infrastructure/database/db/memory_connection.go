@@ -0,0 +1,330 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"reflect"
+	"sync"
+)
+
+// memoryConnection keeps the collections of the database in memory. It executes the queries created by MongoFactory
+// with the same semantics as MongoDB, so that it can replace a live database wherever one is not available.
+type memoryConnection struct {
+	factory QueryFactory
+
+	mutex sync.Mutex
+	// collections holds the documents of each collection in the order they have been inserted. The documents are
+	// never modified in place but replaced, so that queries can process them after releasing the mutex.
+	collections map[string][]bson.D
+}
+
+// NewMemoryConnection creates a connection to a new, empty database that is kept in memory. The database executes
+// the filters, sorts, projections, updates and pipelines of the MongoFactory returned by GetFactory like MongoDB.
+func NewMemoryConnection() IConnection {
+	return &memoryConnection{
+		factory:     &MongoFactory{},
+		collections: map[string][]bson.D{},
+	}
+}
+
+// lock locks the database and returns the function to unlock it again
+func (c *memoryConnection) lock() func() {
+	c.mutex.Lock()
+	return c.mutex.Unlock
+}
+
+func (c *memoryConnection) CleanUpDatabase() error {
+	return nil
+}
+
+func (c *memoryConnection) GetFactory() QueryFactory {
+	return c.factory
+}
+
+func (c *memoryConnection) Insert(ctx context.Context, collection string, document any) (ID, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	doc, err := toDocument(document)
+	if err != nil {
+		return "", err
+	}
+	id, ok := lookupField(doc, "_id")
+	if !ok {
+		id = primitive.NewObjectID().Hex()
+		doc = append(bson.D{{Key: "_id", Value: id}}, doc...)
+	}
+	idString, ok := id.(ID)
+	if !ok {
+		return "", fmt.Errorf("the _id of the document must be a string, not %T", id)
+	}
+
+	defer c.lock()()
+	if c.indexOfId(collection, id) >= 0 {
+		return "", DuplicateKeyError
+	}
+	c.collections[collection] = append(c.collections[collection], doc)
+	return idString, nil
+}
+
+func (c *memoryConnection) FindOne(ctx context.Context, collection string, filter Filter, options *Options,
+	result any) error {
+
+	docs, err := c.find(ctx, collection, filter, options)
+	if err != nil {
+		return err
+	}
+	if len(docs) == 0 {
+		return NoDocumentsError
+	}
+	return decodeDocument(docs[0], result)
+}
+
+func (c *memoryConnection) FindMany(ctx context.Context, collection string, filter Filter, options *Options,
+	results any) error {
+
+	docs, err := c.find(ctx, collection, filter, options)
+	if err != nil {
+		return err
+	}
+	return decodeDocuments(docs, results)
+}
+
+// find returns the documents of the collection that match the filter, sorted and projected as set by the options
+func (c *memoryConnection) find(ctx context.Context, collection string, filter Filter, options *Options) ([]bson.D,
+	error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	query, err := toFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock := c.lock()
+	docs, err := filterDocuments(c.collections[collection], query)
+	unlock()
+	if err != nil || options == nil {
+		return docs, err
+	}
+
+	if options.Sort != nil {
+		sortSpecification, err := toDocument(options.Sort.getSort())
+		if err != nil {
+			return nil, err
+		}
+		if err = sortDocuments(docs, sortSpecification); err != nil {
+			return nil, err
+		}
+	}
+	if options.Projection != nil {
+		projectionSpecification, err := toDocument(options.Projection.getProjection())
+		if err != nil {
+			return nil, err
+		}
+		for i, doc := range docs {
+			if docs[i], err = projectDocument(doc, projectionSpecification); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return docs, nil
+}
+
+func (c *memoryConnection) UpdateOne(ctx context.Context, collection string, filter Filter, update Update,
+	upsert bool) error {
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	query, err := toFilter(filter)
+	if err != nil {
+		return err
+	}
+	updateSpecification, err := toDocument(update.getUpdate())
+	if err != nil {
+		return err
+	}
+
+	defer c.lock()()
+	docs := c.collections[collection]
+	index, err := indexOfMatch(docs, query)
+	if err != nil {
+		return err
+	}
+	if index >= 0 {
+		updated, err := applyUpdate(docs[index], updateSpecification, query)
+		if err != nil {
+			return err
+		}
+		docs[index] = updated
+		return nil
+	}
+	if !upsert {
+		return NoDocumentsError
+	}
+
+	inserted, err := applyUpdate(upsertDocument(query), updateSpecification, query)
+	if err != nil {
+		return err
+	}
+	id, ok := lookupField(inserted, "_id")
+	if !ok {
+		id = primitive.NewObjectID().Hex()
+		inserted = append(bson.D{{Key: "_id", Value: id}}, inserted...)
+	}
+	if c.indexOfId(collection, id) >= 0 {
+		return DuplicateKeyError
+	}
+	c.collections[collection] = append(docs, inserted)
+	return nil
+}
+
+func (c *memoryConnection) DeleteOne(ctx context.Context, collection string, filter Filter) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	query, err := toFilter(filter)
+	if err != nil {
+		return err
+	}
+
+	defer c.lock()()
+	docs := c.collections[collection]
+	index, err := indexOfMatch(docs, query)
+	if err != nil {
+		return err
+	}
+	if index < 0 {
+		return NoDocumentsError
+	}
+	// copy the remaining documents so that the documents a query is processing are kept
+	c.collections[collection] = append(docs[:index:index], docs[index+1:]...)
+	return nil
+}
+
+func (c *memoryConnection) DropCollection(ctx context.Context, collection string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	defer c.lock()()
+	delete(c.collections, collection)
+	return nil
+}
+
+func (c *memoryConnection) Aggregate(ctx context.Context, collection string, pipeline Pipeline, results any) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	stages, err := toStages(pipeline.getPipeline())
+	if err != nil {
+		return err
+	}
+
+	unlock := c.lock()
+	docs := append([]bson.D(nil), c.collections[collection]...)
+	unlock()
+
+	for _, stage := range stages {
+		if docs, err = runStage(docs, stage); err != nil {
+			return err
+		}
+	}
+	return decodeDocuments(docs, results)
+}
+
+func (c *memoryConnection) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// indexOfId returns the index of the document with the given ID in the collection or -1 if there is none.
+// The lock must be held by the caller.
+func (c *memoryConnection) indexOfId(collection string, id any) int {
+	for i, doc := range c.collections[collection] {
+		if otherId, ok := lookupField(doc, "_id"); ok && valuesEqual(id, otherId) {
+			return i
+		}
+	}
+	return -1
+}
+
+// toDocument converts a document, e.g. a struct with bson tags or a bson.D, to a bson.D with the types that are
+// decoded from the database, e.g. bson.A for slices and primitive.DateTime for times
+func toDocument(document any) (bson.D, error) {
+	if document == nil {
+		return bson.D{}, nil
+	}
+	data, err := bson.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	var doc bson.D
+	return doc, bson.Unmarshal(data, &doc)
+}
+
+// toValue converts a value like toDocument converts a document
+func toValue(value any) (any, error) {
+	doc, err := toDocument(bson.D{{Key: "value", Value: value}})
+	if err != nil {
+		return nil, err
+	}
+	return doc[0].Value, nil
+}
+
+// toFilter converts a filter to a bson.D, where a nil filter matches every document
+func toFilter(filter Filter) (bson.D, error) {
+	if filter == nil {
+		return bson.D{}, nil
+	}
+	return toDocument(filter.getFilter())
+}
+
+// toStages converts a pipeline to its stages
+func toStages(pipeline any) ([]bson.D, error) {
+	value, err := toValue(pipeline)
+	if err != nil {
+		return nil, err
+	}
+	array, ok := value.(bson.A)
+	if !ok {
+		return nil, fmt.Errorf("a pipeline must be an array, not %T", value)
+	}
+	stages := make([]bson.D, len(array))
+	for i, stage := range array {
+		if stages[i], ok = stage.(bson.D); !ok || len(stages[i]) != 1 {
+			return nil, errors.New("a pipeline stage must be a document with a single field")
+		}
+	}
+	return stages, nil
+}
+
+// decodeDocument decodes a document into result like MongoDB decodes the documents it returns
+func decodeDocument(doc bson.D, result any) error {
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(data, result)
+}
+
+// decodeDocuments decodes the documents into the slice results points to like mongo.Cursor.All
+func decodeDocuments(docs []bson.D, results any) error {
+	resultsValue := reflect.ValueOf(results)
+	if resultsValue.Kind() != reflect.Pointer || resultsValue.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("results must be a pointer to a slice, not %T", results)
+	}
+	slice := resultsValue.Elem().Slice(0, 0)
+	for _, doc := range docs {
+		element := reflect.New(slice.Type().Elem())
+		if err := decodeDocument(doc, element.Interface()); err != nil {
+			return err
+		}
+		slice = reflect.Append(slice, element.Elem())
+	}
+	resultsValue.Elem().Set(slice)
+	return nil
+}
@@ -0,0 +1,259 @@
+package db_test
+
+import (
+	"RentalManagement/infrastructure/database/db"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+	"time"
+)
+
+type testRental struct {
+	RentalId  string    `bson:"rentalId"`
+	StartDate time.Time `bson:"startDate"`
+	Timeout   int       `bson:"timeout,omitempty"`
+}
+
+type testCar struct {
+	Vin     string       `bson:"_id"`
+	Rentals []testRental `bson:"rentals"`
+}
+
+var (
+	january  = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	february = time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+	march    = time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// insertCars inserts a car with rentals in February and January and a car with a rental in March
+func insertCars(t *testing.T, connection db.IConnection) {
+	for _, car := range []testCar{
+		{"car1", []testRental{{RentalId: "feb", StartDate: february}, {RentalId: "jan", StartDate: january}}},
+		{"car2", []testRental{{RentalId: "mar", StartDate: march}}},
+	} {
+		id, err := connection.Insert(context.Background(), "cars", car)
+		assert.Nil(t, err)
+		assert.Equal(t, car.Vin, id)
+	}
+}
+
+func TestMemoryConnection_insertDuplicate(t *testing.T) {
+	connection := db.NewMemoryConnection()
+	insertCars(t, connection)
+
+	_, err := connection.Insert(context.Background(), "cars", testCar{Vin: "car1"})
+
+	assert.ErrorIs(t, err, db.DuplicateKeyError)
+}
+
+func TestMemoryConnection_findMany(t *testing.T) {
+	connection := db.NewMemoryConnection()
+	insertCars(t, connection)
+	factory := connection.GetFactory()
+
+	for _, test := range []struct {
+		name     string
+		filter   db.Filter
+		sort     db.Sort
+		expected []string
+	}{
+		{"everything", factory.FilterEverything(), nil, []string{"car1", "car2"}},
+		{"equal in array", factory.FilterEqual("rentals.rentalId", "mar"), nil, []string{"car2"}},
+		{"less in array", factory.FilterLess("rentals.startDate", february), nil, []string{"car1"}},
+		{"not", factory.FilterNot(factory.FilterEqual("_id", "car1")), nil, []string{"car2"}},
+		{"or", factory.FilterOr(factory.FilterEqual("_id", "car1"), factory.FilterEqual("_id", "car2")), nil,
+			[]string{"car1", "car2"}},
+		{"element match", factory.FilterElementMatch("rentals", factory.FilterAnd(
+			factory.FilterGreaterEqual("startDate", february),
+			factory.FilterEqual("rentalId", "jan"),
+		)), nil, []string{}},
+		{"missing field is null", factory.FilterEqual("rentals.timeout", nil), nil, []string{"car1", "car2"}},
+		// an array is sorted by its least element in ascending and by its greatest element in descending order
+		{"sort ascending", factory.FilterEverything(), factory.SortAsc("rentals.startDate"),
+			[]string{"car1", "car2"}},
+		{"sort descending", factory.FilterEverything(), factory.SortDesc("rentals.startDate"),
+			[]string{"car2", "car1"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var cars []testCar
+			err := connection.FindMany(context.Background(), "cars", test.filter,
+				&db.Options{Sort: test.sort, Projection: factory.ProjectionID()}, &cars)
+
+			assert.Nil(t, err)
+			vins := []string{}
+			for _, car := range cars {
+				assert.Nil(t, car.Rentals)
+				vins = append(vins, car.Vin)
+			}
+			assert.Equal(t, test.expected, vins)
+		})
+	}
+}
+
+func TestMemoryConnection_projectionSingle(t *testing.T) {
+	connection := db.NewMemoryConnection()
+	insertCars(t, connection)
+	factory := connection.GetFactory()
+
+	var result bson.D
+	err := connection.FindOne(context.Background(), "cars", factory.FilterEqual("_id", "car2"),
+		&db.Options{Projection: factory.ProjectionSingle("rentals.rentalId")}, &result)
+
+	assert.Nil(t, err)
+	assert.Equal(t, bson.D{{Key: "rentals", Value: bson.A{bson.D{{Key: "rentalId", Value: "mar"}}}}}, result)
+}
+
+func TestMemoryConnection_findOneNotFound(t *testing.T) {
+	connection := db.NewMemoryConnection()
+	factory := connection.GetFactory()
+
+	var car testCar
+	err := connection.FindOne(context.Background(), "cars", factory.FilterEqual("_id", "car1"), nil, &car)
+
+	assert.ErrorIs(t, err, db.NoDocumentsError)
+}
+
+func TestMemoryConnection_updateMatchingArrayElement(t *testing.T) {
+	connection := db.NewMemoryConnection()
+	insertCars(t, connection)
+	factory := connection.GetFactory()
+
+	err := connection.UpdateOne(context.Background(), "cars",
+		factory.FilterElementMatch("rentals", factory.FilterEqual("rentalId", "jan")),
+		factory.UpdateMatchingArrayElement("rentals", "timeout", 30), false)
+
+	assert.Nil(t, err)
+	var car testCar
+	assert.Nil(t, connection.FindOne(context.Background(), "cars", factory.FilterEqual("_id", "car1"), nil, &car))
+	assert.Equal(t, []testRental{
+		{RentalId: "feb", StartDate: february},
+		{RentalId: "jan", StartDate: january, Timeout: 30},
+	}, car.Rentals)
+}
+
+func TestMemoryConnection_updateNotFound(t *testing.T) {
+	connection := db.NewMemoryConnection()
+	insertCars(t, connection)
+	factory := connection.GetFactory()
+
+	err := connection.UpdateOne(context.Background(), "cars", factory.FilterEqual("_id", "car3"),
+		factory.UpdateSingle("rentals", bson.A{}), false)
+
+	assert.ErrorIs(t, err, db.NoDocumentsError)
+}
+
+func TestMemoryConnection_updateId(t *testing.T) {
+	connection := db.NewMemoryConnection()
+	insertCars(t, connection)
+	factory := connection.GetFactory()
+
+	err := connection.UpdateOne(context.Background(), "cars", factory.FilterEqual("_id", "car1"),
+		factory.UpdateSingle("_id", "car3"), false)
+
+	assert.NotNil(t, err)
+	assert.NotErrorIs(t, err, db.NoDocumentsError)
+}
+
+func TestMemoryConnection_upsert(t *testing.T) {
+	connection := db.NewMemoryConnection()
+	insertCars(t, connection)
+	factory := connection.GetFactory()
+	// matches cars without a rental in January, which car1 has
+	filter := func(vin string) db.Filter {
+		return factory.FilterAnd(
+			factory.FilterEqual("_id", vin),
+			factory.FilterNot(factory.FilterElementMatch("rentals", factory.FilterEqual("startDate", january))),
+		)
+	}
+	push := factory.UpdatePush("rentals", testRental{RentalId: "new", StartDate: january})
+
+	assert.ErrorIs(t, connection.UpdateOne(context.Background(), "cars", filter("car1"), push, true),
+		db.DuplicateKeyError)
+	assert.Nil(t, connection.UpdateOne(context.Background(), "cars", filter("car2"), push, true))
+	assert.Nil(t, connection.UpdateOne(context.Background(), "cars", filter("car3"), push, true))
+
+	var cars []testCar
+	assert.Nil(t, connection.FindMany(context.Background(), "cars", factory.FilterEverything(), nil, &cars))
+	assert.Equal(t, []testCar{
+		{"car1", []testRental{{RentalId: "feb", StartDate: february}, {RentalId: "jan", StartDate: january}}},
+		{"car2", []testRental{{RentalId: "mar", StartDate: march}, {RentalId: "new", StartDate: january}}},
+		{"car3", []testRental{{RentalId: "new", StartDate: january}}},
+	}, cars)
+}
+
+func TestMemoryConnection_deleteOne(t *testing.T) {
+	connection := db.NewMemoryConnection()
+	insertCars(t, connection)
+	factory := connection.GetFactory()
+
+	assert.Nil(t, connection.DeleteOne(context.Background(), "cars", factory.FilterEqual("_id", "car1")))
+	assert.ErrorIs(t, connection.DeleteOne(context.Background(), "cars", factory.FilterEqual("_id", "car1")),
+		db.NoDocumentsError)
+}
+
+func TestMemoryConnection_arrayFilterAggregation(t *testing.T) {
+	connection := db.NewMemoryConnection()
+	insertCars(t, connection)
+	factory := connection.GetFactory()
+
+	for _, test := range []struct {
+		name     string
+		filter   db.Filter
+		limit    int
+		sort     db.Sort
+		expected []testCar
+	}{
+		{"filter", factory.FilterEqual("rentals.rentalId", "jan"), -1, nil,
+			[]testCar{{"car1", []testRental{{RentalId: "jan", StartDate: january}}}}},
+		{"sort", factory.FilterEverything(), -1, factory.SortAsc("rentals.startDate"), []testCar{
+			{"car1", []testRental{{RentalId: "jan", StartDate: january}, {RentalId: "feb", StartDate: february}}},
+			{"car2", []testRental{{RentalId: "mar", StartDate: march}}},
+		}},
+		{"sort and limit", factory.FilterGreater("rentals.startDate", january), 1, factory.SortDesc("rentals.startDate"),
+			[]testCar{{"car2", []testRental{{RentalId: "mar", StartDate: march}}}}},
+		{"no match", factory.FilterEqual("rentals.rentalId", "apr"), 1, nil, []testCar{}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			cars := []testCar{}
+			err := connection.Aggregate(context.Background(), "cars",
+				factory.ArrayFilterAggregation("rentals", test.filter, test.limit, test.sort), &cars)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, cars)
+		})
+	}
+}
+
+func TestMemoryConnection_updateCombinedPull(t *testing.T) {
+	connection := db.NewMemoryConnection()
+	insertCars(t, connection)
+	factory := connection.GetFactory()
+
+	err := connection.UpdateOne(context.Background(), "cars", factory.FilterEqual("_id", "car1"),
+		factory.UpdateCombine(
+			factory.UpdatePull("rentals", factory.FilterEqual("rentalId", "feb")),
+			factory.UpdatePush("rentals", testRental{RentalId: "mar", StartDate: march}),
+		), false)
+	// MongoDB rejects updates that pull from and push to the same array
+	assert.NotNil(t, err)
+
+	err = connection.UpdateOne(context.Background(), "cars", factory.FilterEqual("_id", "car1"),
+		factory.UpdateCombine(
+			factory.UpdatePull("rentals", factory.FilterLess("startDate", february)),
+			factory.UpdateSingle("name", "first"),
+		), false)
+
+	assert.Nil(t, err)
+	var car bson.D
+	assert.Nil(t, connection.FindOne(context.Background(), "cars", factory.FilterEqual("_id", "car1"), nil, &car))
+	assert.Equal(t, bson.D{
+		{Key: "_id", Value: "car1"},
+		{Key: "rentals", Value: bson.A{bson.D{
+			{Key: "rentalId", Value: "feb"},
+			{Key: "startDate", Value: primitive.NewDateTimeFromTime(february)},
+		}}},
+		{Key: "name", Value: "first"},
+	}, car)
+}
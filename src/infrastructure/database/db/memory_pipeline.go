@@ -0,0 +1,271 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"strings"
+)
+
+// missing is the result of an expression that refers to a field that does not exist, which MongoDB distinguishes
+// from null, e.g. by leaving the field out of the documents an expression creates
+type missing struct{}
+
+// runStage returns the documents the pipeline stage creates from the given ones
+func runStage(docs []bson.D, stage bson.D) ([]bson.D, error) {
+	operator, argument := stage[0].Key, stage[0].Value
+	switch operator {
+	case "$match":
+		filter, ok := argument.(bson.D)
+		if !ok {
+			return nil, errors.New("$match needs a document")
+		}
+		return filterDocuments(docs, filter)
+	case "$sort":
+		specification, ok := argument.(bson.D)
+		if !ok {
+			return nil, errors.New("$sort needs a document")
+		}
+		return docs, sortDocuments(docs, specification)
+	case "$limit":
+		limit, ok := toInteger(argument)
+		if !ok || limit <= 0 {
+			return nil, errors.New("$limit needs a positive integer")
+		}
+		if int64(len(docs)) > limit {
+			docs = docs[:limit]
+		}
+		return docs, nil
+	case "$unwind":
+		return unwind(docs, argument)
+	case "$group":
+		specification, ok := argument.(bson.D)
+		if !ok {
+			return nil, errors.New("$group needs a document")
+		}
+		return group(docs, specification)
+	case "$replaceRoot":
+		specification, ok := argument.(bson.D)
+		if !ok {
+			return nil, errors.New("$replaceRoot needs a document")
+		}
+		newRoot, ok := lookupField(specification, "newRoot")
+		if !ok {
+			return nil, errors.New("$replaceRoot needs newRoot")
+		}
+		return replaceRoot(docs, newRoot)
+	}
+	return nil, fmt.Errorf("the pipeline stage %s is not supported", operator)
+}
+
+// unwind creates a document for each element of the array at the field path, e.g. "$rentals", which replaces the
+// array in the document. Documents without elements in the array are left out.
+func unwind(docs []bson.D, argument any) ([]bson.D, error) {
+	if specification, ok := argument.(bson.D); ok {
+		argument, _ = lookupField(specification, "path")
+	}
+	fieldPath, ok := argument.(string)
+	if !ok || !strings.HasPrefix(fieldPath, "$") {
+		return nil, errors.New("$unwind needs a field path like $field")
+	}
+	path := strings.Split(strings.TrimPrefix(fieldPath, "$"), ".")
+
+	var unwound []bson.D
+	for _, doc := range docs {
+		values := lookupPath(doc, path)
+		if len(values) != 1 {
+			continue
+		}
+		array, ok := values[0].(bson.A)
+		if !ok {
+			if values[0] != nil {
+				// like MongoDB, a value that is no array is treated as an array with a single element
+				unwound = append(unwound, doc)
+			}
+			continue
+		}
+		for _, element := range array {
+			unwoundDoc := deepCopy(doc).(bson.D)
+			if err := setPath(&unwoundDoc, path, element); err != nil {
+				return nil, err
+			}
+			unwound = append(unwound, unwoundDoc)
+		}
+	}
+	return unwound, nil
+}
+
+// group groups the documents by the _id expression of the specification and computes the other fields of the
+// specification, e.g. {doc: {$first: "$$ROOT"}}, for each group. The groups are created in the order of the first
+// document of each group.
+func group(docs []bson.D, specification bson.D) ([]bson.D, error) {
+	idExpression, ok := lookupField(specification, "_id")
+	if !ok {
+		return nil, errors.New("$group needs an _id")
+	}
+	var groups []bson.D
+	for _, doc := range docs {
+		id, err := evaluate(doc, idExpression)
+		if err != nil {
+			return nil, err
+		}
+		if _, isMissing := id.(missing); isMissing {
+			id = nil
+		}
+
+		index := -1
+		for i, group := range groups {
+			if valuesEqual(group[0].Value, id) {
+				index = i
+				break
+			}
+		}
+		first := index < 0
+		if first {
+			groups = append(groups, bson.D{{Key: "_id", Value: id}})
+			index = len(groups) - 1
+		}
+
+		for _, field := range specification {
+			if field.Key == "_id" {
+				continue
+			}
+			accumulator, ok := field.Value.(bson.D)
+			if !ok || len(accumulator) != 1 {
+				return nil, fmt.Errorf("the field %s of $group must be an accumulator", field.Key)
+			}
+			value, err := evaluate(doc, accumulator[0].Value)
+			if err != nil {
+				return nil, err
+			}
+			if err = accumulate(&groups[index], field.Key, accumulator[0].Key, value, first); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return groups, nil
+}
+
+// accumulate adds the value of a document to the field of its group
+func accumulate(group *bson.D, field string, accumulator string, value any, first bool) error {
+	_, isMissing := value.(missing)
+	switch accumulator {
+	case "$first", "$last":
+		if isMissing {
+			value = nil
+		}
+		if first || accumulator == "$last" {
+			return setPath(group, []string{field}, value)
+		}
+		return nil
+	case "$push":
+		if first {
+			if err := setPath(group, []string{field}, bson.A{}); err != nil {
+				return err
+			}
+		}
+		if isMissing {
+			return nil
+		}
+		return pushPath(group, []string{field}, value)
+	}
+	return fmt.Errorf("the accumulator %s is not supported", accumulator)
+}
+
+// replaceRoot replaces each document with the document the expression evaluates to
+func replaceRoot(docs []bson.D, expression any) ([]bson.D, error) {
+	replaced := make([]bson.D, 0, len(docs))
+	for _, doc := range docs {
+		value, err := evaluate(doc, expression)
+		if err != nil {
+			return nil, err
+		}
+		newRoot, ok := value.(bson.D)
+		if !ok {
+			return nil, fmt.Errorf("newRoot must evaluate to a document, not %T", value)
+		}
+		replaced = append(replaced, newRoot)
+	}
+	return replaced, nil
+}
+
+// evaluate evaluates an aggregation expression for the document. It supports field paths like "$field",
+// "$$ROOT", $mergeObjects and literals, including documents and arrays of expressions.
+func evaluate(doc bson.D, expression any) (any, error) {
+	switch typed := expression.(type) {
+	case string:
+		switch {
+		case typed == "$$ROOT" || typed == "$$CURRENT":
+			return deepCopy(doc), nil
+		case strings.HasPrefix(typed, "$$"):
+			return nil, fmt.Errorf("the variable %s is not supported", typed)
+		case strings.HasPrefix(typed, "$"):
+			values := lookupPath(doc, strings.Split(strings.TrimPrefix(typed, "$"), "."))
+			if len(values) == 0 {
+				return missing{}, nil
+			}
+			return deepCopy(values[0]), nil
+		}
+		return typed, nil
+	case bson.D:
+		if isOperatorDocument(typed) {
+			if typed[0].Key != "$mergeObjects" || len(typed) != 1 {
+				return nil, fmt.Errorf("the expression operator %s is not supported", typed[0].Key)
+			}
+			return mergeObjects(doc, typed[0].Value)
+		}
+		result := bson.D{}
+		for _, element := range typed {
+			value, err := evaluate(doc, element.Value)
+			if err != nil {
+				return nil, err
+			}
+			if _, isMissing := value.(missing); !isMissing {
+				result = append(result, bson.E{Key: element.Key, Value: value})
+			}
+		}
+		return result, nil
+	case bson.A:
+		result := make(bson.A, len(typed))
+		for i, element := range typed {
+			value, err := evaluate(doc, element)
+			if err != nil {
+				return nil, err
+			}
+			if _, isMissing := value.(missing); isMissing {
+				value = nil
+			}
+			result[i] = value
+		}
+		return result, nil
+	}
+	return expression, nil
+}
+
+// mergeObjects merges the documents the expressions evaluate to, where later documents overwrite the fields of
+// earlier ones. Expressions that evaluate to null or a missing field are ignored.
+func mergeObjects(doc bson.D, argument any) (any, error) {
+	expressions, ok := argument.(bson.A)
+	if !ok {
+		expressions = bson.A{argument}
+	}
+	merged := bson.D{}
+	for _, expression := range expressions {
+		value, err := evaluate(doc, expression)
+		if err != nil {
+			return nil, err
+		}
+		switch typed := value.(type) {
+		case nil, missing:
+		case bson.D:
+			for _, element := range typed {
+				if err = setPath(&merged, []string{element.Key}, element.Value); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			return nil, fmt.Errorf("$mergeObjects needs documents, not %T", value)
+		}
+	}
+	return merged, nil
+}
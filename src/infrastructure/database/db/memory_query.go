@@ -0,0 +1,568 @@
+package db
+
+import (
+	"bytes"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/exp/slices"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// lookupField returns the value of a top level field of the document
+func lookupField(doc bson.D, name string) (any, bool) {
+	for _, element := range doc {
+		if element.Key == name {
+			return element.Value, true
+		}
+	}
+	return nil, false
+}
+
+// lookupPath returns the values a dotted path refers to in the value. Like in MongoDB, a path traverses arrays,
+// i.e. a path that continues into an array of documents refers to the field of each of them, while a numeric
+// component of the path refers to an array element.
+func lookupPath(value any, path []string) []any {
+	if len(path) == 0 {
+		return []any{value}
+	}
+	switch typed := value.(type) {
+	case bson.D:
+		if field, ok := lookupField(typed, path[0]); ok {
+			return lookupPath(field, path[1:])
+		}
+	case bson.A:
+		if index, err := strconv.Atoi(path[0]); err == nil {
+			if index >= 0 && index < len(typed) {
+				return lookupPath(typed[index], path[1:])
+			}
+			return nil
+		}
+		var values []any
+		for _, element := range typed {
+			if doc, ok := element.(bson.D); ok {
+				values = append(values, lookupPath(doc, path)...)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// filterDocuments returns the documents that match the filter in their order
+func filterDocuments(docs []bson.D, filter bson.D) ([]bson.D, error) {
+	var matching []bson.D
+	for _, doc := range docs {
+		ok, err := matches(doc, filter)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matching = append(matching, doc)
+		}
+	}
+	return matching, nil
+}
+
+// indexOfMatch returns the index of the first document that matches the filter or -1 if there is none
+func indexOfMatch(docs []bson.D, filter bson.D) (int, error) {
+	for i, doc := range docs {
+		ok, err := matches(doc, filter)
+		if err != nil {
+			return -1, err
+		}
+		if ok {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
+
+// matches reports whether the document matches the filter
+func matches(doc bson.D, filter bson.D) (bool, error) {
+	for _, element := range filter {
+		var ok bool
+		var err error
+		switch element.Key {
+		case "$and", "$or", "$nor":
+			ok, err = matchesLogical(doc, element.Key, element.Value)
+		default:
+			if strings.HasPrefix(element.Key, "$") {
+				return false, fmt.Errorf("the filter operator %s is not supported", element.Key)
+			}
+			ok, err = matchesCondition(lookupPath(doc, strings.Split(element.Key, ".")), element.Value)
+		}
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// matchesLogical reports whether the document matches the filters combined by $and, $or or $nor
+func matchesLogical(doc bson.D, operator string, filters any) (bool, error) {
+	array, ok := filters.(bson.A)
+	if !ok || len(array) == 0 {
+		return false, fmt.Errorf("%s must be a nonempty array", operator)
+	}
+	for _, filter := range array {
+		filterDoc, ok := filter.(bson.D)
+		if !ok {
+			return false, fmt.Errorf("the elements of %s must be documents", operator)
+		}
+		ok, err := matches(doc, filterDoc)
+		if err != nil {
+			return false, err
+		}
+		switch {
+		case operator == "$and" && !ok:
+			return false, nil
+		case operator == "$or" && ok:
+			return true, nil
+		case operator == "$nor" && ok:
+			return false, nil
+		}
+	}
+	return operator != "$or", nil
+}
+
+// isOperatorDocument reports whether the value is a document of query operators like {$lt: 5} rather than an
+// embedded document to compare with
+func isOperatorDocument(value any) bool {
+	doc, ok := value.(bson.D)
+	return ok && len(doc) > 0 && strings.HasPrefix(doc[0].Key, "$")
+}
+
+// matchesCondition reports whether the values of a field, as returned by lookupPath, match the condition, which is
+// either a document of query operators or a value the field must be equal to
+func matchesCondition(values []any, condition any) (bool, error) {
+	if !isOperatorDocument(condition) {
+		return matchesEqual(values, condition), nil
+	}
+	for _, element := range condition.(bson.D) {
+		ok, err := matchesOperator(values, element.Key, element.Value)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// matchesEqual reports whether any value or, for arrays, any array element is equal to the operand. A null operand
+// also matches a missing field.
+func matchesEqual(values []any, operand any) bool {
+	if len(values) == 0 {
+		return operand == nil
+	}
+	for _, value := range candidates(values) {
+		if valuesEqual(value, operand) {
+			return true
+		}
+	}
+	return false
+}
+
+// candidates returns the values and the elements of the values that are arrays, which are compared by query
+// operators
+func candidates(values []any) []any {
+	var result []any
+	for _, value := range values {
+		result = append(result, value)
+		if array, ok := value.(bson.A); ok {
+			result = append(result, array...)
+		}
+	}
+	return result
+}
+
+func matchesOperator(values []any, operator string, operand any) (bool, error) {
+	switch operator {
+	case "$eq":
+		return matchesEqual(values, operand), nil
+	case "$ne":
+		return !matchesEqual(values, operand), nil
+	case "$in", "$nin":
+		array, ok := operand.(bson.A)
+		if !ok {
+			return false, fmt.Errorf("%s needs an array", operator)
+		}
+		found := slices.ContainsFunc(array, func(element any) bool {
+			return matchesEqual(values, element)
+		})
+		return found == (operator == "$in"), nil
+	case "$exists":
+		exists, ok := operand.(bool)
+		if !ok {
+			return false, fmt.Errorf("$exists needs a boolean")
+		}
+		return (len(values) > 0) == exists, nil
+	case "$lt", "$lte", "$gt", "$gte":
+		if operand == nil {
+			// only null and missing fields are comparable to null
+			return operator != "$lt" && operator != "$gt" && matchesEqual(values, nil), nil
+		}
+		return slices.ContainsFunc(candidates(values), func(value any) bool {
+			// values of different types never match, e.g. {$lt: date} does not match numbers
+			if typeOrder(value) != typeOrder(operand) {
+				return false
+			}
+			result := compareValues(value, operand)
+			switch operator {
+			case "$lt":
+				return result < 0
+			case "$lte":
+				return result <= 0
+			case "$gt":
+				return result > 0
+			default:
+				return result >= 0
+			}
+		}), nil
+	case "$elemMatch":
+		query, ok := operand.(bson.D)
+		if !ok {
+			return false, fmt.Errorf("$elemMatch needs a document")
+		}
+		for _, value := range values {
+			array, ok := value.(bson.A)
+			if !ok {
+				continue
+			}
+			index, err := indexOfElementMatch(array, query)
+			if err != nil || index >= 0 {
+				return index >= 0, err
+			}
+		}
+		return false, nil
+	}
+	return false, fmt.Errorf("the query operator %s is not supported", operator)
+}
+
+// indexOfElementMatch returns the index of the first array element that matches the query of $elemMatch or -1 if
+// there is none
+func indexOfElementMatch(array bson.A, query bson.D) (int, error) {
+	for i, element := range array {
+		var ok bool
+		var err error
+		if isOperatorDocument(query) && !isLogicalOperator(query[0].Key) {
+			// a query like {$elemMatch: {$gte: 5}} applies to the element itself
+			ok, err = matchesCondition([]any{element}, query)
+		} else if doc, isDoc := element.(bson.D); isDoc {
+			ok, err = matches(doc, query)
+		}
+		if err != nil {
+			return -1, err
+		}
+		if ok {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
+
+func isLogicalOperator(key string) bool {
+	return key == "$and" || key == "$or" || key == "$nor"
+}
+
+// typeOrder ranks the types of values in the order MongoDB compares values of different types
+func typeOrder(value any) int {
+	switch value.(type) {
+	case primitive.MinKey:
+		return 0
+	case nil, primitive.Undefined, primitive.Null:
+		return 1
+	case int32, int64, float64, primitive.Decimal128:
+		return 2
+	case string, primitive.Symbol:
+		return 3
+	case bson.D:
+		return 4
+	case bson.A:
+		return 5
+	case primitive.Binary:
+		return 6
+	case primitive.ObjectID:
+		return 7
+	case bool:
+		return 8
+	case primitive.DateTime:
+		return 9
+	case primitive.Timestamp:
+		return 10
+	case primitive.Regex:
+		return 11
+	case primitive.MaxKey:
+		return 13
+	}
+	return 12
+}
+
+// valuesEqual reports whether two values are equal like MongoDB compares them, e.g. numbers of different types are
+// equal if their values are equal, and embedded documents are only equal if their fields have the same order
+func valuesEqual(a, b any) bool {
+	return typeOrder(a) == typeOrder(b) && compareValues(a, b) == 0
+}
+
+// compareValues compares two values like MongoDB sorts them. It returns a negative number if a is less than b, zero
+// if they are equal and a positive number if a is greater than b.
+func compareValues(a, b any) int {
+	if orderA, orderB := typeOrder(a), typeOrder(b); orderA != orderB {
+		return orderA - orderB
+	}
+
+	switch typedA := a.(type) {
+	case int32, int64, float64, primitive.Decimal128:
+		return compareNumbers(a, b)
+	case string:
+		return strings.Compare(typedA, fmt.Sprint(b))
+	case primitive.Symbol:
+		return strings.Compare(string(typedA), fmt.Sprint(b))
+	case bson.D:
+		typedB := b.(bson.D)
+		for i := 0; i < len(typedA) && i < len(typedB); i++ {
+			if result := typeOrder(typedA[i].Value) - typeOrder(typedB[i].Value); result != 0 {
+				return result
+			}
+			if result := strings.Compare(typedA[i].Key, typedB[i].Key); result != 0 {
+				return result
+			}
+			if result := compareValues(typedA[i].Value, typedB[i].Value); result != 0 {
+				return result
+			}
+		}
+		return len(typedA) - len(typedB)
+	case bson.A:
+		typedB := b.(bson.A)
+		for i := 0; i < len(typedA) && i < len(typedB); i++ {
+			if result := compareValues(typedA[i], typedB[i]); result != 0 {
+				return result
+			}
+		}
+		return len(typedA) - len(typedB)
+	case primitive.Binary:
+		typedB := b.(primitive.Binary)
+		if len(typedA.Data) != len(typedB.Data) {
+			return len(typedA.Data) - len(typedB.Data)
+		}
+		if typedA.Subtype != typedB.Subtype {
+			return int(typedA.Subtype) - int(typedB.Subtype)
+		}
+		return bytes.Compare(typedA.Data, typedB.Data)
+	case primitive.ObjectID:
+		typedB := b.(primitive.ObjectID)
+		return bytes.Compare(typedA[:], typedB[:])
+	case bool:
+		typedB := b.(bool)
+		switch {
+		case typedA == typedB:
+			return 0
+		case typedA:
+			return 1
+		}
+		return -1
+	case primitive.DateTime:
+		return compareIntegers(int64(typedA), int64(b.(primitive.DateTime)))
+	case primitive.Timestamp:
+		return primitive.CompareTimestamp(typedA, b.(primitive.Timestamp))
+	}
+	return 0
+}
+
+func compareNumbers(a, b any) int {
+	integerA, isIntegerA := toInteger(a)
+	integerB, isIntegerB := toInteger(b)
+	if isIntegerA && isIntegerB {
+		return compareIntegers(integerA, integerB)
+	}
+
+	floatA, floatB := toFloat(a), toFloat(b)
+	switch {
+	case floatA < floatB:
+		return -1
+	case floatA > floatB:
+		return 1
+	case floatA == floatB:
+		return 0
+	case math.IsNaN(floatA) && math.IsNaN(floatB):
+		return 0
+	case math.IsNaN(floatA):
+		// NaN is less than any other number
+		return -1
+	}
+	return 1
+}
+
+func compareIntegers(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func toInteger(value any) (int64, bool) {
+	switch typed := value.(type) {
+	case int32:
+		return int64(typed), true
+	case int64:
+		return typed, true
+	}
+	return 0, false
+}
+
+func toFloat(value any) float64 {
+	switch typed := value.(type) {
+	case int32:
+		return float64(typed)
+	case int64:
+		return float64(typed)
+	case float64:
+		return typed
+	case primitive.Decimal128:
+		parsed, err := strconv.ParseFloat(typed.String(), 64)
+		if err != nil {
+			return math.NaN()
+		}
+		return parsed
+	}
+	return math.NaN()
+}
+
+// sortDocuments sorts the documents by the fields of the sort specification, e.g. {rentals.rentalPeriod.endDate: -1},
+// where 1 is ascending and -1 is descending order. Documents that are equal keep their order.
+func sortDocuments(docs []bson.D, specification bson.D) error {
+	directions := make([]int, len(specification))
+	for i, element := range specification {
+		direction, ok := toInteger(element.Value)
+		if !ok {
+			if value, isFloat := element.Value.(float64); isFloat {
+				direction = int64(value)
+			}
+		}
+		if direction != 1 && direction != -1 {
+			return fmt.Errorf("the sort order of %s must be 1 or -1", element.Key)
+		}
+		directions[i] = int(direction)
+	}
+
+	slices.SortStableFunc(docs, func(a, b bson.D) int {
+		for i, element := range specification {
+			path := strings.Split(element.Key, ".")
+			result := compareValues(sortKey(a, path, directions[i]), sortKey(b, path, directions[i]))
+			if result != 0 {
+				return result * directions[i]
+			}
+		}
+		return 0
+	})
+	return nil
+}
+
+// sortKey returns the value a document is sorted by. Like in MongoDB, a field that refers to arrays is sorted by its
+// least element in ascending and by its greatest element in descending order, and a missing field is sorted as null.
+func sortKey(doc bson.D, path []string, direction int) any {
+	var key any
+	found := false
+	for _, value := range lookupPath(doc, path) {
+		values := []any{value}
+		if array, ok := value.(bson.A); ok && len(array) > 0 {
+			values = array
+		}
+		for _, value := range values {
+			if !found || compareValues(value, key)*direction < 0 {
+				key, found = value, true
+			}
+		}
+	}
+	return key
+}
+
+// projectDocument returns the document with the fields selected by the projection specification, which either
+// includes fields like {_id: 0, name: 1} or excludes fields like {name: 0}. The _id is included unless it is
+// excluded explicitly.
+func projectDocument(doc bson.D, specification bson.D) (bson.D, error) {
+	fields := map[string]bool{}
+	includeId := true
+	inclusion := false
+	for _, element := range specification {
+		var include bool
+		switch value := element.Value.(type) {
+		case bool:
+			include = value
+		case int32, int64, float64:
+			include = toFloat(value) != 0
+		default:
+			return nil, fmt.Errorf("the projection of %s is not supported", element.Key)
+		}
+		if element.Key == "_id" {
+			includeId = include
+			continue
+		}
+		if len(fields) > 0 && include != inclusion {
+			return nil, fmt.Errorf("a projection cannot both include and exclude fields")
+		}
+		fields[element.Key] = true
+		inclusion = include
+	}
+	if len(fields) == 0 {
+		// only the _id is projected
+		inclusion = includeId
+	}
+	fields["_id"] = includeId == inclusion
+
+	return projectFields(doc, fields, "", inclusion), nil
+}
+
+// projectFields includes or excludes the fields of the document whose dotted paths, prefixed with the given
+// prefix, are in the set of fields
+func projectFields(doc bson.D, fields map[string]bool, prefix string, inclusion bool) bson.D {
+	projected := bson.D{}
+	for _, element := range doc {
+		path := prefix + element.Key
+		if fields[path] {
+			if inclusion {
+				projected = append(projected, element)
+			}
+			continue
+		}
+		if !hasFieldBelow(fields, path) {
+			if !inclusion {
+				projected = append(projected, element)
+			}
+			continue
+		}
+		switch value := element.Value.(type) {
+		case bson.D:
+			projected = append(projected, bson.E{Key: element.Key, Value: projectFields(value, fields, path+".",
+				inclusion)})
+		case bson.A:
+			array := bson.A{}
+			for _, arrayElement := range value {
+				if arrayDoc, ok := arrayElement.(bson.D); ok {
+					array = append(array, projectFields(arrayDoc, fields, path+".", inclusion))
+				} else if !inclusion {
+					array = append(array, arrayElement)
+				}
+			}
+			projected = append(projected, bson.E{Key: element.Key, Value: array})
+		default:
+			if !inclusion {
+				projected = append(projected, element)
+			}
+		}
+	}
+	return projected
+}
+
+func hasFieldBelow(fields map[string]bool, path string) bool {
+	for field := range fields {
+		if strings.HasPrefix(field, path+".") {
+			return true
+		}
+	}
+	return false
+}
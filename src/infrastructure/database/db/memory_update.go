@@ -0,0 +1,290 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"strconv"
+	"strings"
+)
+
+// applyUpdate returns a copy of the document with the update specification, e.g. {$set: {name: "value"}}, applied.
+// The filter the document has been matched with resolves the positional operator $ in the paths of the update.
+func applyUpdate(doc bson.D, specification bson.D, filter bson.D) (bson.D, error) {
+	updated := deepCopy(doc).(bson.D)
+	var updatedPaths []string
+	for _, operation := range specification {
+		fields, ok := operation.Value.(bson.D)
+		if !ok {
+			return nil, fmt.Errorf("the fields of %s must be a document", operation.Key)
+		}
+		for _, field := range fields {
+			path, err := resolvePositional(updated, strings.Split(field.Key, "."), filter)
+			if err != nil {
+				return nil, err
+			}
+			if err = checkConflict(updatedPaths, strings.Join(path, ".")); err != nil {
+				return nil, err
+			}
+			updatedPaths = append(updatedPaths, strings.Join(path, "."))
+			switch operation.Key {
+			case "$set":
+				err = setPath(&updated, path, deepCopy(field.Value))
+			case "$push":
+				err = pushPath(&updated, path, deepCopy(field.Value))
+			case "$pull":
+				err = pullPath(&updated, path, field.Value)
+			default:
+				err = fmt.Errorf("the update operator %s is not supported", operation.Key)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	id, _ := lookupField(doc, "_id")
+	updatedId, _ := lookupField(updated, "_id")
+	if !valuesEqual(id, updatedId) {
+		return nil, errors.New("the update would modify the immutable field _id")
+	}
+	return updated, nil
+}
+
+// checkConflict returns an error if the path is one of the paths updated already or one of them is part of the other,
+// as MongoDB rejects updates that change a field more than once
+func checkConflict(updatedPaths []string, path string) error {
+	for _, updatedPath := range updatedPaths {
+		if path == updatedPath || strings.HasPrefix(path, updatedPath+".") || strings.HasPrefix(updatedPath, path+".") {
+			return fmt.Errorf("updating the path %s would create a conflict at %s", path, updatedPath)
+		}
+	}
+	return nil
+}
+
+// resolvePositional replaces the positional operator $ in the path with the index of the first element of the array
+// before it that matches the filter, either through $elemMatch on the array or a condition on a field of the elements
+func resolvePositional(doc bson.D, path []string, filter bson.D) ([]string, error) {
+	position := -1
+	for i, component := range path {
+		if component == "$" {
+			position = i
+			break
+		}
+	}
+	if position < 0 {
+		return path, nil
+	}
+
+	arrayPath := strings.Join(path[:position], ".")
+	values := lookupPath(doc, path[:position])
+	if len(values) != 1 {
+		return nil, fmt.Errorf("the positional operator needs %s to be an array", arrayPath)
+	}
+	array, ok := values[0].(bson.A)
+	if !ok {
+		return nil, fmt.Errorf("the positional operator needs %s to be an array", arrayPath)
+	}
+	index, err := indexOfPositionalMatch(array, arrayPath, filter)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 {
+		return nil, errors.New("the positional operator did not find the match needed from the query")
+	}
+
+	resolved := append([]string(nil), path...)
+	resolved[position] = strconv.Itoa(index)
+	return resolved, nil
+}
+
+// indexOfPositionalMatch returns the index of the first array element matched by a condition of the filter on the
+// array at arrayPath or -1 if the filter has no such condition
+func indexOfPositionalMatch(array bson.A, arrayPath string, filter bson.D) (int, error) {
+	for _, element := range filter {
+		if element.Key == "$and" {
+			filters, _ := element.Value.(bson.A)
+			for _, subFilter := range filters {
+				subFilterDoc, ok := subFilter.(bson.D)
+				if !ok {
+					continue
+				}
+				index, err := indexOfPositionalMatch(array, arrayPath, subFilterDoc)
+				if err != nil || index >= 0 {
+					return index, err
+				}
+			}
+			continue
+		}
+
+		var index int
+		var err error
+		switch {
+		case element.Key == arrayPath && isOperatorDocument(element.Value):
+			query, _ := lookupField(element.Value.(bson.D), "$elemMatch")
+			queryDoc, ok := query.(bson.D)
+			if !ok {
+				continue
+			}
+			index, err = indexOfElementMatch(array, queryDoc)
+		case strings.HasPrefix(element.Key, arrayPath+"."):
+			// a condition like {rentals.rentalId: "id"} matches the rentals with that ID
+			fieldPath := strings.TrimPrefix(element.Key, arrayPath+".")
+			index, err = indexOfElementMatch(array, bson.D{{Key: fieldPath, Value: element.Value}})
+		default:
+			continue
+		}
+		if err != nil || index >= 0 {
+			return index, err
+		}
+	}
+	return -1, nil
+}
+
+// setPath sets the field at the path in the document, creating embedded documents for missing fields on the way.
+// An existing field keeps its position, while a new one is appended.
+func setPath(doc *bson.D, path []string, value any) error {
+	updated, err := setValue(*doc, path, value)
+	if err != nil {
+		return err
+	}
+	*doc = updated.(bson.D)
+	return nil
+}
+
+func setValue(container any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	switch typed := container.(type) {
+	case bson.D:
+		for i, element := range typed {
+			if element.Key == path[0] {
+				updated, err := setValue(element.Value, path[1:], value)
+				typed[i].Value = updated
+				return typed, err
+			}
+		}
+		var updated any = value
+		if len(path) > 1 {
+			var err error
+			if updated, err = setValue(bson.D{}, path[1:], value); err != nil {
+				return nil, err
+			}
+		}
+		return append(typed, bson.E{Key: path[0], Value: updated}), nil
+	case bson.A:
+		index, err := strconv.Atoi(path[0])
+		if err != nil || index < 0 {
+			return nil, fmt.Errorf("cannot create the field %s in an array", path[0])
+		}
+		// like MongoDB, pad the array with null up to the index
+		for len(typed) <= index {
+			typed = append(typed, nil)
+		}
+		updated, err := setValue(typed[index], path[1:], value)
+		typed[index] = updated
+		return typed, err
+	}
+	return nil, fmt.Errorf("cannot create the field %s in a value of type %T", path[0], container)
+}
+
+// pushPath appends the value to the array at the path in the document, which is created if it is missing
+func pushPath(doc *bson.D, path []string, value any) error {
+	values := lookupPath(*doc, path)
+	if len(values) == 0 {
+		return setPath(doc, path, bson.A{value})
+	}
+	array, ok := values[0].(bson.A)
+	if len(values) != 1 || !ok {
+		return fmt.Errorf("cannot push to %s, which is not an array", strings.Join(path, "."))
+	}
+	return setPath(doc, path, append(array, value))
+}
+
+// pullPath removes the elements matching the condition from the array at the path in the document. A document
+// condition is a filter the elements have to match, any other condition an element has to be equal to.
+func pullPath(doc *bson.D, path []string, condition any) error {
+	values := lookupPath(*doc, path)
+	if len(values) == 0 {
+		return nil
+	}
+	array, ok := values[0].(bson.A)
+	if len(values) != 1 || !ok {
+		return fmt.Errorf("cannot pull from %s, which is not an array", strings.Join(path, "."))
+	}
+
+	kept := bson.A{}
+	for _, element := range array {
+		var pull bool
+		var err error
+		if filter, isFilter := condition.(bson.D); isFilter && !isOperatorDocument(filter) {
+			if elementDoc, isDoc := element.(bson.D); isDoc {
+				pull, err = matches(elementDoc, filter)
+			}
+		} else {
+			pull, err = matchesCondition([]any{element}, condition)
+		}
+		if err != nil {
+			return err
+		}
+		if !pull {
+			kept = append(kept, element)
+		}
+	}
+	return setPath(doc, path, kept)
+}
+
+// upsertDocument returns the document an upsert inserts before the update is applied to it, which consists of the
+// fields the filter requires to be equal to a value
+func upsertDocument(filter bson.D) bson.D {
+	doc := bson.D{}
+	addEqualityFields(&doc, filter)
+	return doc
+}
+
+func addEqualityFields(doc *bson.D, filter bson.D) {
+	for _, element := range filter {
+		if element.Key == "$and" {
+			filters, _ := element.Value.(bson.A)
+			for _, subFilter := range filters {
+				if subFilterDoc, ok := subFilter.(bson.D); ok {
+					addEqualityFields(doc, subFilterDoc)
+				}
+			}
+			continue
+		}
+		if strings.HasPrefix(element.Key, "$") {
+			continue
+		}
+
+		value := element.Value
+		if isOperatorDocument(value) {
+			var ok bool
+			if value, ok = lookupField(value.(bson.D), "$eq"); !ok {
+				continue
+			}
+		}
+		// conditions that cannot be turned into fields, e.g. because they conflict, are left out
+		_ = setPath(doc, strings.Split(element.Key, "."), deepCopy(value))
+	}
+}
+
+// deepCopy copies the documents and arrays within the value, so that the copy can be modified
+func deepCopy(value any) any {
+	switch typed := value.(type) {
+	case bson.D:
+		copied := make(bson.D, len(typed))
+		for i, element := range typed {
+			copied[i] = bson.E{Key: element.Key, Value: deepCopy(element.Value)}
+		}
+		return copied
+	case bson.A:
+		copied := make(bson.A, len(typed))
+		for i, element := range typed {
+			copied[i] = deepCopy(element)
+		}
+		return copied
+	}
+	return value
+}
@@ -82,6 +82,29 @@ func (f *MongoFactory) UpdatePush(fieldName string, value interface{}) Update {
 	return &update{bson.D{{"$push", bson.D{{fieldName, value}}}}}
 }
 
+func (f *MongoFactory) UpdatePull(fieldName string, filter Filter) Update {
+	return &update{bson.D{{Key: "$pull", Value: bson.D{{Key: fieldName, Value: filter.getFilter()}}}}}
+}
+
+func (f *MongoFactory) UpdateCombine(update1 Update, update2 Update) Update {
+	combined := bson.D{}
+	for _, operation := range append(update1.getUpdate().(bson.D), update2.getUpdate().(bson.D)...) {
+		fields := operation.Value.(bson.D)
+		merged := false
+		// an update may contain each operator only once, so the fields of the same operator are merged
+		for i, combinedOperation := range combined {
+			if combinedOperation.Key == operation.Key {
+				combined[i].Value = append(combinedOperation.Value.(bson.D), fields...)
+				merged = true
+			}
+		}
+		if !merged {
+			combined = append(combined, bson.E{Key: operation.Key, Value: append(bson.D{}, fields...)})
+		}
+	}
+	return &update{combined}
+}
+
 func (f *MongoFactory) UpdateMatchingArrayElement(arrayName string, elementFieldName string, value interface{}) Update {
 	return &update{bson.D{{"$set", bson.D{{arrayName + ".$." + elementFieldName, value}}}}}
 }
@@ -35,6 +35,11 @@ type pseudoUpdate struct {
 	value any
 }
 
+type pseudoMultiUpdate struct {
+	update1 Update
+	update2 Update
+}
+
 type pseudoNestedFilter struct {
 	arrayName string
 	filter    Filter
@@ -114,6 +119,25 @@ func (f *PseudoFactory) UpdatePush(fieldName string, value interface{}) Update {
 	return &update{pseudoUpdate{"#+#+/PUSH/+#+# TO " + fieldName, value}}
 }
 
+func (f *PseudoFactory) UpdatePull(fieldName string, filterParam Filter) Update {
+	return &update{pseudoUpdate{"#+#+/PULL/+#+# FROM " + fieldName, filterParam}}
+}
+
+func (f *PseudoFactory) UpdateCombine(update1 Update, update2 Update) Update {
+	return &update{pseudoMultiUpdate{update1, update2}}
+}
+
+// UnpackCombinedUpdate unpacks a combined update into the updates it combines.
+// Returns an error if the update is not a combined update created by this factory.
+func (f *PseudoFactory) UnpackCombinedUpdate(updateParam Update) (Update, Update, error) {
+	pseudoUpd, ok := updateParam.getUpdate().(pseudoMultiUpdate)
+	if !ok {
+		return nil, nil, errors.New("updateParam is not a pseudo combined update")
+	}
+
+	return pseudoUpd.update1, pseudoUpd.update2, nil
+}
+
 // UnpackPushUpdate unpacks a push update into the field name and the value to push.
 // Returns an error if the update is not a push update created by this factory.
 func (f *PseudoFactory) UnpackPushUpdate(updateParam Update) (string, interface{}, error) {
@@ -67,6 +67,11 @@ type QueryFactory interface {
 	UpdateMultiple(document interface{}) Update
 	// UpdatePush creates an update request that adds value to an array field.
 	UpdatePush(fieldName string, value interface{}) Update
+	// UpdatePull creates an update request that removes the elements matching the filter from an array field.
+	UpdatePull(fieldName string, filter Filter) Update
+	// UpdateCombine creates an update request that applies update1 and update2 at once. The updates must not change
+	// the same field and cannot be created by UpdateMultiple.
+	UpdateCombine(update1 Update, update2 Update) Update
 
 	// UpdateMatchingArrayElement creates an update request that updates the first matching array element
 	// in the array field of a document that matches via FilterElementMatch.
@@ -0,0 +1,176 @@
+package db
+
+import (
+	"RentalManagement/logging"
+	"context"
+	"golang.org/x/exp/slog"
+	"sync"
+	"time"
+)
+
+// ReconnectingConnection performs its operations with a connection created by NewDbConnection and replaces that
+// connection with a new one as soon as the connection string of the configuration changes, e.g. because a rotated
+// credential has been written to the file it is read from.
+type ReconnectingConnection struct {
+	config DatabaseConfig
+	// connect creates the connections, which is NewDbConnection except for testing
+	connect func(config DatabaseConfig) (IConnection, error)
+
+	mutex            sync.RWMutex
+	connection       *usedConnection
+	connectionString string
+}
+
+// usedConnection is a connection together with the operations in flight on it
+type usedConnection struct {
+	IConnection
+	// users counts the operations in flight, they are added while holding the read lock of the ReconnectingConnection
+	// so that no operation starts on a connection once it has been replaced
+	users sync.WaitGroup
+}
+
+// NewReconnectingConnection connects to the database as configured. You should defer a call to the CleanUpDatabase
+// method on the returned connection and run its Run method to reconnect on changes of the connection string.
+// Any rentalErrors are unexpected.
+func NewReconnectingConnection(config DatabaseConfig) (*ReconnectingConnection, error) {
+	return newReconnectingConnection(config, NewDbConnection)
+}
+
+func newReconnectingConnection(config DatabaseConfig,
+	connect func(config DatabaseConfig) (IConnection, error)) (*ReconnectingConnection, error) {
+	connectionString := config.GetMongoDbConnectionString()
+	connection, err := connect(config)
+	if err != nil {
+		return nil, err
+	}
+	return &ReconnectingConnection{
+		config:           config,
+		connect:          connect,
+		connection:       &usedConnection{IConnection: connection},
+		connectionString: connectionString,
+	}, nil
+}
+
+// Run checks in the given interval whether the connection string has changed and reconnects if it has, until the
+// context is done. If reconnecting fails, the previous connection is kept and reconnecting is retried in the next
+// interval.
+func (c *ReconnectingConnection) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.reconnectIfChanged(ctx); err != nil {
+				slog.Error("reconnecting to the database failed, keeping the previous connection", logging.KeyError, err)
+			}
+		}
+	}
+}
+
+// reconnectIfChanged replaces the connection with a new one if the connection string has changed. New operations use
+// the new connection right away, the previous connection is closed once the operations that were started on it
+// before have returned.
+func (c *ReconnectingConnection) reconnectIfChanged(ctx context.Context) error {
+	connectionString := c.config.GetMongoDbConnectionString()
+	c.mutex.RLock()
+	changed := connectionString != c.connectionString
+	c.mutex.RUnlock()
+	if !changed {
+		return nil
+	}
+
+	connection, err := c.connect(c.config)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	previousConnection := c.connection
+	c.connection = &usedConnection{IConnection: connection}
+	c.connectionString = connectionString
+	c.mutex.Unlock()
+	slog.InfoContext(ctx, "reconnected to the database as its connection string has changed")
+
+	previousConnection.users.Wait()
+	if err := previousConnection.CleanUpDatabase(); err != nil {
+		slog.Warn("disconnecting the previous database connection failed", logging.KeyError, err)
+	}
+	return nil
+}
+
+func (c *ReconnectingConnection) current() IConnection {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.connection
+}
+
+// use returns the current connection for an operation, which must call Done on its users when it has returned
+func (c *ReconnectingConnection) use() *usedConnection {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	c.connection.users.Add(1)
+	return c.connection
+}
+
+func (c *ReconnectingConnection) CleanUpDatabase() error {
+	return c.current().CleanUpDatabase()
+}
+
+func (c *ReconnectingConnection) GetFactory() QueryFactory {
+	return c.current().GetFactory()
+}
+
+func (c *ReconnectingConnection) Insert(ctx context.Context, collection string, document any) (ID, error) {
+	connection := c.use()
+	defer connection.users.Done()
+	return connection.Insert(ctx, collection, document)
+}
+
+func (c *ReconnectingConnection) FindOne(ctx context.Context, collection string, filter Filter, options *Options,
+	result any) error {
+	connection := c.use()
+	defer connection.users.Done()
+	return connection.FindOne(ctx, collection, filter, options, result)
+}
+
+func (c *ReconnectingConnection) FindMany(ctx context.Context, collection string, filter Filter, options *Options,
+	results any) error {
+	connection := c.use()
+	defer connection.users.Done()
+	return connection.FindMany(ctx, collection, filter, options, results)
+}
+
+func (c *ReconnectingConnection) UpdateOne(ctx context.Context, collection string, filter Filter, update Update,
+	upsert bool) error {
+	connection := c.use()
+	defer connection.users.Done()
+	return connection.UpdateOne(ctx, collection, filter, update, upsert)
+}
+
+func (c *ReconnectingConnection) DeleteOne(ctx context.Context, collection string, filter Filter) error {
+	connection := c.use()
+	defer connection.users.Done()
+	return connection.DeleteOne(ctx, collection, filter)
+}
+
+func (c *ReconnectingConnection) DropCollection(ctx context.Context, collection string) error {
+	connection := c.use()
+	defer connection.users.Done()
+	return connection.DropCollection(ctx, collection)
+}
+
+func (c *ReconnectingConnection) Aggregate(ctx context.Context, collection string, pipeline Pipeline,
+	results any) error {
+	connection := c.use()
+	defer connection.users.Done()
+	return connection.Aggregate(ctx, collection, pipeline, results)
+}
+
+func (c *ReconnectingConnection) Ping(ctx context.Context) error {
+	connection := c.use()
+	defer connection.users.Done()
+	return connection.Ping(ctx)
+}
@@ -11,6 +11,9 @@ type Car struct {
 
 	// Rentals all rentals for this car
 	Rentals []Rental `bson:"rentals"`
+
+	// PendingEvents the events of changes of the rentals that have not been moved to the outbox yet
+	PendingEvents []RentalEvent `bson:"pendingEvents,omitempty"`
 }
 
 type Rental struct {
@@ -25,6 +28,12 @@ type Rental struct {
 
 	// TrunkToken Trunk access token
 	TrunkToken *TrunkAccessToken `bson:"trunkToken,omitempty"`
+
+	// AutoRelockTimeout Seconds after which an unlocked trunk is locked again (absent if the global default applies)
+	AutoRelockTimeout *int `bson:"autoRelockTimeout,omitempty"`
+
+	// CancelledAt The time the rental was cancelled (absent if it is not cancelled)
+	CancelledAt *time.Time `bson:"cancelledAt,omitempty"`
 }
 
 type TimePeriod struct {
@@ -42,4 +51,174 @@ type TrunkAccessToken struct {
 
 	// ValidityPeriod the time the token is valid
 	ValidityPeriod TimePeriod `bson:"validityPeriod"`
+
+	// Recurrence the weekly recurring time of day range the token is restricted to (absent if not recurring)
+	Recurrence *Recurrence `bson:"recurrence,omitempty"`
+}
+
+// Recurrence A weekly recurring time of day range
+type Recurrence struct {
+	// DaysOfWeek the days of the week on which the time of day range recurs
+	DaysOfWeek []model.Weekday `bson:"daysOfWeek"`
+
+	// StartTime Beginning of the time of day range (HH:MM)
+	StartTime string `bson:"startTime"`
+
+	// EndTime End of the time of day range (HH:MM)
+	EndTime string `bson:"endTime"`
+
+	// TimeZone IANA time zone the time of day range refers to
+	TimeZone string `bson:"timeZone"`
+}
+
+// TrunkAccessLogEntry An entry of the append-only trunk access audit log
+type TrunkAccessLogEntry struct {
+	// Id Unique identification of the log entry
+	Id string `bson:"_id"`
+
+	// Vin Vehicle Identification Number of the car whose trunk was accessed
+	Vin model.Vin `bson:"vin"`
+
+	// RentalId Unique identification of the rental the attempt belongs to (empty if unknown)
+	RentalId model.RentalId `bson:"rentalId,omitempty"`
+
+	// ActorType how the actor identified itself
+	ActorType model.TrunkAccessActorType `bson:"actorType"`
+
+	// Actor customer ID or trunk access token prefix
+	Actor string `bson:"actor"`
+
+	// Action the attempted trunk operation
+	Action model.TrunkAccessAction `bson:"action"`
+
+	// LockState the requested trunk lock state (only set for SET_LOCK_STATE)
+	LockState *model.LockState `bson:"lockState,omitempty"`
+
+	// Outcome whether the attempt was granted, denied or failed
+	Outcome model.TrunkAccessOutcome `bson:"outcome"`
+
+	// Timestamp the time of the attempt
+	Timestamp time.Time `bson:"timestamp"`
+}
+
+// RelockJob A pending request to lock the trunk of a car again. There is at most one job per car.
+type RelockJob struct {
+	// Vin Vehicle Identification Number of the car whose trunk is locked again
+	Vin model.Vin `bson:"_id"`
+
+	// RentalId Unique identification of the rental during which the trunk was unlocked
+	RentalId model.RentalId `bson:"rentalId"`
+
+	// DueAt the time at which the trunk is locked again
+	DueAt time.Time `bson:"dueAt"`
+}
+
+// RentalEvent A domain event in the outbox that has not been published yet
+type RentalEvent struct {
+	// EventId Unique identification of the event
+	EventId string `bson:"_id"`
+
+	// Type the kind of change the event reports
+	Type model.RentalEventType `bson:"type"`
+
+	// OccurredAt the time the change was made
+	OccurredAt time.Time `bson:"occurredAt"`
+
+	// RentalId Unique identification of the rental
+	RentalId model.RentalId `bson:"rentalId"`
+
+	// Vin Vehicle Identification Number of the rented car
+	Vin model.Vin `bson:"vin"`
+
+	// CustomerId Unique identification of the customer who rented the car
+	CustomerId model.CustomerId `bson:"customer"`
+
+	// RentalPeriod the time the car is rented (only set for rentalCreated events)
+	RentalPeriod *TimePeriod `bson:"rentalPeriod,omitempty"`
+
+	// AutoRelockTimeout seconds after which an unlocked trunk is locked again (only set for rentalChanged events)
+	AutoRelockTimeout *int `bson:"autoRelockTimeout,omitempty"`
+
+	// TrunkAccessValidityPeriod the time the granted trunk access token is valid (only set for trunkAccessGranted
+	// events)
+	TrunkAccessValidityPeriod *TimePeriod `bson:"trunkAccessValidityPeriod,omitempty"`
+}
+
+// Webhook A subscription of rental events that are posted to a URL
+type Webhook struct {
+	// Id Unique identification of the webhook
+	Id model.WebhookId `bson:"_id"`
+
+	// Url the URL the rental events are posted to
+	Url string `bson:"url"`
+
+	// EventTypes the types of the rental events that are posted
+	EventTypes []model.RentalEventType `bson:"eventTypes"`
+
+	// Secret the secret the payloads are signed with
+	Secret string `bson:"secret"`
+
+	// CreatedAt the time the webhook was registered
+	CreatedAt time.Time `bson:"createdAt"`
+}
+
+// WebhookDelivery A rental event that is or has been posted to a webhook. There is at most one delivery per webhook
+// and event. Absent optional fields are stored as null so that updates clear them.
+type WebhookDelivery struct {
+	// Id Unique identification of the delivery, derived from the event and the webhook
+	Id string `bson:"_id"`
+
+	// WebhookId Unique identification of the webhook
+	WebhookId model.WebhookId `bson:"webhookId"`
+
+	// Event the rental event that is posted
+	Event RentalEvent `bson:"event"`
+
+	// State whether the delivery is pending, has been delivered or has been given up
+	State model.WebhookDeliveryState `bson:"state"`
+
+	// Attempts the number of attempts made so far
+	Attempts int `bson:"attempts"`
+
+	// CreatedAt the time the delivery was created
+	CreatedAt time.Time `bson:"createdAt"`
+
+	// NextAttemptAt the time of the next attempt (null unless the delivery is pending)
+	NextAttemptAt *time.Time `bson:"nextAttemptAt"`
+
+	// LastAttemptAt the time of the last attempt (null if no attempt has been made yet)
+	LastAttemptAt *time.Time `bson:"lastAttemptAt"`
+
+	// LastStatusCode the HTTP status code of the response to the last attempt (null if there was no response)
+	LastStatusCode *int `bson:"lastStatusCode"`
+
+	// LastError why the last attempt failed (null if it succeeded)
+	LastError *string `bson:"lastError"`
+}
+
+// SentReminder A reminder that has been sent to the customer of a rental. There is at most one per reminder.
+type SentReminder struct {
+	// Id Unique identification of the reminder, derived from the rental, kind and offset
+	Id string `bson:"_id"`
+
+	// RentalId Unique identification of the rental the reminder is about
+	RentalId model.RentalId `bson:"rentalId"`
+
+	// Kind whether the reminder has been sent before the start or the end of the rental
+	Kind model.ReminderKind `bson:"kind"`
+
+	// OffsetSeconds how many seconds before the start or end of the rental the reminder was due
+	OffsetSeconds int64 `bson:"offsetSeconds"`
+
+	// SentAt the time the reminder was sent
+	SentAt time.Time `bson:"sentAt"`
+}
+
+// CalendarSubscription The calendar subscription of a customer. There is at most one per customer.
+type CalendarSubscription struct {
+	// CustomerId Unique identification of the customer whose rentals are subscribed to
+	CustomerId model.CustomerId `bson:"_id"`
+
+	// Token the opaque token of the subscription URL
+	Token model.CalendarToken `bson:"token"`
 }
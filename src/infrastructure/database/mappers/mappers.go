@@ -36,6 +36,7 @@ func mapTokenFromDb(token *entities.TrunkAccessToken) *model.TrunkAccess {
 	return &model.TrunkAccess{
 		Token:          token.Token,
 		ValidityPeriod: mapTimePeriodFromDb(&token.ValidityPeriod),
+		Recurrence:     mapRecurrenceFromDb(token.Recurrence),
 	}
 }
 
@@ -46,14 +47,42 @@ func MapTokenToDb(token *model.TrunkAccess) *entities.TrunkAccessToken {
 	return &entities.TrunkAccessToken{
 		Token:          token.Token,
 		ValidityPeriod: MapTimePeriodToDb(&token.ValidityPeriod),
+		Recurrence:     mapRecurrenceToDb(token.Recurrence),
 	}
 }
 
-func getState(period *entities.TimePeriod, currentTime time.Time) model.State {
-	if !period.StartDate.Before(currentTime) {
+func mapRecurrenceFromDb(recurrence *entities.Recurrence) *model.Recurrence {
+	if recurrence == nil {
+		return nil
+	}
+	return &model.Recurrence{
+		DaysOfWeek: recurrence.DaysOfWeek,
+		StartTime:  recurrence.StartTime,
+		EndTime:    recurrence.EndTime,
+		TimeZone:   recurrence.TimeZone,
+	}
+}
+
+func mapRecurrenceToDb(recurrence *model.Recurrence) *entities.Recurrence {
+	if recurrence == nil {
+		return nil
+	}
+	return &entities.Recurrence{
+		DaysOfWeek: recurrence.DaysOfWeek,
+		StartTime:  recurrence.StartTime,
+		EndTime:    recurrence.EndTime,
+		TimeZone:   recurrence.TimeZone,
+	}
+}
+
+func getState(rental *entities.Rental, currentTime time.Time) model.State {
+	if rental.CancelledAt != nil {
+		return model.CANCELLED
+	}
+	if !rental.RentalPeriod.StartDate.Before(currentTime) {
 		return model.UPCOMING
 	}
-	if period.EndDate.After(currentTime) {
+	if rental.RentalPeriod.EndDate.After(currentTime) {
 		return model.ACTIVE
 	}
 	return model.EXPIRED
@@ -62,15 +91,23 @@ func getState(period *entities.TimePeriod, currentTime time.Time) model.State {
 // mapRentalFromDb only sets the VIN of the car
 func mapRentalFromDb(rental *entities.Rental, vin model.Vin, timeProvider util.ITimeProvider) model.Rental {
 	return model.Rental{
-		State:        getState(&rental.RentalPeriod, timeProvider.Now()),
+		State:        getState(rental, timeProvider.Now()),
 		Car:          &model.Car{Vin: vin},
 		Customer:     &model.Customer{CustomerId: rental.CustomerId},
 		Id:           rental.RentalId,
 		RentalPeriod: mapTimePeriodFromDb(&rental.RentalPeriod),
 		Token:        mapTokenFromDb(rental.TrunkToken),
+		AutoRelock:   mapAutoRelockFromDb(rental.AutoRelockTimeout),
 	}
 }
 
+func mapAutoRelockFromDb(timeoutSeconds *int) *model.AutoRelock {
+	if timeoutSeconds == nil {
+		return nil
+	}
+	return &model.AutoRelock{TimeoutSeconds: *timeoutSeconds}
+}
+
 func MapCarFromDbToRentals(car *entities.Car, timeProvider util.ITimeProvider) []model.Rental {
 	rentals := make([]model.Rental, len(car.Rentals))
 	for i, rental := range car.Rentals {
@@ -86,3 +123,206 @@ func MapCarsFromDbToRentals(cars *[]entities.Car, timeProvider util.ITimeProvide
 	}
 	return rentals
 }
+
+// MapTrunkAccessLogEntryToDb maps a log entry to its database representation with the given ID
+func MapTrunkAccessLogEntryToDb(entry *model.TrunkAccessLogEntry, id string) entities.TrunkAccessLogEntry {
+	var rentalId model.RentalId
+	if entry.RentalId != nil {
+		rentalId = *entry.RentalId
+	}
+	return entities.TrunkAccessLogEntry{
+		Id:        id,
+		Vin:       entry.Vin,
+		RentalId:  rentalId,
+		ActorType: entry.ActorType,
+		Actor:     entry.Actor,
+		Action:    entry.Action,
+		LockState: entry.LockState,
+		Outcome:   entry.Outcome,
+		Timestamp: entry.Timestamp,
+	}
+}
+
+func mapTrunkAccessLogEntryFromDb(entry *entities.TrunkAccessLogEntry) model.TrunkAccessLogEntry {
+	var rentalId *model.RentalId
+	if entry.RentalId != "" {
+		rentalIdValue := entry.RentalId
+		rentalId = &rentalIdValue
+	}
+	return model.TrunkAccessLogEntry{
+		Vin:       entry.Vin,
+		RentalId:  rentalId,
+		ActorType: entry.ActorType,
+		Actor:     entry.Actor,
+		Action:    entry.Action,
+		LockState: entry.LockState,
+		Outcome:   entry.Outcome,
+		Timestamp: entry.Timestamp,
+	}
+}
+
+func MapTrunkAccessLogFromDb(entries *[]entities.TrunkAccessLogEntry) []model.TrunkAccessLogEntry {
+	logEntries := make([]model.TrunkAccessLogEntry, len(*entries))
+	for i, entry := range *entries {
+		logEntries[i] = mapTrunkAccessLogEntryFromDb(&entry)
+	}
+	return logEntries
+}
+
+func MapRelockJobToDb(job *model.RelockJob) entities.RelockJob {
+	return entities.RelockJob{
+		Vin:      job.Vin,
+		RentalId: job.RentalId,
+		DueAt:    job.DueAt,
+	}
+}
+
+func MapRelockJobsFromDb(jobs *[]entities.RelockJob) []model.RelockJob {
+	relockJobs := make([]model.RelockJob, len(*jobs))
+	for i, job := range *jobs {
+		relockJobs[i] = model.RelockJob{
+			Vin:      job.Vin,
+			RentalId: job.RentalId,
+			DueAt:    job.DueAt,
+		}
+	}
+	return relockJobs
+}
+
+func mapOptionalTimePeriodToDb(period *model.TimePeriod) *entities.TimePeriod {
+	if period == nil {
+		return nil
+	}
+	periodEntity := MapTimePeriodToDb(period)
+	return &periodEntity
+}
+
+func mapOptionalTimePeriodFromDb(period *entities.TimePeriod) *model.TimePeriod {
+	if period == nil {
+		return nil
+	}
+	periodModel := mapTimePeriodFromDb(period)
+	return &periodModel
+}
+
+func MapRentalEventToDb(event *model.RentalEvent) entities.RentalEvent {
+	var autoRelockTimeout *int
+	if event.AutoRelock != nil {
+		timeoutSeconds := event.AutoRelock.TimeoutSeconds
+		autoRelockTimeout = &timeoutSeconds
+	}
+	return entities.RentalEvent{
+		EventId:                   event.EventId,
+		Type:                      event.Type,
+		OccurredAt:                event.OccurredAt,
+		RentalId:                  event.RentalId,
+		Vin:                       event.Vin,
+		CustomerId:                event.CustomerId,
+		RentalPeriod:              mapOptionalTimePeriodToDb(event.RentalPeriod),
+		AutoRelockTimeout:         autoRelockTimeout,
+		TrunkAccessValidityPeriod: mapOptionalTimePeriodToDb(event.TrunkAccessValidityPeriod),
+	}
+}
+
+func mapRentalEventFromDb(event *entities.RentalEvent) model.RentalEvent {
+	return model.RentalEvent{
+		EventId:                   event.EventId,
+		Type:                      event.Type,
+		OccurredAt:                event.OccurredAt,
+		RentalId:                  event.RentalId,
+		Vin:                       event.Vin,
+		CustomerId:                event.CustomerId,
+		RentalPeriod:              mapOptionalTimePeriodFromDb(event.RentalPeriod),
+		AutoRelock:                mapAutoRelockFromDb(event.AutoRelockTimeout),
+		TrunkAccessValidityPeriod: mapOptionalTimePeriodFromDb(event.TrunkAccessValidityPeriod),
+	}
+}
+
+func MapRentalEventsFromDb(events *[]entities.RentalEvent) []model.RentalEvent {
+	rentalEvents := make([]model.RentalEvent, len(*events))
+	for i, event := range *events {
+		rentalEvents[i] = mapRentalEventFromDb(&event)
+	}
+	return rentalEvents
+}
+
+func MapWebhookToDb(webhook *model.Webhook) entities.Webhook {
+	return entities.Webhook{
+		Id:         webhook.Id,
+		Url:        webhook.Url,
+		EventTypes: webhook.EventTypes,
+		Secret:     webhook.Secret,
+		CreatedAt:  webhook.CreatedAt,
+	}
+}
+
+func MapWebhookFromDb(webhook *entities.Webhook) model.Webhook {
+	return model.Webhook{
+		Id:         webhook.Id,
+		Url:        webhook.Url,
+		EventTypes: webhook.EventTypes,
+		Secret:     webhook.Secret,
+		CreatedAt:  webhook.CreatedAt,
+	}
+}
+
+func MapWebhooksFromDb(webhooks *[]entities.Webhook) []model.Webhook {
+	webhookModels := make([]model.Webhook, len(*webhooks))
+	for i, webhook := range *webhooks {
+		webhookModels[i] = MapWebhookFromDb(&webhook)
+	}
+	return webhookModels
+}
+
+func MapWebhookDeliveryToDb(delivery *model.WebhookDelivery) entities.WebhookDelivery {
+	return entities.WebhookDelivery{
+		Id:             delivery.Id,
+		WebhookId:      delivery.WebhookId,
+		Event:          MapRentalEventToDb(&delivery.Event),
+		State:          delivery.State,
+		Attempts:       delivery.Attempts,
+		CreatedAt:      delivery.CreatedAt,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+	}
+}
+
+func MapWebhookDeliveriesFromDb(deliveries *[]entities.WebhookDelivery) []model.WebhookDelivery {
+	deliveryModels := make([]model.WebhookDelivery, len(*deliveries))
+	for i, delivery := range *deliveries {
+		deliveryModels[i] = model.WebhookDelivery{
+			Id:             delivery.Id,
+			WebhookId:      delivery.WebhookId,
+			Event:          mapRentalEventFromDb(&delivery.Event),
+			State:          delivery.State,
+			Attempts:       delivery.Attempts,
+			CreatedAt:      delivery.CreatedAt,
+			NextAttemptAt:  delivery.NextAttemptAt,
+			LastAttemptAt:  delivery.LastAttemptAt,
+			LastStatusCode: delivery.LastStatusCode,
+			LastError:      delivery.LastError,
+		}
+	}
+	return deliveryModels
+}
+
+// MapSentReminderToDb maps a reminder that has been sent at the given time to its database representation
+func MapSentReminderToDb(reminder *model.Reminder, sentAt time.Time) entities.SentReminder {
+	return entities.SentReminder{
+		Id:            reminder.Id(),
+		RentalId:      reminder.RentalId,
+		Kind:          reminder.Kind,
+		OffsetSeconds: int64(reminder.Offset / time.Second),
+		SentAt:        sentAt,
+	}
+}
+
+// MapCalendarSubscriptionToDb maps the calendar subscription of a customer to its database representation
+func MapCalendarSubscriptionToDb(customerId model.CustomerId, token model.CalendarToken) entities.CalendarSubscription {
+	return entities.CalendarSubscription{
+		CustomerId: customerId,
+		Token:      token,
+	}
+}
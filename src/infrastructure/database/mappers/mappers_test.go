@@ -43,6 +43,8 @@ var rental1 = entities.Rental{
 	TrunkToken: nil,
 }
 
+var autoRelockTimeout = 300
+
 var rental2 = entities.Rental{
 	RentalId:   "8J7szB1d",
 	CustomerId: "d9COw9vI",
@@ -57,6 +59,7 @@ var rental2 = entities.Rental{
 			EndDate:   time.Date(2023, 2, 11, 0, 0, 0, 0, time.UTC),
 		},
 	},
+	AutoRelockTimeout: &autoRelockTimeout,
 }
 
 var rental3 = entities.Rental{
@@ -97,6 +100,7 @@ var rentalModel2Car1 = model.Rental{
 			EndDate:   time.Date(2023, 2, 11, 0, 0, 0, 0, time.UTC),
 		},
 	},
+	AutoRelock: &model.AutoRelock{TimeoutSeconds: 300},
 }
 
 var rentalModel3Car2 = model.Rental{
@@ -133,6 +137,42 @@ func TestMapTokenToDb_Nil(t *testing.T) {
 	assert.Nil(t, MapTokenToDb(nil))
 }
 
+var recurringTokenModel = model.TrunkAccess{
+	Token: "bumrLuCMbumrLuCMbumrLuCM",
+	ValidityPeriod: model.TimePeriod{
+		StartDate: time.Date(2023, 1, 2, 8, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 1, 6, 12, 0, 0, 0, time.UTC),
+	},
+	Recurrence: &model.Recurrence{
+		DaysOfWeek: []model.Weekday{model.MONDAY, model.FRIDAY},
+		StartTime:  "08:00",
+		EndTime:    "12:00",
+		TimeZone:   "Europe/Berlin",
+	},
+}
+
+var recurringTokenEntity = entities.TrunkAccessToken{
+	Token: "bumrLuCMbumrLuCMbumrLuCM",
+	ValidityPeriod: entities.TimePeriod{
+		StartDate: time.Date(2023, 1, 2, 8, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 1, 6, 12, 0, 0, 0, time.UTC),
+	},
+	Recurrence: &entities.Recurrence{
+		DaysOfWeek: []model.Weekday{model.MONDAY, model.FRIDAY},
+		StartTime:  "08:00",
+		EndTime:    "12:00",
+		TimeZone:   "Europe/Berlin",
+	},
+}
+
+func TestMapTokenToDb_recurring(t *testing.T) {
+	assert.Equal(t, &recurringTokenEntity, MapTokenToDb(&recurringTokenModel))
+}
+
+func TestMapTokenFromDb_recurring(t *testing.T) {
+	assert.Equal(t, &recurringTokenModel, mapTokenFromDb(&recurringTokenEntity))
+}
+
 func TestMapCarFromDbToRentals(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -143,6 +183,22 @@ func TestMapCarFromDbToRentals(t *testing.T) {
 	assert.Equal(t, rentalsModelCar1, MapCarFromDbToRentals(&car1, tp))
 }
 
+func TestMapCarFromDbToRentals_cancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tp := mocks.NewMockITimeProvider(ctrl)
+	tp.EXPECT().Now().Return(currentTime)
+
+	// the rental would be upcoming if it were not cancelled
+	cancelledRental := rental2
+	cancelledRental.CancelledAt = &currentTime
+	rentals := MapCarFromDbToRentals(&entities.Car{Vin: carVin1, Rentals: []entities.Rental{cancelledRental}}, tp)
+
+	expected := rentalModel2Car1
+	expected.State = model.CANCELLED
+	assert.Equal(t, []model.Rental{expected}, rentals)
+}
+
 func TestMapCarsFromDbToRentals(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -153,3 +209,242 @@ func TestMapCarsFromDbToRentals(t *testing.T) {
 
 	assert.Equal(t, rentalsModelAll, MapCarsFromDbToRentals(&cars, tp))
 }
+
+var logRentalId = "rZ6IIwcD"
+var logLockState = model.UNLOCKED
+
+var logEntryModel = model.TrunkAccessLogEntry{
+	Vin:       carVin1,
+	RentalId:  &logRentalId,
+	ActorType: model.TOKEN,
+	Actor:     "bumr",
+	Action:    model.SETLOCKSTATE,
+	LockState: &logLockState,
+	Outcome:   model.GRANTED,
+	Timestamp: currentTime,
+}
+
+var logEntryDb = entities.TrunkAccessLogEntry{
+	Id:        "kL0xXG4vPq2T9sZe",
+	Vin:       carVin1,
+	RentalId:  logRentalId,
+	ActorType: model.TOKEN,
+	Actor:     "bumr",
+	Action:    model.SETLOCKSTATE,
+	LockState: &logLockState,
+	Outcome:   model.GRANTED,
+	Timestamp: currentTime,
+}
+
+var logEntryModelNoRental = model.TrunkAccessLogEntry{
+	Vin:       carVin2,
+	ActorType: model.CUSTOMER,
+	Actor:     "customer@example.com",
+	Action:    model.GETLOCKSTATE,
+	Outcome:   model.DENIED,
+	Timestamp: currentTime,
+}
+
+var logEntryDbNoRental = entities.TrunkAccessLogEntry{
+	Id:        "Ue3Hq8b1ZzL0aPq2",
+	Vin:       carVin2,
+	ActorType: model.CUSTOMER,
+	Actor:     "customer@example.com",
+	Action:    model.GETLOCKSTATE,
+	Outcome:   model.DENIED,
+	Timestamp: currentTime,
+}
+
+func TestMapTrunkAccessLogEntryToDb(t *testing.T) {
+	assert.Equal(t, logEntryDb, MapTrunkAccessLogEntryToDb(&logEntryModel, logEntryDb.Id))
+}
+
+func TestMapTrunkAccessLogEntryToDb_noRental(t *testing.T) {
+	assert.Equal(t, logEntryDbNoRental, MapTrunkAccessLogEntryToDb(&logEntryModelNoRental, logEntryDbNoRental.Id))
+}
+
+func TestMapTrunkAccessLogFromDb(t *testing.T) {
+	entries := []entities.TrunkAccessLogEntry{logEntryDb, logEntryDbNoRental}
+	assert.Equal(t, []model.TrunkAccessLogEntry{logEntryModel, logEntryModelNoRental},
+		MapTrunkAccessLogFromDb(&entries))
+}
+
+var relockJobModel = model.RelockJob{
+	Vin:      carVin1,
+	RentalId: "rZ6IIwcD",
+	DueAt:    time.Date(2023, 2, 2, 3, 15, 12, 0, time.UTC),
+}
+
+var relockJobDb = entities.RelockJob{
+	Vin:      carVin1,
+	RentalId: "rZ6IIwcD",
+	DueAt:    time.Date(2023, 2, 2, 3, 15, 12, 0, time.UTC),
+}
+
+func TestMapRelockJobToDb(t *testing.T) {
+	assert.Equal(t, relockJobDb, MapRelockJobToDb(&relockJobModel))
+}
+
+func TestMapRelockJobsFromDb(t *testing.T) {
+	jobs := []entities.RelockJob{relockJobDb}
+	assert.Equal(t, []model.RelockJob{relockJobModel}, MapRelockJobsFromDb(&jobs))
+}
+
+var eventPeriod = model.TimePeriod{
+	StartDate: time.Date(2023, 2, 2, 3, 0, 0, 0, time.UTC),
+	EndDate:   time.Date(2023, 2, 2, 5, 0, 0, 0, time.UTC),
+}
+
+var rentalEventsModel = []model.RentalEvent{
+	{
+		EventId:      "Xo0kGm4GUtbN2r3h",
+		Type:         model.RentalCreated,
+		OccurredAt:   currentTime,
+		RentalId:     "rZ6IIwcD",
+		Vin:          carVin1,
+		CustomerId:   "d9COw9vI",
+		RentalPeriod: &eventPeriod,
+	},
+	{
+		EventId:    "9QmC1bq5VfJ2oTnE",
+		Type:       model.RentalChanged,
+		OccurredAt: currentTime,
+		RentalId:   "rZ6IIwcD",
+		Vin:        carVin1,
+		CustomerId: "d9COw9vI",
+		AutoRelock: &model.AutoRelock{TimeoutSeconds: autoRelockTimeout},
+	},
+	{
+		EventId:                   "Lw7yPz3RkA0sXe5D",
+		Type:                      model.TrunkAccessGranted,
+		OccurredAt:                currentTime,
+		RentalId:                  "rZ6IIwcD",
+		Vin:                       carVin1,
+		CustomerId:                "d9COw9vI",
+		TrunkAccessValidityPeriod: &eventPeriod,
+	},
+}
+
+var eventPeriodDb = entities.TimePeriod{
+	StartDate: time.Date(2023, 2, 2, 3, 0, 0, 0, time.UTC),
+	EndDate:   time.Date(2023, 2, 2, 5, 0, 0, 0, time.UTC),
+}
+
+var rentalEventsDb = []entities.RentalEvent{
+	{
+		EventId:      "Xo0kGm4GUtbN2r3h",
+		Type:         model.RentalCreated,
+		OccurredAt:   currentTime,
+		RentalId:     "rZ6IIwcD",
+		Vin:          carVin1,
+		CustomerId:   "d9COw9vI",
+		RentalPeriod: &eventPeriodDb,
+	},
+	{
+		EventId:           "9QmC1bq5VfJ2oTnE",
+		Type:              model.RentalChanged,
+		OccurredAt:        currentTime,
+		RentalId:          "rZ6IIwcD",
+		Vin:               carVin1,
+		CustomerId:        "d9COw9vI",
+		AutoRelockTimeout: &autoRelockTimeout,
+	},
+	{
+		EventId:                   "Lw7yPz3RkA0sXe5D",
+		Type:                      model.TrunkAccessGranted,
+		OccurredAt:                currentTime,
+		RentalId:                  "rZ6IIwcD",
+		Vin:                       carVin1,
+		CustomerId:                "d9COw9vI",
+		TrunkAccessValidityPeriod: &eventPeriodDb,
+	},
+}
+
+func TestMapRentalEventToDb(t *testing.T) {
+	for i := range rentalEventsModel {
+		assert.Equal(t, rentalEventsDb[i], MapRentalEventToDb(&rentalEventsModel[i]))
+	}
+}
+
+func TestMapRentalEventsFromDb(t *testing.T) {
+	assert.Equal(t, rentalEventsModel, MapRentalEventsFromDb(&rentalEventsDb))
+}
+
+var webhookModel = model.Webhook{
+	Id:         "Ck3XlmXtlq0xGpH9",
+	Url:        "https://billing.example.com/hooks/rentals",
+	EventTypes: []model.RentalEventType{model.RentalCreated, model.RentalChanged},
+	Secret:     "whsec-0123456789abcdef",
+	CreatedAt:  currentTime,
+}
+
+var webhookDb = entities.Webhook{
+	Id:         "Ck3XlmXtlq0xGpH9",
+	Url:        "https://billing.example.com/hooks/rentals",
+	EventTypes: []model.RentalEventType{model.RentalCreated, model.RentalChanged},
+	Secret:     "whsec-0123456789abcdef",
+	CreatedAt:  currentTime,
+}
+
+func TestMapWebhookToDb(t *testing.T) {
+	assert.Equal(t, webhookDb, MapWebhookToDb(&webhookModel))
+}
+
+func TestMapWebhooksFromDb(t *testing.T) {
+	assert.Equal(t, []model.Webhook{webhookModel}, MapWebhooksFromDb(&[]entities.Webhook{webhookDb}))
+}
+
+var lastStatusCode = 503
+var lastError = "unexpected status code 503"
+
+var webhookDeliveryModel = model.WebhookDelivery{
+	Id:             "Xo0kGm4GUtbN2r3hCk3XlmXtlq0xGpH9",
+	WebhookId:      "Ck3XlmXtlq0xGpH9",
+	Event:          rentalEventsModel[0],
+	State:          model.PENDING,
+	Attempts:       1,
+	CreatedAt:      currentTime,
+	NextAttemptAt:  &currentTime,
+	LastAttemptAt:  &currentTime,
+	LastStatusCode: &lastStatusCode,
+	LastError:      &lastError,
+}
+
+var webhookDeliveryDb = entities.WebhookDelivery{
+	Id:             "Xo0kGm4GUtbN2r3hCk3XlmXtlq0xGpH9",
+	WebhookId:      "Ck3XlmXtlq0xGpH9",
+	Event:          rentalEventsDb[0],
+	State:          model.PENDING,
+	Attempts:       1,
+	CreatedAt:      currentTime,
+	NextAttemptAt:  &currentTime,
+	LastAttemptAt:  &currentTime,
+	LastStatusCode: &lastStatusCode,
+	LastError:      &lastError,
+}
+
+func TestMapWebhookDeliveryToDb(t *testing.T) {
+	assert.Equal(t, webhookDeliveryDb, MapWebhookDeliveryToDb(&webhookDeliveryModel))
+}
+
+func TestMapWebhookDeliveriesFromDb(t *testing.T) {
+	assert.Equal(t, []model.WebhookDelivery{webhookDeliveryModel},
+		MapWebhookDeliveriesFromDb(&[]entities.WebhookDelivery{webhookDeliveryDb}))
+}
+
+func TestMapSentReminderToDb(t *testing.T) {
+	reminder := model.Reminder{RentalId: "rZ6IIwcD", Kind: model.RentalEndReminder, Offset: time.Hour}
+
+	assert.Equal(t, entities.SentReminder{
+		Id:            "rZ6IIwcD-rentalEnd-3600",
+		RentalId:      "rZ6IIwcD",
+		Kind:          model.RentalEndReminder,
+		OffsetSeconds: 3600,
+		SentAt:        currentTime,
+	}, MapSentReminderToDb(&reminder, currentTime))
+}
+
+func TestMapCalendarSubscriptionToDb(t *testing.T) {
+	assert.Equal(t, entities.CalendarSubscription{CustomerId: "d9COw9vI", Token: "Qm7Ax0tWbLk2ZsPfR4uNcYe9JhVd3oGi"},
+		MapCalendarSubscriptionToDb("d9COw9vI", "Qm7Ax0tWbLk2ZsPfR4uNcYe9JhVd3oGi"))
+}
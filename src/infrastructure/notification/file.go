@@ -0,0 +1,33 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// FileSender appends each message as a line of JSON to a file instead of sending it. It is meant for local use and
+// for tests that check which messages would have been sent.
+type FileSender struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewFileSender creates a FileSender that appends the messages to the file at the given path. The file is created if
+// it does not exist.
+func NewFileSender(path string) (*FileSender, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSender{encoder: json.NewEncoder(file)}, nil
+}
+
+func (s *FileSender) Send(_ context.Context, message Message) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// the encoder terminates each message with a newline
+	return s.encoder.Encode(message)
+}
@@ -0,0 +1,61 @@
+// Package notification sends messages, e.g. reminders, to customers.
+package notification
+
+//go:generate mockgen -source=./notification.go -package=mocks -destination=../../mocks/mock_notification.go
+
+import (
+	"context"
+	"fmt"
+)
+
+// Names of the sender implementations that can be configured
+const (
+	NameSmtp = "smtp"
+	NameFile = "file"
+)
+
+type SenderConfig interface {
+	GetReminderSender() string
+	GetReminderFile() string
+	GetSmtpHost() string
+	GetSmtpPort() int
+	GetSmtpUsername() string
+	GetSmtpPassword() string
+	GetSmtpFrom() string
+}
+
+// Message A plain text message to a single recipient
+type Message struct {
+	// To the email address of the recipient
+	To string `json:"to"`
+
+	// Subject the subject line of the message
+	Subject string `json:"subject"`
+
+	// Body the plain text content of the message
+	Body string `json:"body"`
+}
+
+// Sender sends messages to customers. Send returns nil once the message has been handed over, any error means that
+// the message may not have been sent.
+type Sender interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// NewSender creates the sender implementation selected by the configuration
+func NewSender(config SenderConfig) (Sender, error) {
+	switch config.GetReminderSender() {
+	case NameSmtp:
+		if config.GetSmtpHost() == "" || config.GetSmtpFrom() == "" {
+			return nil, fmt.Errorf("the %s sender requires a host and a from address", NameSmtp)
+		}
+		return NewSmtpSender(config.GetSmtpHost(), config.GetSmtpPort(), config.GetSmtpUsername(),
+			config.GetSmtpPassword, config.GetSmtpFrom())
+	case NameFile:
+		if config.GetReminderFile() == "" {
+			return nil, fmt.Errorf("the %s sender requires a file", NameFile)
+		}
+		return NewFileSender(config.GetReminderFile())
+	}
+	return nil, fmt.Errorf("unknown sender %q", config.GetReminderSender())
+}
@@ -0,0 +1,194 @@
+package notification
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type TestSenderConfig struct {
+	sender string
+	file   string
+	host   string
+	port   int
+	from   string
+}
+
+func (c *TestSenderConfig) GetReminderSender() string {
+	return c.sender
+}
+
+func (c *TestSenderConfig) GetReminderFile() string {
+	return c.file
+}
+
+func (c *TestSenderConfig) GetSmtpHost() string {
+	return c.host
+}
+
+func (c *TestSenderConfig) GetSmtpPort() int {
+	return c.port
+}
+
+func (c *TestSenderConfig) GetSmtpUsername() string {
+	return ""
+}
+
+func (c *TestSenderConfig) GetSmtpPassword() string {
+	return ""
+}
+
+func (c *TestSenderConfig) GetSmtpFrom() string {
+	return c.from
+}
+
+var message = Message{
+	To:      "jane.doe@example.com",
+	Subject: "Your rental starts soon",
+	Body:    "Hello,\n\nyour rental starts soon.\n",
+}
+
+const messageJson = `{"to":"jane.doe@example.com","subject":"Your rental starts soon",` +
+	`"body":"Hello,\n\nyour rental starts soon.\n"}`
+
+func TestNewSender(t *testing.T) {
+	smtpSender, err := NewSender(&TestSenderConfig{sender: NameSmtp, host: "localhost", port: 587,
+		from: "rentals@example.com"})
+	assert.Nil(t, err)
+	assert.IsType(t, &SmtpSender{}, smtpSender)
+
+	fileSender, err := NewSender(&TestSenderConfig{sender: NameFile, file: filepath.Join(t.TempDir(), "mails")})
+	assert.Nil(t, err)
+	assert.IsType(t, &FileSender{}, fileSender)
+}
+
+func TestNewSender_invalidConfig(t *testing.T) {
+	_, err := NewSender(&TestSenderConfig{sender: NameFile})
+	assert.NotNil(t, err)
+
+	_, err = NewSender(&TestSenderConfig{sender: NameSmtp, host: "localhost"})
+	assert.NotNil(t, err)
+
+	_, err = NewSender(&TestSenderConfig{sender: NameSmtp, host: "localhost", from: "not an address"})
+	assert.NotNil(t, err)
+
+	_, err = NewSender(&TestSenderConfig{sender: "pigeon"})
+	assert.NotNil(t, err)
+}
+
+func TestFileSender_appends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mails.jsonl")
+	assert.Nil(t, os.WriteFile(path, []byte(messageJson+"\n"), 0o644))
+
+	sender, err := NewFileSender(path)
+	assert.Nil(t, err)
+	assert.Nil(t, sender.Send(context.Background(), message))
+
+	content, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, messageJson+"\n"+messageJson+"\n", string(content))
+}
+
+func TestBuildMessage(t *testing.T) {
+	from := mail.Address{Address: "rentals@example.com"}
+	to := mail.Address{Address: "jane.doe@example.com"}
+	date := time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "From: <rentals@example.com>\r\n"+
+		"To: <jane.doe@example.com>\r\n"+
+		"Subject: Your rental starts soon\r\n"+
+		"Date: Thu, 02 Mar 2023 05:00:00 +0000\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/plain; charset=utf-8\r\n"+
+		"Content-Transfer-Encoding: 8bit\r\n"+
+		"\r\n"+
+		"Hello,\r\n\r\nyour rental starts soon.\r\n", string(buildMessage(from, to, message, date)))
+}
+
+// serveSmtp accepts a single connection and plays a minimal SMTP server without extensions. It returns the
+// commands and the message it received once the client quit.
+func serveSmtp(t *testing.T, listener net.Listener) <-chan []string {
+	received := make(chan []string, 1)
+	go func() {
+		defer close(received)
+
+		conn, err := listener.Accept()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		text := textproto.NewConn(conn)
+		defer text.Close()
+
+		var lines []string
+		_ = text.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch command {
+			case "EHLO", "HELO":
+				_ = text.PrintfLine("250 localhost")
+			case "MAIL", "RCPT":
+				lines = append(lines, line)
+				_ = text.PrintfLine("250 OK")
+			case "DATA":
+				_ = text.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+				data, err := text.ReadDotLines()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				lines = append(lines, data...)
+				_ = text.PrintfLine("250 OK")
+			case "QUIT":
+				_ = text.PrintfLine("221 Bye")
+				received <- lines
+				return
+			default:
+				_ = text.PrintfLine("502 Command not implemented")
+			}
+		}
+	}()
+	return received
+}
+
+func TestSmtpSender_Send(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+	received := serveSmtp(t, listener)
+
+	host, portString, _ := net.SplitHostPort(listener.Addr().String())
+	port, _ := strconv.Atoi(portString)
+
+	sender, err := NewSmtpSender(host, port, "", nil, "Rental Management <rentals@example.com>")
+	assert.Nil(t, err)
+	assert.Nil(t, sender.Send(context.Background(), message))
+
+	lines := <-received
+	assert.Equal(t, "MAIL FROM:<rentals@example.com>", lines[0])
+	assert.Equal(t, "RCPT TO:<jane.doe@example.com>", lines[1])
+	assert.Contains(t, lines, "From: \"Rental Management\" <rentals@example.com>")
+	assert.Contains(t, lines, "Subject: Your rental starts soon")
+	assert.Equal(t, []string{"Hello,", "", "your rental starts soon."}, lines[len(lines)-3:])
+}
+
+func TestSmtpSender_Send_invalidRecipient(t *testing.T) {
+	sender, err := NewSmtpSender("localhost", 587, "", nil, "rentals@example.com")
+	assert.Nil(t, err)
+
+	err = sender.Send(context.Background(), Message{To: "jane.doe", Subject: "Hi", Body: "Hello"})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,121 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// smtpTimeout limits the whole conversation with the SMTP server if the context has no deadline
+const smtpTimeout = 30 * time.Second
+
+// SmtpSender sends messages as plain text emails through an SMTP server. The connection is upgraded with STARTTLS if
+// the server supports it. Credentials are only sent over encrypted connections or to localhost.
+type SmtpSender struct {
+	host     string
+	addr     string
+	from     mail.Address
+	username string
+	password func() string
+}
+
+// NewSmtpSender creates an SmtpSender for the server at the given host and port. If username is empty, no
+// authentication is attempted. The password is requested for every message, so that a rotated password takes effect
+// immediately.
+func NewSmtpSender(host string, port int, username string, password func() string, from string) (*SmtpSender,
+	error) {
+	fromAddress, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address: %w", err)
+	}
+
+	return &SmtpSender{
+		host:     host,
+		addr:     net.JoinHostPort(host, strconv.Itoa(port)),
+		from:     *fromAddress,
+		username: username,
+		password: password,
+	}, nil
+}
+
+func (s *SmtpSender) Send(ctx context.Context, message Message) error {
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient: %w", err)
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password(), s.host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(s.from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(buildMessage(s.from, *to, message, time.Now())); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// buildMessage formats the message as an RFC 5322 email with CRLF line endings
+func buildMessage(from, to mail.Address, message Message, date time.Time) []byte {
+	var buffer bytes.Buffer
+	buffer.WriteString("From: " + from.String() + "\r\n")
+	buffer.WriteString("To: " + to.String() + "\r\n")
+	buffer.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", message.Subject) + "\r\n")
+	buffer.WriteString("Date: " + date.Format(time.RFC1123Z) + "\r\n")
+	buffer.WriteString("MIME-Version: 1.0\r\n")
+	buffer.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buffer.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buffer.WriteString("\r\n")
+
+	body := strings.ReplaceAll(message.Body, "\r\n", "\n")
+	buffer.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return buffer.Bytes()
+}
@@ -0,0 +1,122 @@
+// Package logging provides the structured logger of RentalManagement. The logger of a request is passed through its
+// context.Context, so that every log line of the request contains its request ID and the fields added on the way.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"golang.org/x/exp/slog"
+	"io"
+	"net/http"
+	"regexp"
+)
+
+// The keys of the fields logged by RentalManagement. Use them so that the same data is always logged the same way.
+const (
+	KeyRequestId  = "requestId"
+	KeyTraceId    = "traceId"
+	KeyOperation  = "operation"
+	KeyVin        = "vin"
+	KeyRentalId   = "rentalId"
+	KeyWebhookId  = "webhookId"
+	KeyError      = "error"
+	KeyMethod     = "method"
+	KeyPath       = "path"
+	KeyStatus     = "status"
+	KeyDurationMs = "durationMs"
+)
+
+// HeaderRequestId is the header the request ID is received with, responded with and forwarded to Car with
+const HeaderRequestId = "X-Request-ID"
+
+// requestIdPattern matches the request IDs that are accepted from callers, so that no arbitrary content is logged
+var requestIdPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// Format is the format of the log lines
+type Format string
+
+// Defines values for Format.
+const (
+	// FormatJson writes one JSON object per line
+	FormatJson Format = "json"
+	// FormatText writes key=value pairs, which is easier to read in the local setup mode
+	FormatText Format = "text"
+)
+
+// Config provides the configuration of the logging
+type Config interface {
+	GetLogFormat() string
+}
+
+type contextKey int
+
+const (
+	loggerContextKey contextKey = iota
+	requestIdContextKey
+)
+
+// Setup sets the default logger, which writes the log lines to the given writer in the configured format. The log
+// package writes to the default logger as well.
+func Setup(config Config, writer io.Writer) error {
+	var handler slog.Handler
+	switch Format(config.GetLogFormat()) {
+	case FormatJson:
+		handler = slog.NewJSONHandler(writer, nil)
+	case FormatText:
+		handler = slog.NewTextHandler(writer, nil)
+	default:
+		return fmt.Errorf("unknown log format %q", config.GetLogFormat())
+	}
+
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// FromContext returns the logger of the context, or the default logger if the context has none
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerContextKey).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// With returns a context whose logger adds the given fields to every log line
+func With(ctx context.Context, args ...any) context.Context {
+	return context.WithValue(ctx, loggerContextKey, FromContext(ctx).With(args...))
+}
+
+// WithRequestId returns a context of the request with the given ID, whose logger adds the ID to every log line
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return With(context.WithValue(ctx, requestIdContextKey, requestId), KeyRequestId, requestId)
+}
+
+// RequestIdFromContext returns the ID of the request of the context, or "" if the context belongs to no request
+func RequestIdFromContext(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdContextKey).(string)
+	return requestId
+}
+
+// IsValidRequestId reports whether a request ID received from a caller may be used
+func IsValidRequestId(requestId string) bool {
+	return requestIdPattern.MatchString(requestId)
+}
+
+// NewRequestIdTransport returns a transport that sets the ID of the request of the context of every outgoing request
+// as its X-Request-ID header before sending it with the given transport, so that the request can be correlated in the
+// logs of the called service
+func NewRequestIdTransport(transport http.RoundTripper) http.RoundTripper {
+	return &requestIdTransport{transport: transport}
+}
+
+type requestIdTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *requestIdTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if requestId := RequestIdFromContext(request.Context()); requestId != "" {
+		// a transport must not modify the request it is given
+		request = request.Clone(request.Context())
+		request.Header.Set(HeaderRequestId, requestId)
+	}
+	return t.transport.RoundTrip(request)
+}
@@ -0,0 +1,104 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"golang.org/x/exp/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfig string
+
+func (c testConfig) GetLogFormat() string {
+	return string(c)
+}
+
+// setupBuffer sets a default logger writing JSON to the returned buffer until the test is finished
+func setupBuffer(t *testing.T) *bytes.Buffer {
+	previousLogger := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(previousLogger) })
+
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, Setup(testConfig(FormatJson), buffer))
+	return buffer
+}
+
+func decodeLine(t *testing.T, buffer *bytes.Buffer) map[string]any {
+	var line map[string]any
+	assert.Nil(t, json.Unmarshal(buffer.Bytes(), &line))
+	return line
+}
+
+func TestSetup_text(t *testing.T) {
+	previousLogger := slog.Default()
+	defer slog.SetDefault(previousLogger)
+	buffer := &bytes.Buffer{}
+
+	assert.Nil(t, Setup(testConfig(FormatText), buffer))
+	slog.Info("started", KeyVin, "WVWAA71K08W201030")
+
+	assert.Contains(t, buffer.String(), `msg=started vin=WVWAA71K08W201030`)
+}
+
+func TestSetup_unknownFormat(t *testing.T) {
+	assert.NotNil(t, Setup(testConfig("xml"), &bytes.Buffer{}))
+}
+
+func TestWithRequestId(t *testing.T) {
+	buffer := setupBuffer(t)
+
+	ctx := With(WithRequestId(context.Background(), "8vV3nWq0LzQm5tYb2KcX"), KeyRentalId, "kskgnvsl")
+	FromContext(ctx).Info("rental found")
+
+	line := decodeLine(t, buffer)
+	assert.Equal(t, "rental found", line["msg"])
+	assert.Equal(t, "8vV3nWq0LzQm5tYb2KcX", line[KeyRequestId])
+	assert.Equal(t, "kskgnvsl", line[KeyRentalId])
+	assert.Equal(t, "8vV3nWq0LzQm5tYb2KcX", RequestIdFromContext(ctx))
+}
+
+func TestFromContext_default(t *testing.T) {
+	buffer := setupBuffer(t)
+
+	FromContext(context.Background()).Info("started")
+
+	assert.NotContains(t, decodeLine(t, buffer), KeyRequestId)
+	assert.Equal(t, "", RequestIdFromContext(context.Background()))
+}
+
+func TestIsValidRequestId(t *testing.T) {
+	assert.True(t, IsValidRequestId("8vV3nWq0LzQm5tYb2KcX"))
+	assert.True(t, IsValidRequestId("f47ac10b-58cc-4372-a567-0e02b2c3d479"))
+	assert.False(t, IsValidRequestId(""))
+	assert.False(t, IsValidRequestId("request id"))
+	assert.False(t, IsValidRequestId("id\nmsg=forged"))
+	assert.False(t, IsValidRequestId(string(bytes.Repeat([]byte("a"), 129))))
+}
+
+func TestNewRequestIdTransport(t *testing.T) {
+	var receivedRequestIds []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		receivedRequestIds = append(receivedRequestIds, request.Header.Get(HeaderRequestId))
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: NewRequestIdTransport(http.DefaultTransport)}
+
+	for _, ctx := range []context.Context{
+		WithRequestId(context.Background(), "8vV3nWq0LzQm5tYb2KcX"),
+		context.Background(),
+	} {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		assert.Nil(t, err)
+		response, err := client.Do(request)
+		assert.Nil(t, err)
+		assert.Nil(t, response.Body.Close())
+		assert.Equal(t, "", request.Header.Get(HeaderRequestId))
+	}
+
+	assert.Equal(t, []string{"8vV3nWq0LzQm5tYb2KcX", ""}, receivedRequestIds)
+}
@@ -0,0 +1,139 @@
+// Package carstate provides the live dynamic data of cars to the subscribers of their state.
+package carstate
+
+import (
+	"RentalManagement/infrastructure/car"
+	"RentalManagement/logging"
+	"RentalManagement/logic/model"
+	"RentalManagement/logic/rentalErrors"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Hub polls the dynamic data of cars from the Car server while they have subscribers. All subscribers of a car share
+// one poller, so the number of requests to the Car server does not depend on the number of subscribers.
+type Hub struct {
+	carClient car.ClientWithResponsesInterface
+	interval  time.Duration
+
+	mutex   sync.Mutex
+	pollers map[model.Vin]*poller
+}
+
+// poller holds the subscribers of a car and the last state it has pulled
+type poller struct {
+	cancel      context.CancelFunc
+	subscribers map[chan model.DynamicData]struct{}
+	current     *model.DynamicData
+}
+
+func NewHub(carClient car.ClientWithResponsesInterface, interval time.Duration) *Hub {
+	return &Hub{
+		carClient: carClient,
+		interval:  interval,
+		pollers:   map[model.Vin]*poller{},
+	}
+}
+
+// Subscribe returns a channel on which the dynamic data of the car is sent whenever it changes, starting with the
+// current state. Subscribers only receive the latest state, a state they have not received yet is replaced by newer
+// ones. The returned function ends the subscription, the channel is not closed.
+func (h *Hub) Subscribe(vin model.Vin) (<-chan model.DynamicData, func()) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	p, exists := h.pollers[vin]
+	if !exists {
+		ctx, cancel := context.WithCancel(context.Background())
+		p = &poller{
+			cancel:      cancel,
+			subscribers: map[chan model.DynamicData]struct{}{},
+		}
+		h.pollers[vin] = p
+		go h.poll(ctx, vin, p)
+	}
+
+	updates := make(chan model.DynamicData, 1)
+	p.subscribers[updates] = struct{}{}
+	if p.current != nil {
+		updates <- *p.current
+	}
+
+	var once sync.Once
+	return updates, func() {
+		once.Do(func() {
+			h.unsubscribe(vin, p, updates)
+		})
+	}
+}
+
+// unsubscribe removes the subscriber and stops the poller of the car once it has no subscribers anymore
+func (h *Hub) unsubscribe(vin model.Vin, p *poller, updates chan model.DynamicData) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	delete(p.subscribers, updates)
+	if len(p.subscribers) == 0 {
+		p.cancel()
+		delete(h.pollers, vin)
+	}
+}
+
+// poll pulls the state of the car once per interval until the context is done
+func (h *Hub) poll(ctx context.Context, vin model.Vin, p *poller) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		h.update(ctx, vin, p)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// update pulls the state of the car and sends it to the subscribers if it has changed. Errors are logged, the state
+// is pulled again in the next round.
+func (h *Hub) update(ctx context.Context, vin model.Vin, p *poller) {
+	dynamicData, err := h.fetch(ctx, vin)
+	if err != nil {
+		if ctx.Err() == nil {
+			logging.FromContext(ctx).Warn("car state unavailable", logging.KeyVin, vin, logging.KeyError, err)
+		}
+		return
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if p.current != nil && *p.current == *dynamicData {
+		return
+	}
+	p.current = dynamicData
+
+	for updates := range p.subscribers {
+		// drop the state the subscriber has not received yet, so sending never blocks
+		select {
+		case <-updates:
+		default:
+		}
+		updates <- *dynamicData
+	}
+}
+
+func (h *Hub) fetch(ctx context.Context, vin model.Vin) (*model.DynamicData, error) {
+	carResponse, err := h.carClient.GetCarWithResponse(ctx, vin)
+	if err != nil {
+		return nil, err
+	}
+	if carResponse.ParsedCar == nil {
+		return nil, fmt.Errorf("%w: unknown error (domain code %d)", rentalErrors.ErrDomainAssertion,
+			carResponse.StatusCode())
+	}
+	return car.MapToCar(carResponse.ParsedCar).DynamicData, nil
+}
@@ -0,0 +1,157 @@
+package carstate
+
+import (
+	"RentalManagement/infrastructure/car"
+	"RentalManagement/logic/model"
+	"RentalManagement/mocks"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	carTypes "github.com/ccsapp/cargotypes"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const vin = "WVWAA71K08W201030"
+
+const interval = 5 * time.Millisecond
+
+// receiveTimeout is the time a test waits for a state before it fails
+const receiveTimeout = time.Second
+
+func domainCar(fuelLevel int) *carTypes.Car {
+	return &carTypes.Car{
+		Vin: vin,
+		DynamicData: carTypes.DynamicData{
+			DoorsLockState:      carTypes.LOCKED,
+			EngineState:         carTypes.OFF,
+			FuelLevelPercentage: fuelLevel,
+			TrunkLockState:      carTypes.LOCKED,
+		},
+	}
+}
+
+func dynamicData(fuelLevel int) model.DynamicData {
+	return *car.MapToCar(domainCar(fuelLevel)).DynamicData
+}
+
+// scriptedCar lets the mock car client respond with the given fuel levels in turn, repeating the last one.
+// It returns a function that counts the requests made so far.
+func scriptedCar(mockCar *mocks.MockClientWithResponsesInterface, fuelLevels ...int) func() int {
+	var mutex sync.Mutex
+	requests := 0
+
+	mockCar.EXPECT().GetCarWithResponse(gomock.Any(), vin).
+		DoAndReturn(func(context.Context, string) (*car.GetCarResponse, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			fuelLevel := fuelLevels[len(fuelLevels)-1]
+			if requests < len(fuelLevels) {
+				fuelLevel = fuelLevels[requests]
+			}
+			requests++
+			return &car.GetCarResponse{ParsedCar: domainCar(fuelLevel)}, nil
+		}).
+		AnyTimes()
+
+	return func() int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return requests
+	}
+}
+
+func receive(t *testing.T, updates <-chan model.DynamicData) model.DynamicData {
+	select {
+	case state := <-updates:
+		return state
+	case <-time.After(receiveTimeout):
+		t.Fatal("no state received")
+		return model.DynamicData{}
+	}
+}
+
+func TestHub_Subscribe_sendsChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	scriptedCar(mockCar, 80, 80, 80, 79)
+
+	hub := NewHub(mockCar, interval)
+	updates, unsubscribe := hub.Subscribe(vin)
+	defer unsubscribe()
+
+	assert.Equal(t, dynamicData(80), receive(t, updates))
+	// unchanged states are not sent again
+	assert.Equal(t, dynamicData(79), receive(t, updates))
+}
+
+func TestHub_Subscribe_sharesPoller(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	scriptedCar(mockCar, 80)
+
+	hub := NewHub(mockCar, interval)
+	firstUpdates, unsubscribeFirst := hub.Subscribe(vin)
+	assert.Equal(t, dynamicData(80), receive(t, firstUpdates))
+
+	// the second subscriber receives the current state right away
+	secondUpdates, unsubscribeSecond := hub.Subscribe(vin)
+	assert.Equal(t, dynamicData(80), receive(t, secondUpdates))
+	assert.Len(t, hub.pollers, 1)
+
+	unsubscribeFirst()
+	assert.Len(t, hub.pollers, 1)
+
+	unsubscribeSecond()
+	assert.Empty(t, hub.pollers)
+}
+
+func TestHub_Subscribe_stopsPollingWithoutSubscribers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	requests := scriptedCar(mockCar, 80)
+
+	hub := NewHub(mockCar, interval)
+	updates, unsubscribe := hub.Subscribe(vin)
+	receive(t, updates)
+	unsubscribe()
+	// unsubscribing twice has no effect
+	unsubscribe()
+
+	// a request may still be in flight when the subscription ends
+	time.Sleep(2 * interval)
+	requestsAfterUnsubscribe := requests()
+	time.Sleep(4 * interval)
+
+	assert.Equal(t, requestsAfterUnsubscribe, requests())
+}
+
+func TestHub_Subscribe_carServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	gomock.InOrder(
+		mockCar.EXPECT().GetCarWithResponse(gomock.Any(), vin).Return(nil, errors.New("connection refused")),
+		mockCar.EXPECT().GetCarWithResponse(gomock.Any(), vin).
+			Return(&car.GetCarResponse{ParsedCar: domainCar(80)}, nil).
+			AnyTimes(),
+	)
+
+	hub := NewHub(mockCar, interval)
+	updates, unsubscribe := hub.Subscribe(vin)
+	defer unsubscribe()
+
+	// the state is pulled again after a failure
+	assert.Equal(t, dynamicData(80), receive(t, updates))
+}
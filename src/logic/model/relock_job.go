@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// RelockJob A pending request to lock the trunk of a car again after it was unlocked
+type RelockJob struct {
+	// Vin A Vehicle Identification Number (VIN) which uniquely identifies a car
+	Vin Vin
+
+	// RentalId Unique identification of the rental during which the trunk was unlocked
+	RentalId RentalId
+
+	// DueAt The time at which the trunk is locked again
+	DueAt time.Time
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReminderKind The date of a rental a reminder is sent before
+type ReminderKind string
+
+const (
+	// RentalStartReminder A reminder that the rental starts soon
+	RentalStartReminder ReminderKind = "rentalStart"
+	// RentalEndReminder A reminder that the rental ends soon
+	RentalEndReminder ReminderKind = "rentalEnd"
+)
+
+// Reminder A message to the customer of a rental that is due a fixed offset before the start or end of the rental
+type Reminder struct {
+	// RentalId Unique identification of the rental the reminder is about
+	RentalId RentalId
+
+	// Kind whether the reminder is sent before the start or the end of the rental
+	Kind ReminderKind
+
+	// Offset how long before the start or end of the rental the reminder is due
+	Offset time.Duration
+}
+
+// Id returns the unique identification of the reminder, which is derived from the rental, kind and offset so that
+// each reminder is recorded at most once.
+func (r *Reminder) Id() string {
+	return fmt.Sprintf("%s-%s-%d", r.RentalId, r.Kind, int64(r.Offset/time.Second))
+}
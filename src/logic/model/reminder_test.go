@@ -0,0 +1,13 @@
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestReminder_Id(t *testing.T) {
+	reminder := Reminder{RentalId: "rZ6IIwcD", Kind: RentalStartReminder, Offset: 24 * time.Hour}
+
+	assert.Equal(t, "rZ6IIwcD-rentalStart-86400", reminder.Id())
+}
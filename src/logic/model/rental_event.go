@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// RentalEventType The kind of change a rental event reports
+type RentalEventType string
+
+// Defines values for RentalEventType.
+const (
+	RentalCreated      RentalEventType = "rentalCreated"
+	RentalChanged      RentalEventType = "rentalChanged"
+	RentalCancelled    RentalEventType = "rentalCancelled"
+	TrunkAccessGranted RentalEventType = "trunkAccessGranted"
+)
+
+// RentalEvent A domain event about a rental that is published to other services (e.g. billing or notifications).
+// Events are delivered at least once, so consumers should ignore events whose ID they have already processed.
+type RentalEvent struct {
+	// EventId Unique identification of the event
+	EventId string `json:"eventId"`
+
+	// Type The kind of change the event reports
+	Type RentalEventType `json:"type"`
+
+	// OccurredAt The time the change was made
+	OccurredAt time.Time `json:"occurredAt"`
+
+	// RentalId Unique identification of the rental
+	RentalId RentalId `json:"rentalId"`
+
+	// Vin A Vehicle Identification Number (VIN) which uniquely identifies the rented car
+	Vin Vin `json:"vin"`
+
+	// CustomerId Unique identification of the customer who rented the car
+	CustomerId CustomerId `json:"customerId"`
+
+	// RentalPeriod The time the car is rented (only set for rentalCreated events)
+	RentalPeriod *TimePeriod `json:"rentalPeriod,omitempty"`
+
+	// AutoRelock The changed auto relock settings of the rental (only set for rentalChanged events)
+	AutoRelock *AutoRelock `json:"autoRelock,omitempty"`
+
+	// TrunkAccessValidityPeriod The time the granted trunk access token is valid (only set for trunkAccessGranted
+	// events, the token itself is never published)
+	TrunkAccessValidityPeriod *TimePeriod `json:"trunkAccessValidityPeriod,omitempty"`
+}
@@ -1,6 +1,6 @@
 package model
 
-// ToRentalCustomer selects State, Car, Id, RentalPeriod and Token. Customer is omitted.
+// ToRentalCustomer selects State, Car, Id, RentalPeriod, Token and AutoRelock. Customer is omitted.
 func (r *Rental) ToRentalCustomer() Rental {
 	return Rental{
 		State:        r.State,
@@ -9,10 +9,11 @@ func (r *Rental) ToRentalCustomer() Rental {
 		Customer:     nil,
 		RentalPeriod: r.RentalPeriod,
 		Token:        r.Token,
+		AutoRelock:   r.AutoRelock,
 	}
 }
 
-// ToRentalCustomerShort selects State, Car, Id and RentalPeriod. Customer and Token are omitted.
+// ToRentalCustomerShort selects State, Car, Id and RentalPeriod. Customer, Token and AutoRelock are omitted.
 func (r *Rental) ToRentalCustomerShort() Rental {
 	return Rental{
 		State:        r.State,
@@ -21,10 +22,11 @@ func (r *Rental) ToRentalCustomerShort() Rental {
 		Customer:     nil,
 		RentalPeriod: r.RentalPeriod,
 		Token:        nil,
+		AutoRelock:   nil,
 	}
 }
 
-// ToRentalFleetManager selects State, Id, Customer and RentalPeriod. Car and Token are omitted.
+// ToRentalFleetManager selects State, Id, Customer and RentalPeriod. Car, Token and AutoRelock are omitted.
 func (r *Rental) ToRentalFleetManager() Rental {
 	return Rental{
 		State:        r.State,
@@ -33,5 +35,19 @@ func (r *Rental) ToRentalFleetManager() Rental {
 		Customer:     r.Customer,
 		RentalPeriod: r.RentalPeriod,
 		Token:        nil,
+		AutoRelock:   nil,
+	}
+}
+
+// ToRentalBackOffice selects State, Car, Id, Customer and RentalPeriod. Token and AutoRelock are omitted.
+func (r *Rental) ToRentalBackOffice() Rental {
+	return Rental{
+		State:        r.State,
+		Car:          r.Car,
+		Id:           r.Id,
+		Customer:     r.Customer,
+		RentalPeriod: r.RentalPeriod,
+		Token:        nil,
+		AutoRelock:   nil,
 	}
 }
@@ -22,6 +22,7 @@ var rental = Rental{
 			EndDate:   time.Date(2023, 2, 11, 0, 0, 0, 0, time.UTC),
 		},
 	},
+	AutoRelock: &AutoRelock{TimeoutSeconds: 300},
 }
 
 var rentalCustomer = Rental{
@@ -39,6 +40,7 @@ var rentalCustomer = Rental{
 			EndDate:   time.Date(2023, 2, 11, 0, 0, 0, 0, time.UTC),
 		},
 	},
+	AutoRelock: &AutoRelock{TimeoutSeconds: 300},
 }
 
 var rentalCustomerShort = Rental{
@@ -61,6 +63,17 @@ var rentalFleetManager = Rental{
 	},
 }
 
+var rentalBackOffice = Rental{
+	State:    ACTIVE,
+	Car:      &Car{Vin: "G1YZ23J9P58034280"},
+	Customer: &Customer{CustomerId: "d9COwOvI"},
+	Id:       "rZ6I3weD",
+	RentalPeriod: TimePeriod{
+		StartDate: time.Date(2023, 2, 10, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 2, 11, 0, 0, 0, 0, time.UTC),
+	},
+}
+
 func TestRental_ToRentalCustomer(t *testing.T) {
 	assert.Equal(t, rentalCustomer, rental.ToRentalCustomer())
 }
@@ -72,3 +85,7 @@ func TestRental_ToRentalFleetManager(t *testing.T) {
 func TestRental_ToRentalCustomerShort(t *testing.T) {
 	assert.Equal(t, rentalCustomerShort, rental.ToRentalCustomerShort())
 }
+
+func TestRental_ToRentalBackOffice(t *testing.T) {
+	assert.Equal(t, rentalBackOffice, rental.ToRentalBackOffice())
+}
@@ -0,0 +1,158 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const minutesPerDay = 24 * 60
+
+var weekdays = map[Weekday]time.Weekday{
+	MONDAY:    time.Monday,
+	TUESDAY:   time.Tuesday,
+	WEDNESDAY: time.Wednesday,
+	THURSDAY:  time.Thursday,
+	FRIDAY:    time.Friday,
+	SATURDAY:  time.Saturday,
+	SUNDAY:    time.Sunday,
+}
+
+// IsValidAt reports whether the trunk access may be used at the given point in time, i.e. whether the point in time
+// lies within the validity period and, if the access is recurring, within an occurrence of the recurrence.
+func (ta *TrunkAccess) IsValidAt(t time.Time) bool {
+	if ta.ValidityPeriod.EndDate.Before(t) || ta.ValidityPeriod.StartDate.After(t) {
+		return false
+	}
+	return ta.Recurrence == nil || ta.Recurrence.Contains(t)
+}
+
+// Validate returns an error if the recurrence has no days of week, refers to an unknown day of week or time zone
+// or if its time of day range is malformed or empty.
+func (r *Recurrence) Validate() error {
+	_, _, _, err := r.parse()
+	return err
+}
+
+// Contains reports whether the given point in time lies within an occurrence of the recurrence. The start of an
+// occurrence is inclusive, its end exclusive. An invalid recurrence does not contain any point in time.
+func (r *Recurrence) Contains(t time.Time) bool {
+	location, startMinutes, endMinutes, err := r.parse()
+	if err != nil {
+		return false
+	}
+
+	local := t.In(location)
+	if !r.recursOn(local.Weekday()) {
+		return false
+	}
+
+	occurrence := occurrenceOn(local, location, startMinutes, endMinutes)
+	return !t.Before(occurrence.StartDate) && t.Before(occurrence.EndDate)
+}
+
+// RestrictTo restricts the given time period to the span from the beginning of the first to the end of the last
+// occurrence of the recurrence within that time period and returns the result. If the recurrence does not occur
+// within the time period (or is invalid), nil is returned.
+func (r *Recurrence) RestrictTo(period *TimePeriod) *TimePeriod {
+	location, startMinutes, endMinutes, err := r.parse()
+	if err != nil {
+		return nil
+	}
+
+	var restricted *TimePeriod
+
+	first := period.StartDate.In(location)
+	day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, location)
+	for ; day.Before(period.EndDate); day = day.AddDate(0, 0, 1) {
+		if !r.recursOn(day.Weekday()) {
+			continue
+		}
+
+		occurrence := occurrenceOn(day, location, startMinutes, endMinutes).RestrictTo(period)
+		if occurrence == nil {
+			continue
+		}
+
+		if restricted == nil {
+			restricted = occurrence
+		} else {
+			restricted.EndDate = occurrence.EndDate
+		}
+	}
+
+	return restricted
+}
+
+func (r *Recurrence) recursOn(weekday time.Weekday) bool {
+	for _, day := range r.DaysOfWeek {
+		if weekdays[day] == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Recurrence) parse() (location *time.Location, startMinutes int, endMinutes int, err error) {
+	if len(r.DaysOfWeek) == 0 {
+		return nil, 0, 0, errors.New("recurrence has no days of week")
+	}
+	for _, day := range r.DaysOfWeek {
+		if _, ok := weekdays[day]; !ok {
+			return nil, 0, 0, fmt.Errorf("unknown day of week %q", day)
+		}
+	}
+
+	location, err = time.LoadLocation(r.TimeZone)
+	if err != nil || r.TimeZone == "" {
+		return nil, 0, 0, fmt.Errorf("unknown time zone %q", r.TimeZone)
+	}
+
+	if startMinutes, err = parseTimeOfDay(r.StartTime); err != nil {
+		return nil, 0, 0, err
+	}
+	if endMinutes, err = parseTimeOfDay(r.EndTime); err != nil {
+		return nil, 0, 0, err
+	}
+	if startMinutes >= endMinutes {
+		return nil, 0, 0, errors.New("startTime must be before endTime")
+	}
+
+	return location, startMinutes, endMinutes, nil
+}
+
+// occurrenceOn returns the occurrence (in UTC) of the time of day range on the calendar day of the given point in time
+func occurrenceOn(day time.Time, location *time.Location, startMinutes int, endMinutes int) *TimePeriod {
+	year, month, dayOfMonth := day.In(location).Date()
+	return &TimePeriod{
+		StartDate: time.Date(year, month, dayOfMonth, 0, startMinutes, 0, 0, location).UTC(),
+		EndDate:   time.Date(year, month, dayOfMonth, 0, endMinutes, 0, 0, location).UTC(),
+	}
+}
+
+// parseTimeOfDay parses a time of day in the format HH:MM (where 24:00 is allowed) to the minutes since midnight
+func parseTimeOfDay(timeOfDay string) (int, error) {
+	invalidErr := fmt.Errorf("invalid time of day %q", timeOfDay)
+
+	if len(timeOfDay) != 5 || timeOfDay[2] != ':' {
+		return 0, invalidErr
+	}
+	for _, i := range []int{0, 1, 3, 4} {
+		if timeOfDay[i] < '0' || timeOfDay[i] > '9' {
+			return 0, invalidErr
+		}
+	}
+
+	hours, _ := strconv.Atoi(timeOfDay[:2])
+	minutes, _ := strconv.Atoi(timeOfDay[3:])
+	if minutes > 59 {
+		return 0, invalidErr
+	}
+
+	total := hours*60 + minutes
+	if total > minutesPerDay {
+		return 0, invalidErr
+	}
+	return total, nil
+}
@@ -0,0 +1,121 @@
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// 2023-05-01 is a Monday
+var weekdayMornings = Recurrence{
+	DaysOfWeek: []Weekday{MONDAY, WEDNESDAY, FRIDAY},
+	StartTime:  "08:00",
+	EndTime:    "12:00",
+	TimeZone:   "Europe/Berlin",
+}
+
+func TestRecurrence_Validate_success(t *testing.T) {
+	assert.Nil(t, weekdayMornings.Validate())
+}
+
+func TestRecurrence_Validate_endOfDay(t *testing.T) {
+	recurrence := Recurrence{DaysOfWeek: []Weekday{SUNDAY}, StartTime: "00:00", EndTime: "24:00", TimeZone: "UTC"}
+
+	assert.Nil(t, recurrence.Validate())
+}
+
+func TestRecurrence_Validate_invalid(t *testing.T) {
+	invalidRecurrences := []Recurrence{
+		{DaysOfWeek: []Weekday{}, StartTime: "08:00", EndTime: "12:00", TimeZone: "UTC"},
+		{DaysOfWeek: []Weekday{"FUNDAY"}, StartTime: "08:00", EndTime: "12:00", TimeZone: "UTC"},
+		{DaysOfWeek: []Weekday{MONDAY}, StartTime: "08:00", EndTime: "12:00", TimeZone: ""},
+		{DaysOfWeek: []Weekday{MONDAY}, StartTime: "08:00", EndTime: "12:00", TimeZone: "Middle/Earth"},
+		{DaysOfWeek: []Weekday{MONDAY}, StartTime: "8:00", EndTime: "12:00", TimeZone: "UTC"},
+		{DaysOfWeek: []Weekday{MONDAY}, StartTime: "08:60", EndTime: "12:00", TimeZone: "UTC"},
+		{DaysOfWeek: []Weekday{MONDAY}, StartTime: "08:00", EndTime: "24:01", TimeZone: "UTC"},
+		{DaysOfWeek: []Weekday{MONDAY}, StartTime: "+8:00", EndTime: "12:00", TimeZone: "UTC"},
+		{DaysOfWeek: []Weekday{MONDAY}, StartTime: "12:00", EndTime: "12:00", TimeZone: "UTC"},
+		{DaysOfWeek: []Weekday{MONDAY}, StartTime: "13:00", EndTime: "12:00", TimeZone: "UTC"},
+	}
+
+	for _, recurrence := range invalidRecurrences {
+		assert.NotNil(t, recurrence.Validate(), recurrence)
+	}
+}
+
+func TestRecurrence_Contains(t *testing.T) {
+	// Europe/Berlin is UTC+2 in May
+	assert.True(t, weekdayMornings.Contains(time.Date(2023, 5, 1, 6, 0, 0, 0, time.UTC)))
+	assert.True(t, weekdayMornings.Contains(time.Date(2023, 5, 3, 9, 59, 0, 0, time.UTC)))
+	assert.False(t, weekdayMornings.Contains(time.Date(2023, 5, 1, 5, 59, 0, 0, time.UTC)))
+	assert.False(t, weekdayMornings.Contains(time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)))
+	assert.False(t, weekdayMornings.Contains(time.Date(2023, 5, 2, 7, 0, 0, 0, time.UTC)))
+}
+
+func TestRecurrence_Contains_invalid(t *testing.T) {
+	recurrence := Recurrence{DaysOfWeek: []Weekday{MONDAY}, StartTime: "12:00", EndTime: "08:00", TimeZone: "UTC"}
+
+	assert.False(t, recurrence.Contains(time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)))
+}
+
+func TestRecurrence_RestrictTo_success(t *testing.T) {
+	restricted := weekdayMornings.RestrictTo(&TimePeriod{
+		StartDate: time.Date(2023, 5, 1, 7, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 5, 8, 0, 0, 0, 0, time.UTC),
+	})
+
+	assert.Equal(t, &TimePeriod{
+		StartDate: time.Date(2023, 5, 1, 7, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 5, 5, 10, 0, 0, 0, time.UTC),
+	}, restricted)
+}
+
+func TestRecurrence_RestrictTo_startsBeforeFirstOccurrence(t *testing.T) {
+	restricted := weekdayMornings.RestrictTo(&TimePeriod{
+		StartDate: time.Date(2023, 5, 1, 11, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 5, 3, 8, 0, 0, 0, time.UTC),
+	})
+
+	assert.Equal(t, &TimePeriod{
+		StartDate: time.Date(2023, 5, 3, 6, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 5, 3, 8, 0, 0, 0, time.UTC),
+	}, restricted)
+}
+
+func TestRecurrence_RestrictTo_noOccurrence(t *testing.T) {
+	restricted := weekdayMornings.RestrictTo(&TimePeriod{
+		StartDate: time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 5, 3, 6, 0, 0, 0, time.UTC),
+	})
+
+	assert.Nil(t, restricted)
+}
+
+func TestTrunkAccess_IsValidAt(t *testing.T) {
+	trunkAccess := TrunkAccess{
+		ValidityPeriod: TimePeriod{
+			StartDate: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2023, 5, 4, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	assert.True(t, trunkAccess.IsValidAt(time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, trunkAccess.IsValidAt(time.Date(2023, 5, 2, 15, 0, 0, 0, time.UTC)))
+	assert.True(t, trunkAccess.IsValidAt(time.Date(2023, 5, 4, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, trunkAccess.IsValidAt(time.Date(2023, 4, 30, 23, 0, 0, 0, time.UTC)))
+	assert.False(t, trunkAccess.IsValidAt(time.Date(2023, 5, 4, 0, 0, 1, 0, time.UTC)))
+}
+
+func TestTrunkAccess_IsValidAt_recurring(t *testing.T) {
+	trunkAccess := TrunkAccess{
+		ValidityPeriod: TimePeriod{
+			StartDate: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2023, 5, 4, 0, 0, 0, 0, time.UTC),
+		},
+		Recurrence: &weekdayMornings,
+	}
+
+	assert.True(t, trunkAccess.IsValidAt(time.Date(2023, 5, 1, 7, 0, 0, 0, time.UTC)))
+	assert.False(t, trunkAccess.IsValidAt(time.Date(2023, 5, 2, 7, 0, 0, 0, time.UTC)))
+	assert.False(t, trunkAccess.IsValidAt(time.Date(2023, 5, 5, 7, 0, 0, 0, time.UTC)))
+}
@@ -9,6 +9,37 @@ const (
 	ON  DynamicDataEngineState = "ON"
 )
 
+// Defines values for ErrorCode.
+const (
+	ErrorCodeCARNOTFOUND            ErrorCode = "CAR_NOT_FOUND"
+	ErrorCodeRENTALNOTFOUND         ErrorCode = "RENTAL_NOT_FOUND"
+	ErrorCodeWEBHOOKNOTFOUND        ErrorCode = "WEBHOOK_NOT_FOUND"
+	ErrorCodeCALENDARNOTFOUND       ErrorCode = "CALENDAR_NOT_FOUND"
+	ErrorCodeCONFLICTINGRENTAL      ErrorCode = "CONFLICTING_RENTAL"
+	ErrorCodeRENTALNOTACTIVE        ErrorCode = "RENTAL_NOT_ACTIVE"
+	ErrorCodeRENTALNOTUPCOMING      ErrorCode = "RENTAL_NOT_UPCOMING"
+	ErrorCodeRENTALNOTOVERLAPPING   ErrorCode = "RENTAL_NOT_OVERLAPPING"
+	ErrorCodeTRUNKACCESSDENIED      ErrorCode = "TRUNK_ACCESS_DENIED"
+	ErrorCodeRESOURCECONFLICT       ErrorCode = "RESOURCE_CONFLICT"
+	ErrorCodeDOMAINSERVICEERROR     ErrorCode = "DOMAIN_SERVICE_ERROR"
+	ErrorCodeINVALIDTIMEPERIOD      ErrorCode = "INVALID_TIME_PERIOD"
+	ErrorCodePASTTIMEPERIOD         ErrorCode = "PAST_TIME_PERIOD"
+	ErrorCodeINVALIDRECURRENCE      ErrorCode = "INVALID_RECURRENCE"
+	ErrorCodeINVALIDWEBHOOKURL      ErrorCode = "INVALID_WEBHOOK_URL"
+	ErrorCodeINVALIDRENTALIMPORT    ErrorCode = "INVALID_RENTAL_IMPORT"
+	ErrorCodeINVALIDLOCKSTATEACTOR  ErrorCode = "INVALID_LOCK_STATE_ACTOR"
+	ErrorCodeINVALIDREQUEST         ErrorCode = "INVALID_REQUEST"
+	ErrorCodeINVALIDTOKEN           ErrorCode = "INVALID_TOKEN"
+	ErrorCodeAUTHENTICATIONREQUIRED ErrorCode = "AUTHENTICATION_REQUIRED"
+	ErrorCodeCUSTOMERMISMATCH       ErrorCode = "CUSTOMER_MISMATCH"
+	ErrorCodeMISSINGROLE            ErrorCode = "MISSING_ROLE"
+	ErrorCodeFORBIDDEN              ErrorCode = "FORBIDDEN"
+	ErrorCodeNOTFOUND               ErrorCode = "NOT_FOUND"
+	ErrorCodeMETHODNOTALLOWED       ErrorCode = "METHOD_NOT_ALLOWED"
+	ErrorCodeREQUESTREJECTED        ErrorCode = "REQUEST_REJECTED"
+	ErrorCodeINTERNALERROR          ErrorCode = "INTERNAL_ERROR"
+)
+
 // Defines values for LockState.
 const (
 	LOCKED   LockState = "LOCKED"
@@ -17,9 +48,56 @@ const (
 
 // Defines values for RentalState.
 const (
-	ACTIVE   State = "ACTIVE"
-	UPCOMING State = "UPCOMING"
-	EXPIRED  State = "EXPIRED"
+	ACTIVE    State = "ACTIVE"
+	UPCOMING  State = "UPCOMING"
+	EXPIRED   State = "EXPIRED"
+	CANCELLED State = "CANCELLED"
+)
+
+// Defines values for RentalImportStatus.
+const (
+	CREATED     RentalImportStatus = "CREATED"
+	CONFLICT    RentalImportStatus = "CONFLICT"
+	CARNOTFOUND RentalImportStatus = "CAR_NOT_FOUND"
+	INVALID     RentalImportStatus = "INVALID"
+	ERROR       RentalImportStatus = "ERROR"
+)
+
+// Defines values for TrunkAccessAction.
+const (
+	GETLOCKSTATE TrunkAccessAction = "GET_LOCK_STATE"
+	SETLOCKSTATE TrunkAccessAction = "SET_LOCK_STATE"
+)
+
+// Defines values for TrunkAccessActorType.
+const (
+	CUSTOMER TrunkAccessActorType = "CUSTOMER"
+	TOKEN    TrunkAccessActorType = "TOKEN"
+)
+
+// Defines values for TrunkAccessOutcome.
+const (
+	GRANTED TrunkAccessOutcome = "GRANTED"
+	DENIED  TrunkAccessOutcome = "DENIED"
+	FAILED  TrunkAccessOutcome = "FAILED"
+)
+
+// Defines values for WebhookDeliveryState.
+const (
+	PENDING    WebhookDeliveryState = "PENDING"
+	DELIVERED  WebhookDeliveryState = "DELIVERED"
+	DEADLETTER WebhookDeliveryState = "DEAD_LETTER"
+)
+
+// Defines values for Weekday.
+const (
+	MONDAY    Weekday = "MONDAY"
+	TUESDAY   Weekday = "TUESDAY"
+	WEDNESDAY Weekday = "WEDNESDAY"
+	THURSDAY  Weekday = "THURSDAY"
+	FRIDAY    Weekday = "FRIDAY"
+	SATURDAY  Weekday = "SATURDAY"
+	SUNDAY    Weekday = "SUNDAY"
 )
 
 // Defines values for TechnicalSpecificationFuel.
@@ -37,6 +115,21 @@ const (
 	MANUAL    TechnicalSpecificationTransmission = "MANUAL"
 )
 
+// AutoRelock Auto relock settings of a rental
+type AutoRelock struct {
+	// TimeoutSeconds The number of seconds after which an unlocked trunk is locked again, 0 disables auto relock
+	TimeoutSeconds int `json:"timeoutSeconds"`
+}
+
+// CalendarSubscription An opaque URL calendar clients can poll the rentals of a customer from
+type CalendarSubscription struct {
+	// Url The URL of the iCalendar feed, which is valid until a new subscription is created
+	Url string `json:"url"`
+}
+
+// CalendarToken Opaque token identifying the calendar subscription of a customer
+type CalendarToken = string
+
 // Car defines model for car.
 type Car struct {
 	// Brand Data that specifies the brand name of the manufacturer
@@ -102,10 +195,13 @@ type DynamicData struct {
 // DynamicDataEngineState defines model for DynamicData.EngineState.
 type DynamicDataEngineState string
 
+// ErrorCode A stable, machine-readable code of the problem. Clients should match on it instead of the detail.
+type ErrorCode string
+
 // LockState Data that specifies whether an object is locked or unlocked
 type LockState string
 
-// State Describes whether this rental is active, upcoming or expired
+// State Describes whether this rental is active, upcoming, expired or cancelled
 type State string
 
 // LockStateObject An object containing the trunk lock state
@@ -114,9 +210,37 @@ type LockStateObject struct {
 	TrunkLockState LockState `json:"trunkLockState"`
 }
 
+// Problem An error response as specified by RFC 7807 (problem details for HTTP APIs)
+type Problem struct {
+	// Type A URI reference identifying the problem type, about:blank as the code identifies the problem
+	Type string `json:"type"`
+
+	// Title A short summary of the problem type, the reason phrase of the status code
+	Title string `json:"title"`
+
+	// Status The HTTP status code of the response
+	Status int `json:"status"`
+
+	// Detail A message that describes this occurrence of the problem
+	Detail string `json:"detail,omitempty"`
+
+	// Instance The path of the request the problem occurred in
+	Instance string `json:"instance"`
+
+	// Code A stable, machine-readable code of the problem. Clients should match on it instead of the detail.
+	Code ErrorCode `json:"code"`
+
+	// TraceId Identifies the trace of the request, which continues the W3C trace context of the caller
+	TraceId string `json:"traceId"`
+
+	// RequestId Identifies the request in the logs of RentalManagement, the X-Request-ID of the request if it sent a
+	// valid one
+	RequestId string `json:"requestId,omitempty"`
+}
+
 // Rental defines a model for rentals.
 type Rental struct {
-	// State Describes whether this rental is active, upcoming or expired
+	// State Describes whether this rental is active, upcoming, expired or cancelled
 	State State `json:"state"`
 
 	// Car The rented car
@@ -133,11 +257,62 @@ type Rental struct {
 
 	// Token Trunk access token with time
 	Token *TrunkAccess `json:"token,omitempty"`
+
+	// AutoRelock Auto relock settings of a rental
+	AutoRelock *AutoRelock `json:"autoRelock,omitempty"`
+}
+
+// Recurrence A weekly recurring time of day range
+type Recurrence struct {
+	// DaysOfWeek The days of the week on which the time of day range recurs
+	DaysOfWeek []Weekday `json:"daysOfWeek"`
+
+	// StartTime Beginning of the time of day range (HH:MM)
+	StartTime string `json:"startTime"`
+
+	// EndTime End of the time of day range (HH:MM), 24:00 denotes the end of the day
+	EndTime string `json:"endTime"`
+
+	// TimeZone The IANA time zone the time of day range refers to
+	TimeZone string `json:"timeZone"`
 }
 
 // RentalId Unique identification of a rental
 type RentalId = string
 
+// RentalImportReport The outcome of a rental import, row by row
+type RentalImportReport struct {
+	// Created The number of rows a rental was created for
+	Created int `json:"created"`
+
+	// Failed The number of rows no rental was created for
+	Failed int `json:"failed"`
+
+	// Rows The outcome of every data row in the order of the file
+	Rows []RentalImportResult `json:"rows"`
+}
+
+// RentalImportResult The outcome of a single row of a rental import
+type RentalImportResult struct {
+	// Row The line number of the row in the file, the header being line 1
+	Row int `json:"row"`
+
+	// Status Describes whether a rental was created for the row and why not
+	Status RentalImportStatus `json:"status"`
+
+	// Vin The VIN as given in the row
+	Vin string `json:"vin"`
+
+	// CustomerId The customer ID as given in the row
+	CustomerId string `json:"customerId"`
+
+	// Message Why no rental was created for the row, if none was created
+	Message string `json:"message,omitempty"`
+}
+
+// RentalImportStatus Describes whether a rental was created for the row and why not
+type RentalImportStatus string
+
 // TechnicalSpecification defines model for technicalSpecification.
 type TechnicalSpecification struct {
 	// Color Data on the description of the paint job of a car
@@ -249,6 +424,57 @@ type TrunkAccess struct {
 
 	// ValidityPeriod A period of time
 	ValidityPeriod TimePeriod `json:"validityPeriod"`
+
+	// Recurrence A weekly recurring time of day range
+	Recurrence *Recurrence `json:"recurrence,omitempty"`
+}
+
+// TrunkAccessGrant Requested validity period and optional recurrence for a trunk access token
+type TrunkAccessGrant struct {
+	// StartDate start of the time period
+	StartDate time.Time `json:"startDate"`
+
+	// EndDate end of the time period
+	EndDate time.Time `json:"endDate"`
+
+	// Recurrence A weekly recurring time of day range
+	Recurrence *Recurrence `json:"recurrence,omitempty"`
+}
+
+// TrunkAccessAction Describes which trunk operation was attempted
+type TrunkAccessAction string
+
+// TrunkAccessActorType Describes how the actor of a trunk access attempt identified itself
+type TrunkAccessActorType string
+
+// TrunkAccessOutcome Describes whether a trunk access attempt was granted, denied or failed
+type TrunkAccessOutcome string
+
+// TrunkAccessLogEntry An entry of the trunk access audit log
+type TrunkAccessLogEntry struct {
+	// Vin A Vehicle Identification Number (VIN) which uniquely identifies a car
+	Vin Vin `json:"vin"`
+
+	// RentalId Unique identification of a rental, omitted if the attempt could not be assigned to a rental
+	RentalId *RentalId `json:"rentalId,omitempty"`
+
+	// ActorType Describes how the actor of a trunk access attempt identified itself
+	ActorType TrunkAccessActorType `json:"actorType"`
+
+	// Actor The customer ID or the prefix of the trunk access token used for the attempt
+	Actor string `json:"actor"`
+
+	// Action Describes which trunk operation was attempted
+	Action TrunkAccessAction `json:"action"`
+
+	// LockState The requested trunk lock state, only present for SET_LOCK_STATE
+	LockState *LockState `json:"lockState,omitempty"`
+
+	// Outcome Describes whether a trunk access attempt was granted, denied or failed
+	Outcome TrunkAccessOutcome `json:"outcome"`
+
+	// Timestamp The time of the attempt
+	Timestamp time.Time `json:"timestamp"`
 }
 
 // TrunkAccessToken Trunk access token
@@ -257,6 +483,81 @@ type TrunkAccessToken = string
 // Vin A Vehicle Identification Number (VIN) which uniquely identifies a car
 type Vin = string
 
+// Webhook A subscription of rental events that are posted to a URL
+type Webhook struct {
+	// Id Unique identification of a webhook
+	Id WebhookId `json:"id"`
+
+	// Url The URL the rental events are posted to
+	Url string `json:"url"`
+
+	// EventTypes The types of the rental events that are posted
+	EventTypes []RentalEventType `json:"eventTypes"`
+
+	// Secret The secret the payloads are signed with, it is never returned
+	Secret string `json:"-"`
+
+	// CreatedAt The time the webhook was registered
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// WebhookDelivery A rental event that is or has been posted to a webhook
+type WebhookDelivery struct {
+	// Id Unique identification of the delivery
+	Id string `json:"id"`
+
+	// WebhookId Unique identification of a webhook
+	WebhookId WebhookId `json:"webhookId"`
+
+	// Event The rental event that is posted
+	Event RentalEvent `json:"event"`
+
+	// State Describes whether a delivery is pending, has been delivered or has been given up
+	State WebhookDeliveryState `json:"state"`
+
+	// Attempts The number of attempts made so far
+	Attempts int `json:"attempts"`
+
+	// CreatedAt The time the delivery was created
+	CreatedAt time.Time `json:"createdAt"`
+
+	// NextAttemptAt The time of the next attempt, only present for PENDING deliveries
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+
+	// LastAttemptAt The time of the last attempt, omitted if no attempt has been made yet
+	LastAttemptAt *time.Time `json:"lastAttemptAt,omitempty"`
+
+	// LastStatusCode The HTTP status code of the response to the last attempt, omitted if there was no response
+	LastStatusCode *int `json:"lastStatusCode,omitempty"`
+
+	// LastError Describes why the last attempt failed, omitted if it succeeded
+	LastError *string `json:"lastError,omitempty"`
+}
+
+// WebhookDeliveryState Describes whether a delivery is pending, has been delivered or has been given up
+type WebhookDeliveryState string
+
+// WebhookId Unique identification of a webhook
+type WebhookId = string
+
+// WebhookRegistration A request to post rental events of the given types to a URL
+type WebhookRegistration struct {
+	// Url The URL the rental events are posted to (http or https)
+	Url string `json:"url"`
+
+	// EventTypes The types of the rental events that are posted
+	EventTypes []RentalEventType `json:"eventTypes"`
+
+	// Secret The secret the payloads are signed with (HMAC-SHA256)
+	Secret string `json:"secret"`
+}
+
+// Weekday A day of the week
+type Weekday string
+
+// CalendarTokenParam Opaque token identifying the calendar subscription of a customer
+type CalendarTokenParam = CalendarToken
+
 // CustomerIdOptionalParam Unique identification of a customer
 type CustomerIdOptionalParam = CustomerId
 
@@ -275,11 +576,19 @@ type TrunkAccessTokenParam = TrunkAccessToken
 // VinParam A Vehicle Identification Number (VIN) which uniquely identifies a car
 type VinParam = Vin
 
+// WebhookIdParam Unique identification of a webhook
+type WebhookIdParam = WebhookId
+
 // GetAvailableCarsParams defines parameters for GetAvailableCars.
 type GetAvailableCarsParams struct {
 	TimePeriod TimePeriod `form:"timePeriod" json:"timePeriod"`
 }
 
+// ExportRentalsParams defines parameters for ExportRentals.
+type ExportRentalsParams struct {
+	TimePeriod TimePeriod `form:"timePeriod" json:"timePeriod"`
+}
+
 // CreateRentalParams defines parameters for CreateRental.
 type CreateRentalParams struct {
 	// CustomerId Unique identification of a customer
@@ -306,3 +615,24 @@ type GetOverviewParams struct {
 	// CustomerId Unique identification of a customer
 	CustomerId CustomerIdParam `form:"customerId" json:"customerId"`
 }
+
+// GetRentalCalendarParams defines parameters for GetRentalCalendar.
+type GetRentalCalendarParams struct {
+	// CustomerId Unique identification of a customer
+	CustomerId CustomerIdParam `form:"customerId" json:"customerId"`
+}
+
+// CreateCalendarSubscriptionParams defines parameters for CreateCalendarSubscription.
+type CreateCalendarSubscriptionParams struct {
+	// CustomerId Unique identification of a customer
+	CustomerId CustomerIdParam `form:"customerId" json:"customerId"`
+}
+
+// GrantTrunkAccessJSONRequestBody defines body for GrantTrunkAccess for application/json ContentType.
+type GrantTrunkAccessJSONRequestBody = TrunkAccessGrant
+
+// RegisterWebhookJSONRequestBody defines body for RegisterWebhook for application/json ContentType.
+type RegisterWebhookJSONRequestBody = WebhookRegistration
+
+// SetAutoRelockJSONRequestBody defines body for SetAutoRelock for application/json ContentType.
+type SetAutoRelockJSONRequestBody = AutoRelock
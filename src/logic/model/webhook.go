@@ -0,0 +1,11 @@
+package model
+
+// IsSubscribedTo reports whether rental events of the given type are posted to the webhook
+func (w *Webhook) IsSubscribedTo(eventType RentalEventType) bool {
+	for _, subscribedType := range w.EventTypes {
+		if subscribedType == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,14 @@
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWebhook_IsSubscribedTo(t *testing.T) {
+	webhook := Webhook{EventTypes: []RentalEventType{RentalCreated, TrunkAccessGranted}}
+
+	assert.True(t, webhook.IsSubscribedTo(RentalCreated))
+	assert.True(t, webhook.IsSubscribedTo(TrunkAccessGranted))
+	assert.False(t, webhook.IsSubscribedTo(RentalChanged))
+}
@@ -19,27 +19,79 @@ type IOperations interface {
 	GetCar(ctx context.Context, vin model.Vin) (*model.Car, error)
 	// GetOverview Get an Overview of a Customer’s Rentals
 	GetOverview(ctx context.Context, customerID model.CustomerId) (*[]model.Rental, error)
+	// GetRentalsInPeriod Get all Rentals overlapping with a Time Period in a format suitable for the back office, that
+	// is, the state, the VIN of the car, the customer, the rental period, and the rental ID.
+	// The rentals are ordered by their start date, then by VIN.
+	GetRentalsInPeriod(ctx context.Context, timePeriod model.TimePeriod) (*[]model.Rental, error)
 	// GetRentalStatus Get Rental Status Information (Including Car Data) based on an ID
 	GetRentalStatus(ctx context.Context, rentalId model.RentalId) (*model.Rental, error)
+	// GetRentalCustomer Get the ID of the customer renting the rental with the given rentalId
+	// Returns rentalErrors.ErrRentalNotFound if the rental does not exist
+	GetRentalCustomer(ctx context.Context, rentalId model.RentalId) (model.CustomerId, error)
+	// WatchCarState Stream the dynamic data of the car of an active rental
+	// The current state and every change are sent on the returned channel, which is closed when the rental expires
+	// or the context is done. The rental is read again before each change is sent, and the channel is closed as well
+	// if it is not active anymore, e.g. because it has been cancelled.
+	// Returns rentalErrors.ErrRentalNotFound if the rental does not exist
+	// Returns rentalErrors.ErrRentalNotActive if the rental is not active
+	WatchCarState(ctx context.Context, rentalId model.RentalId) (<-chan model.DynamicData, error)
 	// GrantTrunkAccess Generate a new Trunk Access Token and replace the old one of the rental
 	// with given rentalId with it, if present. The new access token is returned.
+	// If a recurrence is given, the token is only valid during its occurrences within the validity period.
 	// Returns rentalErrors.ErrRentalNotFound if the rental does not exist.
 	// Returns rentalErrors.ErrRentalNotActive if the rental is not active.
-	// Returns rentalErrors.ErrRentalNotOverlapping if the rental is not active at any time during the validity period.
+	// Returns rentalErrors.ErrRentalNotOverlapping if the rental is not active at any time during the validity period
+	// (or, if a recurrence is given, during any of its occurrences).
 	// Returns rentalErrors.ErrResourceConflict if the resource is already in use and retry attempts failed.
-	GrantTrunkAccess(ctx context.Context, rentalId model.RentalId, timePeriod model.TimePeriod) (
-		*model.TrunkAccess, error)
+	GrantTrunkAccess(ctx context.Context, rentalId model.RentalId, timePeriod model.TimePeriod,
+		recurrence *model.Recurrence) (*model.TrunkAccess, error)
 	// GetLockState Get TrunkLockState of a Car if valid token is provided
-	// Returns rentalErrors.ErrTrunkAccessDenied if the token is not valid
+	// Every attempt is recorded in the trunk access log.
+	// Returns rentalErrors.ErrTrunkAccessDenied if the token is not valid (at the current time of day)
 	// Returns rentalErrors.ErrDomainAssertion if communication with the domain microservice
 	// did not return the current trunk lock state
 	GetLockState(ctx context.Context, vin model.Vin, token model.TrunkAccessToken) (*model.LockState, error)
 	// SetLockStateCustomerId Set TrunkLockState of a Car if customer has an active rental for the car
+	// Every attempt is recorded in the trunk access log. An unlocked trunk is locked again automatically
+	// after the auto relock timeout of the rental, unless it is locked in the meantime.
 	// Returns rentalErrors.ErrTrunkAccessDenied if the customer does not have an active rental for the car
 	SetLockStateCustomerId(ctx context.Context, lockState model.LockState, vin model.Vin,
 		customerId model.CustomerId) error
 	// SetLockStateTrunkAccessToken Set TrunkLockState of a Car if a valid token is provided
-	// Returns rentalErrors.ErrTrunkAccessDenied if the token is not valid
+	// Every attempt is recorded in the trunk access log. An unlocked trunk is locked again automatically
+	// after the auto relock timeout of the rental, unless it is locked in the meantime.
+	// Returns rentalErrors.ErrTrunkAccessDenied if the token is not valid (at the current time of day)
 	SetLockStateTrunkAccessToken(ctx context.Context, lockState model.LockState, vin model.Vin,
 		token model.TrunkAccessToken) error
+	// SetAutoRelock Set the auto relock timeout of a rental, overriding the default timeout
+	// Returns rentalErrors.ErrRentalNotFound if the rental does not exist
+	SetAutoRelock(ctx context.Context, rentalId model.RentalId, autoRelock model.AutoRelock) error
+	// CancelRental Cancel a rental that has not started yet, which makes the car available for its period again
+	// Returns rentalErrors.ErrRentalNotFound if the rental does not exist and rentalErrors.ErrRentalNotUpcoming if it
+	// has started or has been cancelled already
+	CancelRental(ctx context.Context, rentalId model.RentalId) error
+	// GetTrunkAccessLogOfRental Get all recorded trunk access attempts assigned to a rental, the oldest first
+	// Returns rentalErrors.ErrRentalNotFound if the rental does not exist
+	GetTrunkAccessLogOfRental(ctx context.Context, rentalId model.RentalId) (*[]model.TrunkAccessLogEntry, error)
+	// GetTrunkAccessLogOfCar Get all recorded trunk access attempts of a car, the oldest first
+	// Returns rentalErrors.ErrCarNotFound if the car does not exist
+	GetTrunkAccessLogOfCar(ctx context.Context, vin model.Vin) (*[]model.TrunkAccessLogEntry, error)
+	// RegisterWebhook Subscribe a URL to rental events of the given types
+	// The events are posted to the URL signed with the secret of the registration.
+	RegisterWebhook(ctx context.Context, registration model.WebhookRegistration) (*model.Webhook, error)
+	// GetWebhooks Get all webhook subscriptions, the oldest first
+	GetWebhooks(ctx context.Context) (*[]model.Webhook, error)
+	// DeleteWebhook Cancel a webhook subscription, pending deliveries are not attempted anymore
+	// Returns rentalErrors.ErrWebhookNotFound if the webhook does not exist
+	DeleteWebhook(ctx context.Context, webhookId model.WebhookId) error
+	// GetWebhookDeliveries Get all deliveries of a webhook including their state, the newest first
+	// Returns rentalErrors.ErrWebhookNotFound if the webhook does not exist
+	GetWebhookDeliveries(ctx context.Context, webhookId model.WebhookId) (*[]model.WebhookDelivery, error)
+	// CreateCalendarToken Generate a new calendar subscription token for a customer and replace the old one with it,
+	// if present. The new token is returned.
+	CreateCalendarToken(ctx context.Context, customerId model.CustomerId) (model.CalendarToken, error)
+	// GetOverviewByCalendarToken Get an Overview of the Rentals of the customer the calendar subscription token
+	// belongs to
+	// Returns rentalErrors.ErrCalendarNotFound if the token is unknown
+	GetOverviewByCalendarToken(ctx context.Context, token model.CalendarToken) (*[]model.Rental, error)
 }
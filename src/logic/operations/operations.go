@@ -3,28 +3,50 @@ package operations
 import (
 	"RentalManagement/infrastructure/car"
 	"RentalManagement/infrastructure/database"
+	"RentalManagement/logging"
+	"RentalManagement/logic/carstate"
 	"RentalManagement/logic/model"
 	"RentalManagement/logic/rentalErrors"
+	"RentalManagement/metrics"
 	"RentalManagement/util"
 	"context"
 	"errors"
 	"fmt"
 	carTypes "github.com/ccsapp/cargotypes"
+	"golang.org/x/exp/slog"
 	"net/http"
+	"sort"
+	"time"
 )
 
+// tokenPrefixLength is the number of characters of a trunk access token that identify the actor in the trunk access log
+const tokenPrefixLength = 4
+
+type OperationsConfig interface {
+	// GetTrunkAutoRelockTimeout returns the duration after which an unlocked trunk is locked again if the rental does
+	// not specify its own timeout. A duration <= 0 disables auto relock by default.
+	GetTrunkAutoRelockTimeout() time.Duration
+	// GetCarStateInterval returns the interval in which the state of cars with subscribers is pulled from the Car
+	// server.
+	GetCarStateInterval() time.Duration
+}
+
 type operations struct {
 	carClient    car.ClientWithResponsesInterface
 	crud         database.ICRUD
 	timeProvider util.ITimeProvider
+	config       OperationsConfig
+	carState     *carstate.Hub
 }
 
 func NewOperations(carClient car.ClientWithResponsesInterface, crud database.ICRUD,
-	timeProvider util.ITimeProvider) IOperations {
+	timeProvider util.ITimeProvider, config OperationsConfig) IOperations {
 	return &operations{
 		carClient:    carClient,
 		crud:         crud,
 		timeProvider: timeProvider,
+		config:       config,
+		carState:     carstate.NewHub(carClient, config.GetCarStateInterval()),
 	}
 }
 
@@ -95,7 +117,17 @@ func (o *operations) CreateRental(ctx context.Context, vin model.Vin, customerID
 	if err := o.ensureCarExists(ctx, vin); err != nil {
 		return err
 	}
-	return o.crud.CreateRental(ctx, vin, customerID, timePeriod)
+
+	err := o.crud.CreateRental(ctx, vin, customerID, timePeriod)
+	switch {
+	case err == nil:
+		metrics.RentalsCreated.Inc()
+		loggerOf(ctx, "CreateRental", logging.KeyVin, vin).Info("rental created")
+	case errors.Is(err, rentalErrors.ErrConflictingRentalExists):
+		metrics.RentalConflicts.Inc()
+		loggerOf(ctx, "CreateRental", logging.KeyVin, vin).Info("rental conflicts with an existing rental")
+	}
+	return err
 }
 
 func (o *operations) ensureCarExists(ctx context.Context, vin model.Vin) error {
@@ -153,12 +185,36 @@ func (o *operations) GetOverview(ctx context.Context, customerID model.CustomerI
 	return rentals, nil
 }
 
-func (o *operations) GrantTrunkAccess(ctx context.Context, rentalId model.RentalId, timePeriod model.TimePeriod) (
-	*model.TrunkAccess, error) {
+func (o *operations) GetRentalsInPeriod(ctx context.Context, timePeriod model.TimePeriod) (*[]model.Rental,
+	error) {
+
+	rentals, err := o.crud.GetRentalsInPeriod(ctx, timePeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, rental := range *rentals {
+		(*rentals)[i] = rental.ToRentalBackOffice()
+	}
+
+	sort.SliceStable(*rentals, func(i, j int) bool {
+		a, b := (*rentals)[i], (*rentals)[j]
+		if !a.RentalPeriod.StartDate.Equal(b.RentalPeriod.StartDate) {
+			return a.RentalPeriod.StartDate.Before(b.RentalPeriod.StartDate)
+		}
+		return a.Car.Vin < b.Car.Vin
+	})
+
+	return rentals, nil
+}
+
+func (o *operations) GrantTrunkAccess(ctx context.Context, rentalId model.RentalId, timePeriod model.TimePeriod,
+	recurrence *model.Recurrence) (*model.TrunkAccess, error) {
 
 	trunkAccess := model.TrunkAccess{
 		Token:          util.GenerateRandomString(24),
 		ValidityPeriod: timePeriod,
+		Recurrence:     recurrence,
 	}
 
 	createdToken, err := o.crud.SetTrunkToken(ctx, rentalId, trunkAccess)
@@ -169,6 +225,7 @@ func (o *operations) GrantTrunkAccess(ctx context.Context, rentalId model.Rental
 		return nil, err
 	}
 
+	loggerOf(ctx, "GrantTrunkAccess", logging.KeyRentalId, rentalId).Info("trunk access granted")
 	return createdToken, nil
 }
 
@@ -203,15 +260,99 @@ func (o *operations) GetRentalStatus(ctx context.Context, rentalId model.RentalI
 	return &rentalReturn, nil
 }
 
+func (o *operations) GetRentalCustomer(ctx context.Context, rentalId model.RentalId) (model.CustomerId, error) {
+	rental, err := o.crud.GetRental(ctx, rentalId)
+	if err != nil {
+		return "", err
+	}
+	return rental.Customer.CustomerId, nil
+}
+
+func (o *operations) WatchCarState(ctx context.Context, rentalId model.RentalId) (<-chan model.DynamicData, error) {
+	rental, err := o.crud.GetRental(ctx, rentalId)
+	if err != nil {
+		return nil, err
+	}
+	if rental.State != model.ACTIVE {
+		return nil, rentalErrors.ErrRentalNotActive
+	}
+
+	updates, unsubscribe := o.carState.Subscribe(rental.Car.Vin)
+	states := make(chan model.DynamicData)
+
+	go func() {
+		defer close(states)
+		defer unsubscribe()
+
+		expiry := time.NewTimer(rental.RentalPeriod.EndDate.Sub(o.timeProvider.Now()))
+		defer expiry.Stop()
+
+		for {
+			var state model.DynamicData
+			select {
+			case <-ctx.Done():
+				return
+			case <-expiry.C:
+				return
+			case state = <-updates:
+			}
+
+			// the rental may have been cancelled or removed in the meantime, which ends the stream like its expiry
+			if !o.isStillActive(ctx, rentalId) {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-expiry.C:
+				return
+			case states <- state:
+			}
+		}
+	}()
+
+	return states, nil
+}
+
+// isStillActive reads the rental again and returns whether it is still active. A stream is not ended because of a
+// database error, so the rental counts as active if it cannot be read.
+func (o *operations) isStillActive(ctx context.Context, rentalId model.RentalId) bool {
+	rental, err := o.crud.GetRental(ctx, rentalId)
+	if errors.Is(err, rentalErrors.ErrRentalNotFound) {
+		return false
+	}
+	if err != nil {
+		if ctx.Err() == nil {
+			loggerOf(ctx, "WatchCarState", logging.KeyRentalId, rentalId).Warn("checking the rental failed",
+				logging.KeyError, err)
+		}
+		return true
+	}
+	return rental.State == model.ACTIVE
+}
+
 func (o *operations) GetLockState(ctx context.Context, vin model.Vin, token model.TrunkAccessToken) (*model.LockState,
 	error) {
 
-	access, err := o.crud.GetTrunkAccess(ctx, vin, token)
+	entry := o.newTrunkAccessLogEntry(vin, model.TOKEN, tokenPrefix(token), model.GETLOCKSTATE, nil)
+	lockState, err := o.getLockState(ctx, vin, token, &entry)
+	if err = o.recordTrunkAccess(ctx, "GetLockState", &entry, err); err != nil {
+		return nil, err
+	}
+	return lockState, nil
+}
+
+func (o *operations) getLockState(ctx context.Context, vin model.Vin, token model.TrunkAccessToken,
+	entry *model.TrunkAccessLogEntry) (*model.LockState, error) {
+
+	rental, err := o.crud.GetTrunkAccessRental(ctx, vin, token)
 	if err != nil {
 		return nil, err
 	}
-	now := o.timeProvider.Now()
-	if access.ValidityPeriod.EndDate.Before(now) || access.ValidityPeriod.StartDate.After(now) {
+	entry.RentalId = &rental.Id
+
+	if !rental.Token.IsValidAt(entry.Timestamp) {
 		return nil, rentalErrors.ErrTrunkAccessDenied
 	}
 
@@ -237,33 +378,70 @@ func (o *operations) GetLockState(ctx context.Context, vin model.Vin, token mode
 func (o *operations) SetLockStateCustomerId(ctx context.Context, lockState model.LockState, vin model.Vin,
 	customerId model.CustomerId) error {
 
+	entry := o.newTrunkAccessLogEntry(vin, model.CUSTOMER, customerId, model.SETLOCKSTATE, &lockState)
+	err := o.setLockStateCustomerId(ctx, lockState, vin, customerId, &entry)
+	return o.recordTrunkAccess(ctx, "SetLockStateCustomerId", &entry, err)
+}
+
+func (o *operations) setLockStateCustomerId(ctx context.Context, lockState model.LockState, vin model.Vin,
+	customerId model.CustomerId, entry *model.TrunkAccessLogEntry) error {
+
 	rental, err := o.crud.GetNextRental(ctx, vin)
 	if err != nil {
 		return err
 	}
-	if rental == nil || rental.Customer.CustomerId != customerId || rental.State != model.ACTIVE {
+	if rental == nil || rental.Customer.CustomerId != customerId {
+		return rentalErrors.ErrTrunkAccessDenied
+	}
+	// only assign the attempt to the rental if it is the customer's own rental,
+	// otherwise the log of a rental would disclose other customers
+	entry.RentalId = &rental.Id
+	if rental.State != model.ACTIVE {
 		return rentalErrors.ErrTrunkAccessDenied
 	}
 
-	return o.setLockState(ctx, lockState, vin)
+	return o.setLockState(ctx, lockState, rental, entry.Timestamp)
 }
 
 func (o *operations) SetLockStateTrunkAccessToken(ctx context.Context, lockState model.LockState, vin model.Vin,
 	token model.TrunkAccessToken) error {
 
-	access, err := o.crud.GetTrunkAccess(ctx, vin, token)
+	entry := o.newTrunkAccessLogEntry(vin, model.TOKEN, tokenPrefix(token), model.SETLOCKSTATE, &lockState)
+	err := o.setLockStateTrunkAccessToken(ctx, lockState, vin, token, &entry)
+	return o.recordTrunkAccess(ctx, "SetLockStateTrunkAccessToken", &entry, err)
+}
+
+func (o *operations) setLockStateTrunkAccessToken(ctx context.Context, lockState model.LockState, vin model.Vin,
+	token model.TrunkAccessToken, entry *model.TrunkAccessLogEntry) error {
+
+	rental, err := o.crud.GetTrunkAccessRental(ctx, vin, token)
 	if err != nil {
 		return err
 	}
-	now := o.timeProvider.Now()
-	if access.ValidityPeriod.EndDate.Before(now) || access.ValidityPeriod.StartDate.After(now) {
+	entry.RentalId = &rental.Id
+
+	if !rental.Token.IsValidAt(entry.Timestamp) {
 		return rentalErrors.ErrTrunkAccessDenied
 	}
 
-	return o.setLockState(ctx, lockState, vin)
+	return o.setLockState(ctx, lockState, rental, entry.Timestamp)
 }
 
-func (o *operations) setLockState(ctx context.Context, lockState model.LockState, vin model.Vin) error {
+// setLockState changes the trunk lock state of the rented car and keeps track of the pending auto relock
+func (o *operations) setLockState(ctx context.Context, lockState model.LockState, rental *model.Rental,
+	now time.Time) error {
+
+	vin := rental.Car.Vin
+
+	// the relock job is persisted before unlocking so that it cannot get lost, the relock scheduler
+	// skips the job if the trunk has not been unlocked after all
+	if timeout := o.autoRelockTimeout(rental); lockState == model.UNLOCKED && timeout > 0 {
+		err := o.crud.ScheduleRelock(ctx, model.RelockJob{Vin: vin, RentalId: rental.Id, DueAt: now.Add(timeout)})
+		if err != nil {
+			return err
+		}
+	}
+
 	response, err := o.carClient.ChangeTrunkLockStateWithResponse(ctx, vin, carTypes.DynamicDataLockState(lockState))
 	if err != nil {
 		return err
@@ -271,5 +449,170 @@ func (o *operations) setLockState(ctx context.Context, lockState model.LockState
 	if response.HTTPResponse.StatusCode != http.StatusNoContent {
 		return rentalErrors.ErrDomainAssertion
 	}
+
+	if lockState == model.LOCKED {
+		return o.crud.CancelRelock(ctx, vin)
+	}
+	return nil
+}
+
+// autoRelockTimeout returns the auto relock timeout of the rental, falling back to the configured default
+func (o *operations) autoRelockTimeout(rental *model.Rental) time.Duration {
+	if rental.AutoRelock != nil {
+		return time.Duration(rental.AutoRelock.TimeoutSeconds) * time.Second
+	}
+	return o.config.GetTrunkAutoRelockTimeout()
+}
+
+func (o *operations) SetAutoRelock(ctx context.Context, rentalId model.RentalId, autoRelock model.AutoRelock) error {
+	if err := o.crud.SetAutoRelockTimeout(ctx, rentalId, autoRelock.TimeoutSeconds); err != nil {
+		return err
+	}
+	loggerOf(ctx, "SetAutoRelock", logging.KeyRentalId, rentalId).Info("auto relock set",
+		"timeoutSeconds", autoRelock.TimeoutSeconds)
+	return nil
+}
+
+func (o *operations) CancelRental(ctx context.Context, rentalId model.RentalId) error {
+	if err := o.crud.CancelRental(ctx, rentalId); err != nil {
+		return err
+	}
+	loggerOf(ctx, "CancelRental", logging.KeyRentalId, rentalId).Info("rental cancelled")
+	return nil
+}
+
+func (o *operations) GetTrunkAccessLogOfRental(ctx context.Context, rentalId model.RentalId) (
+	*[]model.TrunkAccessLogEntry, error) {
+
+	if _, err := o.crud.GetRental(ctx, rentalId); err != nil {
+		return nil, err
+	}
+	return o.crud.GetTrunkAccessLogOfRental(ctx, rentalId)
+}
+
+func (o *operations) GetTrunkAccessLogOfCar(ctx context.Context, vin model.Vin) (*[]model.TrunkAccessLogEntry, error) {
+	if err := o.ensureCarExists(ctx, vin); err != nil {
+		return nil, err
+	}
+	return o.crud.GetTrunkAccessLogOfCar(ctx, vin)
+}
+
+func (o *operations) RegisterWebhook(ctx context.Context, registration model.WebhookRegistration) (
+	*model.Webhook, error) {
+
+	webhook := model.Webhook{
+		Id:         util.GenerateRandomString(16),
+		Url:        registration.Url,
+		EventTypes: registration.EventTypes,
+		Secret:     registration.Secret,
+		CreatedAt:  o.timeProvider.Now(),
+	}
+
+	if err := o.crud.CreateWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+	loggerOf(ctx, "RegisterWebhook", logging.KeyWebhookId, webhook.Id).Info("webhook registered")
+	return &webhook, nil
+}
+
+func (o *operations) GetWebhooks(ctx context.Context) (*[]model.Webhook, error) {
+	return o.crud.GetWebhooks(ctx)
+}
+
+func (o *operations) DeleteWebhook(ctx context.Context, webhookId model.WebhookId) error {
+	if err := o.crud.DeleteWebhook(ctx, webhookId); err != nil {
+		return err
+	}
+	loggerOf(ctx, "DeleteWebhook", logging.KeyWebhookId, webhookId).Info("webhook deleted")
 	return nil
 }
+
+func (o *operations) GetWebhookDeliveries(ctx context.Context, webhookId model.WebhookId) (
+	*[]model.WebhookDelivery, error) {
+
+	if _, err := o.crud.GetWebhook(ctx, webhookId); err != nil {
+		return nil, err
+	}
+	return o.crud.GetWebhookDeliveries(ctx, webhookId)
+}
+
+func (o *operations) CreateCalendarToken(ctx context.Context, customerId model.CustomerId) (
+	model.CalendarToken, error) {
+
+	token := util.GenerateRandomString(32)
+	if err := o.crud.SetCalendarToken(ctx, customerId, token); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (o *operations) GetOverviewByCalendarToken(ctx context.Context, token model.CalendarToken) (
+	*[]model.Rental, error) {
+
+	customerId, err := o.crud.GetCalendarCustomer(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return o.GetOverview(ctx, customerId)
+}
+
+// newTrunkAccessLogEntry creates a trunk access log entry for an attempt happening now.
+// The outcome and the rental ID are filled in while the attempt is processed.
+func (o *operations) newTrunkAccessLogEntry(vin model.Vin, actorType model.TrunkAccessActorType, actor string,
+	action model.TrunkAccessAction, lockState *model.LockState) model.TrunkAccessLogEntry {
+
+	return model.TrunkAccessLogEntry{
+		Vin:       vin,
+		ActorType: actorType,
+		Actor:     actor,
+		Action:    action,
+		LockState: lockState,
+		Timestamp: o.timeProvider.Now(),
+	}
+}
+
+// recordTrunkAccess sets the outcome of the trunk access log entry based on the error of the attempt
+// and appends the entry to the trunk access log.
+// The error of the attempt is returned. The trunk has been accessed at this point, so an error writing the log does
+// not change the result, it is logged and counted instead so that the missing entry is noticed.
+func (o *operations) recordTrunkAccess(ctx context.Context, operation string, entry *model.TrunkAccessLogEntry,
+	err error) error {
+
+	logger := loggerOf(ctx, operation, logging.KeyVin, entry.Vin)
+	if entry.RentalId != nil {
+		logger = logger.With(logging.KeyRentalId, *entry.RentalId)
+	}
+
+	switch {
+	case err == nil:
+		entry.Outcome = model.GRANTED
+		logger.Info("trunk accessed", "action", entry.Action)
+	case errors.Is(err, rentalErrors.ErrTrunkAccessDenied):
+		entry.Outcome = model.DENIED
+		metrics.TrunkAccessDenials.Inc()
+		logger.Warn("trunk access denied", "action", entry.Action)
+	default:
+		entry.Outcome = model.FAILED
+	}
+
+	if logErr := o.crud.AddTrunkAccessLogEntry(ctx, *entry); logErr != nil {
+		metrics.TrunkAccessLogFailures.Inc()
+		logger.Error("recording the trunk access failed", logging.KeyError, logErr, "outcome", entry.Outcome)
+	}
+	return err
+}
+
+// loggerOf returns the logger of the context for an operation, which adds the name of the operation and the given
+// fields identifying its subject, e.g. the VIN or the rental ID, to every log line
+func loggerOf(ctx context.Context, operation string, args ...any) *slog.Logger {
+	return logging.FromContext(ctx).With(append([]any{logging.KeyOperation, operation}, args...)...)
+}
+
+// tokenPrefix returns the part of a trunk access token that is recorded in the trunk access log.
+// The full token is never recorded because anyone reading the log could use it otherwise.
+func tokenPrefix(token model.TrunkAccessToken) string {
+	if len(token) <= tokenPrefixLength {
+		return token
+	}
+	return token[:tokenPrefixLength]
+}
@@ -3,20 +3,42 @@ package operations
 import (
 	"RentalManagement/infrastructure/car"
 	"RentalManagement/infrastructure/database"
+	"RentalManagement/logging"
 	"RentalManagement/logic/model"
 	"RentalManagement/logic/rentalErrors"
+	"RentalManagement/metrics"
 	"RentalManagement/mocks"
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	carTypes "github.com/ccsapp/cargotypes"
 	openapiTypes "github.com/deepmap/oapi-codegen/pkg/types"
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/exp/slog"
 	"net/http"
 	"testing"
 	"time"
 )
 
+type TestOperationsConfig struct {
+	trunkAutoRelockTimeout time.Duration
+	carStateInterval       time.Duration
+}
+
+func (c *TestOperationsConfig) GetTrunkAutoRelockTimeout() time.Duration {
+	return c.trunkAutoRelockTimeout
+}
+
+func (c *TestOperationsConfig) GetCarStateInterval() time.Duration {
+	return c.carStateInterval
+}
+
+// config disables auto relock by default
+var config = &TestOperationsConfig{carStateInterval: 5 * time.Millisecond}
+
 var exampleCustomerID = "34tfewss"
 
 var timePeriod = model.TimePeriod{
@@ -32,6 +54,26 @@ var timePeriod2 = model.TimePeriod{
 const vin1 = "WVWAA71K08W201030"
 const vin2 = "1FVNY5Y90HP312888"
 
+const tokenPrefixCrud = "bumr"
+
+var lockStateLocked = model.LOCKED
+
+func newTrunkAccessLogEntry(actorType model.TrunkAccessActorType, actor string, action model.TrunkAccessAction,
+	lockState *model.LockState, rentalId *model.RentalId, outcome model.TrunkAccessOutcome,
+	timestamp time.Time) model.TrunkAccessLogEntry {
+
+	return model.TrunkAccessLogEntry{
+		Vin:       vin2,
+		RentalId:  rentalId,
+		ActorType: actorType,
+		Actor:     actor,
+		Action:    action,
+		LockState: lockState,
+		Outcome:   outcome,
+		Timestamp: timestamp,
+	}
+}
+
 var domainCar = carTypes.Car{
 	Brand: "Tesla",
 	DynamicData: carTypes.DynamicData{
@@ -97,6 +139,33 @@ var rentalCrud = model.Rental{
 	},
 }
 
+// 2023-03-02 is a Thursday
+var weekdayMornings = model.Recurrence{
+	DaysOfWeek: []model.Weekday{model.MONDAY, model.TUESDAY, model.WEDNESDAY, model.THURSDAY, model.FRIDAY},
+	StartTime:  "08:00",
+	EndTime:    "12:00",
+	TimeZone:   "UTC",
+}
+
+var rentalCrudRecurring = model.Rental{
+	State:    model.ACTIVE,
+	Car:      &model.Car{Vin: domainCar.Vin},
+	Customer: &model.Customer{CustomerId: exampleCustomerID},
+	Id:       "rZ6IIwcD",
+	RentalPeriod: model.TimePeriod{
+		EndDate:   time.Date(2023, 4, 2, 3, 0, 0, 0, time.UTC),
+		StartDate: time.Date(2023, 3, 1, 1, 0, 0, 0, time.UTC),
+	},
+	Token: &model.TrunkAccess{
+		Token: "bumrLuCMbumrLuCMbumrLuCM",
+		ValidityPeriod: model.TimePeriod{
+			EndDate:   time.Date(2023, 3, 31, 12, 0, 0, 0, time.UTC),
+			StartDate: time.Date(2023, 3, 1, 8, 0, 0, 0, time.UTC),
+		},
+		Recurrence: &weekdayMornings,
+	},
+}
+
 var rentalCrudExpired = model.Rental{
 	State:    model.EXPIRED,
 	Car:      &model.Car{Vin: domainCar.Vin},
@@ -351,7 +420,7 @@ func TestOperations_GetAvailableCars_unexpectedCarResponse(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	ret, err := operations.GetAvailableCars(ctx, timePeriod)
 
 	assert.ErrorIs(t, err, rentalErrors.ErrDomainAssertion)
@@ -374,7 +443,7 @@ func TestOperations_GetAvailableCars_crudError(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	ret, err := operations.GetAvailableCars(ctx, timePeriod)
 	assert.ErrorIs(t, err, crudError)
 	assert.Nil(t, ret)
@@ -395,7 +464,7 @@ func TestOperations_GetAvailableCars_success(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	ret, err := operations.GetAvailableCars(ctx, timePeriod)
 	assert.Nil(t, err)
 	assert.Equal(t, &[]model.CarAvailable{carAvailable}, ret)
@@ -413,11 +482,43 @@ func TestOperations_CreateRental_success(t *testing.T) {
 	mockCar.EXPECT().GetCarWithResponse(ctx, vin1).Return(&car.GetCarResponse{ParsedCar: &domainCar}, nil)
 	mockCrud.EXPECT().CreateRental(ctx, vin1, exampleCustomerID, timePeriod).Return(nil)
 
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	rentalsCreated := testutil.ToFloat64(metrics.RentalsCreated)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	err := operations.CreateRental(ctx, vin1, exampleCustomerID, timePeriod)
+	assert.Nil(t, err)
+	assert.Equal(t, rentalsCreated+1, testutil.ToFloat64(metrics.RentalsCreated))
+}
+
+func TestOperations_CreateRental_logged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	previousLogger := slog.Default()
+	defer slog.SetDefault(previousLogger)
+	buffer := &bytes.Buffer{}
+	slog.SetDefault(slog.New(slog.NewJSONHandler(buffer, nil)))
+	ctx := logging.WithRequestId(context.Background(), "8vV3nWq0LzQm5tYb2KcX")
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockCrud := mocks.NewMockICRUD(ctrl)
+
+	mockCar.EXPECT().GetCarWithResponse(ctx, vin1).Return(&car.GetCarResponse{ParsedCar: &domainCar}, nil)
+	mockCrud.EXPECT().CreateRental(ctx, vin1, exampleCustomerID, timePeriod).Return(nil)
+
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	err := operations.CreateRental(ctx, vin1, exampleCustomerID, timePeriod)
 	assert.Nil(t, err)
+
+	var line map[string]any
+	assert.Nil(t, json.Unmarshal(buffer.Bytes(), &line))
+	assert.Equal(t, "rental created", line["msg"])
+	assert.Equal(t, "8vV3nWq0LzQm5tYb2KcX", line[logging.KeyRequestId])
+	assert.Equal(t, "CreateRental", line[logging.KeyOperation])
+	assert.Equal(t, vin1, line[logging.KeyVin])
 }
 
 func TestOperations_CreateRental_unexpectedCarResponse(t *testing.T) {
@@ -437,7 +538,7 @@ func TestOperations_CreateRental_unexpectedCarResponse(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	err := operations.CreateRental(ctx, vin1, exampleCustomerID, timePeriod)
 	assert.ErrorIs(t, err, rentalErrors.ErrDomainAssertion)
 }
@@ -459,7 +560,7 @@ func TestOperations_CreateRental_carNotFound(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	err := operations.CreateRental(ctx, vin1, exampleCustomerID, timePeriod)
 	assert.ErrorIs(t, err, rentalErrors.ErrCarNotFound)
 }
@@ -477,10 +578,12 @@ func TestOperations_CreateRental_conflictingRentalExists(t *testing.T) {
 	mockCrud.EXPECT().CreateRental(ctx, vin1, exampleCustomerID, timePeriod).Return(rentalErrors.ErrConflictingRentalExists)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
+	rentalConflicts := testutil.ToFloat64(metrics.RentalConflicts)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	err := operations.CreateRental(ctx, vin1, exampleCustomerID, timePeriod)
 	assert.ErrorIs(t, err, rentalErrors.ErrConflictingRentalExists)
+	assert.Equal(t, rentalConflicts+1, testutil.ToFloat64(metrics.RentalConflicts))
 }
 
 func TestOperations_GetCar_success(t *testing.T) {
@@ -496,7 +599,7 @@ func TestOperations_GetCar_success(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	retCar, err := operations.GetCar(ctx, vin1)
 
 	assert.Nil(t, err)
@@ -520,7 +623,7 @@ func TestOperations_GetCar_unexpectedCarResponse(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	retCar, err := operations.GetCar(ctx, vin1)
 
 	assert.ErrorIs(t, err, rentalErrors.ErrDomainAssertion)
@@ -544,7 +647,7 @@ func TestOperations_GetCar_carNotFound(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	retCar, err := operations.GetCar(ctx, vin1)
 
 	assert.ErrorIs(t, err, rentalErrors.ErrCarNotFound)
@@ -565,7 +668,7 @@ func TestOperations_GetNextRental_success(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	retRental, err := operations.GetNextRental(ctx, vin1)
 
 	assert.Nil(t, err)
@@ -586,7 +689,7 @@ func TestOperations_GetNextRental_noRental(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	retRental, err := operations.GetNextRental(ctx, vin1)
 
 	assert.Nil(t, err)
@@ -608,7 +711,7 @@ func TestOperations_GetNextRental_crudError(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	retRental, err := operations.GetNextRental(ctx, vin1)
 
 	assert.ErrorIs(t, err, crudError)
@@ -632,7 +735,7 @@ func TestOperations_GetNextRental_carNotFound(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	retRental, err := operations.GetNextRental(ctx, vin1)
 
 	assert.ErrorIs(t, err, rentalErrors.ErrCarNotFound)
@@ -656,7 +759,7 @@ func TestOperations_GetNextRental_unexpectedCarResponse(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	retRental, err := operations.GetNextRental(ctx, vin1)
 
 	assert.ErrorIs(t, err, rentalErrors.ErrDomainAssertion)
@@ -677,7 +780,7 @@ func TestOperations_GetOverview_success(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	rentals, err := operations.GetOverview(ctx, exampleCustomerID)
 
 	assert.Nil(t, err)
@@ -698,7 +801,7 @@ func TestOperations_GetOverview_CrudError(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	rentals, err := operations.GetOverview(ctx, exampleCustomerID)
 
 	assert.ErrorIs(t, err, crudError)
@@ -721,7 +824,7 @@ func TestOperations_GetOverview_DomainError(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	rentals, err := operations.GetOverview(ctx, exampleCustomerID)
 
 	assert.ErrorIs(t, err, domainError)
@@ -746,7 +849,7 @@ func TestOperations_GetOverview_CarNotFound(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	rentals, err := operations.GetOverview(ctx, exampleCustomerID)
 
 	assert.ErrorIs(t, err, rentalErrors.ErrDomainAssertion)
@@ -771,7 +874,7 @@ func TestOperations_GetOverview_UnknownDomainResponse(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	rentals, err := operations.GetOverview(ctx, exampleCustomerID)
 
 	assert.ErrorIs(t, err, rentalErrors.ErrDomainAssertion)
@@ -792,7 +895,7 @@ func TestOperations_GetRentalStatus_success_active(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	rental, err := operations.GetRentalStatus(ctx, rentalCrud.Id)
 
 	assert.Nil(t, err)
@@ -813,7 +916,7 @@ func TestOperations_GetRentalStatus_success_Expired(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	rental, err := operations.GetRentalStatus(ctx, rentalCrud.Id)
 
 	assert.Nil(t, err)
@@ -834,7 +937,7 @@ func TestOperations_GetRentalStatus_success_Upcoming(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	rental, err := operations.GetRentalStatus(ctx, rentalCrud.Id)
 
 	assert.Nil(t, err)
@@ -855,7 +958,7 @@ func TestOperations_GetRentalStatus_crudError(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	rental, err := operations.GetRentalStatus(ctx, "rentalId")
 
 	assert.ErrorIs(t, err, crudError)
@@ -878,7 +981,7 @@ func TestOperations_GetRentalStatus_domainError(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	rental, err := operations.GetRentalStatus(ctx, rentalCustomerShort.Id)
 
 	assert.ErrorIs(t, err, domainError)
@@ -903,7 +1006,7 @@ func TestOperations_GetRentalStatus_carNotFound(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	rental, err := operations.GetRentalStatus(ctx, rentalCustomerShort.Id)
 
 	assert.ErrorIs(t, err, rentalErrors.ErrDomainAssertion)
@@ -928,13 +1031,50 @@ func TestOperations_GetRentalStatus_UnknownDomainResponse(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	rental, err := operations.GetRentalStatus(ctx, rentalCustomerShort.Id)
 
 	assert.ErrorIs(t, err, rentalErrors.ErrDomainAssertion)
 	assert.Nil(t, rental)
 }
 
+func TestOperations_GetRentalCustomer_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetRental(ctx, rentalCrud.Id).Return(&rentalCrud, nil)
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	customerId, err := operations.GetRentalCustomer(ctx, rentalCrud.Id)
+
+	assert.Nil(t, err)
+	assert.Equal(t, exampleCustomerID, customerId)
+}
+
+func TestOperations_GetRentalCustomer_rentalNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetRental(ctx, rentalCrud.Id).Return(nil, rentalErrors.ErrRentalNotFound)
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	_, err := operations.GetRentalCustomer(ctx, rentalCrud.Id)
+
+	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
+}
+
 func TestOperations_GrantTrunkAccess_success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -953,12 +1093,44 @@ func TestOperations_GrantTrunkAccess_success(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
-	trunkAccess, err := operations.GrantTrunkAccess(ctx, "rentalId", timePeriod)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	trunkAccess, err := operations.GrantTrunkAccess(ctx, "rentalId", timePeriod, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 24, len(trunkAccess.Token))
+	assert.Equal(t, timePeriod2, trunkAccess.ValidityPeriod)
+}
+
+func TestOperations_GrantTrunkAccess_success_recurring(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().SetTrunkToken(ctx, "rentalId", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, trunkAccess model.TrunkAccess) (*model.TrunkAccess, error) {
+			assert.Equal(t, timePeriod, trunkAccess.ValidityPeriod)
+			assert.Equal(t, &weekdayMornings, trunkAccess.Recurrence)
+			return &model.TrunkAccess{
+				Token:          trunkAccess.Token,
+				ValidityPeriod: timePeriod2,
+				Recurrence:     trunkAccess.Recurrence,
+			}, nil
+		},
+	)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	trunkAccess, err := operations.GrantTrunkAccess(ctx, "rentalId", timePeriod, &weekdayMornings)
 
 	assert.Nil(t, err)
 	assert.Equal(t, 24, len(trunkAccess.Token))
 	assert.Equal(t, timePeriod2, trunkAccess.ValidityPeriod)
+	assert.Equal(t, &weekdayMornings, trunkAccess.Recurrence)
 }
 
 func TestOperations_GrantTrunkAccess_unknownRental(t *testing.T) {
@@ -974,8 +1146,8 @@ func TestOperations_GrantTrunkAccess_unknownRental(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
-	trunkAccess, err := operations.GrantTrunkAccess(ctx, "rentalId", timePeriod)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	trunkAccess, err := operations.GrantTrunkAccess(ctx, "rentalId", timePeriod, nil)
 
 	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
 	assert.Nil(t, trunkAccess)
@@ -996,8 +1168,8 @@ func TestOperations_GrantTrunkAccess_unexpectedCrudError(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
-	trunkAccess, err := operations.GrantTrunkAccess(ctx, "rentalId", timePeriod)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	trunkAccess, err := operations.GrantTrunkAccess(ctx, "rentalId", timePeriod, nil)
 
 	assert.ErrorIs(t, err, crudError)
 	assert.Nil(t, trunkAccess)
@@ -1017,8 +1189,8 @@ func TestOperations_GrantTrunkAccess_rentalExpired(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
-	trunkAccess, err := operations.GrantTrunkAccess(ctx, "rentalId", timePeriod)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	trunkAccess, err := operations.GrantTrunkAccess(ctx, "rentalId", timePeriod, nil)
 
 	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotActive)
 	assert.Nil(t, trunkAccess)
@@ -1038,8 +1210,8 @@ func TestOperations_GrantTrunkAccess_rentalUpcoming(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
-	trunkAccess, err := operations.GrantTrunkAccess(ctx, "rentalId", timePeriod)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	trunkAccess, err := operations.GrantTrunkAccess(ctx, "rentalId", timePeriod, nil)
 
 	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotActive)
 	assert.Nil(t, trunkAccess)
@@ -1059,8 +1231,8 @@ func TestOperations_GrantTrunkAccess_rentalNotOverlapping(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
-	trunkAccess, err := operations.GrantTrunkAccess(ctx, "rentalId", timePeriod)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	trunkAccess, err := operations.GrantTrunkAccess(ctx, "rentalId", timePeriod, nil)
 
 	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotOverlapping)
 	assert.Nil(t, trunkAccess)
@@ -1080,8 +1252,8 @@ func TestOperations_GrantTrunkAccess_resourceConflict(t *testing.T) {
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
-	trunkAccess, err := operations.GrantTrunkAccess(ctx, "rentalId", timePeriod)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	trunkAccess, err := operations.GrantTrunkAccess(ctx, "rentalId", timePeriod, nil)
 
 	assert.ErrorIs(t, err, rentalErrors.ErrResourceConflict)
 	assert.Nil(t, trunkAccess)
@@ -1094,7 +1266,10 @@ func TestOperations_GetLockState_success(t *testing.T) {
 	ctx := context.Background()
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
-	mockCrud.EXPECT().GetTrunkAccess(ctx, vin2, rentalCrud.Token.Token).Return(rentalCrud.Token, nil)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrud.Token.Token).Return(&rentalCrud, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.GETLOCKSTATE, nil, &rentalCrud.Id, model.GRANTED,
+		time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
 	mockCar.EXPECT().GetCarWithResponse(ctx, vin2).Return(&car.GetCarResponse{ParsedCar: &domainCar}, nil)
@@ -1102,7 +1277,7 @@ func TestOperations_GetLockState_success(t *testing.T) {
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	lockState, err := operations.GetLockState(ctx, vin2, rentalCrud.Token.Token)
 	assert.Nil(t, err)
 	assert.Equal(t, model.LOCKED, *lockState)
@@ -1118,11 +1293,14 @@ func TestOperations_GetLockState_crudError(t *testing.T) {
 	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
-	mockCrud.EXPECT().GetTrunkAccess(ctx, vin2, rentalCrud.Token.Token).Return(nil, crudError)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrud.Token.Token).Return(nil, crudError)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.GETLOCKSTATE, nil, nil, model.FAILED, time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	lockState, err := operations.GetLockState(ctx, vin2, rentalCrud.Token.Token)
 
 	assert.ErrorIs(t, err, crudError)
@@ -1138,15 +1316,43 @@ func TestOperations_GetLockState_validInFutureTrunkAccessDeniedError(t *testing.
 	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
-	mockCrud.EXPECT().GetTrunkAccess(ctx, vin2, rentalCrudUpcoming.Token.Token).Return(rentalCrudUpcoming.Token, nil)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrudUpcoming.Token.Token).Return(&rentalCrudUpcoming, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.GETLOCKSTATE, nil, &rentalCrudUpcoming.Id, model.DENIED,
+		time.Date(1900, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 	mockTime.EXPECT().Now().Return(time.Date(1900, 3, 2, 5, 0, 0, 0, time.UTC))
+	trunkAccessDenials := testutil.ToFloat64(metrics.TrunkAccessDenials)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	lockState, err := operations.GetLockState(ctx, vin2, rentalCrud.Token.Token)
 	assert.Equal(t, rentalErrors.ErrTrunkAccessDenied, err)
 	assert.Nil(t, lockState)
+	assert.Equal(t, trunkAccessDenials+1, testutil.ToFloat64(metrics.TrunkAccessDenials))
+}
+
+func TestOperations_GetLockState_outsideRecurrenceTrunkAccessDeniedError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrudRecurring.Token.Token).Return(&rentalCrudRecurring, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.GETLOCKSTATE, nil, &rentalCrudRecurring.Id, model.DENIED,
+		time.Date(2023, 3, 2, 13, 0, 0, 0, time.UTC))).Return(nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 13, 0, 0, 0, time.UTC))
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	lockState, err := operations.GetLockState(ctx, vin2, rentalCrudRecurring.Token.Token)
+	assert.Equal(t, rentalErrors.ErrTrunkAccessDenied, err)
+	assert.Nil(t, lockState)
 }
 
 func TestOperations_GetLockState_validInPastTrunkAccessDeniedError(t *testing.T) {
@@ -1158,12 +1364,15 @@ func TestOperations_GetLockState_validInPastTrunkAccessDeniedError(t *testing.T)
 	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
-	mockCrud.EXPECT().GetTrunkAccess(ctx, vin2, rentalCrudExpired.Token.Token).Return(rentalCrudExpired.Token, nil)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrudExpired.Token.Token).Return(&rentalCrudExpired, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.GETLOCKSTATE, nil, &rentalCrudExpired.Id, model.DENIED,
+		time.Date(3000, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 	mockTime.EXPECT().Now().Return(time.Date(3000, 3, 2, 5, 0, 0, 0, time.UTC))
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	lockState, err := operations.GetLockState(ctx, vin2, rentalCrud.Token.Token)
 	assert.Equal(t, rentalErrors.ErrTrunkAccessDenied, err)
 	assert.Nil(t, lockState)
@@ -1183,12 +1392,14 @@ func TestOperations_GetLockState_unknownDomainResponse(t *testing.T) {
 	}, nil)
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
-	mockCrud.EXPECT().GetTrunkAccess(ctx, vin2, rentalCrud.Token.Token).Return(rentalCrud.Token, nil)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrud.Token.Token).Return(&rentalCrud, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.GETLOCKSTATE, nil, &rentalCrud.Id, model.FAILED, time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	lockState, err := operations.GetLockState(ctx, vin2, rentalCrud.Token.Token)
 
 	assert.ErrorIs(t, err, rentalErrors.ErrDomainAssertion)
@@ -1209,12 +1420,14 @@ func TestOperations_GetLockState_carNotFound(t *testing.T) {
 	}, nil)
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
-	mockCrud.EXPECT().GetTrunkAccess(ctx, vin2, rentalCrud.Token.Token).Return(rentalCrud.Token, nil)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrud.Token.Token).Return(&rentalCrud, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.GETLOCKSTATE, nil, &rentalCrud.Id, model.FAILED, time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	lockState, err := operations.GetLockState(ctx, vin2, rentalCrud.Token.Token)
 
 	assert.ErrorIs(t, err, rentalErrors.ErrDomainAssertion)
@@ -1233,12 +1446,14 @@ func TestOperations_GetLockState_domainError(t *testing.T) {
 	mockCar.EXPECT().GetCarWithResponse(ctx, vin2).Return(nil, domainError)
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
-	mockCrud.EXPECT().GetTrunkAccess(ctx, vin2, rentalCrud.Token.Token).Return(rentalCrud.Token, nil)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrud.Token.Token).Return(&rentalCrud, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.GETLOCKSTATE, nil, &rentalCrud.Id, model.FAILED, time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	lockState, err := operations.GetLockState(ctx, vin2, rentalCrud.Token.Token)
 
 	assert.ErrorIs(t, err, domainError)
@@ -1252,7 +1467,11 @@ func TestOperations_SetLockStateCustomerId_success(t *testing.T) {
 	ctx := context.Background()
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().CancelRelock(ctx, vin2).Return(nil)
 	mockCrud.EXPECT().GetNextRental(ctx, vin2).Return(&rentalCrud, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.CUSTOMER, exampleCustomerID,
+		model.SETLOCKSTATE, &lockStateLocked, &rentalCrud.Id, model.GRANTED,
+		time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
 	mockCar.EXPECT().ChangeTrunkLockStateWithResponse(ctx, vin2,
@@ -1263,8 +1482,9 @@ func TestOperations_SetLockStateCustomerId_success(t *testing.T) {
 	}, nil)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	err := operations.SetLockStateCustomerId(ctx, model.LOCKED, vin2, exampleCustomerID)
 	assert.Nil(t, err)
 }
@@ -1278,12 +1498,16 @@ func TestOperations_SetLockStateCustomerId_crudError(t *testing.T) {
 	mockCrud := mocks.NewMockICRUD(ctrl)
 	crudError := errors.New("crud error")
 	mockCrud.EXPECT().GetNextRental(ctx, vin2).Return(nil, crudError)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.CUSTOMER, exampleCustomerID,
+		model.SETLOCKSTATE, &lockStateLocked, nil, model.FAILED,
+		time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	err := operations.SetLockStateCustomerId(ctx, model.LOCKED, vin2, exampleCustomerID)
 	assert.ErrorIs(t, err, crudError)
 }
@@ -1296,12 +1520,16 @@ func TestOperations_SetLockStateCustomerId_upcomingRental(t *testing.T) {
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
 	mockCrud.EXPECT().GetNextRental(ctx, vin2).Return(&rentalCrudUpcoming, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.CUSTOMER, exampleCustomerID,
+		model.SETLOCKSTATE, &lockStateLocked, &rentalCrudUpcoming.Id, model.DENIED,
+		time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	err := operations.SetLockStateCustomerId(ctx, model.LOCKED, vin2, exampleCustomerID)
 	assert.ErrorIs(t, err, rentalErrors.ErrTrunkAccessDenied)
 }
@@ -1314,12 +1542,16 @@ func TestOperations_SetLockStateCustomerId_wrongCustomer(t *testing.T) {
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
 	mockCrud.EXPECT().GetNextRental(ctx, vin2).Return(&rentalCrud, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.CUSTOMER, "wrong customer",
+		model.SETLOCKSTATE, &lockStateLocked, nil, model.DENIED,
+		time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	err := operations.SetLockStateCustomerId(ctx, model.LOCKED, vin2, "wrong customer")
 	assert.ErrorIs(t, err, rentalErrors.ErrTrunkAccessDenied)
 }
@@ -1332,6 +1564,9 @@ func TestOperations_SetLockStateCustomerId_carNotFound(t *testing.T) {
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
 	mockCrud.EXPECT().GetNextRental(ctx, vin2).Return(&rentalCrud, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.CUSTOMER, exampleCustomerID,
+		model.SETLOCKSTATE, &lockStateLocked, &rentalCrud.Id, model.FAILED,
+		time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
 	mockCar.EXPECT().ChangeTrunkLockStateWithResponse(ctx, vin2,
@@ -1342,8 +1577,9 @@ func TestOperations_SetLockStateCustomerId_carNotFound(t *testing.T) {
 	}, nil)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	err := operations.SetLockStateCustomerId(ctx, model.LOCKED, vin2, exampleCustomerID)
 	assert.ErrorIs(t, err, rentalErrors.ErrDomainAssertion)
 }
@@ -1356,12 +1592,16 @@ func TestOperations_SetLockStateCustomerId_noActiveOrUpcomingRental(t *testing.T
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
 	mockCrud.EXPECT().GetNextRental(ctx, vin2).Return(nil, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.CUSTOMER, exampleCustomerID,
+		model.SETLOCKSTATE, &lockStateLocked, nil, model.DENIED,
+		time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	err := operations.SetLockStateCustomerId(ctx, model.LOCKED, vin2, exampleCustomerID)
 	assert.ErrorIs(t, err, rentalErrors.ErrTrunkAccessDenied)
 }
@@ -1374,6 +1614,9 @@ func TestOperations_SetLockStateCustomerId_domainError(t *testing.T) {
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
 	mockCrud.EXPECT().GetNextRental(ctx, vin2).Return(&rentalCrud, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.CUSTOMER, exampleCustomerID,
+		model.SETLOCKSTATE, &lockStateLocked, &rentalCrud.Id, model.FAILED,
+		time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
 	domainError := errors.New("domain error")
@@ -1381,8 +1624,9 @@ func TestOperations_SetLockStateCustomerId_domainError(t *testing.T) {
 		carTypes.DynamicDataLockState(model.LOCKED)).Return(nil, domainError)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	err := operations.SetLockStateCustomerId(ctx, model.LOCKED, vin2, exampleCustomerID)
 	assert.ErrorIs(t, err, domainError)
 }
@@ -1395,6 +1639,9 @@ func TestOperations_SetLockStateCustomerId_unknownDomainResponse(t *testing.T) {
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
 	mockCrud.EXPECT().GetNextRental(ctx, vin2).Return(&rentalCrud, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.CUSTOMER, exampleCustomerID,
+		model.SETLOCKSTATE, &lockStateLocked, &rentalCrud.Id, model.FAILED,
+		time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
 	mockCar.EXPECT().ChangeTrunkLockStateWithResponse(ctx, vin2,
@@ -1405,8 +1652,9 @@ func TestOperations_SetLockStateCustomerId_unknownDomainResponse(t *testing.T) {
 	}, nil)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	err := operations.SetLockStateCustomerId(ctx, model.LOCKED, vin2, exampleCustomerID)
 	assert.ErrorIs(t, err, rentalErrors.ErrDomainAssertion)
 }
@@ -1418,7 +1666,11 @@ func TestOperations_SetLockStateTrunkAccessToken_success(t *testing.T) {
 	ctx := context.Background()
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
-	mockCrud.EXPECT().GetTrunkAccess(ctx, vin2, rentalCrud.Token.Token).Return(rentalCrud.Token, nil)
+	mockCrud.EXPECT().CancelRelock(ctx, vin2).Return(nil)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrud.Token.Token).Return(&rentalCrud, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.SETLOCKSTATE, &lockStateLocked, &rentalCrud.Id, model.GRANTED,
+		time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
@@ -1431,7 +1683,7 @@ func TestOperations_SetLockStateTrunkAccessToken_success(t *testing.T) {
 		},
 	}, nil)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	err := operations.SetLockStateTrunkAccessToken(ctx, model.LOCKED, vin2, rentalCrud.Token.Token)
 	assert.Nil(t, err)
 }
@@ -1444,13 +1696,17 @@ func TestOperations_SetLockStateTrunkAccessToken_crudError(t *testing.T) {
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
 	crudError := errors.New("crud error")
-	mockCrud.EXPECT().GetTrunkAccess(ctx, vin2, rentalCrud.Token.Token).Return(nil, crudError)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrud.Token.Token).Return(nil, crudError)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.SETLOCKSTATE, &lockStateLocked, nil, model.FAILED,
+		time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	err := operations.SetLockStateTrunkAccessToken(ctx, model.LOCKED, vin2, rentalCrud.Token.Token)
 	assert.ErrorIs(t, err, crudError)
 }
@@ -1462,18 +1718,72 @@ func TestOperations_SetLockStateTrunkAccessToken_validInFutureTrunkAccess(t *tes
 	ctx := context.Background()
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
-	mockCrud.EXPECT().GetTrunkAccess(ctx, vin2, rentalCrudUpcoming.Token.Token).Return(rentalCrudUpcoming.Token, nil)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrudUpcoming.Token.Token).Return(&rentalCrudUpcoming, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.SETLOCKSTATE, &lockStateLocked, &rentalCrudUpcoming.Id, model.DENIED,
+		time.Date(1900, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 	mockTime.EXPECT().Now().Return(time.Date(1900, 3, 2, 5, 0, 0, 0, time.UTC))
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	err := operations.SetLockStateTrunkAccessToken(ctx, model.LOCKED, vin2, rentalCrud.Token.Token)
 	assert.ErrorIs(t, err, rentalErrors.ErrTrunkAccessDenied)
 }
 
+func TestOperations_SetLockStateTrunkAccessToken_success_recurring(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().CancelRelock(ctx, vin2).Return(nil)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrudRecurring.Token.Token).Return(&rentalCrudRecurring, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.SETLOCKSTATE, &lockStateLocked, &rentalCrudRecurring.Id, model.GRANTED,
+		time.Date(2023, 3, 2, 9, 0, 0, 0, time.UTC))).Return(nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 9, 0, 0, 0, time.UTC))
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockCar.EXPECT().ChangeTrunkLockStateWithResponse(ctx, vin2,
+		carTypes.DynamicDataLockState(model.LOCKED)).Return(&car.ChangeTrunkLockStateResponse{
+		HTTPResponse: &http.Response{
+			StatusCode: http.StatusNoContent,
+		},
+	}, nil)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	err := operations.SetLockStateTrunkAccessToken(ctx, model.LOCKED, vin2, rentalCrudRecurring.Token.Token)
+	assert.Nil(t, err)
+}
+
+func TestOperations_SetLockStateTrunkAccessToken_outsideRecurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrudRecurring.Token.Token).Return(&rentalCrudRecurring, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.SETLOCKSTATE, &lockStateLocked, &rentalCrudRecurring.Id, model.DENIED,
+		time.Date(2023, 3, 2, 13, 0, 0, 0, time.UTC))).Return(nil)
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 13, 0, 0, 0, time.UTC))
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	err := operations.SetLockStateTrunkAccessToken(ctx, model.LOCKED, vin2, rentalCrudRecurring.Token.Token)
+	assert.ErrorIs(t, err, rentalErrors.ErrTrunkAccessDenied)
+}
+
 func TestOperations_SetLockStateTrunkAccessToken_validInPastTrunkAccess(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1481,14 +1791,17 @@ func TestOperations_SetLockStateTrunkAccessToken_validInPastTrunkAccess(t *testi
 	ctx := context.Background()
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
-	mockCrud.EXPECT().GetTrunkAccess(ctx, vin2, rentalCrudExpired.Token.Token).Return(rentalCrudExpired.Token, nil)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrudExpired.Token.Token).Return(&rentalCrudExpired, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.SETLOCKSTATE, &lockStateLocked, &rentalCrudExpired.Id, model.DENIED,
+		time.Date(2100, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 	mockTime.EXPECT().Now().Return(time.Date(2100, 3, 2, 5, 0, 0, 0, time.UTC))
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	err := operations.SetLockStateTrunkAccessToken(ctx, model.LOCKED, vin2, rentalCrud.Token.Token)
 	assert.ErrorIs(t, err, rentalErrors.ErrTrunkAccessDenied)
 }
@@ -1500,7 +1813,10 @@ func TestOperations_SetLockStateTrunkAccessToken_carNotFound(t *testing.T) {
 	ctx := context.Background()
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
-	mockCrud.EXPECT().GetTrunkAccess(ctx, vin2, rentalCrud.Token.Token).Return(rentalCrud.Token, nil)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrud.Token.Token).Return(&rentalCrud, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.SETLOCKSTATE, &lockStateLocked, &rentalCrud.Id, model.FAILED,
+		time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
@@ -1513,7 +1829,7 @@ func TestOperations_SetLockStateTrunkAccessToken_carNotFound(t *testing.T) {
 		},
 	}, nil)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	err := operations.SetLockStateTrunkAccessToken(ctx, model.LOCKED, vin2, rentalCrud.Token.Token)
 	assert.ErrorIs(t, err, rentalErrors.ErrDomainAssertion)
 }
@@ -1525,7 +1841,10 @@ func TestOperations_SetLockStateTrunkAccessToken_domainError(t *testing.T) {
 	ctx := context.Background()
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
-	mockCrud.EXPECT().GetTrunkAccess(ctx, vin2, rentalCrud.Token.Token).Return(rentalCrud.Token, nil)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrud.Token.Token).Return(&rentalCrud, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.SETLOCKSTATE, &lockStateLocked, &rentalCrud.Id, model.FAILED,
+		time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
@@ -1535,7 +1854,7 @@ func TestOperations_SetLockStateTrunkAccessToken_domainError(t *testing.T) {
 	mockCar.EXPECT().ChangeTrunkLockStateWithResponse(ctx, vin2,
 		carTypes.DynamicDataLockState(model.LOCKED)).Return(nil, domainError)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	err := operations.SetLockStateTrunkAccessToken(ctx, model.LOCKED, vin2, rentalCrud.Token.Token)
 	assert.ErrorIs(t, err, domainError)
 }
@@ -1547,7 +1866,10 @@ func TestOperations_SetLockStateTrunkAccessToken_unknownDomainResponse(t *testin
 	ctx := context.Background()
 
 	mockCrud := mocks.NewMockICRUD(ctrl)
-	mockCrud.EXPECT().GetTrunkAccess(ctx, vin2, rentalCrud.Token.Token).Return(rentalCrud.Token, nil)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrud.Token.Token).Return(&rentalCrud, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.SETLOCKSTATE, &lockStateLocked, &rentalCrud.Id, model.FAILED,
+		time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(nil)
 
 	mockTime := mocks.NewMockITimeProvider(ctrl)
 	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
@@ -1560,7 +1882,822 @@ func TestOperations_SetLockStateTrunkAccessToken_unknownDomainResponse(t *testin
 		},
 	}, nil)
 
-	operations := NewOperations(mockCar, mockCrud, mockTime)
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
 	err := operations.SetLockStateTrunkAccessToken(ctx, model.LOCKED, vin2, rentalCrud.Token.Token)
 	assert.ErrorIs(t, err, rentalErrors.ErrDomainAssertion)
 }
+
+func TestOperations_SetLockStateTrunkAccessToken_logError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	logError := errors.New("log error")
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().CancelRelock(ctx, vin2).Return(nil)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrud.Token.Token).Return(&rentalCrud, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.SETLOCKSTATE, &lockStateLocked, &rentalCrud.Id, model.GRANTED,
+		time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(logError)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockCar.EXPECT().ChangeTrunkLockStateWithResponse(ctx, vin2,
+		carTypes.DynamicDataLockState(model.LOCKED)).Return(&car.ChangeTrunkLockStateResponse{
+		HTTPResponse: &http.Response{
+			StatusCode: http.StatusNoContent,
+		},
+	}, nil)
+
+	trunkAccessLogFailures := testutil.ToFloat64(metrics.TrunkAccessLogFailures)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	err := operations.SetLockStateTrunkAccessToken(ctx, model.LOCKED, vin2, rentalCrud.Token.Token)
+
+	// the trunk has been locked, so the failed log write is only counted
+	assert.Nil(t, err)
+	assert.Equal(t, trunkAccessLogFailures+1, testutil.ToFloat64(metrics.TrunkAccessLogFailures))
+}
+
+func TestOperations_GetLockState_logErrorAfterDenial(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	logError := errors.New("log error")
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrud.Token.Token).Return(nil,
+		rentalErrors.ErrTrunkAccessDenied)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.GETLOCKSTATE, nil, nil, model.DENIED,
+		time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))).Return(logError)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC))
+
+	trunkAccessLogFailures := testutil.ToFloat64(metrics.TrunkAccessLogFailures)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	lockState, err := operations.GetLockState(ctx, vin2, rentalCrud.Token.Token)
+
+	assert.ErrorIs(t, err, rentalErrors.ErrTrunkAccessDenied)
+	assert.Nil(t, lockState)
+	assert.Equal(t, trunkAccessLogFailures+1, testutil.ToFloat64(metrics.TrunkAccessLogFailures))
+}
+
+func TestOperations_GetTrunkAccessLogOfRental_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	logEntries := []model.TrunkAccessLogEntry{
+		newTrunkAccessLogEntry(model.CUSTOMER, exampleCustomerID, model.SETLOCKSTATE, &lockStateLocked,
+			&rentalCrud.Id, model.GRANTED, time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC)),
+	}
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetRental(ctx, rentalCrud.Id).Return(&rentalCrud, nil)
+	mockCrud.EXPECT().GetTrunkAccessLogOfRental(ctx, rentalCrud.Id).Return(&logEntries, nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	returnedEntries, err := operations.GetTrunkAccessLogOfRental(ctx, rentalCrud.Id)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &logEntries, returnedEntries)
+}
+
+func TestOperations_GetTrunkAccessLogOfRental_rentalNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetRental(ctx, "rentalId").Return(nil, rentalErrors.ErrRentalNotFound)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	returnedEntries, err := operations.GetTrunkAccessLogOfRental(ctx, "rentalId")
+
+	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
+	assert.Nil(t, returnedEntries)
+}
+
+func TestOperations_GetTrunkAccessLogOfCar_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	logEntries := []model.TrunkAccessLogEntry{
+		newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud, model.GETLOCKSTATE, nil,
+			nil, model.DENIED, time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC)),
+	}
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockCar.EXPECT().GetCarWithResponse(ctx, vin2).Return(&car.GetCarResponse{ParsedCar: &domainCar}, nil)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetTrunkAccessLogOfCar(ctx, vin2).Return(&logEntries, nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	returnedEntries, err := operations.GetTrunkAccessLogOfCar(ctx, vin2)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &logEntries, returnedEntries)
+}
+
+func TestOperations_GetTrunkAccessLogOfCar_carNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockCar.EXPECT().GetCarWithResponse(ctx, vin1).Return(&car.GetCarResponse{
+		HTTPResponse: &http.Response{
+			StatusCode: http.StatusNotFound,
+		},
+	}, nil)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	returnedEntries, err := operations.GetTrunkAccessLogOfCar(ctx, vin1)
+
+	assert.ErrorIs(t, err, rentalErrors.ErrCarNotFound)
+	assert.Nil(t, returnedEntries)
+}
+
+var lockStateUnlocked = model.UNLOCKED
+
+func TestOperations_SetLockStateTrunkAccessToken_unlock_schedulesRelock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	now := time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrud.Token.Token).Return(&rentalCrud, nil)
+	mockCrud.EXPECT().ScheduleRelock(ctx, model.RelockJob{
+		Vin:      vin2,
+		RentalId: rentalCrud.Id,
+		DueAt:    now.Add(10 * time.Minute),
+	}).Return(nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.SETLOCKSTATE, &lockStateUnlocked, &rentalCrud.Id, model.GRANTED, now)).Return(nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockCar.EXPECT().ChangeTrunkLockStateWithResponse(ctx, vin2,
+		carTypes.DynamicDataLockState(model.UNLOCKED)).Return(&car.ChangeTrunkLockStateResponse{
+		HTTPResponse: &http.Response{
+			StatusCode: http.StatusNoContent,
+		},
+	}, nil)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime,
+		&TestOperationsConfig{trunkAutoRelockTimeout: 10 * time.Minute})
+	err := operations.SetLockStateTrunkAccessToken(ctx, model.UNLOCKED, vin2, rentalCrud.Token.Token)
+	assert.Nil(t, err)
+}
+
+func TestOperations_SetLockStateCustomerId_unlock_rentalTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	now := time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC)
+
+	rental := rentalCrud
+	rental.AutoRelock = &model.AutoRelock{TimeoutSeconds: 90}
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetNextRental(ctx, vin2).Return(&rental, nil)
+	mockCrud.EXPECT().ScheduleRelock(ctx, model.RelockJob{
+		Vin:      vin2,
+		RentalId: rental.Id,
+		DueAt:    now.Add(90 * time.Second),
+	}).Return(nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.CUSTOMER, exampleCustomerID,
+		model.SETLOCKSTATE, &lockStateUnlocked, &rental.Id, model.GRANTED, now)).Return(nil)
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockCar.EXPECT().ChangeTrunkLockStateWithResponse(ctx, vin2,
+		carTypes.DynamicDataLockState(model.UNLOCKED)).Return(&car.ChangeTrunkLockStateResponse{
+		HTTPResponse: &http.Response{
+			StatusCode: http.StatusNoContent,
+		},
+	}, nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime,
+		&TestOperationsConfig{trunkAutoRelockTimeout: 10 * time.Minute})
+	err := operations.SetLockStateCustomerId(ctx, model.UNLOCKED, vin2, exampleCustomerID)
+	assert.Nil(t, err)
+}
+
+func TestOperations_SetLockStateCustomerId_unlock_autoRelockDisabledForRental(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	now := time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC)
+
+	rental := rentalCrud
+	rental.AutoRelock = &model.AutoRelock{TimeoutSeconds: 0}
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetNextRental(ctx, vin2).Return(&rental, nil)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.CUSTOMER, exampleCustomerID,
+		model.SETLOCKSTATE, &lockStateUnlocked, &rental.Id, model.GRANTED, now)).Return(nil)
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockCar.EXPECT().ChangeTrunkLockStateWithResponse(ctx, vin2,
+		carTypes.DynamicDataLockState(model.UNLOCKED)).Return(&car.ChangeTrunkLockStateResponse{
+		HTTPResponse: &http.Response{
+			StatusCode: http.StatusNoContent,
+		},
+	}, nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime,
+		&TestOperationsConfig{trunkAutoRelockTimeout: 10 * time.Minute})
+	err := operations.SetLockStateCustomerId(ctx, model.UNLOCKED, vin2, exampleCustomerID)
+	assert.Nil(t, err)
+}
+
+func TestOperations_SetLockStateTrunkAccessToken_unlock_scheduleError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	now := time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC)
+	crudError := errors.New("crud error")
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetTrunkAccessRental(ctx, vin2, rentalCrud.Token.Token).Return(&rentalCrud, nil)
+	mockCrud.EXPECT().ScheduleRelock(ctx, gomock.Any()).Return(crudError)
+	mockCrud.EXPECT().AddTrunkAccessLogEntry(ctx, newTrunkAccessLogEntry(model.TOKEN, tokenPrefixCrud,
+		model.SETLOCKSTATE, &lockStateUnlocked, &rentalCrud.Id, model.FAILED, now)).Return(nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	// the trunk must not be unlocked if the relock job could not be stored
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime,
+		&TestOperationsConfig{trunkAutoRelockTimeout: 10 * time.Minute})
+	err := operations.SetLockStateTrunkAccessToken(ctx, model.UNLOCKED, vin2, rentalCrud.Token.Token)
+	assert.ErrorIs(t, err, crudError)
+}
+
+func TestOperations_SetAutoRelock_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().SetAutoRelockTimeout(ctx, rentalCrud.Id, 120).Return(nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	err := operations.SetAutoRelock(ctx, rentalCrud.Id, model.AutoRelock{TimeoutSeconds: 120})
+	assert.Nil(t, err)
+}
+
+func TestOperations_SetAutoRelock_rentalNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().SetAutoRelockTimeout(ctx, rentalCrud.Id, 120).Return(rentalErrors.ErrRentalNotFound)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	err := operations.SetAutoRelock(ctx, rentalCrud.Id, model.AutoRelock{TimeoutSeconds: 120})
+	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
+}
+
+func TestOperations_CancelRental_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().CancelRental(ctx, rentalCrud.Id).Return(nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	err := operations.CancelRental(ctx, rentalCrud.Id)
+	assert.Nil(t, err)
+}
+
+func TestOperations_CancelRental_notUpcoming(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().CancelRental(ctx, rentalCrud.Id).Return(rentalErrors.ErrRentalNotUpcoming)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	err := operations.CancelRental(ctx, rentalCrud.Id)
+	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotUpcoming)
+}
+
+var webhook = model.Webhook{
+	Id:         "Ck3XlmXtlq0xGpH9",
+	Url:        "https://billing.example.com/hooks/rentals",
+	EventTypes: []model.RentalEventType{model.RentalCreated},
+	Secret:     "whsec-0123456789abcdef",
+	CreatedAt:  time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC),
+}
+
+func TestOperations_RegisterWebhook_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(webhook.CreatedAt)
+
+	var createdWebhook model.Webhook
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().CreateWebhook(ctx, gomock.Any()).
+		Do(func(_ context.Context, created model.Webhook) {
+			createdWebhook = created
+		}).
+		Return(nil)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	registeredWebhook, err := operations.RegisterWebhook(ctx, model.WebhookRegistration{
+		Url:        webhook.Url,
+		EventTypes: webhook.EventTypes,
+		Secret:     webhook.Secret,
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, registeredWebhook.Id, 16)
+	assert.Equal(t, createdWebhook, *registeredWebhook)
+
+	expectedWebhook := webhook
+	expectedWebhook.Id = registeredWebhook.Id
+	assert.Equal(t, expectedWebhook, *registeredWebhook)
+}
+
+func TestOperations_RegisterWebhook_databaseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	dbError := errors.New("db error")
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(webhook.CreatedAt)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().CreateWebhook(ctx, gomock.Any()).Return(dbError)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	registeredWebhook, err := operations.RegisterWebhook(ctx, model.WebhookRegistration{
+		Url:        webhook.Url,
+		EventTypes: webhook.EventTypes,
+		Secret:     webhook.Secret,
+	})
+
+	assert.ErrorIs(t, err, dbError)
+	assert.Nil(t, registeredWebhook)
+}
+
+func TestOperations_GetWebhookDeliveries_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	deliveries := []model.WebhookDelivery{
+		{
+			Id:        "Xo0kGm4GUtbN2r3h" + webhook.Id,
+			WebhookId: webhook.Id,
+			Event:     model.RentalEvent{EventId: "Xo0kGm4GUtbN2r3h", Type: model.RentalCreated},
+			State:     model.DELIVERED,
+			Attempts:  1,
+			CreatedAt: webhook.CreatedAt,
+		},
+	}
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetWebhook(ctx, webhook.Id).Return(&webhook, nil)
+	mockCrud.EXPECT().GetWebhookDeliveries(ctx, webhook.Id).Return(&deliveries, nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	returnedDeliveries, err := operations.GetWebhookDeliveries(ctx, webhook.Id)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &deliveries, returnedDeliveries)
+}
+
+func TestOperations_GetWebhookDeliveries_webhookNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetWebhook(ctx, webhook.Id).Return(nil, rentalErrors.ErrWebhookNotFound)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	returnedDeliveries, err := operations.GetWebhookDeliveries(ctx, webhook.Id)
+
+	assert.ErrorIs(t, err, rentalErrors.ErrWebhookNotFound)
+	assert.Nil(t, returnedDeliveries)
+}
+
+// watchedRental returns an active rental of the domain car that expires after the given duration from now
+func watchedRental(now time.Time, expiresIn time.Duration) *model.Rental {
+	rental := rentalCrud
+	rental.RentalPeriod = model.TimePeriod{StartDate: now.Add(-time.Hour), EndDate: now.Add(expiresIn)}
+	return &rental
+}
+
+// receiveState waits for the next state on the channel, ok is false if the channel has been closed
+func receiveState(t *testing.T, states <-chan model.DynamicData) (state model.DynamicData, ok bool) {
+	select {
+	case state, ok = <-states:
+		return state, ok
+	case <-time.After(time.Second):
+		t.Fatal("channel neither sent a state nor was closed")
+		return state, false
+	}
+}
+
+func TestOperations_WatchCarState_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	now := time.Date(2023, 3, 10, 5, 0, 0, 0, time.UTC)
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockCar.EXPECT().GetCarWithResponse(gomock.Any(), vin2).
+		Return(&car.GetCarResponse{ParsedCar: &domainCar}, nil).
+		AnyTimes()
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	// the rental is read again before the state is sent
+	mockCrud.EXPECT().GetRental(ctx, rentalCrud.Id).Return(watchedRental(now, time.Hour), nil).Times(2)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	states, err := operations.WatchCarState(ctx, rentalCrud.Id)
+	assert.Nil(t, err)
+
+	state, ok := receiveState(t, states)
+	assert.True(t, ok)
+	assert.Equal(t, *car.MapToCar(&domainCar).DynamicData, state)
+
+	// the stream ends with the request
+	cancel()
+	_, ok = receiveState(t, states)
+	assert.False(t, ok)
+}
+
+func TestOperations_WatchCarState_rentalExpires(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	now := time.Date(2023, 3, 10, 5, 0, 0, 0, time.UTC)
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockCar.EXPECT().GetCarWithResponse(gomock.Any(), vin2).
+		Return(&car.GetCarResponse{ParsedCar: &domainCar}, nil).
+		AnyTimes()
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetRental(ctx, rentalCrud.Id).Return(watchedRental(now, 50*time.Millisecond), nil).
+		MinTimes(1)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	states, err := operations.WatchCarState(ctx, rentalCrud.Id)
+	assert.Nil(t, err)
+
+	// the state is sent until the rental expires, then the channel is closed
+	for ok := true; ok; {
+		_, ok = receiveState(t, states)
+	}
+}
+
+func TestOperations_WatchCarState_rentalCancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	now := time.Date(2023, 3, 10, 5, 0, 0, 0, time.UTC)
+	cancelledRental := watchedRental(now, time.Hour)
+	cancelledRental.State = model.CANCELLED
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockCar.EXPECT().GetCarWithResponse(gomock.Any(), vin2).
+		Return(&car.GetCarResponse{ParsedCar: &domainCar}, nil).
+		AnyTimes()
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	gomock.InOrder(
+		mockCrud.EXPECT().GetRental(ctx, rentalCrud.Id).Return(watchedRental(now, time.Hour), nil),
+		mockCrud.EXPECT().GetRental(ctx, rentalCrud.Id).Return(cancelledRental, nil),
+	)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	states, err := operations.WatchCarState(ctx, rentalCrud.Id)
+	assert.Nil(t, err)
+
+	// the rental has been cancelled before the state is sent, so the channel is closed long before the rental ends
+	_, ok := receiveState(t, states)
+	assert.False(t, ok)
+}
+
+func TestOperations_WatchCarState_databaseErrorOnUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	now := time.Date(2023, 3, 10, 5, 0, 0, 0, time.UTC)
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockCar.EXPECT().GetCarWithResponse(gomock.Any(), vin2).
+		Return(&car.GetCarResponse{ParsedCar: &domainCar}, nil).
+		AnyTimes()
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	gomock.InOrder(
+		mockCrud.EXPECT().GetRental(ctx, rentalCrud.Id).Return(watchedRental(now, time.Hour), nil),
+		mockCrud.EXPECT().GetRental(ctx, rentalCrud.Id).Return(nil, errors.New("db error")),
+	)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	states, err := operations.WatchCarState(ctx, rentalCrud.Id)
+	assert.Nil(t, err)
+
+	// the stream is not ended because the rental could not be read again
+	state, ok := receiveState(t, states)
+	assert.True(t, ok)
+	assert.Equal(t, *car.MapToCar(&domainCar).DynamicData, state)
+}
+
+func TestOperations_WatchCarState_rentalNotActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	upcomingRental := rentalCrud
+	upcomingRental.State = model.UPCOMING
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetRental(ctx, rentalCrud.Id).Return(&upcomingRental, nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	states, err := operations.WatchCarState(ctx, rentalCrud.Id)
+
+	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotActive)
+	assert.Nil(t, states)
+}
+
+func TestOperations_WatchCarState_rentalNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetRental(ctx, rentalCrud.Id).Return(nil, rentalErrors.ErrRentalNotFound)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	states, err := operations.WatchCarState(ctx, rentalCrud.Id)
+
+	assert.ErrorIs(t, err, rentalErrors.ErrRentalNotFound)
+	assert.Nil(t, states)
+}
+
+func TestOperations_CreateCalendarToken_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	var storedToken model.CalendarToken
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().SetCalendarToken(ctx, exampleCustomerID, gomock.Any()).
+		Do(func(_ context.Context, _ model.CustomerId, token model.CalendarToken) {
+			storedToken = token
+		}).
+		Return(nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	token, err := operations.CreateCalendarToken(ctx, exampleCustomerID)
+
+	assert.Nil(t, err)
+	assert.Len(t, token, 32)
+	assert.Equal(t, storedToken, token)
+}
+
+func TestOperations_CreateCalendarToken_databaseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	dbError := errors.New("db error")
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().SetCalendarToken(ctx, exampleCustomerID, gomock.Any()).Return(dbError)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	token, err := operations.CreateCalendarToken(ctx, exampleCustomerID)
+
+	assert.ErrorIs(t, err, dbError)
+	assert.Empty(t, token)
+}
+
+func TestOperations_GetRentalsInPeriod_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	rentalOtherCar := rentalCrud
+	rentalOtherCar.Car = &model.Car{Vin: vin1}
+	rentalOtherCar.Id = "jJ8mNg6Z"
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetRentalsInPeriod(ctx, timePeriod).
+		Return(&[]model.Rental{rentalOtherCar, rentalCrud, rentalCrudExpired}, nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	rentals, err := operations.GetRentalsInPeriod(ctx, timePeriod)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &[]model.Rental{
+		rentalCrudExpired.ToRentalBackOffice(),
+		rentalCrud.ToRentalBackOffice(),
+		rentalOtherCar.ToRentalBackOffice(),
+	}, rentals)
+	for _, rental := range *rentals {
+		assert.Nil(t, rental.Token)
+	}
+}
+
+func TestOperations_GetRentalsInPeriod_databaseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	dbError := errors.New("db error")
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetRentalsInPeriod(ctx, timePeriod).Return(nil, dbError)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	rentals, err := operations.GetRentalsInPeriod(ctx, timePeriod)
+
+	assert.ErrorIs(t, err, dbError)
+	assert.Nil(t, rentals)
+}
+
+func TestOperations_GetOverviewByCalendarToken_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	token := "Qm7Ax0tWbLk2ZsPfR4uNcYe9JhVd3oGi"
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockCar.EXPECT().GetCarWithResponse(ctx, vin2).Return(&car.GetCarResponse{ParsedCar: &domainCar}, nil)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetCalendarCustomer(ctx, token).Return(exampleCustomerID, nil)
+	mockCrud.EXPECT().GetRentalsOfCustomer(ctx, exampleCustomerID).Return(&[]model.Rental{rentalCrud}, nil)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	rentals, err := operations.GetOverviewByCalendarToken(ctx, token)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &[]model.Rental{rentalCustomerShort}, rentals)
+}
+
+func TestOperations_GetOverviewByCalendarToken_unknownToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	token := "Qm7Ax0tWbLk2ZsPfR4uNcYe9JhVd3oGi"
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetCalendarCustomer(ctx, token).Return("", rentalErrors.ErrCalendarNotFound)
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+
+	operations := NewOperations(mockCar, mockCrud, mockTime, config)
+	rentals, err := operations.GetOverviewByCalendarToken(ctx, token)
+
+	assert.ErrorIs(t, err, rentalErrors.ErrCalendarNotFound)
+	assert.Nil(t, rentals)
+}
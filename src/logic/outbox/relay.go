@@ -0,0 +1,71 @@
+// Package outbox publishes the rental events that are written along with the changes they report.
+package outbox
+
+import (
+	"RentalManagement/infrastructure/broker"
+	"RentalManagement/infrastructure/database"
+	"RentalManagement/logging"
+	"context"
+	"fmt"
+	"time"
+)
+
+// Relay moves the pending rental events from the outbox to the message broker
+type Relay struct {
+	broker broker.Broker
+	crud   database.ICRUD
+}
+
+func NewRelay(broker broker.Broker, crud database.ICRUD) *Relay {
+	return &Relay{
+		broker: broker,
+		crud:   crud,
+	}
+}
+
+// Run publishes the pending events once per interval until the context is done. Errors are logged, the affected
+// events are retried in the next round. As the events are persisted, events of changes made during a downtime of the
+// broker are published as soon as it is available again.
+func (r *Relay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.PublishPending(ctx); err != nil {
+			logging.FromContext(ctx).Error("publishing events failed", logging.KeyError, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PublishPending moves the pending events of the cars to the outbox, then publishes the events in the outbox in the
+// order they occurred and removes them from it.
+// It stops at the first event that cannot be published so that the events are not reordered.
+// An event is removed after it has been published, so if the removal fails, it is published again in the next
+// round. Thus, events are delivered at least once.
+func (r *Relay) PublishPending(ctx context.Context) error {
+	if err := r.crud.MoveRentalEventsToOutbox(ctx); err != nil {
+		return err
+	}
+
+	events, err := r.crud.GetPendingRentalEvents(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range *events {
+		if err := r.broker.Publish(ctx, event); err != nil {
+			return fmt.Errorf("publishing event %s failed: %w", event.EventId, err)
+		}
+		if err := r.crud.RemoveRentalEvent(ctx, event.EventId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package outbox
+
+import (
+	"RentalManagement/logic/model"
+	"RentalManagement/mocks"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var occurredAt = time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC)
+
+var event1 = model.RentalEvent{
+	EventId:    "Xo0kGm4GUtbN2r3h",
+	Type:       model.RentalCreated,
+	OccurredAt: occurredAt,
+	RentalId:   "rZ6IIwcD",
+	Vin:        "WVWAA71K08W201030",
+	CustomerId: "d9COw9vI",
+	RentalPeriod: &model.TimePeriod{
+		StartDate: occurredAt.Add(time.Hour),
+		EndDate:   occurredAt.Add(2 * time.Hour),
+	},
+}
+
+var event2 = model.RentalEvent{
+	EventId:    "9QmC1bq5VfJ2oTnE",
+	Type:       model.RentalChanged,
+	OccurredAt: occurredAt.Add(time.Minute),
+	RentalId:   "rZ6IIwcD",
+	Vin:        "WVWAA71K08W201030",
+	CustomerId: "d9COw9vI",
+	AutoRelock: &model.AutoRelock{TimeoutSeconds: 300},
+}
+
+func TestRelay_PublishPending_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockBroker := mocks.NewMockBroker(ctrl)
+	gomock.InOrder(
+		mockCrud.EXPECT().MoveRentalEventsToOutbox(ctx).Return(nil),
+		mockCrud.EXPECT().GetPendingRentalEvents(ctx).Return(&[]model.RentalEvent{event1, event2}, nil),
+		mockBroker.EXPECT().Publish(ctx, event1).Return(nil),
+		mockCrud.EXPECT().RemoveRentalEvent(ctx, event1.EventId).Return(nil),
+		mockBroker.EXPECT().Publish(ctx, event2).Return(nil),
+		mockCrud.EXPECT().RemoveRentalEvent(ctx, event2.EventId).Return(nil),
+	)
+
+	relay := NewRelay(mockBroker, mockCrud)
+	err := relay.PublishPending(ctx)
+
+	assert.Nil(t, err)
+}
+
+func TestRelay_PublishPending_noEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().MoveRentalEventsToOutbox(ctx).Return(nil)
+	mockCrud.EXPECT().GetPendingRentalEvents(ctx).Return(&[]model.RentalEvent{}, nil)
+
+	mockBroker := mocks.NewMockBroker(ctrl)
+
+	relay := NewRelay(mockBroker, mockCrud)
+	err := relay.PublishPending(ctx)
+
+	assert.Nil(t, err)
+}
+
+func TestRelay_PublishPending_databaseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	dbError := errors.New("db error")
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().MoveRentalEventsToOutbox(ctx).Return(nil)
+	mockCrud.EXPECT().GetPendingRentalEvents(ctx).Return(nil, dbError)
+
+	mockBroker := mocks.NewMockBroker(ctrl)
+
+	relay := NewRelay(mockBroker, mockCrud)
+	err := relay.PublishPending(ctx)
+
+	assert.ErrorIs(t, err, dbError)
+}
+
+func TestRelay_PublishPending_moveError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	dbError := errors.New("db error")
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().MoveRentalEventsToOutbox(ctx).Return(dbError)
+
+	mockBroker := mocks.NewMockBroker(ctrl)
+
+	relay := NewRelay(mockBroker, mockCrud)
+	err := relay.PublishPending(ctx)
+
+	assert.ErrorIs(t, err, dbError)
+}
+
+func TestRelay_PublishPending_brokerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	brokerError := errors.New("broker error")
+
+	// the first event stays in the outbox and the second one is not published before it
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().MoveRentalEventsToOutbox(ctx).Return(nil)
+	mockCrud.EXPECT().GetPendingRentalEvents(ctx).Return(&[]model.RentalEvent{event1, event2}, nil)
+
+	mockBroker := mocks.NewMockBroker(ctrl)
+	mockBroker.EXPECT().Publish(ctx, event1).Return(brokerError)
+
+	relay := NewRelay(mockBroker, mockCrud)
+	err := relay.PublishPending(ctx)
+
+	assert.ErrorIs(t, err, brokerError)
+}
+
+func TestRelay_PublishPending_removalError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	dbError := errors.New("db error")
+
+	// the published event stays in the outbox, so it is published again in the next round
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().MoveRentalEventsToOutbox(ctx).Return(nil)
+	mockCrud.EXPECT().GetPendingRentalEvents(ctx).Return(&[]model.RentalEvent{event1, event2}, nil)
+	mockCrud.EXPECT().RemoveRentalEvent(ctx, event1.EventId).Return(dbError)
+
+	mockBroker := mocks.NewMockBroker(ctrl)
+	mockBroker.EXPECT().Publish(ctx, event1).Return(nil)
+
+	relay := NewRelay(mockBroker, mockCrud)
+	err := relay.PublishPending(ctx)
+
+	assert.ErrorIs(t, err, dbError)
+}
+
+func TestRelay_Run_stopsWhenContextIsDone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().MoveRentalEventsToOutbox(ctx).Return(nil)
+	mockCrud.EXPECT().GetPendingRentalEvents(ctx).DoAndReturn(
+		func(_ context.Context) (*[]model.RentalEvent, error) {
+			cancel()
+			return &[]model.RentalEvent{}, nil
+		})
+
+	mockBroker := mocks.NewMockBroker(ctrl)
+
+	relay := NewRelay(mockBroker, mockCrud)
+	relay.Run(ctx, time.Hour)
+}
@@ -0,0 +1,104 @@
+// Package relock locks trunks again that have been left unlocked longer than the auto relock timeout.
+package relock
+
+import (
+	"RentalManagement/infrastructure/car"
+	"RentalManagement/infrastructure/database"
+	"RentalManagement/logging"
+	"RentalManagement/logic/model"
+	"RentalManagement/logic/rentalErrors"
+	"RentalManagement/util"
+	"context"
+	"errors"
+	"fmt"
+	carTypes "github.com/ccsapp/cargotypes"
+	"net/http"
+	"time"
+)
+
+// Scheduler processes the relock jobs that are persisted when a trunk is unlocked
+type Scheduler struct {
+	carClient    car.ClientWithResponsesInterface
+	crud         database.ICRUD
+	timeProvider util.ITimeProvider
+}
+
+func NewScheduler(carClient car.ClientWithResponsesInterface, crud database.ICRUD,
+	timeProvider util.ITimeProvider) *Scheduler {
+	return &Scheduler{
+		carClient:    carClient,
+		crud:         crud,
+		timeProvider: timeProvider,
+	}
+}
+
+// Run processes the due relock jobs once per interval until the context is done. Errors are logged, the affected
+// jobs are retried in the next round. As the jobs are persisted, jobs that were due during downtime are processed
+// right after the start.
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RelockDue(ctx); err != nil {
+			logging.FromContext(ctx).Error("relocking trunks failed", logging.KeyError, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelockDue locks the trunks of all cars whose relock job is due, unless they have been locked in the meantime.
+// Processed jobs are removed. Jobs that could not be processed are kept, the errors of all of them are returned.
+func (s *Scheduler) RelockDue(ctx context.Context) error {
+	jobs, err := s.crud.GetDueRelockJobs(ctx, s.timeProvider.Now())
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, job := range *jobs {
+		if err := s.relock(ctx, job.Vin); err != nil {
+			errs = append(errs, fmt.Errorf("relocking the trunk of car %s failed: %w", job.Vin, err))
+			continue
+		}
+		if err := s.crud.CompleteRelock(ctx, job); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *Scheduler) relock(ctx context.Context, vin model.Vin) error {
+	carResponse, err := s.carClient.GetCarWithResponse(ctx, vin)
+	if err != nil {
+		return err
+	}
+
+	statusCode := carResponse.StatusCode()
+	if statusCode == http.StatusNotFound {
+		// the car is no longer part of the domain, so there is no trunk to lock
+		return nil
+	}
+	if carResponse.ParsedCar == nil {
+		return fmt.Errorf("%w: unknown error (domain code %d)", rentalErrors.ErrDomainAssertion, statusCode)
+	}
+	if carResponse.ParsedCar.DynamicData.TrunkLockState == carTypes.LOCKED {
+		// someone locked the trunk in the meantime
+		return nil
+	}
+
+	response, err := s.carClient.ChangeTrunkLockStateWithResponse(ctx, vin, carTypes.LOCKED)
+	if err != nil {
+		return err
+	}
+	if response.HTTPResponse.StatusCode != http.StatusNoContent {
+		return rentalErrors.ErrDomainAssertion
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package relock
+
+import (
+	"RentalManagement/infrastructure/car"
+	"RentalManagement/logic/model"
+	"RentalManagement/logic/rentalErrors"
+	"RentalManagement/mocks"
+	"context"
+	"errors"
+	carTypes "github.com/ccsapp/cargotypes"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const vin1 = "WVWAA71K08W201030"
+const vin2 = "1FVNY5Y90HP312888"
+
+var now = time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC)
+
+var job1 = model.RelockJob{Vin: vin1, RentalId: "rZ6IIwcD", DueAt: now.Add(-time.Minute)}
+var job2 = model.RelockJob{Vin: vin2, RentalId: "P2zUdL3C", DueAt: now}
+
+func carResponse(vin model.Vin, trunkLockState carTypes.DynamicDataLockState) *car.GetCarResponse {
+	return &car.GetCarResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+		ParsedCar: &carTypes.Car{
+			Vin:         vin,
+			DynamicData: carTypes.DynamicData{TrunkLockState: trunkLockState},
+		},
+	}
+}
+
+var lockedResponse = &car.ChangeTrunkLockStateResponse{
+	HTTPResponse: &http.Response{StatusCode: http.StatusNoContent},
+}
+
+func TestScheduler_RelockDue_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetDueRelockJobs(ctx, now).Return(&[]model.RelockJob{job1, job2}, nil)
+	mockCrud.EXPECT().CompleteRelock(ctx, job1).Return(nil)
+	mockCrud.EXPECT().CompleteRelock(ctx, job2).Return(nil)
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockCar.EXPECT().GetCarWithResponse(ctx, vin1).Return(carResponse(vin1, carTypes.UNLOCKED), nil)
+	mockCar.EXPECT().ChangeTrunkLockStateWithResponse(ctx, vin1, carTypes.LOCKED).Return(lockedResponse, nil)
+	// the trunk of the second car has been locked in the meantime
+	mockCar.EXPECT().GetCarWithResponse(ctx, vin2).Return(carResponse(vin2, carTypes.LOCKED), nil)
+
+	scheduler := NewScheduler(mockCar, mockCrud, mockTime)
+	err := scheduler.RelockDue(ctx)
+
+	assert.Nil(t, err)
+}
+
+func TestScheduler_RelockDue_noJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetDueRelockJobs(ctx, now).Return(&[]model.RelockJob{}, nil)
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	scheduler := NewScheduler(mockCar, mockCrud, mockTime)
+	err := scheduler.RelockDue(ctx)
+
+	assert.Nil(t, err)
+}
+
+func TestScheduler_RelockDue_carNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetDueRelockJobs(ctx, now).Return(&[]model.RelockJob{job1}, nil)
+	mockCrud.EXPECT().CompleteRelock(ctx, job1).Return(nil)
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockCar.EXPECT().GetCarWithResponse(ctx, vin1).Return(&car.GetCarResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusNotFound},
+	}, nil)
+
+	scheduler := NewScheduler(mockCar, mockCrud, mockTime)
+	err := scheduler.RelockDue(ctx)
+
+	assert.Nil(t, err)
+}
+
+func TestScheduler_RelockDue_failedJobIsKept(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	carError := errors.New("car error")
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetDueRelockJobs(ctx, now).Return(&[]model.RelockJob{job1, job2}, nil)
+	mockCrud.EXPECT().CompleteRelock(ctx, job2).Return(nil)
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockCar.EXPECT().GetCarWithResponse(ctx, vin1).Return(carResponse(vin1, carTypes.UNLOCKED), nil)
+	mockCar.EXPECT().ChangeTrunkLockStateWithResponse(ctx, vin1, carTypes.LOCKED).Return(nil, carError)
+	mockCar.EXPECT().GetCarWithResponse(ctx, vin2).Return(carResponse(vin2, carTypes.UNLOCKED), nil)
+	mockCar.EXPECT().ChangeTrunkLockStateWithResponse(ctx, vin2, carTypes.LOCKED).Return(lockedResponse, nil)
+
+	scheduler := NewScheduler(mockCar, mockCrud, mockTime)
+	err := scheduler.RelockDue(ctx)
+
+	assert.ErrorIs(t, err, carError)
+}
+
+func TestScheduler_RelockDue_unknownDomainResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetDueRelockJobs(ctx, now).Return(&[]model.RelockJob{job1}, nil)
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+	mockCar.EXPECT().GetCarWithResponse(ctx, vin1).Return(&car.GetCarResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusInternalServerError},
+	}, nil)
+
+	scheduler := NewScheduler(mockCar, mockCrud, mockTime)
+	err := scheduler.RelockDue(ctx)
+
+	assert.ErrorIs(t, err, rentalErrors.ErrDomainAssertion)
+}
+
+func TestScheduler_RelockDue_crudError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	crudError := errors.New("crud error")
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetDueRelockJobs(ctx, now).Return(nil, crudError)
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	scheduler := NewScheduler(mockCar, mockCrud, mockTime)
+	err := scheduler.RelockDue(ctx)
+
+	assert.ErrorIs(t, err, crudError)
+}
+
+func TestScheduler_Run_stopsWhenContextIsDone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetDueRelockJobs(ctx, now).DoAndReturn(
+		func(context.Context, time.Time) (*[]model.RelockJob, error) {
+			// stop the scheduler after the first round
+			cancel()
+			return &[]model.RelockJob{}, nil
+		},
+	)
+
+	mockCar := mocks.NewMockClientWithResponsesInterface(ctrl)
+
+	scheduler := NewScheduler(mockCar, mockCrud, mockTime)
+	scheduler.Run(ctx, time.Hour)
+}
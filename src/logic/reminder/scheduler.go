@@ -0,0 +1,177 @@
+// Package reminder reminds customers of the start and end of their rentals.
+package reminder
+
+import (
+	"RentalManagement/infrastructure/database"
+	"RentalManagement/infrastructure/notification"
+	"RentalManagement/logging"
+	"RentalManagement/logic/model"
+	"RentalManagement/util"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// dateLayout is the layout of the dates in the reminders, which are always given in UTC
+const dateLayout = "Monday, 2 January 2006 15:04 MST"
+
+type SchedulerConfig interface {
+	// GetReminderStartOffsets returns how long before the start of a rental its customer is reminded
+	GetReminderStartOffsets() []time.Duration
+	// GetReminderEndOffsets returns how long before the end of a rental its customer is reminded
+	GetReminderEndOffsets() []time.Duration
+}
+
+// Scheduler sends the reminders that are due to the customers of upcoming and active rentals
+type Scheduler struct {
+	sender       notification.Sender
+	crud         database.ICRUD
+	timeProvider util.ITimeProvider
+	config       SchedulerConfig
+}
+
+func NewScheduler(sender notification.Sender, crud database.ICRUD, timeProvider util.ITimeProvider,
+	config SchedulerConfig) *Scheduler {
+	return &Scheduler{
+		sender:       sender,
+		crud:         crud,
+		timeProvider: timeProvider,
+		config:       config,
+	}
+}
+
+// Run sends the due reminders once per interval until the context is done. Errors are logged, the affected
+// reminders are retried in the next round as long as they are still due.
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.SendDue(ctx); err != nil {
+			logging.FromContext(ctx).Error("sending reminders failed", logging.KeyError, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDue sends the reminders that are due and have not been sent yet. A reminder is due once the start or end of
+// its rental is no further away than its offset. If several reminders of the same rental and kind are due, e.g.
+// because the rental was booked at short notice or the service was down, only the one with the smallest offset is
+// sent and all of them are recorded as sent.
+// Reminders are recorded after they have been sent, so a reminder may be sent twice if recording fails.
+func (s *Scheduler) SendDue(ctx context.Context) error {
+	now := s.timeProvider.Now()
+
+	return errors.Join(
+		s.sendDue(ctx, now, model.RentalStartReminder, s.config.GetReminderStartOffsets()),
+		s.sendDue(ctx, now, model.RentalEndReminder, s.config.GetReminderEndOffsets()),
+	)
+}
+
+func (s *Scheduler) sendDue(ctx context.Context, now time.Time, kind model.ReminderKind,
+	offsets []time.Duration) error {
+
+	if len(offsets) == 0 {
+		return nil
+	}
+	offsets = sortedOffsets(offsets)
+	window := model.TimePeriod{StartDate: now, EndDate: now.Add(offsets[len(offsets)-1])}
+
+	var rentals *[]model.Rental
+	var err error
+	if kind == model.RentalStartReminder {
+		rentals, err = s.crud.GetRentalsStartingIn(ctx, window)
+	} else {
+		rentals, err = s.crud.GetRentalsEndingIn(ctx, window)
+	}
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, rental := range *rentals {
+		if kind == model.RentalEndReminder && rental.State != model.ACTIVE {
+			// rentals that have not started yet are covered by the start reminders
+			continue
+		}
+		if err := s.remind(ctx, now, rental, kind, offsets); err != nil {
+			errs = append(errs, fmt.Errorf("reminding of rental %s failed: %w", rental.Id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// remind sends the due reminder with the smallest offset unless it has been sent already and records all due
+// reminders of the given kind as sent. The offsets must be sorted in ascending order.
+func (s *Scheduler) remind(ctx context.Context, now time.Time, rental model.Rental, kind model.ReminderKind,
+	offsets []time.Duration) error {
+
+	remaining := date(rental, kind).Sub(now)
+	var due []model.Reminder
+	for _, offset := range offsets {
+		if offset >= remaining {
+			due = append(due, model.Reminder{RentalId: rental.Id, Kind: kind, Offset: offset})
+		}
+	}
+	if len(due) == 0 {
+		return nil
+	}
+
+	sent, err := s.crud.IsReminderSent(ctx, due[0])
+	if err != nil {
+		return err
+	}
+	if sent {
+		return nil
+	}
+
+	if err := s.sender.Send(ctx, newMessage(rental, kind)); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, reminder := range due {
+		errs = append(errs, s.crud.AddSentReminder(ctx, reminder, now))
+	}
+	return errors.Join(errs...)
+}
+
+func sortedOffsets(offsets []time.Duration) []time.Duration {
+	sorted := append([]time.Duration(nil), offsets...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+func date(rental model.Rental, kind model.ReminderKind) time.Time {
+	if kind == model.RentalStartReminder {
+		return rental.RentalPeriod.StartDate
+	}
+	return rental.RentalPeriod.EndDate
+}
+
+func newMessage(rental model.Rental, kind model.ReminderKind) notification.Message {
+	startDate := rental.RentalPeriod.StartDate.UTC().Format(dateLayout)
+	endDate := rental.RentalPeriod.EndDate.UTC().Format(dateLayout)
+
+	if kind == model.RentalStartReminder {
+		return notification.Message{
+			To:      rental.Customer.CustomerId,
+			Subject: "Your rental starts soon",
+			Body: fmt.Sprintf("Hello,\n\nyour rental %s of the car %s starts on %s and ends on %s.\n",
+				rental.Id, rental.Car.Vin, startDate, endDate),
+		}
+	}
+	return notification.Message{
+		To:      rental.Customer.CustomerId,
+		Subject: "Your rental ends soon",
+		Body: fmt.Sprintf("Hello,\n\nyour rental %s of the car %s ends on %s. "+
+			"Please return the car in time.\n", rental.Id, rental.Car.Vin, endDate),
+	}
+}
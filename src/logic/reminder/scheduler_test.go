@@ -0,0 +1,229 @@
+package reminder
+
+import (
+	"RentalManagement/infrastructure/notification"
+	"RentalManagement/logic/model"
+	"RentalManagement/mocks"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC)
+
+type TestSchedulerConfig struct {
+	startOffsets []time.Duration
+	endOffsets   []time.Duration
+}
+
+func (c *TestSchedulerConfig) GetReminderStartOffsets() []time.Duration {
+	return c.startOffsets
+}
+
+func (c *TestSchedulerConfig) GetReminderEndOffsets() []time.Duration {
+	return c.endOffsets
+}
+
+var config = &TestSchedulerConfig{
+	startOffsets: []time.Duration{time.Hour, 24 * time.Hour},
+	endOffsets:   []time.Duration{time.Hour},
+}
+
+func rental(id model.RentalId, state model.State, startDate, endDate time.Time) model.Rental {
+	return model.Rental{
+		State:        state,
+		Car:          &model.Car{Vin: "WVWAA71K08W201030"},
+		Customer:     &model.Customer{CustomerId: "jane.doe@example.com"},
+		Id:           id,
+		RentalPeriod: model.TimePeriod{StartDate: startDate, EndDate: endDate},
+	}
+}
+
+var startWindow = model.TimePeriod{StartDate: now, EndDate: now.Add(24 * time.Hour)}
+var endWindow = model.TimePeriod{StartDate: now, EndDate: now.Add(time.Hour)}
+
+func startReminder(id model.RentalId, offset time.Duration) model.Reminder {
+	return model.Reminder{RentalId: id, Kind: model.RentalStartReminder, Offset: offset}
+}
+
+func TestScheduler_SendDue_startReminder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	upcoming := rental("rZ6IIwcD", model.UPCOMING, now.Add(20*time.Hour), now.Add(30*time.Hour))
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetRentalsStartingIn(ctx, startWindow).Return(&[]model.Rental{upcoming}, nil)
+	mockCrud.EXPECT().GetRentalsEndingIn(ctx, endWindow).Return(&[]model.Rental{}, nil)
+	mockCrud.EXPECT().IsReminderSent(ctx, startReminder(upcoming.Id, 24*time.Hour)).Return(false, nil)
+	mockCrud.EXPECT().AddSentReminder(ctx, startReminder(upcoming.Id, 24*time.Hour), now).Return(nil)
+
+	mockSender := mocks.NewMockSender(ctrl)
+	mockSender.EXPECT().Send(ctx, notification.Message{
+		To:      "jane.doe@example.com",
+		Subject: "Your rental starts soon",
+		Body: "Hello,\n\nyour rental rZ6IIwcD of the car WVWAA71K08W201030 starts on " +
+			"Friday, 3 March 2023 01:00 UTC and ends on Friday, 3 March 2023 11:00 UTC.\n",
+	}).Return(nil)
+
+	scheduler := NewScheduler(mockSender, mockCrud, mockTime, config)
+	err := scheduler.SendDue(ctx)
+
+	assert.Nil(t, err)
+}
+
+func TestScheduler_SendDue_alreadySent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	upcoming := rental("rZ6IIwcD", model.UPCOMING, now.Add(20*time.Hour), now.Add(30*time.Hour))
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetRentalsStartingIn(ctx, startWindow).Return(&[]model.Rental{upcoming}, nil)
+	mockCrud.EXPECT().GetRentalsEndingIn(ctx, endWindow).Return(&[]model.Rental{}, nil)
+	mockCrud.EXPECT().IsReminderSent(ctx, startReminder(upcoming.Id, 24*time.Hour)).Return(true, nil)
+
+	mockSender := mocks.NewMockSender(ctrl)
+
+	scheduler := NewScheduler(mockSender, mockCrud, mockTime, config)
+	err := scheduler.SendDue(ctx)
+
+	assert.Nil(t, err)
+}
+
+func TestScheduler_SendDue_severalDueOnlyClosestSent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	// booked at short notice, so both start reminders are due
+	upcoming := rental("rZ6IIwcD", model.UPCOMING, now.Add(30*time.Minute), now.Add(30*time.Hour))
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetRentalsStartingIn(ctx, startWindow).Return(&[]model.Rental{upcoming}, nil)
+	mockCrud.EXPECT().GetRentalsEndingIn(ctx, endWindow).Return(&[]model.Rental{}, nil)
+	mockCrud.EXPECT().IsReminderSent(ctx, startReminder(upcoming.Id, time.Hour)).Return(false, nil)
+	mockCrud.EXPECT().AddSentReminder(ctx, startReminder(upcoming.Id, time.Hour), now).Return(nil)
+	mockCrud.EXPECT().AddSentReminder(ctx, startReminder(upcoming.Id, 24*time.Hour), now).Return(nil)
+
+	mockSender := mocks.NewMockSender(ctrl)
+	mockSender.EXPECT().Send(ctx, gomock.Any()).Return(nil)
+
+	scheduler := NewScheduler(mockSender, mockCrud, mockTime, config)
+	err := scheduler.SendDue(ctx)
+
+	assert.Nil(t, err)
+}
+
+func TestScheduler_SendDue_endReminderOnlyForActiveRentals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	active := rental("rZ6IIwcD", model.ACTIVE, now.Add(-time.Hour), now.Add(45*time.Minute))
+	upcoming := rental("P2zUdL3C", model.UPCOMING, now.Add(10*time.Minute), now.Add(50*time.Minute))
+	endReminder := model.Reminder{RentalId: active.Id, Kind: model.RentalEndReminder, Offset: time.Hour}
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetRentalsStartingIn(ctx, startWindow).Return(&[]model.Rental{}, nil)
+	mockCrud.EXPECT().GetRentalsEndingIn(ctx, endWindow).Return(&[]model.Rental{active, upcoming}, nil)
+	mockCrud.EXPECT().IsReminderSent(ctx, endReminder).Return(false, nil)
+	mockCrud.EXPECT().AddSentReminder(ctx, endReminder, now).Return(nil)
+
+	mockSender := mocks.NewMockSender(ctrl)
+	mockSender.EXPECT().Send(ctx, notification.Message{
+		To:      "jane.doe@example.com",
+		Subject: "Your rental ends soon",
+		Body: "Hello,\n\nyour rental rZ6IIwcD of the car WVWAA71K08W201030 ends on " +
+			"Thursday, 2 March 2023 05:45 UTC. Please return the car in time.\n",
+	}).Return(nil)
+
+	scheduler := NewScheduler(mockSender, mockCrud, mockTime, config)
+	err := scheduler.SendDue(ctx)
+
+	assert.Nil(t, err)
+}
+
+func TestScheduler_SendDue_failedSendIsNotRecorded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	sendError := errors.New("send error")
+	first := rental("rZ6IIwcD", model.UPCOMING, now.Add(20*time.Hour), now.Add(30*time.Hour))
+	second := rental("P2zUdL3C", model.UPCOMING, now.Add(21*time.Hour), now.Add(30*time.Hour))
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetRentalsStartingIn(ctx, startWindow).Return(&[]model.Rental{first, second}, nil)
+	mockCrud.EXPECT().GetRentalsEndingIn(ctx, endWindow).Return(&[]model.Rental{}, nil)
+	mockCrud.EXPECT().IsReminderSent(ctx, startReminder(first.Id, 24*time.Hour)).Return(false, nil)
+	mockCrud.EXPECT().IsReminderSent(ctx, startReminder(second.Id, 24*time.Hour)).Return(false, nil)
+	mockCrud.EXPECT().AddSentReminder(ctx, startReminder(second.Id, 24*time.Hour), now).Return(nil)
+
+	mockSender := mocks.NewMockSender(ctrl)
+	gomock.InOrder(
+		mockSender.EXPECT().Send(ctx, gomock.Any()).Return(sendError),
+		mockSender.EXPECT().Send(ctx, gomock.Any()).Return(nil),
+	)
+
+	scheduler := NewScheduler(mockSender, mockCrud, mockTime, config)
+	err := scheduler.SendDue(ctx)
+
+	assert.ErrorIs(t, err, sendError)
+}
+
+func TestScheduler_SendDue_databaseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	dbError := errors.New("db error")
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockCrud.EXPECT().GetRentalsStartingIn(ctx, startWindow).Return(nil, dbError)
+	mockCrud.EXPECT().GetRentalsEndingIn(ctx, endWindow).Return(&[]model.Rental{}, nil)
+
+	mockSender := mocks.NewMockSender(ctrl)
+
+	scheduler := NewScheduler(mockSender, mockCrud, mockTime, config)
+	err := scheduler.SendDue(ctx)
+
+	assert.ErrorIs(t, err, dbError)
+}
+
+func TestScheduler_SendDue_noOffsets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTime := mocks.NewMockITimeProvider(ctrl)
+	mockTime.EXPECT().Now().Return(now)
+
+	scheduler := NewScheduler(mocks.NewMockSender(ctrl), mocks.NewMockICRUD(ctrl), mockTime,
+		&TestSchedulerConfig{})
+	err := scheduler.SendDue(context.Background())
+
+	assert.Nil(t, err)
+}
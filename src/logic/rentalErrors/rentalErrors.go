@@ -9,8 +9,11 @@ var (
 	ErrConflictingRentalExists = errors.New("conflicting rental exists")
 	ErrRentalNotFound          = errors.New("rental not found")
 	ErrRentalNotActive         = errors.New("rental not active")
+	ErrRentalNotUpcoming       = errors.New("rental not upcoming")
 	ErrRentalNotOverlapping    = errors.New("rental does not overlap the requested time period")
 	// ErrResourceConflict is returned when a resource is already in use and retry attempts failed.
 	ErrResourceConflict  = errors.New("resource conflict")
 	ErrTrunkAccessDenied = errors.New("trunk access denied")
+	ErrWebhookNotFound   = errors.New("webhook not found")
+	ErrCalendarNotFound  = errors.New("calendar subscription not found")
 )
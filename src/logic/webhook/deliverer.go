@@ -0,0 +1,168 @@
+package webhook
+
+import (
+	"RentalManagement/infrastructure/database"
+	"RentalManagement/logging"
+	"RentalManagement/logic/model"
+	"RentalManagement/logic/rentalErrors"
+	"RentalManagement/util"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// maxRetryDelay limits the exponential growth of the delay between two attempts
+const maxRetryDelay = 24 * time.Hour
+
+type DelivererConfig interface {
+	// GetWebhookMaxAttempts returns the number of attempts after which a delivery is given up.
+	GetWebhookMaxAttempts() int
+	// GetWebhookRetryDelay returns the delay after the first failed attempt. It is doubled after every further one.
+	GetWebhookRetryDelay() time.Duration
+}
+
+// Deliverer posts the due webhook deliveries to the URLs of their webhooks
+type Deliverer struct {
+	httpClient   *http.Client
+	crud         database.ICRUD
+	timeProvider util.ITimeProvider
+	config       DelivererConfig
+}
+
+func NewDeliverer(httpClient *http.Client, crud database.ICRUD, timeProvider util.ITimeProvider,
+	config DelivererConfig) *Deliverer {
+	return &Deliverer{
+		httpClient:   httpClient,
+		crud:         crud,
+		timeProvider: timeProvider,
+		config:       config,
+	}
+}
+
+// Run posts the due deliveries once per interval until the context is done. Errors are logged, the affected
+// deliveries are retried in the next round.
+func (d *Deliverer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.DeliverDue(ctx); err != nil {
+			logging.FromContext(ctx).Error("delivering webhooks failed", logging.KeyError, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue makes an attempt for every due delivery and records its outcome. A delivery succeeds if the webhook
+// responds with a 2xx status code. Failed deliveries are retried with exponential backoff until the maximum number of
+// attempts is reached, after which they are moved to the dead letter state.
+// Failing attempts are no error, only the errors of deliveries whose outcome could not be recorded are returned.
+func (d *Deliverer) DeliverDue(ctx context.Context) error {
+	deliveries, err := d.crud.GetDueWebhookDeliveries(ctx, d.timeProvider.Now())
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, delivery := range *deliveries {
+		if err := d.deliver(ctx, delivery); err != nil {
+			errs = append(errs, fmt.Errorf("webhook delivery %s failed: %w", delivery.Id, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (d *Deliverer) deliver(ctx context.Context, delivery model.WebhookDelivery) error {
+	webhook, err := d.crud.GetWebhook(ctx, delivery.WebhookId)
+	if errors.Is(err, rentalErrors.ErrWebhookNotFound) {
+		// the webhook has been deleted in the meantime, so there is no one to deliver to anymore
+		lastError := "webhook deleted"
+		delivery.State = model.DEADLETTER
+		delivery.NextAttemptAt = nil
+		delivery.LastError = &lastError
+		return d.crud.UpdateWebhookDelivery(ctx, delivery)
+	}
+	if err != nil {
+		return err
+	}
+
+	now := d.timeProvider.Now()
+	statusCode, postErr := d.post(ctx, webhook, &delivery, now)
+
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.LastStatusCode = statusCode
+
+	if postErr == nil {
+		delivery.State = model.DELIVERED
+		delivery.NextAttemptAt = nil
+		delivery.LastError = nil
+	} else if delivery.Attempts >= d.config.GetWebhookMaxAttempts() {
+		lastError := postErr.Error()
+		delivery.State = model.DEADLETTER
+		delivery.NextAttemptAt = nil
+		delivery.LastError = &lastError
+	} else {
+		lastError := postErr.Error()
+		nextAttemptAt := now.Add(d.retryDelay(delivery.Attempts))
+		delivery.NextAttemptAt = &nextAttemptAt
+		delivery.LastError = &lastError
+	}
+
+	return d.crud.UpdateWebhookDelivery(ctx, delivery)
+}
+
+// post sends the event of the delivery to the webhook, signed at the given time. The status code of the response is
+// returned if there is one.
+func (d *Deliverer) post(ctx context.Context, webhook *model.Webhook, delivery *model.WebhookDelivery,
+	signedAt time.Time) (*int, error) {
+	payload, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(SignatureHeader, Sign(webhook.Secret, signedAt, payload))
+	request.Header.Set(TimestampHeader, Timestamp(signedAt))
+	request.Header.Set(EventTypeHeader, string(delivery.Event.Type))
+	request.Header.Set(DeliveryHeader, delivery.Id)
+
+	response, err := d.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	_ = response.Body.Close()
+
+	statusCode := response.StatusCode
+	if statusCode < 200 || statusCode > 299 {
+		return &statusCode, fmt.Errorf("unexpected status code %d", statusCode)
+	}
+	return &statusCode, nil
+}
+
+// retryDelay returns the delay before the next attempt after the given number of failed attempts: the configured
+// delay doubled after every attempt but the first, at most maxRetryDelay
+func (d *Deliverer) retryDelay(attempts int) time.Duration {
+	delay := d.config.GetWebhookRetryDelay()
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,264 @@
+package webhook
+
+import (
+	"RentalManagement/logic/model"
+	"RentalManagement/logic/rentalErrors"
+	"RentalManagement/mocks"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type TestDelivererConfig struct{}
+
+func (c *TestDelivererConfig) GetWebhookMaxAttempts() int {
+	return 3
+}
+
+func (c *TestDelivererConfig) GetWebhookRetryDelay() time.Duration {
+	return 30 * time.Second
+}
+
+// newWebhookServer returns a server that checks the requests of the deliverer and responds with the given status code
+func newWebhookServer(t *testing.T, statusCode int, deliveryId string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, err := io.ReadAll(request.Body)
+		assert.Nil(t, err)
+
+		var postedEvent model.RentalEvent
+		assert.Nil(t, json.Unmarshal(body, &postedEvent))
+		assert.Equal(t, event, postedEvent)
+
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+		assert.Equal(t, Sign(billingWebhook.Secret, now, body), request.Header.Get(SignatureHeader))
+		assert.Equal(t, Timestamp(now), request.Header.Get(TimestampHeader))
+		assert.Equal(t, string(model.RentalCreated), request.Header.Get(EventTypeHeader))
+		assert.Equal(t, deliveryId, request.Header.Get(DeliveryHeader))
+
+		writer.WriteHeader(statusCode)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// pendingDelivery returns a due delivery of the event to the billing webhook after the given number of failed attempts
+func pendingDelivery(attempts int) model.WebhookDelivery {
+	return model.WebhookDelivery{
+		Id:            event.EventId + billingWebhook.Id,
+		WebhookId:     billingWebhook.Id,
+		Event:         event,
+		State:         model.PENDING,
+		Attempts:      attempts,
+		CreatedAt:     now.Add(-time.Hour),
+		NextAttemptAt: &now,
+	}
+}
+
+// webhookWithUrl returns the billing webhook with the given URL
+func webhookWithUrl(url string) *model.Webhook {
+	webhook := billingWebhook
+	webhook.Url = url
+	return &webhook
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func TestDeliverer_DeliverDue_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	delivery := pendingDelivery(0)
+	server := newWebhookServer(t, http.StatusNoContent, delivery.Id)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
+
+	mockTimeProvider.EXPECT().Now().Return(now).Times(2)
+	mockCrud.EXPECT().GetDueWebhookDeliveries(ctx, now).Return(&[]model.WebhookDelivery{delivery}, nil)
+	mockCrud.EXPECT().GetWebhook(ctx, billingWebhook.Id).Return(webhookWithUrl(server.URL), nil)
+	mockCrud.EXPECT().UpdateWebhookDelivery(ctx, model.WebhookDelivery{
+		Id:             delivery.Id,
+		WebhookId:      billingWebhook.Id,
+		Event:          event,
+		State:          model.DELIVERED,
+		Attempts:       1,
+		CreatedAt:      delivery.CreatedAt,
+		LastAttemptAt:  &now,
+		LastStatusCode: ptr(http.StatusNoContent),
+	}).Return(nil)
+
+	deliverer := NewDeliverer(server.Client(), mockCrud, mockTimeProvider, &TestDelivererConfig{})
+	err := deliverer.DeliverDue(ctx)
+
+	assert.Nil(t, err)
+}
+
+func TestDeliverer_DeliverDue_retry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	delivery := pendingDelivery(1)
+	server := newWebhookServer(t, http.StatusServiceUnavailable, delivery.Id)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
+
+	mockTimeProvider.EXPECT().Now().Return(now).Times(2)
+	mockCrud.EXPECT().GetDueWebhookDeliveries(ctx, now).Return(&[]model.WebhookDelivery{delivery}, nil)
+	mockCrud.EXPECT().GetWebhook(ctx, billingWebhook.Id).Return(webhookWithUrl(server.URL), nil)
+	mockCrud.EXPECT().UpdateWebhookDelivery(ctx, model.WebhookDelivery{
+		Id:        delivery.Id,
+		WebhookId: billingWebhook.Id,
+		Event:     event,
+		State:     model.PENDING,
+		Attempts:  2,
+		CreatedAt: delivery.CreatedAt,
+		// the delay is doubled after the second attempt
+		NextAttemptAt:  ptr(now.Add(time.Minute)),
+		LastAttemptAt:  &now,
+		LastStatusCode: ptr(http.StatusServiceUnavailable),
+		LastError:      ptr("unexpected status code 503"),
+	}).Return(nil)
+
+	deliverer := NewDeliverer(server.Client(), mockCrud, mockTimeProvider, &TestDelivererConfig{})
+	err := deliverer.DeliverDue(ctx)
+
+	assert.Nil(t, err)
+}
+
+func TestDeliverer_DeliverDue_deadLetter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	delivery := pendingDelivery(2)
+	server := newWebhookServer(t, http.StatusInternalServerError, delivery.Id)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
+
+	mockTimeProvider.EXPECT().Now().Return(now).Times(2)
+	mockCrud.EXPECT().GetDueWebhookDeliveries(ctx, now).Return(&[]model.WebhookDelivery{delivery}, nil)
+	mockCrud.EXPECT().GetWebhook(ctx, billingWebhook.Id).Return(webhookWithUrl(server.URL), nil)
+	mockCrud.EXPECT().UpdateWebhookDelivery(ctx, model.WebhookDelivery{
+		Id:             delivery.Id,
+		WebhookId:      billingWebhook.Id,
+		Event:          event,
+		State:          model.DEADLETTER,
+		Attempts:       3,
+		CreatedAt:      delivery.CreatedAt,
+		LastAttemptAt:  &now,
+		LastStatusCode: ptr(http.StatusInternalServerError),
+		LastError:      ptr("unexpected status code 500"),
+	}).Return(nil)
+
+	deliverer := NewDeliverer(server.Client(), mockCrud, mockTimeProvider, &TestDelivererConfig{})
+	err := deliverer.DeliverDue(ctx)
+
+	assert.Nil(t, err)
+}
+
+func TestDeliverer_DeliverDue_unreachable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	delivery := pendingDelivery(0)
+	server := httptest.NewServer(http.NotFoundHandler())
+	// the webhook does not respond at all
+	server.Close()
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
+
+	mockTimeProvider.EXPECT().Now().Return(now).Times(2)
+	mockCrud.EXPECT().GetDueWebhookDeliveries(ctx, now).Return(&[]model.WebhookDelivery{delivery}, nil)
+	mockCrud.EXPECT().GetWebhook(ctx, billingWebhook.Id).Return(webhookWithUrl(server.URL), nil)
+	mockCrud.EXPECT().UpdateWebhookDelivery(ctx, gomock.Any()).
+		Do(func(_ context.Context, updated model.WebhookDelivery) {
+			assert.Equal(t, model.PENDING, updated.State)
+			assert.Equal(t, 1, updated.Attempts)
+			assert.Equal(t, now.Add(30*time.Second), *updated.NextAttemptAt)
+			assert.Nil(t, updated.LastStatusCode)
+			assert.NotNil(t, updated.LastError)
+		}).
+		Return(nil)
+
+	deliverer := NewDeliverer(server.Client(), mockCrud, mockTimeProvider, &TestDelivererConfig{})
+	err := deliverer.DeliverDue(ctx)
+
+	assert.Nil(t, err)
+}
+
+func TestDeliverer_DeliverDue_webhookDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	delivery := pendingDelivery(1)
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
+
+	mockTimeProvider.EXPECT().Now().Return(now)
+	mockCrud.EXPECT().GetDueWebhookDeliveries(ctx, now).Return(&[]model.WebhookDelivery{delivery}, nil)
+	mockCrud.EXPECT().GetWebhook(ctx, billingWebhook.Id).Return(nil, rentalErrors.ErrWebhookNotFound)
+	mockCrud.EXPECT().UpdateWebhookDelivery(ctx, model.WebhookDelivery{
+		Id:        delivery.Id,
+		WebhookId: billingWebhook.Id,
+		Event:     event,
+		State:     model.DEADLETTER,
+		Attempts:  1,
+		CreatedAt: delivery.CreatedAt,
+		LastError: ptr("webhook deleted"),
+	}).Return(nil)
+
+	deliverer := NewDeliverer(http.DefaultClient, mockCrud, mockTimeProvider, &TestDelivererConfig{})
+	err := deliverer.DeliverDue(ctx)
+
+	assert.Nil(t, err)
+}
+
+func TestDeliverer_DeliverDue_databaseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	delivery := pendingDelivery(0)
+	server := newWebhookServer(t, http.StatusOK, delivery.Id)
+	dbError := errors.New("db error")
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
+
+	mockTimeProvider.EXPECT().Now().Return(now).Times(2)
+	mockCrud.EXPECT().GetDueWebhookDeliveries(ctx, now).Return(&[]model.WebhookDelivery{delivery}, nil)
+	mockCrud.EXPECT().GetWebhook(ctx, billingWebhook.Id).Return(webhookWithUrl(server.URL), nil)
+	mockCrud.EXPECT().UpdateWebhookDelivery(ctx, gomock.Any()).Return(dbError)
+
+	deliverer := NewDeliverer(server.Client(), mockCrud, mockTimeProvider, &TestDelivererConfig{})
+	err := deliverer.DeliverDue(ctx)
+
+	assert.ErrorIs(t, err, dbError)
+}
+
+func TestDeliverer_retryDelay(t *testing.T) {
+	deliverer := NewDeliverer(http.DefaultClient, nil, nil, &TestDelivererConfig{})
+
+	assert.Equal(t, 30*time.Second, deliverer.retryDelay(1))
+	assert.Equal(t, time.Minute, deliverer.retryDelay(2))
+	assert.Equal(t, 4*time.Minute, deliverer.retryDelay(4))
+	assert.Equal(t, maxRetryDelay, deliverer.retryDelay(20))
+}
@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"RentalManagement/infrastructure/database"
+	"RentalManagement/logic/model"
+	"RentalManagement/util"
+	"context"
+)
+
+// Dispatcher creates a delivery for every webhook that is subscribed to a rental event. It is a broker.Broker, so the
+// outbox relay feeds it with the events of the rental lifecycle operations.
+type Dispatcher struct {
+	crud         database.ICRUD
+	timeProvider util.ITimeProvider
+}
+
+func NewDispatcher(crud database.ICRUD, timeProvider util.ITimeProvider) *Dispatcher {
+	return &Dispatcher{
+		crud:         crud,
+		timeProvider: timeProvider,
+	}
+}
+
+// Publish stores a pending delivery of the event for every webhook subscribed to its type. The deliveries are due
+// immediately. As the ID of a delivery is derived from the event and the webhook, publishing an event again does not
+// create further deliveries.
+func (d *Dispatcher) Publish(ctx context.Context, event model.RentalEvent) error {
+	webhooks, err := d.crud.GetWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := d.timeProvider.Now()
+	for _, webhook := range *webhooks {
+		if !webhook.IsSubscribedTo(event.Type) {
+			continue
+		}
+
+		err := d.crud.AddWebhookDelivery(ctx, model.WebhookDelivery{
+			Id:            event.EventId + webhook.Id,
+			WebhookId:     webhook.Id,
+			Event:         event,
+			State:         model.PENDING,
+			CreatedAt:     now,
+			NextAttemptAt: &now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
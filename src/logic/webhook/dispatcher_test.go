@@ -0,0 +1,108 @@
+package webhook
+
+import (
+	"RentalManagement/logic/model"
+	"RentalManagement/mocks"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2023, 3, 2, 5, 0, 0, 0, time.UTC)
+
+var event = model.RentalEvent{
+	EventId:    "Xo0kGm4GUtbN2r3h",
+	Type:       model.RentalCreated,
+	OccurredAt: now.Add(-time.Minute),
+	RentalId:   "rZ6IIwcD",
+	Vin:        "WVWAA71K08W201030",
+	CustomerId: "d9COw9vI",
+	RentalPeriod: &model.TimePeriod{
+		StartDate: now.Add(time.Hour),
+		EndDate:   now.Add(2 * time.Hour),
+	},
+}
+
+var billingWebhook = model.Webhook{
+	Id:         "Ck3XlmXtlq0xGpH9",
+	Url:        "https://billing.example.com/hooks/rentals",
+	EventTypes: []model.RentalEventType{model.RentalCreated, model.RentalChanged},
+	Secret:     "whsec-0123456789abcdef",
+	CreatedAt:  now.Add(-time.Hour),
+}
+
+var trunkWebhook = model.Webhook{
+	Id:         "b2VtY0ZpIoKq8WcL",
+	Url:        "https://notifications.example.com/trunk",
+	EventTypes: []model.RentalEventType{model.TrunkAccessGranted},
+	Secret:     "whsec-fedcba9876543210",
+	CreatedAt:  now.Add(-time.Hour),
+}
+
+func TestDispatcher_Publish_success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
+
+	mockTimeProvider.EXPECT().Now().Return(now)
+	mockCrud.EXPECT().GetWebhooks(ctx).Return(&[]model.Webhook{billingWebhook, trunkWebhook}, nil)
+	// only the billing webhook is subscribed to rentalCreated events
+	mockCrud.EXPECT().AddWebhookDelivery(ctx, model.WebhookDelivery{
+		Id:            event.EventId + billingWebhook.Id,
+		WebhookId:     billingWebhook.Id,
+		Event:         event,
+		State:         model.PENDING,
+		CreatedAt:     now,
+		NextAttemptAt: &now,
+	}).Return(nil)
+
+	dispatcher := NewDispatcher(mockCrud, mockTimeProvider)
+	err := dispatcher.Publish(ctx, event)
+
+	assert.Nil(t, err)
+}
+
+func TestDispatcher_Publish_noWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
+
+	mockTimeProvider.EXPECT().Now().Return(now)
+	mockCrud.EXPECT().GetWebhooks(ctx).Return(&[]model.Webhook{}, nil)
+
+	dispatcher := NewDispatcher(mockCrud, mockTimeProvider)
+	err := dispatcher.Publish(ctx, event)
+
+	assert.Nil(t, err)
+}
+
+func TestDispatcher_Publish_databaseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	dbError := errors.New("db error")
+
+	mockCrud := mocks.NewMockICRUD(ctrl)
+	mockTimeProvider := mocks.NewMockITimeProvider(ctrl)
+
+	mockTimeProvider.EXPECT().Now().Return(now)
+	mockCrud.EXPECT().GetWebhooks(ctx).Return(&[]model.Webhook{billingWebhook}, nil)
+	mockCrud.EXPECT().AddWebhookDelivery(ctx, gomock.Any()).Return(dbError)
+
+	dispatcher := NewDispatcher(mockCrud, mockTimeProvider)
+	err := dispatcher.Publish(ctx, event)
+
+	assert.ErrorIs(t, err, dbError)
+}
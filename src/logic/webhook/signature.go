@@ -0,0 +1,40 @@
+// Package webhook posts rental events to the URLs partners have registered as webhooks.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// Headers of the requests that post rental events to webhooks
+const (
+	// SignatureHeader contains the signature of the timestamp and the request body, see Sign
+	SignatureHeader = "X-RentalManagement-Signature"
+	// TimestampHeader contains the time the request has been signed at as Unix seconds, see Timestamp
+	TimestampHeader = "X-RentalManagement-Timestamp"
+	// EventTypeHeader contains the type of the rental event
+	EventTypeHeader = "X-RentalManagement-Event"
+	// DeliveryHeader contains the ID of the delivery, which is the same for all attempts
+	DeliveryHeader = "X-RentalManagement-Delivery"
+)
+
+const signaturePrefix = "sha256="
+
+// Timestamp returns the time as it is sent in the TimestampHeader: the decimal Unix seconds
+func Timestamp(signedAt time.Time) string {
+	return strconv.FormatInt(signedAt.Unix(), 10)
+}
+
+// Sign returns the signature of a payload as it is sent in the SignatureHeader: "sha256=" followed by the hex encoded
+// HMAC-SHA256 of the Timestamp, a dot and the payload, keyed with the secret of the webhook. The timestamp is signed
+// as well, so that receivers can reject requests older than 5 minutes and a captured request cannot be replayed with
+// a new timestamp.
+func Sign(secret string, signedAt time.Time, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(Timestamp(signedAt) + "."))
+	mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,34 @@
+package webhook
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var signedAt = time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC)
+
+func TestSign(t *testing.T) {
+	// reference value computed with:
+	// printf '1685581200.{"eventId":"Xo0kGm4GUtbN2r3h"}' | openssl dgst -sha256 -hmac secret
+	signature := Sign("secret", signedAt, []byte(`{"eventId":"Xo0kGm4GUtbN2r3h"}`))
+
+	assert.Equal(t, "sha256=b77e039b2ad0489252cb4ced7b193ee79b8749873cdf96aa013708a0402d4381", signature)
+}
+
+func TestSign_differentSecrets(t *testing.T) {
+	payload := []byte(`{"eventId":"Xo0kGm4GUtbN2r3h"}`)
+
+	assert.NotEqual(t, Sign("secret", signedAt, payload), Sign("other secret", signedAt, payload))
+}
+
+func TestSign_differentTimestamps(t *testing.T) {
+	payload := []byte(`{"eventId":"Xo0kGm4GUtbN2r3h"}`)
+
+	// a replayed request cannot carry a new timestamp without a new signature
+	assert.NotEqual(t, Sign("secret", signedAt, payload), Sign("secret", signedAt.Add(time.Second), payload))
+}
+
+func TestTimestamp(t *testing.T) {
+	assert.Equal(t, "1685581200", Timestamp(signedAt))
+}
@@ -3,22 +3,100 @@ package main
 import (
 	"RentalManagement/api"
 	"RentalManagement/environment"
+	"RentalManagement/grpcapi"
+	"RentalManagement/infrastructure/broker"
 	"RentalManagement/infrastructure/car"
 	"RentalManagement/infrastructure/database"
 	"RentalManagement/infrastructure/database/db"
+	"RentalManagement/infrastructure/notification"
+	"RentalManagement/logging"
 	"RentalManagement/logic/operations"
+	"RentalManagement/logic/outbox"
+	"RentalManagement/logic/relock"
+	"RentalManagement/logic/reminder"
+	"RentalManagement/logic/webhook"
+	"RentalManagement/metrics"
+	"RentalManagement/tlsconfig"
+	"RentalManagement/tracing"
 	"RentalManagement/util"
+	"context"
+	"crypto/tls"
+	"flag"
 	"fmt"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/exp/slog"
+	"google.golang.org/grpc"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	// embed the time zone database so that recurring trunk access works in images without one
+	_ "time/tzdata"
 )
 
+// newOperations allows production as well as testing to create the operations the APIs perform
+// Configuration values are read from the environment.
+func newOperations(dbConnection db.IConnection) (operations.IOperations, error) {
+	carClient, err := newCarClient()
+	if err != nil {
+		return nil, err
+	}
+
+	crudInstance := database.NewICRUD(dbConnection, environment.GetEnvironment(), util.TimeProvider{})
+	return operations.NewOperations(carClient, crudInstance, util.TimeProvider{}, environment.GetEnvironment()), nil
+}
+
+// newHealth allows production as well as testing to create the health of RentalManagement, which is ready as long as
+// the database and the Car server are available
+// Configuration values are read from the environment.
+func newHealth(dbConnection db.IConnection) (*api.Health, error) {
+	carClient, err := newCarClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return api.NewHealth(map[string]api.DependencyCheck{
+		"mongodb": dbConnection.Ping,
+		"car": func(ctx context.Context) error {
+			return car.Ping(ctx, carClient)
+		},
+	}), nil
+}
+
 // newApp allows production as well as testing to create a new Echo instance for the API
 // Configuration values are read from the environment.
-func newApp(dbConnection db.IConnection) (*echo.Echo, error) {
+func newApp(operationsInstance operations.IOperations, verifier *api.TokenVerifier,
+	health *api.Health) (*echo.Echo, error) {
 	app := echo.New()
+	// respond with a problem (RFC 7807) for every error, unexpected errors are logged
+	app.HTTPErrorHandler = api.HandleError
+
+	// trace the requests, continuing the trace context of the callers
+	app.Use(otelecho.Middleware(tracing.ServiceName))
+
+	// identify every request and log it with its ID, which the logger of its context adds to every log line
+	api.AddLoggingMiddleware(app)
+
+	// record the count and latency of the requests and expose the metrics for Prometheus
+	err := api.AddMetricsMiddleware(app)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate the responses against the OpenAPI specification if configured, e.g. in local setup mode
+	err = api.AddOpenApiResponseValidationMiddleware(app,
+		api.ResponseValidation(environment.GetEnvironment().GetResponseValidation()))
+	if err != nil {
+		return nil, err
+	}
 
 	// add CORS middleware if allowed origins are configured
 	allowOrigins := environment.GetEnvironment().GetAppAllowOrigins()
@@ -28,59 +106,320 @@ func newApp(dbConnection db.IConnection) (*echo.Echo, error) {
 		}))
 	}
 
+	// authenticate and authorize the callers by their bearer tokens before validating their requests
+	api.AddAuthenticationMiddleware(app, verifier)
+	err = api.AddAuthorizationMiddleware(app)
+	if err != nil {
+		return nil, err
+	}
+
 	// add OpenAPI validation to the echo instance
-	err := api.AddOpenApiValidationMiddleware(app)
+	err = api.AddOpenApiValidationMiddleware(app)
+	if err != nil {
+		return nil, err
+	}
+
+	// restrict the operations on a rental to its customer once its rentalId has been validated
+	api.AddRentalOwnershipMiddleware(app, operationsInstance)
+
+	// serve the OpenAPI specification and an explorer for it
+	err = api.AddOpenApiDocumentation(app)
 	if err != nil {
 		return nil, err
 	}
 
+	// let the orchestrator probe whether the server is alive and ready to serve requests
+	api.AddHealthEndpoints(app, health)
+
+	controllerInstance := api.NewController(operationsInstance, util.TimeProvider{},
+		environment.GetEnvironment().GetPublicBaseUrl(), endStreamsOnShutdown(app))
+
+	api.RegisterHandlers(app, controllerInstance)
+
+	return app, nil
+}
+
+// newCarClient creates a client for the Car server of the domain layer as configured in the environment, whose calls
+// are traced, with the trace context and the request ID propagated to Car, and recorded as metrics. A client
+// certificate is presented to Car if one is configured.
+func newCarClient() (car.ClientWithResponsesInterface, error) {
+	transport := http.DefaultTransport
+	tlsConfig, err := tlsconfig.NewCarClientConfig(environment.GetEnvironment())
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		tlsTransport := http.DefaultTransport.(*http.Transport).Clone()
+		tlsTransport.TLSClientConfig = tlsConfig
+		transport = tlsTransport
+	}
+
 	carClient, err := car.NewClientWithResponses(environment.GetEnvironment().GetCarServerUrl(),
 		car.WithHTTPClient(
 			&http.Client{
 				Timeout: environment.GetEnvironment().GetRequestTimeout(),
+				Transport: otelhttp.NewTransport(logging.NewRequestIdTransport(transport),
+					otelhttp.WithSpanNameFormatter(func(_ string, request *http.Request) string {
+						return "Car " + request.Method
+					}),
+				),
 			},
 		),
 	)
-
 	if err != nil {
 		return nil, err
 	}
+	return car.NewInstrumentedClient(carClient), nil
+}
 
-	crudInstance := database.NewICRUD(dbConnection, environment.GetEnvironment(), util.TimeProvider{})
-	operationsInstance := operations.NewOperations(carClient, crudInstance, util.TimeProvider{})
-	controllerInstance := api.NewController(operationsInstance, util.TimeProvider{})
-
-	api.RegisterHandlers(app, controllerInstance)
+func main() {
+	printConfig := flag.Bool("print-config", false,
+		"print the effective configuration with secrets redacted and exit")
+	flag.Parse()
 
-	// Use custom error handling that logs any rentalErrors that occur but passes any HTTP rentalErrors directly to the client.
-	// Any other rentalErrors are converted to HTTP 500 rentalErrors.
-	app.Use(func(fun echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			if err := fun(c); err != nil {
-				if err, isHttpError := err.(*echo.HTTPError); isHttpError {
-					return err
-				}
-				app.Logger.Error(err.Error())
-				return echo.NewHTTPError(http.StatusInternalServerError, "Internal Server Error")
-			}
-			return nil
+	// report all problems of the configuration at once instead of failing on the first one when it is used
+	if err := environment.LoadEnvironment(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if *printConfig {
+		if err := environment.GetEnvironment().PrintConfiguration(os.Stdout); err != nil {
+			log.Fatal(err)
 		}
-	})
+		return
+	}
 
-	return app, nil
-}
+	if err := logging.Setup(environment.GetEnvironment(), os.Stdout); err != nil {
+		log.Fatal(err)
+	}
 
-func main() {
-	dbConnection, err := db.NewDbConnection(environment.GetEnvironment())
+	shutdownTracing, err := tracing.Setup(environment.GetEnvironment())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// reconnect to the database as soon as a rotated connection string is written to its file
+	reconnectingConnection, err := db.NewReconnectingConnection(environment.GetEnvironment())
+	if err != nil {
+		log.Fatal(err)
+	}
+	// trace the database operations and record their latency as metrics
+	dbConnection := db.NewInstrumentedConnection(reconnectingConnection)
+
+	operationsInstance, err := newOperations(dbConnection)
+	if err != nil {
+		log.Fatal(err)
+	}
+	verifier, err := api.NewTokenVerifier(environment.GetEnvironment())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	health, err := newHealth(dbConnection)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	app, err := newApp(operationsInstance, verifier, health)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// the background workers run until the requests in flight have been drained during the shutdown
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	runWorker := func(run func(ctx context.Context, interval time.Duration), interval time.Duration) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run(workersCtx, interval)
+		}()
+	}
+
+	if environment.GetEnvironment().IsMongoDbConnectionStringFromFile() {
+		runWorker(reconnectingConnection.Run, environment.GetEnvironment().GetSecretRefreshInterval())
+	}
+
+	// lock trunks again that have been left unlocked longer than the auto relock timeout
+	carClient, err := newCarClient()
+	if err != nil {
+		log.Fatal(err)
+	}
+	crudInstance := database.NewICRUD(dbConnection, environment.GetEnvironment(), util.TimeProvider{})
+	scheduler := relock.NewScheduler(carClient, crudInstance, util.TimeProvider{})
+	runWorker(scheduler.Run, environment.GetEnvironment().GetTrunkAutoRelockInterval())
+
+	// publish the rental events written to the outbox, first to the webhooks as their dispatcher tolerates publishing
+	// an event twice
+	eventBroker, err := broker.NewBroker(environment.GetEnvironment())
 	if err != nil {
 		log.Fatal(err)
 	}
+	dispatcher := webhook.NewDispatcher(crudInstance, util.TimeProvider{})
+	relay := outbox.NewRelay(broker.NewFanOutBroker(dispatcher, eventBroker), crudInstance)
+	runWorker(relay.Run, environment.GetEnvironment().GetOutboxRelayInterval())
+
+	// post the rental events to the subscribed webhooks
+	deliverer := webhook.NewDeliverer(
+		&http.Client{Timeout: environment.GetEnvironment().GetRequestTimeout()},
+		crudInstance,
+		util.TimeProvider{},
+		environment.GetEnvironment(),
+	)
+	runWorker(deliverer.Run, environment.GetEnvironment().GetWebhookInterval())
+
+	// remind customers of the start and end of their rentals if a sender is configured
+	if environment.GetEnvironment().GetReminderSender() != "" {
+		sender, err := notification.NewSender(environment.GetEnvironment())
+		if err != nil {
+			log.Fatal(err)
+		}
+		reminders := reminder.NewScheduler(sender, crudInstance, util.TimeProvider{}, environment.GetEnvironment())
+		runWorker(reminders.Run, environment.GetEnvironment().GetReminderInterval())
+	}
+
+	// serve until a shutdown is requested or a server fails
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	serverErrors := make(chan error, 3)
+
+	// serve the same operations over gRPC for internal services if a port is configured
+	var grpcServer *grpc.Server
+	if grpcPort := environment.GetEnvironment().GetGrpcPort(); grpcPort != 0 {
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", grpcPort))
+		if err != nil {
+			log.Fatal(err)
+		}
+		grpcServer, err = grpcapi.NewServer(operationsInstance, util.TimeProvider{}, verifier)
+		if err != nil {
+			log.Fatal(err)
+		}
+		go func() {
+			serverErrors <- grpcServer.Serve(listener)
+		}()
+	}
 
-	app, err := newApp(dbConnection)
+	// expose the metrics for Prometheus on a separate port, which is not reachable through the public one
+	var metricsServer *http.Server
+	if metricsPort := environment.GetEnvironment().GetMetricsPort(); metricsPort != 0 {
+		metricsServer = metrics.NewServer(fmt.Sprintf(":%d", metricsPort))
+		go func() {
+			serverErrors <- metricsServer.ListenAndServe()
+		}()
+	}
+
+	// start the server on the configured port, over TLS if a certificate is configured
+	tlsConfig, err := tlsconfig.NewServerConfig(environment.GetEnvironment())
 	if err != nil {
 		log.Fatal(err)
 	}
+	go func() {
+		serverErrors <- startApp(app, fmt.Sprintf(":%d", environment.GetEnvironment().GetAppExposePort()), tlsConfig)
+	}()
+
+	var serverErr error
+	select {
+	case <-signalCtx.Done():
+		slog.Info("shutting down")
+	case serverErr = <-serverErrors:
+		slog.Error("serving failed, shutting down", logging.KeyError, serverErr)
+	}
+
+	// stop accepting requests and drain the requests in flight, then stop the background workers, which may still
+	// need the event broker and the database, and close these last. Every phase has the full shutdown timeout, so that
+	// a slow phase does not leave the next one without time.
+	health.StartShutdown()
+	shutdownTimeout := environment.GetEnvironment().GetShutdownTimeout()
+	shutdownServers(shutdownTimeout, app, grpcServer, metricsServer)
+
+	stopWorkers()
+	workers.Wait()
+
+	// the relay has stopped, so no event is published anymore
+	if err := broker.Close(eventBroker); err != nil {
+		slog.Error("closing the event broker failed", logging.KeyError, err)
+	}
+	if err := dbConnection.CleanUpDatabase(); err != nil {
+		slog.Error("disconnecting from the database failed", logging.KeyError, err)
+	}
+	withTimeout(shutdownTimeout, func(ctx context.Context) {
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("flushing the spans failed", logging.KeyError, err)
+		}
+	})
+
+	if serverErr != nil {
+		os.Exit(1)
+	}
+	slog.Info("shut down")
+}
+
+// startApp serves the app on the given address until it is shut down, over TLS if tlsConfig is not nil
+func startApp(app *echo.Echo, address string, tlsConfig *tls.Config) error {
+	if tlsConfig == nil {
+		return app.Start(address)
+	}
+
+	server := app.TLSServer
+	server.Addr = address
+	server.TLSConfig = tlsConfig
+	if !app.DisableHTTP2 {
+		server.TLSConfig.NextProtos = append(server.TLSConfig.NextProtos, "h2")
+	}
+	return app.StartServer(server)
+}
+
+// endStreamsOnShutdown returns a context that is done as soon as a shutdown of the app starts. The streams of the
+// live car state end with it, since the shutdown would wait for them until its timeout otherwise.
+func endStreamsOnShutdown(app *echo.Echo) context.Context {
+	streams, endStreams := context.WithCancel(context.Background())
+	app.Server.RegisterOnShutdown(endStreams)
+	app.TLSServer.RegisterOnShutdown(endStreams)
+	return streams
+}
+
+// shutdownServers stops the servers from accepting requests and waits for the requests in flight, for each server
+// until the timeout. Requests still in flight then are cut off.
+func shutdownServers(timeout time.Duration, app *echo.Echo, grpcServer *grpc.Server, metricsServer *http.Server) {
+	withTimeout(timeout, func(ctx context.Context) {
+		if err := app.Shutdown(ctx); err != nil {
+			slog.Warn("draining the requests failed, closing the remaining connections", logging.KeyError, err)
+			if err := app.Close(); err != nil {
+				slog.Error("closing the connections failed", logging.KeyError, err)
+			}
+		}
+	})
+
+	if grpcServer != nil {
+		withTimeout(timeout, func(ctx context.Context) {
+			stopped := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+				slog.Warn("draining the gRPC requests failed, closing the remaining connections",
+					logging.KeyError, ctx.Err())
+				grpcServer.Stop()
+			}
+		})
+	}
+
+	// the metrics are served until the requests have been drained, so that they can still be scraped
+	if metricsServer != nil {
+		withTimeout(timeout, func(ctx context.Context) {
+			if err := metricsServer.Shutdown(ctx); err != nil {
+				slog.Warn("shutting down the metrics server failed", logging.KeyError, err)
+			}
+		})
+	}
+}
 
-	// start the server on the configured port
-	app.Logger.Fatal(app.Start(fmt.Sprintf(":%d", environment.GetEnvironment().GetAppExposePort())))
+// withTimeout performs a phase of the shutdown with a context that is done after the timeout
+func withTimeout(timeout time.Duration, phase func(ctx context.Context)) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	phase(ctx)
 }
@@ -0,0 +1,134 @@
+// Package metrics provides the Prometheus metrics of RentalManagement, which are exposed at /metrics of a separate
+// server.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"time"
+)
+
+const namespace = "rentalmanagement"
+
+// readHeaderTimeout is the time the metrics server waits for the headers of a request
+const readHeaderTimeout = 10 * time.Second
+
+// registry contains the metrics of RentalManagement as well as those of the Go runtime and the process
+var registry = prometheus.NewRegistry()
+
+var factory = promauto.With(registry)
+
+func init() {
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+}
+
+var (
+	// HttpRequests counts the requests to the REST API per OpenAPI operation ID, method and status code
+	HttpRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Requests to the REST API per OpenAPI operation ID, method and status code.",
+	}, []string{"operation", "method", "status"})
+
+	// HttpRequestDuration observes the latency of the requests to the REST API per OpenAPI operation ID and method
+	HttpRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of the requests to the REST API per OpenAPI operation ID and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation", "method"})
+
+	// GrpcRequests counts the calls of the gRPC API per method and status code
+	GrpcRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "Calls of the gRPC API per method and status code.",
+	}, []string{"method", "status"})
+
+	// GrpcRequestDuration observes the latency of the calls of the gRPC API per method
+	GrpcRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "Latency of the calls of the gRPC API per method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	// CarRequestDuration observes the latency of the calls to the Car server per endpoint
+	CarRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "car_request_duration_seconds",
+		Help:      "Latency of the calls to the Car server per endpoint.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"endpoint"})
+
+	// CarRequestErrors counts the calls to the Car server per endpoint that failed or were answered with a server
+	// error
+	CarRequestErrors = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "car_request_errors_total",
+		Help:      "Calls to the Car server per endpoint that failed or were answered with a server error.",
+	}, []string{"endpoint"})
+
+	// DatabaseOperationDuration observes the latency of the database operations per method of the connection
+	DatabaseOperationDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "mongo_operation_duration_seconds",
+		Help:      "Latency of the MongoDB operations per method of the connection.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	// TrunkTokenRetries counts the retries of setting a trunk token after an optimistic locking error
+	TrunkTokenRetries = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "trunk_token_retries_total",
+		Help:      "Retries of setting a trunk token after an optimistic locking error.",
+	})
+
+	// RentalsCreated counts the rentals created by customers
+	RentalsCreated = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rentals_created_total",
+		Help:      "Rentals created by customers.",
+	})
+
+	// RentalConflicts counts the rentals rejected because of a conflicting rental of the car
+	RentalConflicts = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rental_conflicts_total",
+		Help:      "Rentals rejected because of a conflicting rental of the car.",
+	})
+
+	// TrunkAccessDenials counts the denied attempts to access a trunk with a trunk access token
+	TrunkAccessDenials = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "trunk_access_denials_total",
+		Help:      "Denied attempts to access a trunk with a trunk access token.",
+	})
+
+	// TrunkAccessLogFailures counts the attempts to access a trunk whose entry could not be written to the trunk
+	// access log
+	TrunkAccessLogFailures = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "trunk_access_log_failures_total",
+		Help:      "Attempts to access a trunk that could not be recorded in the trunk access log.",
+	})
+)
+
+// Path is the path the metrics are exposed at
+const Path = "/metrics"
+
+// Handler returns the handler exposing the metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// NewServer returns a server exposing the metrics at Path on the given address. It is separate from the REST API,
+// so that the metrics are not reachable through the public port and need no authentication.
+func NewServer(address string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle(Path, Handler())
+	return &http.Server{Addr: address, Handler: mux, ReadHeaderTimeout: readHeaderTimeout}
+}
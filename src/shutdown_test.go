@@ -0,0 +1,89 @@
+package main
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// startSlowApp starts an app whose only route responds once the returned channel is closed
+func startSlowApp(t *testing.T) (*echo.Echo, chan struct{}) {
+	release := make(chan struct{})
+	app := echo.New()
+	app.HideBanner = true
+	app.GET("/slow", func(ctx echo.Context) error {
+		<-release
+		return ctx.NoContent(http.StatusNoContent)
+	})
+
+	go func() {
+		_ = app.Start("127.0.0.1:0")
+	}()
+	assert.Eventually(t, func() bool { return app.ListenerAddr() != nil }, time.Second, 10*time.Millisecond)
+	return app, release
+}
+
+// requestSlow requests the slow route in the background and returns the channel its outcome is sent on
+func requestSlow(app *echo.Echo) chan error {
+	outcome := make(chan error, 1)
+	go func() {
+		response, err := http.Get("http://" + app.ListenerAddr().String() + "/slow")
+		if err == nil {
+			err = response.Body.Close()
+		}
+		outcome <- err
+	}()
+	return outcome
+}
+
+func TestShutdownServers_drainsRequests(t *testing.T) {
+	app, release := startSlowApp(t)
+	outcome := requestSlow(app)
+	time.Sleep(50 * time.Millisecond)
+
+	start := time.Now()
+	time.AfterFunc(50*time.Millisecond, func() { close(release) })
+	shutdownServers(5*time.Second, app, nil, nil)
+
+	assert.Nil(t, <-outcome)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestShutdownServers_endsStreams(t *testing.T) {
+	app, release := startSlowApp(t)
+	defer close(release)
+	streams := endStreamsOnShutdown(app)
+	app.GET("/stream", func(ctx echo.Context) error {
+		<-streams.Done()
+		return ctx.NoContent(http.StatusNoContent)
+	})
+	outcome := make(chan error, 1)
+	go func() {
+		response, err := http.Get("http://" + app.ListenerAddr().String() + "/stream")
+		if err == nil {
+			err = response.Body.Close()
+		}
+		outcome <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	start := time.Now()
+	shutdownServers(5*time.Second, app, nil, nil)
+
+	assert.Nil(t, <-outcome)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestShutdownServers_cutsOffRequestsAfterTimeout(t *testing.T) {
+	app, release := startSlowApp(t)
+	defer close(release)
+	outcome := requestSlow(app)
+	time.Sleep(50 * time.Millisecond)
+
+	shutdownServers(100*time.Millisecond, app, nil, nil)
+
+	assert.NotNil(t, <-outcome)
+}
@@ -68,3 +68,16 @@ var TimePeriodSemanticInvalid string
 
 //go:embed timePeriodSyntaxInvalid.json
 var TimePeriodSyntaxInvalid string
+
+//go:embed trunkAccessGrantUnknownTimeZone.json
+var TrunkAccessGrantUnknownTimeZone string
+
+//go:embed trunkAccessGrantSyntaxInvalid.json
+var TrunkAccessGrantSyntaxInvalid string
+
+//go:embed webhookRegistration.json
+var WebhookRegistration string
+
+const UnknownWebhookId = "Ck3XlmXtlq0xGpH9"
+
+const UnknownCalendarToken = "Qm7Ax0tWbLk2ZsPfR4uNcYe9JhVd3oGi"
@@ -0,0 +1,83 @@
+package testhelpers
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const tokenIssuerKeyId = "test-key"
+
+// TokenIssuer signs bearer tokens for tests. Its public key is published in a JWKS file
+// that can be used to configure the authentication of RentalManagement.
+type TokenIssuer struct {
+	key      *rsa.PrivateKey
+	JwksFile string
+}
+
+// NewTokenIssuer creates a new TokenIssuer with a random key and writes its JWKS file to the given directory
+func NewTokenIssuer(dir string) (*TokenIssuer, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	jwks, err := json.Marshal(map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": tokenIssuerKeyId,
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jwksFile := filepath.Join(dir, "jwks.json")
+	if err := os.WriteFile(jwksFile, jwks, 0o600); err != nil {
+		return nil, err
+	}
+
+	return &TokenIssuer{key: key, JwksFile: jwksFile}, nil
+}
+
+// Sign returns a token with the given claims signed by the issuer
+func (i *TokenIssuer) Sign(claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = tokenIssuerKeyId
+
+	signed, err := token.SignedString(i.key)
+	if err != nil {
+		panic(err)
+	}
+	return signed
+}
+
+// BearerToken returns the value of an Authorization header authenticating the customer with the given ID
+// for one hour
+func (i *TokenIssuer) BearerToken(customerId string) string {
+	return "Bearer " + i.Sign(jwt.MapClaims{
+		"sub":   customerId,
+		"roles": []string{"customer"},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	})
+}
+
+// StaffBearerToken returns the value of an Authorization header authenticating a user with the given roles
+// who does not act on behalf of a customer for one hour
+func (i *TokenIssuer) StaffBearerToken(roles ...string) string {
+	return "Bearer " + i.Sign(jwt.MapClaims{
+		"sub":   "staff",
+		"roles": roles,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	})
+}
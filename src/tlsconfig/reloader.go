@@ -0,0 +1,86 @@
+package tlsconfig
+
+import (
+	"RentalManagement/logging"
+	"crypto/tls"
+	"fmt"
+	"golang.org/x/exp/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// CertificateReloader provides a certificate and its key read from files. Before every TLS handshake, it checks
+// whether either file has changed and reads both again if so. If they cannot be read or do not match, e.g. because
+// only one of them has been replaced yet, the previous certificate is kept.
+type CertificateReloader struct {
+	certFile string
+	keyFile  string
+
+	mutex       sync.Mutex
+	certificate *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+	lastError   error
+}
+
+// NewCertificateReloader creates a CertificateReloader for the given files. The certificate is loaded immediately.
+func NewCertificateReloader(certFile, keyFile string) (*CertificateReloader, error) {
+	r := &CertificateReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.reloadIfChanged(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate returns the current certificate to servers, see tls.Config
+func (r *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.current(), nil
+}
+
+// GetClientCertificate returns the current certificate to clients, see tls.Config
+func (r *CertificateReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return r.current(), nil
+}
+
+func (r *CertificateReloader) current() *tls.Certificate {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := r.reloadIfChanged(); err != nil {
+		// report a failure once instead of on every handshake until the files change again
+		if r.lastError == nil || r.lastError.Error() != err.Error() {
+			slog.Warn("reloading the certificate failed, keeping the previous one", "certFile", r.certFile,
+				logging.KeyError, err)
+		}
+		r.lastError = err
+	} else {
+		r.lastError = nil
+	}
+	return r.certificate
+}
+
+// reloadIfChanged loads the certificate and its key if either file has been modified since they have been loaded.
+// The mutex must be held by the caller unless the reloader is being created.
+func (r *CertificateReloader) reloadIfChanged() error {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return err
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return err
+	}
+	if r.certificate != nil && certInfo.ModTime().Equal(r.certModTime) && keyInfo.ModTime().Equal(r.keyModTime) {
+		return nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("loading the certificate %s failed: %w", r.certFile, err)
+	}
+	r.certificate = &certificate
+	r.certModTime = certInfo.ModTime()
+	r.keyModTime = keyInfo.ModTime()
+	return nil
+}
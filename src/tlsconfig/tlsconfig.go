@@ -0,0 +1,69 @@
+// Package tlsconfig sets up TLS for the REST API and client certificates (mTLS) for the calls to the Car server.
+// Certificates are read from files and read again whenever they change, so that renewed certificates take effect
+// without a restart.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Config provides the configuration of TLS
+type Config interface {
+	GetTlsCertFile() string
+	GetTlsKeyFile() string
+	GetCarTlsCertFile() string
+	GetCarTlsKeyFile() string
+	GetCarTlsCaFile() string
+}
+
+// NewServerConfig returns the TLS configuration of the REST API with the configured certificate or nil if no
+// certificate is configured, in which case the REST API is served without TLS
+func NewServerConfig(config Config) (*tls.Config, error) {
+	if config.GetTlsCertFile() == "" {
+		return nil, nil
+	}
+
+	reloader, err := NewCertificateReloader(config.GetTlsCertFile(), config.GetTlsKeyFile())
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate,
+	}, nil
+}
+
+// NewCarClientConfig returns the TLS configuration of the calls to the Car server, which present the configured
+// client certificate and verify the Car server with the configured CA certificates. It returns nil if neither is
+// configured, in which case the default TLS configuration is used.
+func NewCarClientConfig(config Config) (*tls.Config, error) {
+	if config.GetCarTlsCertFile() == "" && config.GetCarTlsCaFile() == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if config.GetCarTlsCertFile() != "" {
+		reloader, err := NewCertificateReloader(config.GetCarTlsCertFile(), config.GetCarTlsKeyFile())
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.GetClientCertificate = reloader.GetClientCertificate
+	}
+
+	if config.GetCarTlsCaFile() != "" {
+		pem, err := os.ReadFile(config.GetCarTlsCaFile())
+		if err != nil {
+			return nil, fmt.Errorf("reading the CA certificates of the Car server failed: %w", err)
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("the CA file of the Car server contains no PEM certificates")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	return tlsConfig, nil
+}
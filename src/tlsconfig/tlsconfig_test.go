@@ -0,0 +1,181 @@
+package tlsconfig_test
+
+import (
+	"RentalManagement/tlsconfig"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfig struct {
+	certFile    string
+	keyFile     string
+	carCertFile string
+	carKeyFile  string
+	carCaFile   string
+}
+
+func (c *testConfig) GetTlsCertFile() string {
+	return c.certFile
+}
+
+func (c *testConfig) GetTlsKeyFile() string {
+	return c.keyFile
+}
+
+func (c *testConfig) GetCarTlsCertFile() string {
+	return c.carCertFile
+}
+
+func (c *testConfig) GetCarTlsKeyFile() string {
+	return c.carKeyFile
+}
+
+func (c *testConfig) GetCarTlsCaFile() string {
+	return c.carCaFile
+}
+
+// testCertificate is a self-signed certificate for localhost, which is its own CA
+type testCertificate struct {
+	certPem []byte
+	keyPem  []byte
+	x509    *x509.Certificate
+}
+
+func newTestCertificate(t *testing.T, commonName string) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	assert.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+
+	return &testCertificate{
+		certPem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPem:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+		x509:    certificate,
+	}
+}
+
+// write writes the certificate and its key to the given files
+func (c *testCertificate) write(t *testing.T, certFile, keyFile string, modTime time.Time) {
+	assert.Nil(t, os.WriteFile(certFile, c.certPem, 0600))
+	assert.Nil(t, os.WriteFile(keyFile, c.keyPem, 0600))
+	// set the modification time explicitly as a rewrite within the resolution of the file system is not detected
+	assert.Nil(t, os.Chtimes(certFile, modTime, modTime))
+	assert.Nil(t, os.Chtimes(keyFile, modTime, modTime))
+}
+
+func TestCertificateReloader(t *testing.T) {
+	directory := t.TempDir()
+	certFile, keyFile := filepath.Join(directory, "tls.crt"), filepath.Join(directory, "tls.key")
+	first, second := newTestCertificate(t, "first"), newTestCertificate(t, "second")
+	first.write(t, certFile, keyFile, time.Now().Add(-time.Minute))
+
+	reloader, err := tlsconfig.NewCertificateReloader(certFile, keyFile)
+	assert.Nil(t, err)
+	certificate, err := reloader.GetCertificate(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, first.x509.Raw, certificate.Certificate[0])
+
+	// only the certificate has been replaced yet, so the key does not match
+	assert.Nil(t, os.WriteFile(certFile, second.certPem, 0600))
+	certificate, err = reloader.GetCertificate(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, first.x509.Raw, certificate.Certificate[0])
+
+	second.write(t, certFile, keyFile, time.Now())
+	certificate, err = reloader.GetClientCertificate(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, second.x509.Raw, certificate.Certificate[0])
+}
+
+func TestNewCertificateReloader_missingFile(t *testing.T) {
+	directory := t.TempDir()
+
+	_, err := tlsconfig.NewCertificateReloader(filepath.Join(directory, "tls.crt"), filepath.Join(directory, "tls.key"))
+
+	assert.NotNil(t, err)
+}
+
+func TestNewServerConfig_disabled(t *testing.T) {
+	tlsConfig, err := tlsconfig.NewServerConfig(&testConfig{})
+
+	assert.Nil(t, err)
+	assert.Nil(t, tlsConfig)
+}
+
+func TestNewCarClientConfig_disabled(t *testing.T) {
+	tlsConfig, err := tlsconfig.NewCarClientConfig(&testConfig{})
+
+	assert.Nil(t, err)
+	assert.Nil(t, tlsConfig)
+}
+
+func TestMutualTls(t *testing.T) {
+	directory := t.TempDir()
+	serverCertificate, clientCertificate := newTestCertificate(t, "car"), newTestCertificate(t, "rentals")
+	config := &testConfig{
+		certFile:    filepath.Join(directory, "server.crt"),
+		keyFile:     filepath.Join(directory, "server.key"),
+		carCertFile: filepath.Join(directory, "client.crt"),
+		carKeyFile:  filepath.Join(directory, "client.key"),
+		carCaFile:   filepath.Join(directory, "server.crt"),
+	}
+	serverCertificate.write(t, config.certFile, config.keyFile, time.Now())
+	clientCertificate.write(t, config.carCertFile, config.carKeyFile, time.Now())
+
+	serverConfig, err := tlsconfig.NewServerConfig(config)
+	assert.Nil(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCertificate.x509)
+	serverConfig.ClientCAs = clientCAs
+	serverConfig.ClientAuth = tls.RequireAndVerifyClientCert
+
+	var clientName string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		clientName = request.TLS.PeerCertificates[0].Subject.CommonName
+	}))
+	// httptest.Server.StartTLS would present its own certificate
+	server.Listener = tls.NewListener(server.Listener, serverConfig)
+	server.Start()
+	defer server.Close()
+
+	clientConfig, err := tlsconfig.NewCarClientConfig(config)
+	assert.Nil(t, err)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientConfig}}
+
+	response, err := client.Get("https://" + server.Listener.Addr().String())
+	if assert.Nil(t, err) {
+		assert.Nil(t, response.Body.Close())
+	}
+	assert.Equal(t, "rentals", clientName)
+}
@@ -0,0 +1,77 @@
+// Package tracing sets up OpenTelemetry tracing of RentalManagement. The spans of the REST API, the calls to the Car
+// server and the database operations are exported to the configured exporter.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName is the name RentalManagement reports its spans with
+const ServiceName = "RentalManagement"
+
+// Exporter is the exporter the spans are exported with
+type Exporter string
+
+// Defines values for Exporter.
+const (
+	// ExporterNone does not export any spans
+	ExporterNone Exporter = ""
+	// ExporterOtlp exports the spans via OTLP over HTTP. The collector is configured with the standard
+	// OTEL_EXPORTER_OTLP_* environment variables, e.g. OTEL_EXPORTER_OTLP_ENDPOINT.
+	ExporterOtlp Exporter = "otlp"
+	// ExporterStdout writes the spans to stdout in between the log lines, which is meant for debugging
+	ExporterStdout Exporter = "stdout"
+)
+
+// Config provides the configuration of the tracing
+type Config interface {
+	GetTracingExporter() string
+}
+
+// Setup sets up the global tracer provider with the configured exporter. The W3C trace context of incoming requests
+// is continued and propagated to outgoing requests even without an exporter, so that the trace IDs of the problems
+// match those of the callers.
+// The returned function flushes the remaining spans and must be called before the program exits.
+func Setup(config Config) (func(ctx context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{},
+		propagation.Baggage{}))
+
+	exporter, err := newExporter(Exporter(config.GetTracingExporter()))
+	if err != nil || exporter == nil {
+		return func(ctx context.Context) error { return nil }, err
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(ServiceName))),
+	)
+	otel.SetTracerProvider(tracerProvider)
+
+	return tracerProvider.Shutdown, nil
+}
+
+func newExporter(exporter Exporter) (sdktrace.SpanExporter, error) {
+	switch exporter {
+	case ExporterNone:
+		return nil, nil
+	case ExporterOtlp:
+		return otlptracehttp.New(context.Background())
+	case ExporterStdout:
+		return stdouttrace.New()
+	}
+	return nil, fmt.Errorf("unknown tracing exporter %q", exporter)
+}
+
+// Tracer returns the tracer RentalManagement creates its own spans with
+func Tracer() trace.Tracer {
+	return otel.Tracer(ServiceName)
+}
@@ -0,0 +1,40 @@
+package tracing
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfig string
+
+func (c testConfig) GetTracingExporter() string {
+	return string(c)
+}
+
+func TestSetup_noExporter(t *testing.T) {
+	shutdown, err := Setup(testConfig(ExporterNone))
+
+	assert.Nil(t, err)
+	assert.Contains(t, otel.GetTextMapPropagator().Fields(), "traceparent")
+	assert.Nil(t, shutdown(context.Background()))
+}
+
+func TestSetup_stdout(t *testing.T) {
+	previousProvider := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(previousProvider)
+
+	shutdown, err := Setup(testConfig(ExporterStdout))
+
+	assert.Nil(t, err)
+	assert.NotEqual(t, previousProvider, otel.GetTracerProvider())
+	assert.Nil(t, shutdown(context.Background()))
+}
+
+func TestSetup_unknownExporter(t *testing.T) {
+	_, err := Setup(testConfig("jaeger"))
+
+	assert.NotNil(t, err)
+}